@@ -13,6 +13,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"digital.vasic.translator/pkg/grpc/proto"
 	"digital.vasic.translator/pkg/logger"
@@ -334,7 +335,7 @@ func (s *APIServer) getTranslationStatus(c *gin.Context) {
 }
 
 func (s *APIServer) listTranslations(c *gin.Context) {
-	req := &proto.Empty{}
+	req := &emptypb.Empty{}
 	
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
@@ -459,7 +460,7 @@ func (s *APIServer) streamTranslationProgress(c *gin.Context) {
 }
 
 func (s *APIServer) getProviders(c *gin.Context) {
-	req := &proto.Empty{}
+	req := &emptypb.Empty{}
 	
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
@@ -9,19 +9,31 @@ import (
 	"digital.vasic.translator/pkg/format"
 	"digital.vasic.translator/pkg/language"
 	"digital.vasic.translator/pkg/script"
+	"digital.vasic.translator/pkg/tracing"
 	"digital.vasic.translator/pkg/translator"
 	"digital.vasic.translator/pkg/translator/llm"
 	versionpkg "digital.vasic.translator/pkg/version"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const version = "2.0.0"
 
+// shutdownTracing flushes pending spans; nil unless tracing is enabled
+var shutdownTracing func(context.Context) error
+
 func main() {
+	// Handle subcommands
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(runValidate(os.Args[2:], os.Stdout))
+	}
+
 	// Define CLI flags
 	var (
 		inputFile         string
@@ -43,6 +55,9 @@ func main() {
 		disableLocalLLMs  bool
 		preferDistributed bool
 		hashCodebase      bool
+		inPlace           bool
+		bilingual         string
+		ocrLanguages      string
 	)
 
 	flag.StringVar(&inputFile, "input", "", "Input ebook file (any format: FB2, EPUB, TXT, HTML, PDF, DOCX)")
@@ -56,7 +71,7 @@ func main() {
 	flag.StringVar(&model, "model", "", "LLM model name")
 	flag.StringVar(&apiKey, "api-key", "", "API key for LLM provider")
 	flag.StringVar(&baseURL, "base-url", "", "Base URL for LLM provider")
-	flag.StringVar(&scriptType, "script", "default", "Output script (default, cyrillic, latin, arabic, etc.)")
+	flag.StringVar(&scriptType, "script", "default", "Output script (default, latin, or latin:<standard> with iso9, iso843, bgn-pcgn, national)")
 	flag.StringVar(&locale, "locale", "", "Target language locale (e.g., sr, de, DE)")
 	flag.StringVar(&targetLanguage, "language", "", "Target language name (e.g., English, Spanish, French)")
 	flag.StringVar(&sourceLanguage, "source", "", "Source language (optional, auto-detected if not specified)")
@@ -71,9 +86,22 @@ func main() {
 	flag.StringVar(&configFile, "config", "", "Configuration file path")
 	flag.StringVar(&configFile, "c", "", "Configuration file path (shorthand)")
 	flag.BoolVar(&hashCodebase, "hash-codebase", false, "Calculate codebase hash and exit")
+	flag.BoolVar(&inPlace, "in-place", false, "Translate an EPUB or DOCX keeping its original markup, styles and images")
+	flag.StringVar(&ocrLanguages, "ocr", "", "Recognize text in images with tesseract in these languages (e.g. eng, rus+eng) and translate it")
+	flag.StringVar(&bilingual, "bilingual", "", "Write original and translation together (interleaved or side-by-side) as epub, html, docx or md")
 
 	flag.Parse()
 
+	// Set up text recognition in images
+	if ocrLanguages != "" {
+		recognizer := ebook.NewTesseractRecognizer(ocrLanguages)
+		if !recognizer.Available() {
+			fmt.Fprintf(os.Stderr, "Text recognition needs tesseract, which was not found in PATH\n")
+			os.Exit(1)
+		}
+		imageTextRecognizer = recognizer
+	}
+
 	// Handle version
 	if showVersion {
 		fmt.Printf("Universal Ebook Translator v%s\n", version)
@@ -158,6 +186,23 @@ func main() {
 		fmt.Printf("Loaded configuration from: %s\n", configFile)
 	}
 
+	// Export spans to the OTLP collector when the configuration asks for it
+	ctx := context.Background()
+	if appConfig != nil && appConfig.Tracing.Enabled {
+		shutdown, err := tracing.Setup(ctx, tracing.Config{
+			Endpoint:       appConfig.Tracing.Endpoint,
+			Insecure:       appConfig.Tracing.Insecure,
+			ServiceName:    appConfig.Tracing.ServiceName,
+			ServiceVersion: version,
+			SampleRatio:    appConfig.Tracing.SampleRatio,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to initialize tracing: %v\n", err)
+		} else {
+			shutdownTracing = shutdown
+		}
+	}
+
 	// Create event bus
 	eventBus := events.NewEventBus()
 
@@ -170,11 +215,49 @@ func main() {
 	fmt.Printf("Universal Ebook Translator v%s\n\n", version)
 	fmt.Printf("Input file: %s\n", inputFile)
 
+	// Translate the string tables of apps string by string, written back
+	// in their own format
+	if inputFormat, err := format.NewDetector().DetectFile(inputFile); err == nil && inputFormat.IsLocalization() {
+		fmt.Printf("Detected format: %s\n", inputFormat)
+		fmt.Printf("Target language: %s (%s)\n", targetLang.Name, targetLang.Code)
+
+		if bilingual != "" || scriptType != "default" || inPlace || detectLang {
+			fmt.Fprintf(os.Stderr, "Bilingual output, script conversion, in-place translation and language detection are not supported for localization files\n")
+			exit(1)
+		}
+		if outputFile == "" {
+			outputFile = generateOutputFilename(inputFile, targetLang.Code, localizationExtension(inputFile))
+		}
+
+		if err := translateLocalization(
+			ctx,
+			inputFile,
+			outputFile,
+			provider,
+			model,
+			apiKey,
+			baseURL,
+			appConfig,
+			sourceLang,
+			targetLang,
+			eventBus,
+			disableLocalLLMs,
+			preferDistributed,
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "Translation failed: %v\n", err)
+			exit(1)
+		}
+
+		fmt.Printf("\n✓ Translation completed successfully!\n")
+		fmt.Printf("Output file: %s\n", outputFile)
+		exit(0)
+	}
+
 	parser := ebook.NewUniversalParser()
-	book, err := parser.Parse(inputFile)
+	book, err := parser.ParseContext(ctx, inputFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to parse ebook: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	fmt.Printf("Detected format: %s\n", book.Format)
@@ -189,25 +272,152 @@ func main() {
 			sample = sample[:2000]
 		}
 
-		detectedLang, err := langDetector.Detect(context.Background(), sample)
+		detectedLang, err := langDetector.Detect(ctx, sample)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Language detection failed: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fmt.Printf("\nDetected language: %s (%s)\n", detectedLang.Name, detectedLang.Code)
-		os.Exit(0)
+		exit(0)
 	}
 
 	fmt.Printf("Target language: %s (%s)\n", targetLang.Name, targetLang.Code)
 
+	// Word documents translated in place stay Word documents, and
+	// subtitles stay in their format, unless another output format is
+	// asked for
+	if (inPlace && book.Format == format.FormatDOCX) || book.Format.IsSubtitle() {
+		formatSet := false
+		flag.Visit(func(f *flag.Flag) {
+			formatSet = formatSet || f.Name == "format" || f.Name == "f"
+		})
+		if !formatSet {
+			outputFormat = string(book.Format)
+		}
+	}
+
 	// Generate output filename if not provided
 	if outputFile == "" {
 		outputFile = generateOutputFilename(inputFile, targetLang.Code, outputFormat)
 	}
 
+	// Translate subtitles cue by cue, keeping their timing and styling
+	if book.Format.IsSubtitle() {
+		if !format.ParseFormat(outputFormat).IsSubtitle() {
+			fmt.Fprintf(os.Stderr, "Subtitles can only be written as srt, vtt or ass\n")
+			exit(1)
+		}
+		if bilingual != "" || scriptType != "default" {
+			fmt.Fprintf(os.Stderr, "Bilingual output and script conversion are not supported for subtitles\n")
+			exit(1)
+		}
+
+		if err := translateSubtitles(
+			ctx,
+			inputFile,
+			outputFile,
+			provider,
+			model,
+			apiKey,
+			baseURL,
+			appConfig,
+			sourceLang,
+			targetLang,
+			eventBus,
+			disableLocalLLMs,
+			preferDistributed,
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "Translation failed: %v\n", err)
+			exit(1)
+		}
+
+		fmt.Printf("\n✓ Translation completed successfully!\n")
+		fmt.Printf("Output file: %s\n", outputFile)
+		exit(0)
+	}
+
+	// Translate EPUBs and Word documents in place when requested
+	if inPlace {
+		if (book.Format != format.FormatEPUB && book.Format != format.FormatDOCX) || format.ParseFormat(outputFormat) != book.Format {
+			fmt.Fprintf(os.Stderr, "In-place translation needs EPUB or DOCX input and output of the same format\n")
+			exit(1)
+		}
+		if scriptType != "default" {
+			fmt.Fprintf(os.Stderr, "Script conversion is not supported with in-place translation\n")
+			exit(1)
+		}
+
+		translateInPlace := translateEPUBInPlace
+		if book.Format == format.FormatDOCX {
+			translateInPlace = translateDOCXInPlace
+		}
+		if err := translateInPlace(
+			ctx,
+			inputFile,
+			outputFile,
+			provider,
+			model,
+			apiKey,
+			baseURL,
+			appConfig,
+			sourceLang,
+			targetLang,
+			eventBus,
+			disableLocalLLMs,
+			preferDistributed,
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "Translation failed: %v\n", err)
+			exit(1)
+		}
+
+		fmt.Printf("\n✓ Translation completed successfully!\n")
+		fmt.Printf("Output file: %s\n", outputFile)
+		exit(0)
+	}
+
+	// Write original and translation together when requested
+	if bilingual != "" {
+		layout, err := ebook.ParseBilingualLayout(bilingual)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			exit(1)
+		}
+		if inPlace {
+			fmt.Fprintf(os.Stderr, "Bilingual output cannot be combined with in-place translation\n")
+			exit(1)
+		}
+
+		if err := translateBilingual(
+			ctx,
+			book,
+			outputFile,
+			outputFormat,
+			layout,
+			provider,
+			model,
+			apiKey,
+			baseURL,
+			scriptType,
+			appConfig,
+			sourceLang,
+			targetLang,
+			eventBus,
+			disableLocalLLMs,
+			preferDistributed,
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "Translation failed: %v\n", err)
+			exit(1)
+		}
+
+		fmt.Printf("\n✓ Translation completed successfully!\n")
+		fmt.Printf("Output file: %s\n", outputFile)
+		exit(0)
+	}
+
 	// Run translation
 	if err := translateEbook(
+		ctx,
 		book,
 		outputFile,
 		outputFormat,
@@ -224,15 +434,29 @@ func main() {
 		preferDistributed,
 	); err != nil {
 		fmt.Fprintf(os.Stderr, "Translation failed: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	fmt.Printf("\n✓ Translation completed successfully!\n")
 	fmt.Printf("Output file: %s\n", outputFile)
 	fmt.Printf("Output format: %s\n", outputFormat)
+	exit(0)
+}
+
+// exit flushes pending spans and ends the process with code
+func exit(code int) {
+	if shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := shutdownTracing(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Tracing shutdown error: %v\n", err)
+		}
+		cancel()
+	}
+	os.Exit(code)
 }
 
 func translateEbook(
+	ctx context.Context,
 	book *ebook.Book,
 	outputFile, outputFormat, providerName, model, apiKey, baseURL, scriptType string,
 	appConfig *config.Config,
@@ -240,8 +464,342 @@ func translateEbook(
 	eventBus *events.EventBus,
 	disableLocalLLMs, preferDistributed bool,
 ) error {
-	ctx := context.Background()
+	sessionID := "cli-session"
+
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Translate the book
+	if err := universalTrans.TranslateBook(ctx, book, eventBus, sessionID); err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+
+	// Convert script if needed
+	if err := convertScript(book, scriptType, targetLang.Code); err != nil {
+		return err
+	}
+
+	// Write output in requested format
+	fmt.Printf("Writing output file...\n")
+	outFormat := format.ParseFormat(outputFormat)
+
+	switch outFormat {
+	case format.FormatEPUB:
+		writer := ebook.NewEPUBWriter()
+		if err := writer.Write(book, outputFile); err != nil {
+			return fmt.Errorf("failed to write EPUB: %w", err)
+		}
+
+	case format.FormatFB2:
+		// Convert to FB2 and write
+		// For now, we'll use EPUB as primary format
+		return fmt.Errorf("FB2 output format not yet implemented")
+
+	case format.FormatTXT:
+		// Write as plain text
+		if err := writeAsText(book, outputFile); err != nil {
+			return fmt.Errorf("failed to write TXT: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	printStats(trans)
+
+	return nil
+}
+
+// convertScript converts a translated book to the script asked for
+func convertScript(book *ebook.Book, scriptType, targetCode string) error {
+	target, standard, _ := strings.Cut(scriptType, ":")
+	if target != "latin" {
+		return nil
+	}
+	if targetCode == "sr" && standard == "" {
+		fmt.Printf("Converting to Latin script...\n")
+		convertBookToLatin(book, script.NewConverter())
+		return nil
+	}
+	return transliterateBook(book, targetCode, standard)
+}
+
+// translateBilingual translates a book and writes it together with the
+// original, paragraph by paragraph
+func translateBilingual(
+	ctx context.Context,
+	book *ebook.Book,
+	outputFile, outputFormat string,
+	layout ebook.BilingualLayout,
+	providerName, model, apiKey, baseURL, scriptType string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) error {
+	writers := map[string]func(w *ebook.BilingualWriter, original, translated *ebook.Book, filename string) error{
+		"epub":     (*ebook.BilingualWriter).WriteEPUB,
+		"html":     (*ebook.BilingualWriter).WriteHTML,
+		"docx":     (*ebook.BilingualWriter).WriteDOCX,
+		"md":       (*ebook.BilingualWriter).WriteMarkdown,
+		"markdown": (*ebook.BilingualWriter).WriteMarkdown,
+	}
+	write, ok := writers[strings.ToLower(outputFormat)]
+	if !ok {
+		return fmt.Errorf("bilingual output supports epub, html, docx and md, not %s", outputFormat)
+	}
+
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	original := book.Clone()
+	if err := universalTrans.TranslateBook(ctx, book, eventBus, "cli-session"); err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	if err := convertScript(book, scriptType, targetLang.Code); err != nil {
+		return err
+	}
+
+	// The source language is known once the book was translated
+	detected := universalTrans.GetSourceLanguage()
+	writer := ebook.NewBilingualWriter(ebook.BilingualOptions{
+		Layout:         layout,
+		SourceLanguage: detected.Code,
+		TargetLanguage: targetLang.Code,
+		SourceLabel:    detected.Name,
+		TargetLabel:    targetLang.Name,
+	})
+
+	fmt.Printf("Writing output file...\n")
+	if err := write(writer, original, book, outputFile); err != nil {
+		return fmt.Errorf("failed to write bilingual %s: %w", outputFormat, err)
+	}
+
+	printStats(trans)
+
+	return nil
+}
+
+// translateEPUBInPlace translates an EPUB into a new EPUB that keeps the
+// original markup, styles and images, changing only text and language tags
+func translateEPUBInPlace(
+	ctx context.Context,
+	inputFile, outputFile, providerName, model, apiKey, baseURL string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) error {
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	stats, err := universalTrans.TranslateEPUBInPlace(ctx, inputFile, outputFile, eventBus, "cli-session")
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	fmt.Printf("Translated %d blocks in %d documents (%d text by text)\n", stats.Blocks+stats.Fragments, stats.Documents, stats.Fragments)
+	for _, issue := range stats.Validation.Issues {
+		fmt.Printf("  %s\n", issue)
+	}
+
+	printStats(trans)
+
+	return nil
+}
+
+// translateDOCXInPlace translates a Word document into a new document that
+// keeps its styles, run formatting, tables, notes and comments
+func translateDOCXInPlace(
+	ctx context.Context,
+	inputFile, outputFile, providerName, model, apiKey, baseURL string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) error {
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	stats, err := universalTrans.TranslateDOCXInPlace(ctx, inputFile, outputFile, eventBus, "cli-session")
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	fmt.Printf("Translated %d paragraphs in %d parts (%d without their run formatting)\n", stats.Paragraphs+stats.Merged, stats.Parts, stats.Merged)
+
+	printStats(trans)
+
+	return nil
+}
+
+// translateSubtitles translates an SRT, WebVTT or ASS file cue by cue,
+// wrapping lines and condensing cues that are too long to read
+func translateSubtitles(
+	ctx context.Context,
+	inputFile, outputFile, providerName, model, apiKey, baseURL string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) error {
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	stats, err := universalTrans.TranslateSubtitles(ctx, inputFile, outputFile, eventBus, "cli-session")
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	fmt.Printf("Translated %d cues (%d condensed, %d still too long, %d without their styling in place)\n",
+		stats.Cues, stats.Condensed, stats.TooLong, stats.Merged)
+
+	printStats(trans)
+
+	return nil
+}
+
+// translateLocalization translates a PO, XLIFF, JSON, Android or iOS string
+// file, listing the strings left for review
+func translateLocalization(
+	ctx context.Context,
+	inputFile, outputFile, providerName, model, apiKey, baseURL string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) error {
+	universalTrans, trans, err := newUniversalTranslator(
+		providerName, model, apiKey, baseURL,
+		appConfig, sourceLang, targetLang, eventBus,
+		disableLocalLLMs, preferDistributed,
+	)
+	if err != nil {
+		return err
+	}
+
+	stats, err := universalTrans.TranslateLocalization(ctx, inputFile, outputFile, eventBus, "cli-session")
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	fmt.Printf("Translated %d strings (%d already translated, %d need review)\n",
+		stats.Units, stats.Skipped, stats.NeedsReview)
+	for _, id := range stats.Review {
+		fmt.Printf("  needs review: %s\n", id)
+	}
+
+	printStats(trans)
+
+	return nil
+}
+
+// localizationExtension returns the extension of a translated localization
+// file: that of the input, with templates becoming PO files
+func localizationExtension(inputFile string) string {
+	ext := strings.TrimPrefix(filepath.Ext(inputFile), ".")
+	if strings.EqualFold(ext, "pot") {
+		return "po"
+	}
+	return ext
+}
+
+// runValidate runs the validate subcommand and returns the exit code: 0 when
+// every EPUB is valid, 1 when one is not and 2 for usage errors
+func runValidate(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	jsonOutput := fs.Bool("json", false, "Print the reports as JSON")
+	strict := fs.Bool("strict", false, "Treat warnings as errors")
+	fs.Usage = func() {
+		fmt.Fprintf(out, "Usage: translator validate [-json] [-strict] <file.epub>...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	validator := ebook.NewEPUBValidator()
+	var reports []*ebook.ValidationReport
+	code := 0
+	for _, filename := range fs.Args() {
+		report, err := validator.Validate(filename)
+		if err != nil {
+			fmt.Fprintf(out, "%s: %v\n", filename, err)
+			code = 1
+			continue
+		}
+		reports = append(reports, report)
+		if !report.Valid() || (*strict && len(report.Warnings()) > 0) {
+			code = 1
+		}
+
+		if *jsonOutput {
+			continue
+		}
+		for _, issue := range report.Issues {
+			fmt.Fprintf(out, "%s: %s\n", filename, issue)
+		}
+		status := "valid"
+		if !report.Valid() {
+			status = "invalid"
+		}
+		fmt.Fprintf(out, "%s: %s (%d errors, %d warnings)\n", filename, status, len(report.Errors()), len(report.Warnings()))
+	}
 
+	if *jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			fmt.Fprintf(out, "failed to encode reports: %v\n", err)
+			return 2
+		}
+	}
+	return code
+}
+
+// imageTextRecognizer, when set by -ocr, recognizes the text of images
+var imageTextRecognizer ebook.ImageTextRecognizer
+
+// newUniversalTranslator creates the translator for the provider, with
+// configuration values filling in unset parameters
+func newUniversalTranslator(
+	providerName, model, apiKey, baseURL string,
+	appConfig *config.Config,
+	sourceLang, targetLang language.Language,
+	eventBus *events.EventBus,
+	disableLocalLLMs, preferDistributed bool,
+) (*translator.UniversalTranslator, translator.Translator, error) {
 	// Load configuration if specified
 	if appConfig != nil {
 		fmt.Printf("Using loaded configuration\n")
@@ -297,7 +855,7 @@ func translateEbook(
 			fmt.Printf("Using translator: multi-llm-coordinator (%d instances)\n\n", multiTrans.Coordinator.GetInstanceCount())
 		} else if providerName == "multi-llm" || providerName == "distributed" {
 			// User explicitly requested multi-llm or distributed but it failed
-			return fmt.Errorf("failed to create multi-LLM translator: %w", multiErr)
+			return nil, nil, fmt.Errorf("failed to create multi-LLM translator: %w", multiErr)
 		}
 		// Otherwise fall through to single translator
 	}
@@ -306,7 +864,7 @@ func translateEbook(
 	if trans == nil {
 		trans, err = llm.NewLLMTranslator(config)
 		if err != nil {
-			return fmt.Errorf("failed to create translator: %w", err)
+			return nil, nil, fmt.Errorf("failed to create translator: %w", err)
 		}
 		fmt.Printf("Using translator: %s\n\n", trans.GetName())
 	}
@@ -325,85 +883,79 @@ func translateEbook(
 		sourceLang,
 		targetLang,
 	)
-
-	// Translate the book
-	if err := universalTrans.TranslateBook(ctx, book, eventBus, sessionID); err != nil {
-		return fmt.Errorf("translation failed: %w", err)
+	if imageTextRecognizer != nil {
+		universalTrans.SetImageTextRecognizer(imageTextRecognizer)
 	}
 
-	// Convert script if needed
-	if scriptType == "latin" && targetLang.Code == "sr" {
-		fmt.Printf("Converting to Latin script...\n")
-		converter := script.NewConverter()
-		convertBookToLatin(book, converter)
-	}
-
-	// Write output in requested format
-	fmt.Printf("Writing output file...\n")
-	outFormat := format.ParseFormat(outputFormat)
-
-	switch outFormat {
-	case format.FormatEPUB:
-		writer := ebook.NewEPUBWriter()
-		if err := writer.Write(book, outputFile); err != nil {
-			return fmt.Errorf("failed to write EPUB: %w", err)
-		}
-
-	case format.FormatFB2:
-		// Convert to FB2 and write
-		// For now, we'll use EPUB as primary format
-		return fmt.Errorf("FB2 output format not yet implemented")
-
-	case format.FormatTXT:
-		// Write as plain text
-		if err := writeAsText(book, outputFile); err != nil {
-			return fmt.Errorf("failed to write TXT: %w", err)
-		}
-
-	default:
-		return fmt.Errorf("unsupported output format: %s", outputFormat)
-	}
+	return universalTrans, trans, nil
+}
 
-	// Print statistics
+// printStats prints the statistics of the translator
+func printStats(trans translator.Translator) {
 	stats := trans.GetStats()
 	fmt.Printf("\nTranslation Statistics:\n")
 	fmt.Printf("  Total: %d\n", stats.Total)
 	fmt.Printf("  Translated: %d\n", stats.Translated)
 	fmt.Printf("  Cached: %d\n", stats.Cached)
 	fmt.Printf("  Errors: %d\n", stats.Errors)
+}
 
+// transliterateBook converts a book in a language with a transliteration
+// scheme to Latin script. Without an explicit standard, books in languages
+// without a scheme are left as they are.
+func transliterateBook(book *ebook.Book, lang, standard string) error {
+	explicit := standard != ""
+	if !explicit {
+		standard = string(script.National)
+	}
+
+	tr, err := script.NewTransliterator(lang, script.Standard(standard))
+	if err != nil {
+		if explicit {
+			return err
+		}
+		return nil
+	}
+
+	fmt.Printf("Transliterating to Latin script (%s)...\n", tr.Scheme().Name)
+	convertBook(book, tr.Transliterate)
 	return nil
 }
 
 func convertBookToLatin(book *ebook.Book, converter *script.Converter) {
+	convertBook(book, converter.ToLatin)
+}
+
+// convertBook applies convert to the metadata and every chapter of book
+func convertBook(book *ebook.Book, convert func(string) string) {
 	// Convert metadata
-	book.Metadata.Title = converter.ToLatin(book.Metadata.Title)
-	book.Metadata.Description = converter.ToLatin(book.Metadata.Description)
+	book.Metadata.Title = convert(book.Metadata.Title)
+	book.Metadata.Description = convert(book.Metadata.Description)
 
 	for i := range book.Metadata.Authors {
-		book.Metadata.Authors[i] = converter.ToLatin(book.Metadata.Authors[i])
+		book.Metadata.Authors[i] = convert(book.Metadata.Authors[i])
 	}
 
 	// Convert chapters
 	for i := range book.Chapters {
-		convertChapterToLatin(&book.Chapters[i], converter)
+		convertChapter(&book.Chapters[i], convert)
 	}
 }
 
-func convertChapterToLatin(chapter *ebook.Chapter, converter *script.Converter) {
-	chapter.Title = converter.ToLatin(chapter.Title)
+func convertChapter(chapter *ebook.Chapter, convert func(string) string) {
+	chapter.Title = convert(chapter.Title)
 
 	for i := range chapter.Sections {
-		convertSectionToLatin(&chapter.Sections[i], converter)
+		convertSection(&chapter.Sections[i], convert)
 	}
 }
 
-func convertSectionToLatin(section *ebook.Section, converter *script.Converter) {
-	section.Title = converter.ToLatin(section.Title)
-	section.Content = converter.ToLatin(section.Content)
+func convertSection(section *ebook.Section, convert func(string) string) {
+	section.Title = convert(section.Title)
+	section.Content = convert(section.Content)
 
 	for i := range section.Subsections {
-		convertSectionToLatin(&section.Subsections[i], converter)
+		convertSection(&section.Subsections[i], convert)
 	}
 }
 
@@ -474,9 +1026,13 @@ Translate ebooks between any languages with support for multiple formats.
 
 Usage:
   translator [options] -input <file>
+  translator validate [-json] [-strict] <file.epub>...
 
 Options:
-  -i, -input <file>       Input ebook file (any format: FB2, EPUB, TXT, HTML, PDF, DOCX)
+  -i, -input <file>       Input ebook file (any format: FB2, EPUB, TXT, HTML, PDF,
+                          DOCX), subtitles (SRT, WebVTT, ASS) or localization
+                          file (PO, XLIFF, JSON, Android strings.xml, iOS
+                          .strings)
   -o, -output <file>      Output file (auto-generated if not specified)
   -f, -format <format>    Output format (epub, fb2, txt) [default: epub]
 
@@ -492,7 +1048,20 @@ Options:
   -api-key <key>          API key for LLM provider
   -base-url <url>         Base URL for LLM provider
 
-  -script <type>          Output script (default, cyrillic, latin, arabic, etc.)
+  -in-place               Translate an EPUB or DOCX into the same format keeping
+                          its markup, styles and images; only text and
+                          language tags change
+  -ocr <languages>        Recognize text in images with tesseract (e.g. eng,
+                          rus+eng) and add its translation as a caption or
+                          note; alt texts, titles and captions are always
+                          translated
+  -bilingual <layout>     Write original and translation together, interleaved
+                          or side-by-side, as epub, html, docx or md
+                          (-format); EPUB and HTML can show one language only
+  -script <type>          Output script: default, latin, or latin:<standard>
+                          to transliterate Russian, Ukrainian, Belarusian,
+                          Bulgarian, Macedonian, Serbian, Mongolian or Greek
+                          with iso9, iso843, bgn-pcgn or national
                           [default: default]
 
    -c, -config <file>      Configuration file path
@@ -503,10 +1072,12 @@ Options:
    -h, -help               Show this help
 
 Supported Input Formats:
-  FB2, EPUB, TXT, HTML, PDF, DOCX
+  FB2, EPUB, TXT, HTML, PDF, DOCX, SRT, VTT, ASS, PO, POT, XLIFF, JSON,
+  Android strings.xml, iOS .strings
 
 Supported Output Formats:
-  EPUB (default), TXT
+  EPUB (default), TXT; SRT, VTT and ASS for subtitles; localization files
+  keep their own format
 
 Supported Languages:
   %s
@@ -536,6 +1107,29 @@ Examples:
   # Latin script output (for Serbian)
   translator -input book.fb2 -script latin
 
+  # ISO 9 transliteration of a Ukrainian translation
+  translator -input book.epub -locale uk -script latin:iso9
+
+  # Keep the layout of an EPUB
+  translator -input book.epub -locale de -in-place
+
+  # Keep the styles, comments and notes of a Word manuscript
+  translator -input manuscript.docx -locale de -in-place
+
+  # Original and German translation in two columns
+  translator -input book.epub -locale de -bilingual side-by-side -format html
+
+  # Subtitles of a video course, timing and styling kept
+  translator -input lesson01.srt -locale de
+  translator -input lesson01.ass -locale de -format vtt
+
+  # App strings, placeholders and plural forms kept
+  translator -input messages.pot -locale ru
+  translator -input res/values/strings.xml -locale de -output res/values-de/strings.xml
+
+  # Check an EPUB before delivery
+  translator validate book_de.epub
+
   # Output as plain text
   translator -input book.epub -locale de -format txt
 
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"digital.vasic.translator/internal/config"
 	"digital.vasic.translator/pkg/ebook"
 	"digital.vasic.translator/pkg/events"
@@ -208,6 +210,15 @@ func TestGenerateOutputFilenameComprehensive(t *testing.T) {
 	}
 }
 
+// TestLocalizationExtension tests that localization files keep their extension
+func TestLocalizationExtension(t *testing.T) {
+	assert.Equal(t, "po", localizationExtension("messages.pot"))
+	assert.Equal(t, "po", localizationExtension("locale/de.po"))
+	assert.Equal(t, "xml", localizationExtension("res/values/strings.xml"))
+	assert.Equal(t, "strings", localizationExtension("Localizable.strings"))
+	assert.Equal(t, "messages_ru.po", generateOutputFilename("messages.pot", "ru", localizationExtension("messages.pot")))
+}
+
 // TestGetAPIKeyFromEnv tests API key retrieval from environment
 func TestGetAPIKeyFromEnvComprehensive(t *testing.T) {
 	tests := []struct {
@@ -372,6 +383,36 @@ func TestScriptConversionComprehensive(t *testing.T) {
 	}
 }
 
+// TestTransliterateBook tests transliteration of books in other languages
+func TestTransliterateBook(t *testing.T) {
+	newBook := func() *ebook.Book {
+		return &ebook.Book{
+			Metadata: ebook.Metadata{Title: "Київ", Authors: []string{"Леся Українка"}},
+			Chapters: []ebook.Chapter{{
+				Title:    "Розділ І",
+				Sections: []ebook.Section{{Content: "Згурівка"}},
+			}},
+		}
+	}
+
+	book := newBook()
+	require.NoError(t, transliterateBook(book, "uk", ""))
+	assert.Equal(t, "Kyiv", book.Metadata.Title)
+	assert.Equal(t, "Lesia Ukrainka", book.Metadata.Authors[0])
+	assert.Equal(t, "Rozdil I", book.Chapters[0].Title)
+	assert.Equal(t, "Zghurivka", book.Chapters[0].Sections[0].Content)
+
+	book = newBook()
+	require.NoError(t, transliterateBook(book, "uk", "iso9"))
+	assert.Equal(t, "Kiïv", book.Metadata.Title)
+
+	// Languages without a scheme are left alone unless a standard is requested
+	book = newBook()
+	require.NoError(t, transliterateBook(book, "de", ""))
+	assert.Equal(t, "Київ", book.Metadata.Title)
+	assert.Error(t, transliterateBook(book, "de", "iso9"))
+}
+
 // TestWriteAsText tests text writing functionality
 func TestWriteAsTextComprehensive(t *testing.T) {
 	tests := []struct {
@@ -656,6 +697,7 @@ func TestTranslateEbookFunction(t *testing.T) {
 	// Test with minimal parameters
 	t.Run("minimal_parameters", func(t *testing.T) {
 		err := translateEbook(
+			context.Background(),
 			book,
 			"test_output.epub",
 			"epub",
@@ -691,6 +733,7 @@ func TestTranslateEbookFunction(t *testing.T) {
 		}
 		
 		err := translateEbook(
+			context.Background(),
 			book,
 			"test_output.epub",
 			"epub",
@@ -712,4 +755,55 @@ func TestTranslateEbookFunction(t *testing.T) {
 		// This might be due to test mode or mock translators being used
 		assert.NoError(t, err)
 	})
-}
\ No newline at end of file
+}
+
+// TestRunValidate tests the validate subcommand
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.epub")
+	require.NoError(t, ebook.NewEPUBWriter().Write(createTestBook(t, "Test Book", "Test content"), valid))
+
+	invalid := filepath.Join(dir, "invalid.epub")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a zip"), 0644))
+
+	var out bytes.Buffer
+	assert.Equal(t, 0, runValidate([]string{valid}, &out))
+	assert.Contains(t, out.String(), "valid.epub: valid (0 errors, 0 warnings)")
+
+	out.Reset()
+	assert.Equal(t, 1, runValidate([]string{valid, invalid}, &out))
+	assert.Contains(t, out.String(), "invalid.epub: error [container] not a ZIP archive")
+	assert.Contains(t, out.String(), "invalid.epub: invalid (1 errors, 0 warnings)")
+
+	out.Reset()
+	assert.Equal(t, 1, runValidate([]string{"-json", invalid}, &out))
+	var reports []ebook.ValidationReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, ebook.SeverityError, reports[0].Issues[0].Severity)
+
+	out.Reset()
+	assert.Equal(t, 2, runValidate(nil, &out))
+	assert.Contains(t, out.String(), "Usage: translator validate")
+}
+
+func TestTranslateBilingualRejectsFormat(t *testing.T) {
+	book := createTestBook(t, "Test Book", "Test content")
+
+	err := translateBilingual(
+		context.Background(),
+		book,
+		filepath.Join(t.TempDir(), "out.fb2"),
+		"fb2",
+		ebook.BilingualSideBySide,
+		"openai", "gpt-3.5-turbo", "test-key", "", "",
+		nil,
+		language.English,
+		language.Spanish,
+		nil,
+		false,
+		false,
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bilingual output supports epub, html, docx and md")
+}
@@ -1,23 +1,31 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"digital.vasic.translator/internal/cache"
+	appconfig "digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/coordination"
+	"digital.vasic.translator/pkg/deployment"
+	"digital.vasic.translator/pkg/distributed"
 	"digital.vasic.translator/pkg/events"
-	"digital.vasic.translator/pkg/grpc"
+	grpcserver "digital.vasic.translator/pkg/grpc"
 	"digital.vasic.translator/pkg/grpc/proto"
 	"digital.vasic.translator/pkg/logger"
+	"digital.vasic.translator/pkg/metrics"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
 )
 
 const (
@@ -31,7 +39,9 @@ type ServerConfig struct {
 	MaxConnections int
 	EnableReflection bool
 	EnableMetrics  bool
+	MetricsAddress string
 	LogLevel       string
+	ConfigFile     string
 }
 
 func main() {
@@ -54,18 +64,128 @@ func main() {
 	eventBus := events.NewEventBus()
 	
 	// Initialize core translator
-	coreTranslator := grpc.NewCoreTranslator(logger)
+	coreTranslator := grpcserver.NewCoreTranslator(logger)
 	
 	// Initialize server configuration
-	serverConfig := &grpc.ServerConfig{
+	serverConfig := &grpcserver.ServerConfig{
 		MaxConcurrentTranslations: 50,
 		SessionTimeout:          24 * time.Hour,
 		StreamBufferSize:        1000,
-		EnableMetrics:           config.EnableMetrics,
 	}
 	
 	// Create gRPC server
-	grpcServer := grpc.NewServer(eventBus, logger, coreTranslator, serverConfig)
+	grpcServer := grpcserver.NewServer(eventBus, logger, coreTranslator, serverConfig)
+	
+	// Load translator configuration shared with the REST server
+	appConfig := appconfig.DefaultConfig()
+	var store storage.Storage
+	if config.ConfigFile != "" {
+		loaded, err := appconfig.LoadConfig(config.ConfigFile)
+		if err != nil {
+			logger.Fatal("Failed to load configuration", map[string]interface{}{
+				"config": config.ConfigFile,
+				"error":  err.Error(),
+			})
+		}
+		if err := loaded.Validate(); err != nil {
+			logger.Fatal("Invalid configuration", map[string]interface{}{
+				"config": config.ConfigFile,
+				"error":  err.Error(),
+			})
+		}
+		appConfig = loaded
+		
+		// Persist sessions and preparation results
+		store, err = storage.NewStorage(&storage.Config{
+			Type:     appConfig.Storage.Type,
+			Database: appConfig.Storage.Database,
+			Host:     appConfig.Storage.Host,
+			Port:     appConfig.Storage.Port,
+			Username: appConfig.Storage.Username,
+			Password: appConfig.Storage.Password,
+			SSLMode:  appConfig.Storage.SSLMode,
+		})
+		if err != nil {
+			logger.Warn("Failed to initialize storage, sessions kept in memory", map[string]interface{}{
+				"error": err.Error(),
+			})
+			store = nil
+		} else {
+			defer store.Close()
+			if err := grpcServer.SetSessionStore(store); err != nil {
+				logger.Warn("Failed to restore sessions", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+	}
+	
+	// Manage remote workers the same way the REST server does
+	var distributedManager *distributed.DistributedManager
+	if appConfig.Distributed.Enabled {
+		apiLogger, err := deployment.NewAPICommunicationLogger("workers_api_communication.log")
+		if err != nil {
+			logger.Warn("Failed to initialize API logger", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		
+		distributedManager = distributed.NewDistributedManager(appConfig, eventBus, apiLogger)
+		localCoordinator := coordination.NewMultiLLMCoordinator(coordination.CoordinatorConfig{
+			EventBus: eventBus,
+		})
+		if err := distributedManager.Initialize(localCoordinator); err != nil {
+			logger.Warn("Failed to initialize distributed manager", map[string]interface{}{
+				"error": err.Error(),
+			})
+			distributedManager = nil
+		}
+	}
+	
+	// Scope sessions, cached translations and preparation results to projects
+	if store != nil {
+		var authService *security.AuthService
+		if appConfig.Security.EnableAuth {
+			authService = security.NewAuthService(appConfig.Security.JWTSecret, 24*time.Hour)
+		}
+		grpcServer.SetProjectAccess(store, authService)
+	}
+	
+	translationCache := cache.NewCache(
+		time.Duration(appConfig.Translation.CacheTTL)*time.Second,
+		appConfig.Translation.CacheEnabled,
+	)
+	
+	// Collect metrics for the Prometheus / OpenMetrics endpoint
+	var serverMetrics *metrics.Metrics
+	if config.EnableMetrics {
+		serverMetrics = metrics.New()
+		if err := serverMetrics.RegisterCache(translationCache); err != nil {
+			logger.Warn("Failed to register cache metrics", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		if distributedManager != nil {
+			if err := serverMetrics.Register(distributedManager.Collector()); err != nil {
+				logger.Warn("Failed to register distributed metrics", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		grpcServer.SetMetrics(serverMetrics)
+	}
+	
+	// Share text translation, preparation and worker management with the REST API
+	translatorService := service.New(appConfig, eventBus, func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		trans, err := service.NewLLMTranslator(appConfig, provider, model, sourceLang, targetLang)
+		if err != nil {
+			return nil, err
+		}
+		return serverMetrics.InstrumentTranslator(trans, service.TranslatorLabels(appConfig, provider, model, sourceLang, targetLang)), nil
+	}, distributedManager, store)
+	translatorService.SetCache(translationCache)
+	translatorService.SetMetrics(serverMetrics)
+	grpcServer.SetService(translatorService)
 	
 	// Create listener
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.Address, config.Port))
@@ -79,12 +199,33 @@ func main() {
 	// Register reflection if enabled
 	if config.EnableReflection {
 		reflection.Register(grpcServer.GetGRPCServer())
-		logger.Info("gRPC reflection enabled")
+		logger.Info("gRPC reflection enabled", nil)
 	}
 	
 	// Register translation service
 	proto.RegisterTranslationServiceServer(grpcServer.GetGRPCServer(), grpcServer)
 	
+	// Serve metrics over HTTP on their own address
+	if serverMetrics != nil {
+		path := appConfig.Metrics.Path
+		if path == "" {
+			path = "/metrics"
+		}
+		mux := http.NewServeMux()
+		mux.Handle(path, serverMetrics.Handler())
+		go func() {
+			logger.Info("Metrics endpoint starting", map[string]interface{}{
+				"address": config.MetricsAddress,
+				"path":    path,
+			})
+			if err := http.ListenAndServe(config.MetricsAddress, mux); err != nil {
+				logger.Error("Metrics endpoint failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}()
+	}
+	
 	// Start server in goroutine
 	errChan := make(chan error, 1)
 	go func() {
@@ -107,9 +248,8 @@ func main() {
 			"error": err.Error(),
 		})
 	case <-quit:
-		logger.Info("Shutting down gRPC server...")
+		logger.Info("Shutting down gRPC server...", nil)
 		grpcServer.Shutdown()
-		logger.Info("gRPC server shutdown complete")
 	}
 }
 
@@ -121,8 +261,10 @@ func parseFlags() *ServerConfig {
 	flag.IntVar(&config.Port, "port", 50051, "Server port")
 	flag.IntVar(&config.MaxConnections, "max-connections", 1000, "Maximum concurrent connections")
 	flag.BoolVar(&config.EnableReflection, "reflection", true, "Enable gRPC reflection")
-	flag.BoolVar(&config.EnableMetrics, "metrics", true, "Enable metrics collection")
+	flag.BoolVar(&config.EnableMetrics, "metrics", false, "Serve Prometheus / OpenMetrics metrics on -metrics-address")
+	flag.StringVar(&config.MetricsAddress, "metrics-address", "127.0.0.1:9090", "Address of the metrics endpoint")
 	flag.StringVar(&config.LogLevel, "log-level", "info", "Log level: debug, info, warn, error")
+	flag.StringVar(&config.ConfigFile, "config", "", "Translator configuration file (providers, storage)")
 	
 	versionFlag := flag.Bool("version", false, "Show version information")
 	help := flag.Bool("help", false, "Show help information")
@@ -154,14 +296,16 @@ Options:
   -port <port>              Server port (default: 50051)
   -max-connections <num>    Maximum concurrent connections (default: 1000)
   -reflection               Enable gRPC reflection (default: true)
-  -metrics                  Enable metrics collection (default: true)
+  -metrics                  Serve Prometheus / OpenMetrics metrics (default: false)
+  -metrics-address <addr>   Address of the unauthenticated metrics endpoint (default: 127.0.0.1:9090)
   -log-level <level>        Log level: debug, info, warn, error (default: info)
+  -config <file>            Translator configuration file (providers, storage)
   -version                  Show version information
   -help                     Show this help
 
 Examples:
   grpc-server -port 50051 -address 127.0.0.1
-  grpc-server -log-level debug -metrics false
+  grpc-server -log-level debug -metrics -metrics-address 127.0.0.1:9090
   grpc-server -reflection -max-connections 500
 
 Features:
@@ -188,10 +332,16 @@ Services:
     - StreamTranslationProgress: Stream progress events
     - GetProviders: Get available providers
     - SubscribeEvents: Subscribe to system events
+    - TranslateText: Translate a piece of text
+    - TranslateSegments: Stream segments, receive translations as they complete
+    - ConvertScript, DetectLanguage: Script conversion and language detection
+    - AnalyzePreparation, GetPreparationResult: Preparation analysis
+    - GetDistributedStatus, DiscoverWorkers, PairWorker, UnpairWorker: Worker management
+    - GetVersionHealth, ListVersionAlerts, CheckVersionDrift, AcknowledgeAlert: Version monitoring
 
 Monitoring:
   - Health check: Available through service calls
-  - Metrics: Available if enabled
+  - Metrics: Served at http://<metrics-address>/metrics with -metrics
   - Event streaming: Real-time progress and system events
   - Provider status: Available through GetProviders API
 
@@ -205,8 +355,8 @@ For more information, see the project documentation.
 `, appVersion)
 }
 
-// parseLogLevel converts string to logger.Level
-func parseLogLevel(level string) logger.Level {
+// parseLogLevel normalizes a log level flag, defaulting to info
+func parseLogLevel(level string) string {
 	switch level {
 	case "debug":
 		return logger.DEBUG
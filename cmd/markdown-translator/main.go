@@ -37,6 +37,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx := context.Background()
+
 	// Validate input file
 	if _, err := os.Stat(*inputFile); os.IsNotExist(err) {
 		log.Fatalf("Input file does not exist: %s", *inputFile)
@@ -111,7 +113,7 @@ func main() {
 		if !isMarkdownInput {
 			parser := ebook.NewUniversalParser()
 			var err error
-			book, err = parser.Parse(*inputFile)
+			book, err = parser.ParseContext(ctx, *inputFile)
 			if err != nil {
 				log.Fatalf("Failed to parse book for preparation: %v", err)
 			}
@@ -149,7 +151,6 @@ func main() {
 			log.Fatalf("Failed to create preparation coordinator: %v", err)
 		}
 
-		ctx := context.Background()
 		prepResult, err = prepCoordinator.PrepareBook(ctx, book)
 		if err != nil {
 			log.Printf("⚠️  Warning: Preparation failed: %v", err)
@@ -185,7 +186,6 @@ func main() {
 
 	// Step 3: Translate Markdown
 	fmt.Printf("🌍 Step %d/%d: Translating markdown content...\n", stepNum, totalSteps)
-	ctx := context.Background()
 	mdTranslator := markdown.NewMarkdownTranslator(func(text string) (string, error) {
 		return llmTranslator.Translate(ctx, text, "")
 	})
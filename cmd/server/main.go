@@ -10,8 +10,14 @@ import (
 	"digital.vasic.translator/pkg/deployment"
 	"digital.vasic.translator/pkg/distributed"
 	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/metrics"
 	"digital.vasic.translator/pkg/models"
 	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/tracing"
+	"digital.vasic.translator/pkg/translator"
+	"digital.vasic.translator/pkg/webhooks"
 	"digital.vasic.translator/pkg/websocket"
 	"flag"
 	"fmt"
@@ -28,6 +34,9 @@ import (
 
 const version = "1.0.0"
 
+// shutdownTracing flushes pending spans; nil unless tracing is enabled
+var shutdownTracing func(context.Context) error
+
 func main() {
 	// Parse command-line flags
 	configFile := flag.String("config", "config.json", "Configuration file path")
@@ -59,8 +68,33 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
+	// Export spans to the OTLP collector
+	if cfg.Tracing.Enabled {
+		shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+			Endpoint:       cfg.Tracing.Endpoint,
+			Insecure:       cfg.Tracing.Insecure,
+			ServiceName:    cfg.Tracing.ServiceName,
+			ServiceVersion: version,
+			SampleRatio:    cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			log.Printf("Warning: failed to initialize tracing: %v", err)
+		} else {
+			shutdownTracing = shutdown
+			log.Printf("Exporting traces to %s", cfg.Tracing.Endpoint)
+		}
+	}
+
 	// Initialize components
 	eventBus := events.NewEventBus()
+	if cfg.Events.LogType != "" {
+		eventLog, err := events.NewEventLog(cfg.Events.LogType, cfg.Events.LogPath)
+		if err != nil {
+			log.Fatalf("Failed to open event log: %v", err)
+		}
+		defer eventLog.Close()
+		eventBus.SetLog(eventLog)
+	}
 	translationCache := cache.NewCache(time.Duration(cfg.Translation.CacheTTL)*time.Second, cfg.Translation.CacheEnabled)
 	userRepo := models.NewInMemoryUserRepository()
 	authService := security.NewUserAuthService(cfg.Security.JWTSecret, 24*time.Hour, userRepo)
@@ -95,6 +129,46 @@ func main() {
 		}
 	}
 
+	// Collect metrics for the Prometheus / OpenMetrics endpoint
+	var serverMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		serverMetrics = metrics.New()
+		if err := serverMetrics.RegisterCache(translationCache); err != nil {
+			log.Printf("Warning: failed to register cache metrics: %v", err)
+		}
+		if err := serverMetrics.RegisterRateLimiter(rateLimiter); err != nil {
+			log.Printf("Warning: failed to register rate limiter metrics: %v", err)
+		}
+		if dm, ok := distributedManager.(*distributed.DistributedManager); ok {
+			if err := serverMetrics.Register(dm.Collector()); err != nil {
+				log.Printf("Warning: failed to register distributed metrics: %v", err)
+			}
+		}
+	}
+
+	// Pull jobs from a coordinator that cannot reach this server
+	if pw := cfg.Distributed.PullWorker; pw.Enabled {
+		worker := distributed.NewQueueWorker(
+			distributed.NewHTTPQueueClient(pw.CoordinatorURL, pw.Token, nil),
+			distributed.WorkerRegistration{
+				WorkerID: pw.WorkerID,
+				Tags:     pw.Tags,
+				Capacity: pw.Capacity,
+				Provider: pw.Provider,
+				Model:    pw.Model,
+			},
+			func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+				trans, err := service.NewLLMTranslator(cfg, provider, model, sourceLang, targetLang)
+				if err != nil {
+					return nil, err
+				}
+				return serverMetrics.InstrumentTranslator(trans, service.TranslatorLabels(cfg, provider, model, sourceLang, targetLang)), nil
+			},
+		)
+		go worker.Run(context.Background())
+		log.Printf("Pulling jobs from coordinator %s as worker %s", pw.CoordinatorURL, pw.WorkerID)
+	}
+
 	// Start WebSocket hub
 	go wsHub.Run()
 
@@ -106,25 +180,94 @@ func main() {
 	router := gin.Default()
 
 	// Setup middleware
+	if shutdownTracing != nil {
+		router.Use(tracing.Middleware())
+	}
 	router.Use(corsMiddleware(cfg.Security.CORSOrigins))
 	router.Use(rateLimitMiddleware(rateLimiter))
 
 	// Create API handler
 	apiHandler := api.NewHandler(cfg, eventBus, translationCache, authService, wsHub, distributedManager)
+	apiHandler.SetMetrics(serverMetrics)
+
+	// Initialize persistent storage for projects, sessions and preparation results
+	store, err := storage.NewStorage(&storage.Config{
+		Type:     cfg.Storage.Type,
+		Database: cfg.Storage.Database,
+		Host:     cfg.Storage.Host,
+		Port:     cfg.Storage.Port,
+		Username: cfg.Storage.Username,
+		Password: cfg.Storage.Password,
+		SSLMode:  cfg.Storage.SSLMode,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to initialize storage, projects disabled: %v", err)
+		store = nil
+	} else {
+		defer store.Close()
+		apiHandler.SetStorage(store)
+	}
+
+	// Deliver translation and verification events to users' webhooks
+	if cfg.Webhooks.Enabled {
+		dispatcher, err := webhooks.NewDispatcher(webhooks.NewStorageResolver(store), webhooks.Options{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			Timeout:     time.Duration(cfg.Webhooks.TimeoutSeconds) * time.Second,
+			StorePath:   cfg.Webhooks.StorePath,
+
+			AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize webhooks: %v", err)
+		}
+		dispatcher.Start(eventBus)
+		defer dispatcher.Close()
+		apiHandler.SetWebhooks(dispatcher)
+	}
+
+	// Initialize single sign-on if configured
+	if oidcCfg := cfg.Security.OIDC; cfg.Security.EnableAuth && oidcCfg.Enabled {
+		oidcCtx, oidcCancel := context.WithTimeout(context.Background(), 15*time.Second)
+		provider, err := security.NewOIDCProvider(oidcCtx, security.OIDCConfig{
+			IssuerURL:    oidcCfg.IssuerURL,
+			ClientID:     oidcCfg.ClientID,
+			ClientSecret: oidcCfg.ClientSecret,
+			RedirectURL:  oidcCfg.RedirectURL,
+			Scopes:       oidcCfg.Scopes,
+			RoleClaim:    oidcCfg.RoleClaim,
+			RoleMapping:  oidcCfg.RoleMapping,
+			DefaultRoles: oidcCfg.DefaultRoles,
+
+			PostLoginRedirect: oidcCfg.PostLoginRedirect,
+		})
+		oidcCancel()
+		if err != nil {
+			log.Printf("Warning: failed to initialize OIDC provider, SSO disabled: %v", err)
+		} else {
+			apiHandler.SetOIDCProvider(provider)
+		}
+	}
+
 	apiHandler.RegisterRoutes(router)
 
 	// Server configuration
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 
+	// A worker using mutual TLS serves only discovery endpoints until it is paired
+	var handler http.Handler = router
+	if cfg.Server.TLSClientCAFile != "" {
+		handler = distributed.WorkerBootstrapGuard(router)
+	}
+
 	// Create HTTP/3 server if enabled
 	if cfg.Server.EnableHTTP3 {
 		log.Printf("Starting HTTP/3 server on %s", addr)
-		if err := startHTTP3Server(addr, cfg, router); err != nil {
+		if err := startHTTP3Server(addr, cfg, handler); err != nil {
 			log.Fatalf("HTTP/3 server failed: %v", err)
 		}
 	} else {
 		log.Printf("Starting HTTP/2 server on %s", addr)
-		if err := startHTTP2Server(addr, cfg, router); err != nil {
+		if err := startHTTP2Server(addr, cfg, handler); err != nil {
 			log.Fatalf("HTTP/2 server failed: %v", err)
 		}
 	}
@@ -153,11 +296,10 @@ func startHTTP3Server(addr string, cfg *config.Config, handler http.Handler) err
 		NextProtos: []string{"h3"},
 	}
 
-	cert, err := tls.LoadX509KeyPair(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+	tlsConfig, err := loadServerTLS(cfg, tlsConfig)
 	if err != nil {
-		return fmt.Errorf("failed to load TLS certificates: %w", err)
+		return err
 	}
-	tlsConfig.Certificates = []tls.Certificate{cert}
 
 	// Create HTTP/3 server
 	server := &http3.Server{
@@ -191,8 +333,8 @@ func startHTTP3Server(addr string, cfg *config.Config, handler http.Handler) err
 	log.Printf("HTTP/2 (TLS): https://%s", addr)
 	log.Printf("WebSocket: wss://%s/ws", addr)
 
-	// Start HTTP/3 server
-	return server.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+	// Start HTTP/3 server with the configured TLS settings
+	return server.ListenAndServe()
 }
 
 func startHTTP2Server(addr string, cfg *config.Config, handler http.Handler) error {
@@ -201,11 +343,10 @@ func startHTTP2Server(addr string, cfg *config.Config, handler http.Handler) err
 		MinVersion: tls.VersionTLS12,
 	}
 
-	cert, err := tls.LoadX509KeyPair(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+	tlsConfig, err := loadServerTLS(cfg, tlsConfig)
 	if err != nil {
-		return fmt.Errorf("failed to load TLS certificates: %w", err)
+		return err
 	}
-	tlsConfig.Certificates = []tls.Certificate{cert}
 
 	server := &http.Server{
 		Addr:         addr,
@@ -224,6 +365,33 @@ func startHTTP2Server(addr string, cfg *config.Config, handler http.Handler) err
 	return server.ListenAndServeTLS("", "")
 }
 
+// loadServerTLS adds the server certificate to base. When a client CA is
+// configured, the server is a worker using mutual TLS: before pairing installs
+// the files it serves a temporary certificate for discovery, afterwards only
+// the coordinator's certificate is accepted, without restarting.
+func loadServerTLS(cfg *config.Config, base *tls.Config) (*tls.Config, error) {
+	if cfg.Server.TLSClientCAFile == "" {
+		cert, err := tls.LoadX509KeyPair(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
+		base.Certificates = []tls.Certificate{cert}
+		return base, nil
+	}
+
+	tlsConfig, err := distributed.WorkerServerTLSConfig(nil, cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile, cfg.Server.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mutual TLS configuration: %w", err)
+	}
+	tlsConfig.MinVersion = base.MinVersion
+	if len(base.NextProtos) > 0 {
+		tlsConfig.NextProtos = base.NextProtos
+	}
+
+	log.Printf("Mutual TLS enabled, client CA: %s", cfg.Server.TLSClientCAFile)
+	return tlsConfig, nil
+}
+
 func handleShutdown(http3Server *http3.Server, http2Server *http.Server) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -246,6 +414,12 @@ func handleShutdown(http3Server *http3.Server, http2Server *http.Server) {
 		}
 	}
 
+	if shutdownTracing != nil {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Tracing shutdown error: %v", err)
+		}
+	}
+
 	log.Println("Server stopped")
 	os.Exit(0)
 }
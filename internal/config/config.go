@@ -13,9 +13,48 @@ type Config struct {
 	Translation TranslationConfig `json:"translation"`
 	Preparation PreparationConfig `json:"preparation"`
 	Distributed DistributedConfig `json:"distributed"`
+	Storage     StorageConfig     `json:"storage"`
+	Events      EventsConfig      `json:"events"`
+	Webhooks    WebhooksConfig    `json:"webhooks"`
+	Metrics     MetricsConfig     `json:"metrics"`
+	Tracing     TracingConfig     `json:"tracing"`
 	Logging     LoggingConfig     `json:"logging"`
 }
 
+// EventsConfig configures the durable event log used to replay events
+type EventsConfig struct {
+	LogType string `json:"log_type"` // "" (disabled), "file" or "sqlite"
+	LogPath string `json:"log_path"`
+}
+
+// WebhooksConfig configures the delivery of events to users' webhook endpoints
+type WebhooksConfig struct {
+	Enabled        bool   `json:"enabled"`
+	StorePath      string `json:"store_path"` // JSON file holding the subscriptions
+	MaxAttempts    int    `json:"max_attempts"`
+	TimeoutSeconds int    `json:"timeout_seconds"`
+
+	// AllowPrivateNetworks permits endpoints on loopback, private and link-local addresses
+	AllowPrivateNetworks bool `json:"allow_private_networks"`
+}
+
+// MetricsConfig configures the Prometheus / OpenMetrics endpoint. It is off
+// by default; without a token it is served to anyone who can reach the server.
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+	Token   string `json:"token,omitempty"` // bearer token scrapers must send, if set
+}
+
+// TracingConfig configures the export of OpenTelemetry spans over OTLP/HTTP
+type TracingConfig struct {
+	Enabled     bool    `json:"enabled"`
+	Endpoint    string  `json:"endpoint"` // collector host:port, e.g. "localhost:4318"
+	Insecure    bool    `json:"insecure"` // send spans over plain HTTP
+	ServiceName string  `json:"service_name"`
+	SampleRatio float64 `json:"sample_ratio"` // fraction of new traces recorded, 0 to 1
+}
+
 // ServerConfig represents server configuration
 type ServerConfig struct {
 	Host          string `json:"host"`
@@ -26,16 +65,37 @@ type ServerConfig struct {
 	ReadTimeout   int    `json:"read_timeout"`
 	WriteTimeout  int    `json:"write_timeout"`
 	MaxUploadSize int64  `json:"max_upload_size"`
+
+	// TLSClientCAFile enables mutual TLS: only clients with a certificate from this CA are accepted
+	TLSClientCAFile string `json:"tls_client_ca_file,omitempty"`
 }
 
 // SecurityConfig represents security configuration
 type SecurityConfig struct {
-	EnableAuth     bool     `json:"enable_auth"`
-	JWTSecret      string   `json:"jwt_secret"`
-	APIKeyHeader   string   `json:"api_key_header"`
-	RateLimitRPS   int      `json:"rate_limit_rps"`
-	RateLimitBurst int      `json:"rate_limit_burst"`
-	CORSOrigins    []string `json:"cors_origins"`
+	EnableAuth     bool       `json:"enable_auth"`
+	JWTSecret      string     `json:"jwt_secret"`
+	APIKeyHeader   string     `json:"api_key_header"`
+	RateLimitRPS   int        `json:"rate_limit_rps"`
+	RateLimitBurst int        `json:"rate_limit_burst"`
+	CORSOrigins    []string   `json:"cors_origins"`
+	OIDC           OIDCConfig `json:"oidc"`
+}
+
+// OIDCConfig represents OpenID Connect single sign-on configuration
+type OIDCConfig struct {
+	Enabled      bool              `json:"enabled"`
+	IssuerURL    string            `json:"issuer_url"`
+	ClientID     string            `json:"client_id"`
+	ClientSecret string            `json:"client_secret,omitempty"`
+	RedirectURL  string            `json:"redirect_url"`
+	Scopes       []string          `json:"scopes,omitempty"`
+	RoleClaim    string            `json:"role_claim,omitempty"`
+	RoleMapping  map[string]string `json:"role_mapping,omitempty"`
+	DefaultRoles []string          `json:"default_roles,omitempty"`
+
+	// PostLoginRedirect is where the browser lands after login; the API token is
+	// delivered in an HttpOnly session cookie rather than in the response body
+	PostLoginRedirect string `json:"post_login_redirect,omitempty"`
 }
 
 // TranslationConfig represents translation configuration
@@ -64,6 +124,35 @@ type DistributedConfig struct {
 	SSHMaxRetries       int                     `json:"ssh_max_retries"`
 	HealthCheckInterval int                     `json:"health_check_interval"`
 	MaxRemoteInstances  int                     `json:"max_remote_instances"`
+	MutualTLS           MutualTLSConfig         `json:"mutual_tls"`
+	Queue               QueueConfig             `json:"queue"`
+	PullWorker          PullWorkerConfig        `json:"pull_worker"`
+}
+
+// QueueConfig configures the job queue that pull workers connect to
+type QueueConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Token        string `json:"token,omitempty"` // shared secret pull workers send in X-Worker-Token
+	LeaseSeconds int    `json:"lease_seconds"`
+}
+
+// PullWorkerConfig makes this server a pull worker of a coordinator it connects to outbound,
+// for workers behind NAT that the coordinator cannot reach
+type PullWorkerConfig struct {
+	Enabled        bool     `json:"enabled"`
+	CoordinatorURL string   `json:"coordinator_url"`
+	Token          string   `json:"token,omitempty"`
+	WorkerID       string   `json:"worker_id"`
+	Capacity       int      `json:"capacity"`
+	Tags           []string `json:"tags,omitempty"`
+	Provider       string   `json:"provider,omitempty"`
+	Model          string   `json:"model,omitempty"`
+}
+
+// MutualTLSConfig configures certificates issued to workers during pairing
+type MutualTLSConfig struct {
+	Enabled bool   `json:"enabled"`
+	CADir   string `json:"ca_dir"` // directory holding the coordinator's CA certificate and key
 }
 
 // WorkerConfig represents a remote worker configuration
@@ -79,6 +168,17 @@ type WorkerConfig struct {
 	Enabled     bool     `json:"enabled"`
 }
 
+// StorageConfig represents persistence configuration for projects and sessions
+type StorageConfig struct {
+	Type     string `json:"type"` // "sqlite", "postgres", "redis"
+	Database string `json:"database"`
+	Host     string `json:"host,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	SSLMode  string `json:"ssl_mode,omitempty"`
+}
+
 // LoggingConfig represents logging configuration
 type LoggingConfig struct {
 	Level      string `json:"level"`
@@ -146,6 +246,43 @@ func DefaultConfig() *Config {
 			SSHMaxRetries:       3,
 			HealthCheckInterval: 30,
 			MaxRemoteInstances:  20,
+			MutualTLS: MutualTLSConfig{
+				Enabled: false,
+				CADir:   "certs/ca",
+			},
+			Queue: QueueConfig{
+				Enabled:      false,
+				LeaseSeconds: 120,
+			},
+			PullWorker: PullWorkerConfig{
+				Enabled:  false,
+				Capacity: 1,
+			},
+		},
+		Storage: StorageConfig{
+			Type:     "sqlite",
+			Database: "translator.db",
+		},
+		Events: EventsConfig{
+			LogType: "",
+			LogPath: "events.jsonl",
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:        false,
+			StorePath:      "webhooks.json",
+			MaxAttempts:    6,
+			TimeoutSeconds: 10,
+		},
+		Metrics: MetricsConfig{
+			Enabled: false,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			ServiceName: "translator",
+			SampleRatio: 1,
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -231,6 +368,20 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT secret is required when authentication is enabled")
 	}
 
+	if oidc := c.Security.OIDC; oidc.Enabled {
+		if oidc.IssuerURL == "" || oidc.ClientID == "" || oidc.RedirectURL == "" {
+			return fmt.Errorf("OIDC issuer URL, client ID and redirect URL are required when OIDC is enabled")
+		}
+	}
+
+	if c.Webhooks.Enabled && !c.Security.EnableAuth {
+		return fmt.Errorf("webhooks require authentication to be enabled")
+	}
+
+	if c.Tracing.Enabled && (c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1) {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+
 	// Validate distributed configuration
 	if err := c.validateDistributedConfig(); err != nil {
 		return err
@@ -241,6 +392,16 @@ func (c *Config) Validate() error {
 
 // validateDistributedConfig validates distributed work configuration
 func (c *Config) validateDistributedConfig() error {
+	if pw := c.Distributed.PullWorker; pw.Enabled {
+		if pw.CoordinatorURL == "" || pw.WorkerID == "" {
+			return fmt.Errorf("pull worker requires coordinator URL and worker ID")
+		}
+	}
+
+	if c.Distributed.Queue.Enabled && c.Distributed.Queue.Token == "" {
+		return fmt.Errorf("job queue requires a worker token when enabled")
+	}
+
 	if !c.Distributed.Enabled {
 		return nil // Skip validation if distributed work is disabled
 	}
@@ -255,8 +416,8 @@ func (c *Config) validateDistributedConfig() error {
 		return fmt.Errorf("SSH max retries cannot be negative")
 	}
 
-	// Validate workers configuration
-	if len(c.Distributed.Workers) == 0 {
+	// Validate workers configuration; pull workers register themselves through the queue
+	if len(c.Distributed.Workers) == 0 && !c.Distributed.Queue.Enabled {
 		return fmt.Errorf("at least one worker must be configured when distributed work is enabled")
 	}
 
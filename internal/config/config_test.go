@@ -297,6 +297,21 @@ func TestConfig_Validate_AuthDisabled(t *testing.T) {
 	assert.NoError(t, err, "Should not require JWT secret when auth is disabled")
 }
 
+// TestConfig_Validate_QueueWithoutToken tests that the job queue needs a worker token
+func TestConfig_Validate_QueueWithoutToken(t *testing.T) {
+	config := DefaultConfig()
+	config.Security.JWTSecret = "test-secret-key-16-chars"
+	config.Distributed.Queue.Enabled = true
+	config.Distributed.Queue.Token = ""
+
+	err := config.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "worker token")
+
+	config.Distributed.Queue.Token = "worker-secret"
+	assert.NoError(t, config.Validate())
+}
+
 // TestConfig_RoundTrip tests saving and loading
 func TestConfig_RoundTrip(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "config-*.json")
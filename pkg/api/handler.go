@@ -2,18 +2,21 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"digital.vasic.translator/internal/cache"
 	"digital.vasic.translator/internal/config"
 	"digital.vasic.translator/pkg/distributed"
 	"digital.vasic.translator/pkg/ebook"
 	"digital.vasic.translator/pkg/events"
 	"digital.vasic.translator/pkg/language"
+	"digital.vasic.translator/pkg/metrics"
 	"digital.vasic.translator/pkg/preparation"
-	"digital.vasic.translator/pkg/script"
 	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
 	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/storage"
 	"digital.vasic.translator/pkg/translator"
-	"digital.vasic.translator/pkg/translator/llm"
+	"digital.vasic.translator/pkg/webhooks"
 	"digital.vasic.translator/pkg/websocket"
 	"fmt"
 	"io"
@@ -30,6 +33,7 @@ import (
 	"github.com/google/uuid"
 	gorillaws "github.com/gorilla/websocket"
 	"errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Handler handles API requests
@@ -40,6 +44,10 @@ type Handler struct {
 	authService        *security.UserAuthService
 	wsHub              *websocket.Hub
 	distributedManager interface{} // Will be *distributed.DistributedManager
+	storage            storage.Storage
+	oidcProvider       *security.OIDCProvider
+	webhooks           *webhooks.Dispatcher
+	metrics            *metrics.Metrics
 }
 
 // NewHandler creates a new API handler
@@ -68,13 +76,23 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 	router.GET("/", h.apiInfo)
 
 	// WebSocket endpoint
-	router.GET("/ws", h.websocketHandler)
+	router.GET("/ws", h.projectScope(), h.websocketHandler)
+
+	// Prometheus / OpenMetrics exporter
+	if h.metrics != nil {
+		path := h.config.Metrics.Path
+		if path == "" {
+			path = "/metrics"
+		}
+		router.GET(path, h.metricsTokenAuth(), gin.WrapH(h.metrics.Handler()))
+	}
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	v1.Use(h.projectScope())
 	{
 		// Translation endpoints
-		v1.POST("/translate", h.translateText)
+		v1.POST("/translate", h.projectEditor(), h.translateText)
 		v1.POST("/translate/fb2", h.translateFB2)
 		v1.POST("/translate/batch", h.batchTranslate)
 
@@ -87,16 +105,17 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 		v1.GET("/providers", h.listProviders)
 		v1.GET("/stats", h.getStats)
 		v1.GET("/languages", h.listLanguages)
+		v1.GET("/events", h.listEvents)
 
 		// Translation validation
 		v1.POST("/translate/validate", h.validateTranslationRequest)
 
 		// Preparation endpoints
-		v1.POST("/preparation/analyze", h.preparationAnalysis)
+		v1.POST("/preparation/analyze", h.projectEditor(), h.preparationAnalysis)
 		v1.GET("/preparation/result/:session_id", h.getPreparationResult)
 
 		// Additional translation endpoints
-		v1.POST("/translate/ebook", h.translateEbook)
+		v1.POST("/translate/ebook", h.projectEditor(), h.translateEbook)
 		v1.POST("/translate/cancel/:session_id", h.cancelTranslation)
 
 		// Distributed work endpoints
@@ -106,6 +125,8 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 			v1.POST("/distributed/workers/:worker_id/pair", h.pairWorker)
 			v1.DELETE("/distributed/workers/:worker_id/pair", h.unpairWorker)
 			v1.POST("/distributed/translate", h.translateDistributed)
+			v1.POST("/distributed/jobs", h.runDistributedJob)
+			h.RegisterQueueRoutes(v1)
 
 			// Update endpoints for workers
 			v1.POST("/update/upload", h.uploadUpdate)
@@ -133,12 +154,17 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 		if h.config.Security.EnableAuth {
 			v1.POST("/auth/login", h.login)
 			v1.POST("/auth/token", h.generateToken)
+			if h.oidcProvider != nil {
+				h.RegisterOIDCRoutes(v1)
+			}
 
 			// Protected routes
 			protected := v1.Group("/")
 			protected.Use(h.authMiddleware())
 			{
 				protected.GET("/profile", h.getProfile)
+				h.RegisterProjectRoutes(protected)
+				h.RegisterWebhookRoutes(protected)
 			}
 		}
 	}
@@ -185,74 +211,34 @@ func (h *Handler) translateText(c *gin.Context) {
 		return
 	}
 
-	// Create translator
-	trans, err := h.createTranslator(req.Provider, req.Model)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Generate session ID
-	sessionID := uuid.New().String()
-
-	// Translate - use distributed coordinator if available
-	ctx := context.Background()
-	var translated string
-
-	if h.distributedManager != nil {
-		// Try distributed translation first
-		if dm, ok := h.distributedManager.(*distributed.DistributedManager); ok {
-			distributedResult, distributedErr := dm.TranslateDistributed(ctx, req.Text, req.Context)
-			if distributedErr == nil {
-				translated = distributedResult
-			} else {
-				// Fallback to local translation
-				h.eventBus.Publish(events.Event{
-					Type:      "distributed_fallback",
-					SessionID: sessionID,
-					Message:   "Distributed translation failed, using local translator",
-					Data: map[string]interface{}{
-						"error": distributedErr.Error(),
-					},
-				})
-				localResult, localErr := trans.TranslateWithProgress(ctx, req.Text, req.Context, h.eventBus, sessionID)
-				if localErr != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": localErr.Error()})
-					return
-				}
-				translated = localResult
-			}
-		} else {
-			// Type assertion failed, use local translator
-			localResult, localErr := trans.TranslateWithProgress(ctx, req.Text, req.Context, h.eventBus, sessionID)
-			if localErr != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": localErr.Error()})
-				return
-			}
-			translated = localResult
+	// Fall back to the project's provider settings
+	project := scopedProject(c)
+	if project != nil && req.Provider == "" {
+		req.Provider = project.ProviderSettings.Provider
+		if req.Model == "" {
+			req.Model = project.ProviderSettings.Model
 		}
-	} else {
-		// Use local translator
-		localResult, localErr := trans.TranslateWithProgress(ctx, req.Text, req.Context, h.eventBus, sessionID)
-		if localErr != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": localErr.Error()})
-			return
-		}
-		translated = localResult
 	}
 
-	// Convert script if requested
-	if req.Script == "latin" {
-		converter := script.NewConverter()
-		translated = converter.ToLatin(translated)
+	result, err := h.service().TranslateText(c.Request.Context(), service.TextRequest{
+		Text:     req.Text,
+		Provider: req.Provider,
+		Model:    req.Model,
+		Context:  req.Context,
+		Script:   req.Script,
+		Project:  project,
+	})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"original":   req.Text,
-		"translated": translated,
-		"provider":   trans.GetName(),
-		"session_id": sessionID,
-		"stats":      trans.GetStats(),
+		"original":   result.Original,
+		"translated": result.Translated,
+		"provider":   result.Provider,
+		"session_id": result.SessionID,
+		"stats":      result.Stats,
 	})
 }
 
@@ -310,21 +296,21 @@ func (h *Handler) translateFB2(c *gin.Context) {
 
 	// Parse ebook
 	parser := ebook.NewUniversalParser()
-	book, err := parser.Parse(tempFile.Name())
+	book, err := parser.ParseContext(c.Request.Context(), tempFile.Name())
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to parse ebook: %v", err)})
 		return
 	}
 
 	// Create translator
-	baseTrans, err := h.createTranslator(provider, model)
+	baseTrans, err := h.createTranslator(provider, model, "", "")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Translate
-	ctx := context.Background()
+	// Translate, keeping the request's trace but not its cancellation
+	ctx := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(c.Request.Context()))
 
 	if h.config.Preparation.Enabled {
 		// Use preparation-aware translation
@@ -368,6 +354,12 @@ func (h *Handler) translateFB2(c *gin.Context) {
 		} else {
 			log.Printf("Preparation analysis saved to: %s", prepAnalysisPath)
 		}
+	} else if provider == "distributed" {
+		// Ship whole chapters to the workers instead of one request per segment
+		if err := h.service().TranslateBook(ctx, book, scopedProject(c), sessionID); err != nil {
+			c.JSON(statusForError(err), gin.H{"error": err.Error()})
+			return
+		}
 	} else {
 		// Use standard translation
 		if err := h.translateBook(ctx, book, baseTrans, sessionID); err != nil {
@@ -448,7 +440,7 @@ func (h *Handler) batchTranslate(c *gin.Context) {
 	}
 
 	// Create translator
-	trans, err := h.createTranslator(req.Provider, req.Model)
+	trans, err := h.createTranslator(req.Provider, req.Model, "", "")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -482,8 +474,10 @@ func (h *Handler) batchTranslate(c *gin.Context) {
 // convertScript handles script conversion
 func (h *Handler) convertScript(c *gin.Context) {
 	var req struct {
-		Text   string `json:"text" binding:"required"`
-		Target string `json:"target" binding:"required"`
+		Text     string `json:"text" binding:"required"`
+		Target   string `json:"target" binding:"required"`
+		Language string `json:"language"` // Serbian when empty
+		Standard string `json:"standard"` // national when empty
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -491,15 +485,24 @@ func (h *Handler) convertScript(c *gin.Context) {
 		return
 	}
 
-	converter := script.NewConverter()
-	var result string
+	if req.Language != "" && (req.Language != "sr" || req.Standard != "") {
+		result, err := h.service().Transliterate(req.Text, req.Language, req.Standard, req.Target)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-	switch req.Target {
-	case "latin":
-		result = converter.ToLatin(req.Text)
-	case "cyrillic":
-		result = converter.ToCyrillic(req.Text)
-	default:
+		c.JSON(http.StatusOK, gin.H{
+			"original":  req.Text,
+			"converted": result,
+			"target":    req.Target,
+			"language":  req.Language,
+		})
+		return
+	}
+
+	result, err := h.service().ConvertScript(req.Text, req.Target)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target script"})
 		return
 	}
@@ -514,6 +517,9 @@ func (h *Handler) convertScript(c *gin.Context) {
 // getStatus returns translation status for a session
 func (h *Handler) getStatus(c *gin.Context) {
 	sessionID := c.Param("session_id")
+	if !h.sessionVisible(c, sessionID) {
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"session_id": sessionID,
@@ -521,6 +527,47 @@ func (h *Handler) getStatus(c *gin.Context) {
 	})
 }
 
+// listEvents returns the logged events of a session after a sequence number
+func (h *Handler) listEvents(c *gin.Context) {
+	after, err := strconv.ParseInt(c.DefaultQuery("after", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid after sequence"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+
+	if !h.sessionVisible(c, sessionID) {
+		return
+	}
+
+	list, err := h.service().EventsSince(sessionID, after, limit)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	lastSequence := after
+	if len(list) > 0 {
+		lastSequence = list[len(list)-1].Sequence
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":    sessionID,
+		"events":        list,
+		"last_sequence": lastSequence,
+	})
+}
+
 // listProviders lists available translation providers
 func (h *Handler) listProviders(c *gin.Context) {
 	providers := []gin.H{
@@ -669,6 +716,16 @@ func (h *Handler) getStats(c *gin.Context) {
 
 // websocketHandler handles WebSocket connections
 func (h *Handler) websocketHandler(c *gin.Context) {
+	// Clients only receive the events of a session they may see
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+	if !h.sessionVisible(c, sessionID) {
+		return
+	}
+
 	upgrader := gorillaws.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
 			return true // Configure properly in production
@@ -680,7 +737,6 @@ func (h *Handler) websocketHandler(c *gin.Context) {
 		return
 	}
 
-	sessionID := c.Query("session_id")
 	client := &websocket.Client{
 		ID:        uuid.New().String(),
 		SessionID: sessionID,
@@ -697,7 +753,57 @@ func (h *Handler) websocketHandler(c *gin.Context) {
 
 // Helper methods
 
-func (h *Handler) createTranslator(providerName, model string) (translator.Translator, error) {
+// service returns the operations shared with the gRPC API, bound to the handler's current dependencies
+func (h *Handler) service() *service.Service {
+	dm, _ := h.distributedManager.(*distributed.DistributedManager)
+	svc := service.New(h.config, h.eventBus, h.createTranslator, dm, h.storage)
+	svc.SetCache(h.cache)
+	svc.SetMetrics(h.metrics)
+	return svc
+}
+
+// distributedService returns the service for distributed work handlers, responding
+// with an error when distributed work is not available
+func (h *Handler) distributedService(c *gin.Context) (*service.Service, bool) {
+	if h.distributedManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+		return nil, false
+	}
+
+	if _, ok := h.distributedManager.(*distributed.DistributedManager); !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
+		return nil, false
+	}
+
+	return h.service(), true
+}
+
+// statusForError maps service errors to HTTP status codes
+func statusForError(err error) int {
+	switch {
+	case service.IsInvalidArgument(err), isInvalidSubscription(err):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrDistributedUnavailable), errors.Is(err, service.ErrStorageUnavailable),
+		errors.Is(err, service.ErrQueueUnavailable), errors.Is(err, distributed.ErrQueueClosed),
+		errors.Is(err, events.ErrNoEventLog), errors.Is(err, webhooks.ErrDispatcherClosed):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, distributed.ErrUnknownQueueWorker), errors.Is(err, webhooks.ErrSubscriptionNotFound),
+		errors.Is(err, webhooks.ErrDeadLetterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, distributed.ErrLeaseNotFound):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// isInvalidSubscription reports whether err rejects a webhook subscription
+func isInvalidSubscription(err error) bool {
+	var target *webhooks.InvalidSubscriptionError
+	return errors.As(err, &target)
+}
+
+func (h *Handler) createTranslator(providerName, model, sourceLang, targetLang string) (translator.Translator, error) {
 	if providerName == "" {
 		providerName = h.config.Translation.DefaultProvider
 	}
@@ -708,28 +814,34 @@ func (h *Handler) createTranslator(providerName, model string) (translator.Trans
 			return nil, fmt.Errorf("distributed translation not available")
 		}
 		// Return a special distributed translator wrapper
-		return &distributedTranslator{dm: h.distributedManager.(*distributed.DistributedManager)}, nil
+		trans := &distributedTranslator{dm: h.distributedManager.(*distributed.DistributedManager)}
+		return h.metrics.InstrumentTranslator(trans, metrics.Labels{Provider: providerName, Model: model}), nil
 	}
 
-	config := translator.TranslationConfig{
-		SourceLang: "ru",
-		TargetLang: "sr",
-		Provider:   providerName,
-		Model:      model,
-		Options:    make(map[string]interface{}),
+	trans, err := service.NewLLMTranslator(h.config, providerName, model, sourceLang, targetLang)
+	if err != nil {
+		return nil, err
 	}
+	return h.metrics.InstrumentTranslator(trans, service.TranslatorLabels(h.config, providerName, model, sourceLang, targetLang)), nil
+}
+
+// SetMetrics enables the metrics endpoint and instruments the translators of the handler
+func (h *Handler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
 
-	// Load provider config
-	if providerCfg, ok := h.config.Translation.Providers[providerName]; ok {
-		config.APIKey = providerCfg.APIKey
-		config.BaseURL = providerCfg.BaseURL
-		if model == "" {
-			config.Model = providerCfg.Model
+// metricsTokenAuth rejects scrapers without the configured bearer token; with
+// no token configured the metrics are open
+func (h *Handler) metricsTokenAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := h.config.Metrics.Token
+		if token != "" && subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+token)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid metrics token"})
+			c.Abort()
+			return
 		}
-		config.Options = providerCfg.Options
+		c.Next()
 	}
-
-	return llm.NewLLMTranslator(config)
 }
 
 // distributedTranslator wraps the distributed manager to implement translator.Translator interface
@@ -845,19 +957,13 @@ func generateOutputFilename(inputFilename, provider string) string {
 // Authentication middleware
 func (h *Handler) authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		token := requestToken(c)
+		if token == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "No authorization header"})
 			c.Abort()
 			return
 		}
 
-		// Extract token
-		token := authHeader
-		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-			token = authHeader[7:]
-		}
-
 		// Validate token
 		claims, err := h.authService.ValidateToken(token)
 		if err != nil {
@@ -875,6 +981,16 @@ func (h *Handler) authMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requestToken returns the bearer token from the Authorization header, falling
+// back to the session cookie set by single sign-on
+func requestToken(c *gin.Context) string {
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	token, _ := c.Cookie(sessionCookie)
+	return token
+}
+
 // Authentication handlers
 func (h *Handler) login(c *gin.Context) {
 	var req security.LoginRequest
@@ -940,38 +1056,29 @@ func (h *Handler) getProfile(c *gin.Context) {
 // Distributed work handlers
 
 func (h *Handler) getDistributedStatus(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
-	// Type assertion to access methods
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
+	status, err := svc.DistributedStatus()
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	status := dm.GetStatus()
 	c.JSON(http.StatusOK, status)
 }
 
 func (h *Handler) discoverWorkers(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
-		return
-	}
-
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
+	svc, ok := h.distributedService(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	if err := dm.DiscoverAndPairWorkers(ctx); err != nil {
+	if err := svc.DiscoverWorkers(ctx); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -980,8 +1087,8 @@ func (h *Handler) discoverWorkers(c *gin.Context) {
 }
 
 func (h *Handler) pairWorker(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
@@ -991,13 +1098,7 @@ func (h *Handler) pairWorker(c *gin.Context) {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
-		return
-	}
-
-	if err := dm.PairWorker(workerID); err != nil {
+	if err := svc.PairWorker(workerID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1006,8 +1107,8 @@ func (h *Handler) pairWorker(c *gin.Context) {
 }
 
 func (h *Handler) unpairWorker(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
@@ -1017,13 +1118,7 @@ func (h *Handler) unpairWorker(c *gin.Context) {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
-		return
-	}
-
-	if err := dm.UnpairWorker(workerID); err != nil {
+	if err := svc.UnpairWorker(workerID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1032,8 +1127,8 @@ func (h *Handler) unpairWorker(c *gin.Context) {
 }
 
 func (h *Handler) translateDistributed(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
@@ -1047,12 +1142,6 @@ func (h *Handler) translateDistributed(c *gin.Context) {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
@@ -1061,7 +1150,7 @@ func (h *Handler) translateDistributed(c *gin.Context) {
 		sessionID = uuid.New().String()
 	}
 
-	translated, err := dm.TranslateDistributed(ctx, req.Text, req.ContextHint)
+	translated, err := svc.TranslateDistributed(ctx, req.Text, req.ContextHint)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -1073,6 +1162,25 @@ func (h *Handler) translateDistributed(c *gin.Context) {
 	})
 }
 
+// runDistributedJob translates a job shipped by a coordinator on this worker,
+// streaming one JSON message per line as segments complete
+func (h *Handler) runDistributedJob(c *gin.Context) {
+	var job distributed.TranslationJob
+	if err := c.ShouldBindJSON(&job); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Cache-Control", "no-cache")
+
+	err := h.service().RunJob(c.Request.Context(), &job, c.Writer, c.Writer.Flush)
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+	}
+}
+
 // uploadUpdate handles update package uploads
 func (h *Handler) uploadUpdate(c *gin.Context) {
 	// Get the uploaded file
@@ -1231,46 +1339,31 @@ func (h *Handler) rollbackUpdate(c *gin.Context) {
 
 // getVersionMetrics returns comprehensive version management metrics
 func (h *Handler) getVersionMetrics(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
+	metrics, err := svc.VersionMetrics()
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	metrics := dm.GetVersionMetrics()
 	c.JSON(http.StatusOK, metrics)
 }
 
 // getVersionAlerts returns current version drift alerts
 func (h *Handler) getVersionAlerts(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
-		return
-	}
-
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
+	svc, ok := h.distributedService(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
 		return
 	}
 
-	alerts := dm.GetVersionAlerts()
-
 	// Filter alerts by severity if requested
-	severity := c.Query("severity")
-	if severity != "" {
-		filtered := make([]*distributed.DriftAlert, 0)
-		for _, alert := range alerts {
-			if alert.Severity == severity {
-				filtered = append(filtered, alert)
-			}
-		}
-		alerts = filtered
+	alerts, err := svc.VersionAlerts(c.Query("severity"))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -1281,18 +1374,16 @@ func (h *Handler) getVersionAlerts(c *gin.Context) {
 
 // getVersionHealth returns overall version management health status
 func (h *Handler) getVersionHealth(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
+	health, err := svc.VersionHealth()
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	health := dm.GetVersionHealth()
 	c.JSON(http.StatusOK, health)
 }
 
@@ -1374,21 +1465,19 @@ func (h *Handler) getVersionDashboard(c *gin.Context) {
 
 // triggerVersionDriftCheck manually triggers a version drift check
 func (h *Handler) triggerVersionDriftCheck(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
-		return
-	}
-
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
+	svc, ok := h.distributedService(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	alerts := dm.CheckVersionDrift(ctx)
+	alerts, err := svc.CheckVersionDrift(ctx)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":          "Version drift check completed",
@@ -1399,14 +1488,8 @@ func (h *Handler) triggerVersionDriftCheck(c *gin.Context) {
 
 // getAlertHistory returns alert history
 func (h *Handler) getAlertHistory(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
-		return
-	}
-
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
+	svc, ok := h.distributedService(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
 		return
 	}
 
@@ -1418,7 +1501,11 @@ func (h *Handler) getAlertHistory(c *gin.Context) {
 		}
 	}
 
-	alerts := dm.GetAlertHistory(limit)
+	alerts, err := svc.AlertHistory(limit)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"alerts": alerts,
@@ -1429,8 +1516,8 @@ func (h *Handler) getAlertHistory(c *gin.Context) {
 
 // acknowledgeAlert marks an alert as acknowledged
 func (h *Handler) acknowledgeAlert(c *gin.Context) {
-	if h.distributedManager == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Distributed work not available"})
+	svc, ok := h.distributedService(c)
+	if !ok {
 		return
 	}
 
@@ -1449,13 +1536,12 @@ func (h *Handler) acknowledgeAlert(c *gin.Context) {
 		return
 	}
 
-	dm, ok := h.distributedManager.(*distributed.DistributedManager)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid distributed manager"})
+	acknowledged, err := svc.AcknowledgeAlert(alertID, req.AcknowledgedBy)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	if acknowledged := dm.AcknowledgeAlert(alertID, req.AcknowledgedBy); !acknowledged {
+	if !acknowledged {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Alert not found or already acknowledged"})
 		return
 	}
@@ -1837,84 +1923,22 @@ func (h *Handler) preparationAnalysis(c *gin.Context) {
 		return
 	}
 
-	// Generate session ID
-	sessionID := uuid.New().String()
-
-	// Validate target language
-	targetLang, err := language.ParseLanguage(req.TargetLanguage)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid target language: %v", err)})
-		return
-	}
-
-	// Check if input path exists
-	if _, err := os.Stat(req.InputPath); os.IsNotExist(err) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "input path does not exist"})
-		return
-	}
-
-	// Analyze the input
-	analysis := map[string]interface{}{
-		"input_path":      req.InputPath,
-		"target_language": targetLang.Code,
-		"format":          req.Format,
-		"status":          "analyzing",
-		"session_id":      sessionID,
-	}
-
-	// Emit analysis started event
-	startData := make(map[string]interface{})
-	for k, v := range analysis {
-		startData[k] = v
-	}
-	h.eventBus.Publish(events.Event{
-		Type:      events.EventTranslationStarted,
-		SessionID: sessionID,
-		Message:   "Content preparation analysis started",
-		Data:      startData,
+	result, err := h.service().AnalyzePreparation(c.Request.Context(), service.PreparationRequest{
+		InputPath:      req.InputPath,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Format:         req.Format,
+		ProjectID:      c.GetString("project_id"),
 	})
-
-	// Perform basic analysis
-	fileInfo, err := os.Stat(req.InputPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to analyze input: %v", err)})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	analysis["file_size"] = fileInfo.Size()
-	analysis["file_modified"] = fileInfo.ModTime()
-	analysis["is_directory"] = fileInfo.IsDir()
-
-	if fileInfo.IsDir() {
-		// Count files in directory
-		fileCount := 0
-		filepath.Walk(req.InputPath, func(path string, info os.FileInfo, err error) error {
-			if err == nil && !info.IsDir() {
-				fileCount++
-			}
-			return nil
-		})
-		analysis["file_count"] = fileCount
-	}
-
-	analysis["status"] = "completed"
-
-	// Emit completion event
-	completionData := make(map[string]interface{})
-	for k, v := range analysis {
-		completionData[k] = v
-	}
-	h.eventBus.Publish(events.Event{
-		Type:      events.EventTranslationCompleted,
-		SessionID: sessionID,
-		Message:   "Content preparation analysis completed",
-		Data:      completionData,
-	})
-
 	c.JSON(http.StatusOK, gin.H{
-		"session_id": sessionID,
-		"analysis":   analysis,
-		"status":     "completed",
+		"session_id": result.SessionID,
+		"analysis":   result.Analysis,
+		"status":     result.Status,
 	})
 }
 
@@ -1927,6 +1951,29 @@ func (h *Handler) getPreparationResult(c *gin.Context) {
 		return
 	}
 
+	if h.storage != nil {
+		stored, err := h.service().GetPreparationResult(c.Request.Context(), sessionID)
+		// Results of other projects are reported as missing so they cannot be probed
+		if errors.Is(err, storage.ErrPreparationResultNotFound) ||
+			(err == nil && stored.ProjectID != c.GetString("project_id")) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "preparation result not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to load result: %v", err)})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"session_id":   stored.SessionID,
+			"project_id":   stored.ProjectID,
+			"status":       stored.Status,
+			"analysis":     stored.Analysis,
+			"completed_at": stored.CreatedAt.Format(time.RFC3339),
+		})
+		return
+	}
+
 	// For now, return a mock result
 	// In a real implementation, this would query the preparation service
 	result := map[string]interface{}{
@@ -2005,6 +2052,35 @@ func (h *Handler) translateEbook(c *gin.Context) {
 		req.OutputPath = filepath.Join(dir, name+"_translated."+req.Format)
 	}
 
+	if h.storage != nil {
+		now := time.Now()
+		provider := req.Provider
+		model := req.Model
+		if project := scopedProject(c); project != nil && provider == "" {
+			provider = project.ProviderSettings.Provider
+			model = project.ProviderSettings.Model
+		}
+		err := h.storage.CreateSession(c.Request.Context(), &storage.TranslationSession{
+			ID:             sessionID,
+			ProjectID:      c.GetString("project_id"),
+			BookTitle:      strings.TrimSuffix(filepath.Base(req.InputPath), filepath.Ext(req.InputPath)),
+			InputFile:      req.InputPath,
+			OutputFile:     req.OutputPath,
+			SourceLanguage: req.SourceLanguage,
+			TargetLanguage: targetLang.Code,
+			Provider:       provider,
+			Model:          model,
+			Status:         "initializing",
+			StartTime:      now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to create session: %v", err)})
+			return
+		}
+	}
+
 	// Emit start event
 	h.eventBus.Publish(events.Event{
 		Type:      events.EventTranslationStarted,
@@ -9,10 +9,12 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"digital.vasic.translator/internal/cache"
 	"digital.vasic.translator/internal/config"
 	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/metrics"
 	"digital.vasic.translator/pkg/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -603,6 +605,30 @@ func TestConvertScript(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			shouldContain:  "converted",
 		},
+		{
+			name:           "ukrainian transliteration",
+			requestBody:    `{"text":"Згурівка","target":"latin","language":"uk"}`,
+			expectedStatus: http.StatusOK,
+			shouldContain:  "Zghurivka",
+		},
+		{
+			name:           "reversible greek standard",
+			requestBody:    `{"text":"Elláda","target":"greek","language":"el","standard":"iso843"}`,
+			expectedStatus: http.StatusOK,
+			shouldContain:  "Ελλάδα",
+		},
+		{
+			name:           "irreversible standard",
+			requestBody:    `{"text":"Kyiv","target":"cyrillic","language":"uk"}`,
+			expectedStatus: http.StatusBadRequest,
+			shouldContain:  "not reversible",
+		},
+		{
+			name:           "unsupported language",
+			requestBody:    `{"text":"text","target":"latin","language":"xx"}`,
+			expectedStatus: http.StatusBadRequest,
+			shouldContain:  "unsupported transliteration scheme",
+		},
 	}
 	
 	for _, tt := range tests {
@@ -1101,3 +1127,42 @@ func TestDistributedHandlers(t *testing.T) {
 	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
 	assert.Contains(t, w.Body.String(), "Distributed work not available")
 }
+
+func TestMetricsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.DefaultConfig()
+	eventBus := events.NewEventBus()
+	translationCache := cache.NewCache(time.Hour, true)
+	handler := NewHandler(cfg, eventBus, translationCache, nil, websocket.NewHub(eventBus), nil)
+
+	// Without metrics there is no endpoint
+	router := gin.New()
+	handler.RegisterRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	m := metrics.New()
+	assert.NoError(t, m.RegisterCache(translationCache))
+	handler.SetMetrics(m)
+
+	router = gin.New()
+	handler.RegisterRoutes(router)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "translator_cache_entries 0")
+
+	// With a token, scrapers must present it
+	cfg.Metrics.Token = "scrape-secret"
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape-secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
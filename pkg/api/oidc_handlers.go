@@ -0,0 +1,101 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetOIDCProvider enables single sign-on through an OpenID Connect issuer
+func (h *Handler) SetOIDCProvider(provider *security.OIDCProvider) {
+	h.oidcProvider = provider
+}
+
+// RegisterOIDCRoutes registers the authorization-code login routes
+func (h *Handler) RegisterOIDCRoutes(rg *gin.RouterGroup) {
+	oidc := rg.Group("/auth/oidc")
+	{
+		oidc.GET("/login", h.oidcLogin)
+		oidc.GET("/callback", h.oidcCallback)
+	}
+}
+
+const (
+	// oidcStateCookie binds a login attempt to the browser that started it
+	oidcStateCookie = "translator_oidc_state"
+	// sessionCookie carries the API token issued after a single sign-on login
+	sessionCookie = "translator_session"
+)
+
+// oidcLogin redirects the browser to the identity provider. Passing link=email asks
+// to link an existing local account that has the same verified email address
+func (h *Handler) oidcLogin(c *gin.Context) {
+	state, authURL, err := h.oidcProvider.AuthCodeURL(c.Query("link") == "email")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+
+	h.setAuthCookie(c, oidcStateCookie, state, int(h.oidcProvider.StateTTL().Seconds()), http.SameSiteLaxMode)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// oidcCallback completes the login, stores the API token in an HttpOnly session
+// cookie and sends the browser on to the application
+func (h *Handler) oidcCallback(c *gin.Context) {
+	expectedState, _ := c.Cookie(oidcStateCookie)
+	h.setAuthCookie(c, oidcStateCookie, "", -1, http.SameSiteLaxMode)
+
+	if errCode := c.Query("error"); errCode != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":       "Identity provider rejected the login",
+			"reason":      errCode,
+			"description": c.Query("error_description"),
+		})
+		return
+	}
+
+	state := c.Query("state")
+	if expectedState == "" || subtle.ConstantTimeCompare([]byte(expectedState), []byte(state)) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired login state"})
+		return
+	}
+
+	identity, err := h.oidcProvider.Exchange(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		if errors.Is(err, security.ErrOIDCStateInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired login state"})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "SSO authentication failed"})
+		return
+	}
+
+	response, err := h.authService.AuthenticateOIDC(identity)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrUserInactive):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Account is inactive"})
+		case errors.Is(err, models.ErrInvalidCredentials), errors.Is(err, models.ErrUserAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "Identity cannot be linked to a local account"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
+		}
+		return
+	}
+
+	h.setAuthCookie(c, sessionCookie, response.Token, int(response.TokenTTL.Seconds()), http.SameSiteStrictMode)
+	c.Redirect(http.StatusFound, h.oidcProvider.PostLoginRedirect())
+}
+
+// setAuthCookie writes an HttpOnly cookie scoped to the API, marked Secure when
+// the request arrived over TLS; a negative maxAge deletes it
+func (h *Handler) setAuthCookie(c *gin.Context, name, value string, maxAge int, sameSite http.SameSite) {
+	c.SetSameSite(sameSite)
+	c.SetCookie(name, value, maxAge, "/api/v1", "", c.Request.TLS != nil, true)
+}
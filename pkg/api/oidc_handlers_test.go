@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOIDCTestRouter(t *testing.T) (*gin.Engine, string) {
+	gin.SetMode(gin.TestMode)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var issuer *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.URL,
+			"authorization_endpoint": issuer.URL + "/authorize",
+			"token_endpoint":         issuer.URL + "/token",
+			"jwks_uri":               issuer.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	issuer = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	provider, err := security.NewOIDCProvider(context.Background(), security.OIDCConfig{
+		IssuerURL:   issuer.URL,
+		ClientID:    "translator",
+		RedirectURL: "http://localhost/api/v1/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			EnableAuth: true,
+			JWTSecret:  "test-secret-key-16-chars",
+		},
+	}
+	eventBus := events.NewEventBus()
+	authService := security.NewUserAuthService(cfg.Security.JWTSecret, time.Hour, models.NewInMemoryUserRepository())
+	handler := NewHandler(cfg, eventBus, cache.NewCache(time.Hour, true), authService, websocket.NewHub(eventBus), nil)
+	handler.SetOIDCProvider(provider)
+
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	return router, issuer.URL
+}
+
+func TestOIDCLoginRoutes(t *testing.T) {
+	router, issuerURL := setupOIDCTestRouter(t)
+
+	// Browsers are redirected to the identity provider with the state bound to a cookie
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+	location := w.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, issuerURL+"/authorize?"))
+
+	var stateCookie *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == oidcStateCookie {
+			stateCookie = cookie
+		}
+	}
+	require.NotNil(t, stateCookie)
+	assert.True(t, stateCookie.HttpOnly)
+	assert.Contains(t, location, "state="+stateCookie.Value)
+
+	// A state that was not issued to this browser is rejected before contacting the token endpoint
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?state="+stateCookie.Value+"&code=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?state=forged&code=abc", nil)
+	req.AddCookie(stateCookie)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Errors reported by the identity provider are surfaced
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?error=access_denied", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "access_denied")
+}
+
+func TestSessionCookieAuthentication(t *testing.T) {
+	router, _ := setupOIDCTestRouter(t)
+
+	authService := security.NewUserAuthService("test-secret-key-16-chars", time.Hour, models.NewInMemoryUserRepository())
+	token, err := authService.GenerateToken("user-1", "ana", []string{"user"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/profile", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookie, Value: token})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/profile", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
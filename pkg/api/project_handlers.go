@@ -0,0 +1,468 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"digital.vasic.translator/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProjectHeader is the request header that selects the project a call is scoped to
+const ProjectHeader = "X-Project-ID"
+
+// CreateProjectRequest represents a project creation request
+type CreateProjectRequest struct {
+	Name                  string                          `json:"name" binding:"required"`
+	Description           string                          `json:"description,omitempty"`
+	DefaultSourceLanguage string                          `json:"default_source_language,omitempty"`
+	DefaultTargetLanguage string                          `json:"default_target_language,omitempty"`
+	ProviderSettings      storage.ProjectProviderSettings `json:"provider_settings"`
+	Glossary              []storage.GlossaryEntry         `json:"glossary,omitempty"`
+	StyleGuide            string                          `json:"style_guide,omitempty"`
+}
+
+// UpdateProjectRequest represents a partial project update
+type UpdateProjectRequest struct {
+	Name                  *string                          `json:"name,omitempty"`
+	Description           *string                          `json:"description,omitempty"`
+	DefaultSourceLanguage *string                          `json:"default_source_language,omitempty"`
+	DefaultTargetLanguage *string                          `json:"default_target_language,omitempty"`
+	ProviderSettings      *storage.ProjectProviderSettings `json:"provider_settings,omitempty"`
+	Glossary              []storage.GlossaryEntry          `json:"glossary,omitempty"`
+	StyleGuide            *string                          `json:"style_guide,omitempty"`
+}
+
+// ProjectMemberRequest represents a request to add or change a project member
+type ProjectMemberRequest struct {
+	UserID string              `json:"user_id" binding:"required"`
+	Role   storage.ProjectRole `json:"role" binding:"required"`
+}
+
+// SetStorage sets the persistence backend used for projects, sessions and preparation results
+func (h *Handler) SetStorage(store storage.Storage) {
+	h.storage = store
+}
+
+// RegisterProjectRoutes registers project management routes; they require an authenticated caller
+func (h *Handler) RegisterProjectRoutes(rg *gin.RouterGroup) {
+	projects := rg.Group("/projects")
+	{
+		projects.POST("", h.createProject)
+		projects.GET("", h.listProjects)
+		projects.GET("/:project_id", h.getProject)
+		projects.PUT("/:project_id", h.updateProject)
+		projects.DELETE("/:project_id", h.deleteProject)
+		projects.POST("/:project_id/members", h.setProjectMember)
+		projects.DELETE("/:project_id/members/:user_id", h.removeProjectMember)
+		projects.GET("/:project_id/sessions", h.listProjectSessions)
+	}
+}
+
+// projectScope resolves the project selected by the X-Project-ID header and
+// rejects callers that are not members of it
+func (h *Handler) projectScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID := c.GetHeader(ProjectHeader)
+		if projectID == "" {
+			projectID = c.Query("project_id")
+		}
+		if projectID == "" {
+			c.Next()
+			return
+		}
+
+		if h.storage == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Project storage not available"})
+			c.Abort()
+			return
+		}
+
+		project, err := h.storage.GetProject(c.Request.Context(), projectID)
+		if err != nil {
+			if errors.Is(err, storage.ErrProjectNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load project"})
+			}
+			c.Abort()
+			return
+		}
+
+		if h.config.Security.EnableAuth {
+			userID := h.callerID(c)
+			if userID == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required for project access"})
+				c.Abort()
+				return
+			}
+			if !project.IsMember(userID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Not a member of this project"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("project_id", project.ID)
+		c.Set("project", project)
+		c.Next()
+	}
+}
+
+// projectEditor rejects callers that may view but not edit the project the
+// request is scoped to; requests without a project pass
+func (h *Handler) projectEditor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		project := scopedProject(c)
+		if project != nil && h.config.Security.EnableAuth && !project.CanEdit(h.callerID(c)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient project permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// callerID returns the authenticated user ID, validating the bearer token if
+// the auth middleware has not already done so
+func (h *Handler) callerID(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+
+	token := requestToken(c)
+	if token == "" || h.authService == nil {
+		return ""
+	}
+
+	claims, err := h.authService.ValidateToken(token)
+	if err != nil {
+		return ""
+	}
+
+	c.Set("user_id", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("roles", claims.Roles)
+	return claims.UserID
+}
+
+// scopedProject returns the project resolved by projectScope, if any
+func scopedProject(c *gin.Context) *storage.Project {
+	value, ok := c.Get("project")
+	if !ok {
+		return nil
+	}
+	project, _ := value.(*storage.Project)
+	return project
+}
+
+// sessionInScope reports whether a translation session or preparation analysis
+// belongs to the project the request is scoped to. Sessions that were never
+// stored belong to no project.
+func (h *Handler) sessionInScope(c *gin.Context, sessionID string) (bool, error) {
+	projectID := c.GetString("project_id")
+	if h.storage == nil {
+		return projectID == "", nil
+	}
+
+	ctx := c.Request.Context()
+	session, err := h.storage.GetSession(ctx, sessionID)
+	if err == nil {
+		return session.ProjectID == projectID, nil
+	}
+	if !errors.Is(err, storage.ErrSessionNotFound) {
+		return false, err
+	}
+
+	result, err := h.storage.GetPreparationResult(ctx, sessionID)
+	if err == nil {
+		return result.ProjectID == projectID, nil
+	}
+	if !errors.Is(err, storage.ErrPreparationResultNotFound) {
+		return false, err
+	}
+
+	return projectID == "", nil
+}
+
+// sessionVisible checks that a session belongs to the request's project scope,
+// responding with an error when it does not. Sessions of other projects are
+// reported as missing so they cannot be probed.
+func (h *Handler) sessionVisible(c *gin.Context, sessionID string) bool {
+	inScope, err := h.sessionInScope(c, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to load session: %v", err)})
+		return false
+	}
+	if !inScope {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return false
+	}
+	return true
+}
+
+// loadMemberProject loads the project named in the URL and checks that the caller belongs to it
+func (h *Handler) loadMemberProject(c *gin.Context) (*storage.Project, bool) {
+	if h.storage == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Project storage not available"})
+		return nil, false
+	}
+
+	project, err := h.storage.GetProject(c.Request.Context(), c.Param("project_id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load project"})
+		}
+		return nil, false
+	}
+
+	if !project.IsMember(c.GetString("user_id")) {
+		// Do not reveal the existence of projects the caller cannot see
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return nil, false
+	}
+
+	return project, true
+}
+
+// createProject creates a project owned by the caller
+func (h *Handler) createProject(c *gin.Context) {
+	if h.storage == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Project storage not available"})
+		return
+	}
+
+	var req CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	project := &storage.Project{
+		ID:                    uuid.New().String(),
+		Name:                  req.Name,
+		Description:           req.Description,
+		OwnerID:               c.GetString("user_id"),
+		Members:               []storage.ProjectMember{},
+		DefaultSourceLanguage: req.DefaultSourceLanguage,
+		DefaultTargetLanguage: req.DefaultTargetLanguage,
+		ProviderSettings:      req.ProviderSettings,
+		Glossary:              req.Glossary,
+		StyleGuide:            req.StyleGuide,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+
+	if err := h.storage.CreateProject(c.Request.Context(), project); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// listProjects lists the projects the caller belongs to
+func (h *Handler) listProjects(c *gin.Context) {
+	if h.storage == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Project storage not available"})
+		return
+	}
+
+	projects, err := h.storage.ListProjects(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list projects"})
+		return
+	}
+	if projects == nil {
+		projects = []*storage.Project{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projects": projects,
+		"count":    len(projects),
+	})
+}
+
+// getProject returns a single project
+func (h *Handler) getProject(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// updateProject updates project settings; owners and editors may change settings
+func (h *Handler) updateProject(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	if !project.CanEdit(c.GetString("user_id")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient project permissions"})
+		return
+	}
+
+	var req UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	if req.DefaultSourceLanguage != nil {
+		project.DefaultSourceLanguage = *req.DefaultSourceLanguage
+	}
+	if req.DefaultTargetLanguage != nil {
+		project.DefaultTargetLanguage = *req.DefaultTargetLanguage
+	}
+	if req.ProviderSettings != nil {
+		project.ProviderSettings = *req.ProviderSettings
+	}
+	if req.Glossary != nil {
+		project.Glossary = req.Glossary
+	}
+	if req.StyleGuide != nil {
+		project.StyleGuide = *req.StyleGuide
+	}
+
+	if err := h.storage.UpdateProject(c.Request.Context(), project); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// deleteProject deletes a project; only the owner may do this
+func (h *Handler) deleteProject(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	if project.OwnerID != c.GetString("user_id") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the project owner can delete a project"})
+		return
+	}
+
+	if err := h.storage.DeleteProject(c.Request.Context(), project.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.ID,
+		"status":     "deleted",
+	})
+}
+
+// setProjectMember adds a member or changes a member's role; only the owner may do this
+func (h *Handler) setProjectMember(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	if project.OwnerID != c.GetString("user_id") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the project owner can manage members"})
+		return
+	}
+
+	var req ProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !storage.ValidProjectRole(req.Role) || req.Role == storage.ProjectRoleOwner {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be editor or viewer"})
+		return
+	}
+	if req.UserID == project.OwnerID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The owner is always a member"})
+		return
+	}
+
+	project.SetMember(req.UserID, req.Role)
+	if err := h.storage.UpdateProject(c.Request.Context(), project); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// removeProjectMember removes a member; owners may remove anyone, members may leave
+func (h *Handler) removeProjectMember(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	callerID := c.GetString("user_id")
+	userID := c.Param("user_id")
+	if project.OwnerID != callerID && userID != callerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the project owner can manage members"})
+		return
+	}
+
+	if !project.RemoveMember(userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return
+	}
+
+	if err := h.storage.UpdateProject(c.Request.Context(), project); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// listProjectSessions lists the translation sessions of a project
+func (h *Handler) listProjectSessions(c *gin.Context) {
+	project, ok := h.loadMemberProject(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	sessions, err := h.storage.ListProjectSessions(c.Request.Context(), project.ID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+	if sessions == nil {
+		sessions = []*storage.TranslationSession{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.ID,
+		"sessions":   sessions,
+		"count":      len(sessions),
+	})
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProjectTestRouter(t *testing.T) (*gin.Engine, *security.UserAuthService) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			EnableAuth: true,
+			JWTSecret:  "test-secret-key-16-chars",
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	eventLog, err := events.NewEventLog("file", filepath.Join(t.TempDir(), "events.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { eventLog.Close() })
+	eventBus.SetLog(eventLog)
+
+	authService := security.NewUserAuthService(cfg.Security.JWTSecret, time.Hour, models.NewInMemoryUserRepository())
+	handler := NewHandler(cfg, eventBus, cache.NewCache(time.Hour, true), authService, websocket.NewHub(eventBus), nil)
+
+	store, err := storage.NewSQLiteStorage(&storage.Config{
+		Type:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "projects.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	handler.SetStorage(store)
+
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	return router, authService
+}
+
+func projectRequest(t *testing.T, router *gin.Engine, token, method, path string, body interface{}, headers map[string]string) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProjectLifecycle(t *testing.T) {
+	router, authService := setupProjectTestRouter(t)
+
+	ownerToken, err := authService.GenerateToken("owner-1", "owner", []string{"user"})
+	require.NoError(t, err)
+	memberToken, err := authService.GenerateToken("member-1", "member", []string{"user"})
+	require.NoError(t, err)
+	strangerToken, err := authService.GenerateToken("stranger-1", "stranger", []string{"user"})
+	require.NoError(t, err)
+
+	// Create a project
+	w := projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/projects", CreateProjectRequest{
+		Name:                  "Publisher A",
+		DefaultTargetLanguage: "sr",
+		ProviderSettings:      storage.ProjectProviderSettings{Provider: "deepseek"},
+		StyleGuide:            "Use ekavica.",
+	}, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var project storage.Project
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	assert.Equal(t, "owner-1", project.OwnerID)
+	projectPath := "/api/v1/projects/" + project.ID
+
+	// Strangers cannot see the project
+	w = projectRequest(t, router, strangerToken, http.MethodGet, projectPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Add a viewer
+	w = projectRequest(t, router, ownerToken, http.MethodPost, projectPath+"/members", ProjectMemberRequest{
+		UserID: "member-1",
+		Role:   storage.ProjectRoleViewer,
+	}, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = projectRequest(t, router, memberToken, http.MethodGet, projectPath, nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Viewers cannot change settings
+	styleGuide := "Use ijekavica."
+	w = projectRequest(t, router, memberToken, http.MethodPut, projectPath, UpdateProjectRequest{StyleGuide: &styleGuide}, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = projectRequest(t, router, ownerToken, http.MethodPut, projectPath, UpdateProjectRequest{StyleGuide: &styleGuide}, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	assert.Equal(t, "Use ijekavica.", project.StyleGuide)
+
+	// Listing only returns the caller's projects
+	w = projectRequest(t, router, strangerToken, http.MethodGet, "/api/v1/projects", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listing struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	assert.Equal(t, 0, listing.Count)
+
+	w = projectRequest(t, router, memberToken, http.MethodGet, "/api/v1/projects", nil, nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	assert.Equal(t, 1, listing.Count)
+
+	// Only the owner can delete
+	w = projectRequest(t, router, memberToken, http.MethodDelete, projectPath, nil, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = projectRequest(t, router, ownerToken, http.MethodDelete, projectPath, nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = projectRequest(t, router, ownerToken, http.MethodGet, projectPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestProjectScopeMiddleware(t *testing.T) {
+	router, authService := setupProjectTestRouter(t)
+
+	ownerToken, err := authService.GenerateToken("owner-1", "owner", nil)
+	require.NoError(t, err)
+	strangerToken, err := authService.GenerateToken("stranger-1", "stranger", nil)
+	require.NoError(t, err)
+
+	w := projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/projects", CreateProjectRequest{Name: "Scoped"}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var project storage.Project
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+
+	inputPath := t.TempDir()
+	scope := map[string]string{ProjectHeader: project.ID}
+
+	// Non-members are rejected before the handler runs
+	w = projectRequest(t, router, strangerToken, http.MethodPost, "/api/v1/preparation/analyze",
+		map[string]string{"input_path": inputPath, "target_language": "sr"}, scope)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Anonymous callers cannot select a project
+	w = projectRequest(t, router, "", http.MethodPost, "/api/v1/preparation/analyze",
+		map[string]string{"input_path": inputPath, "target_language": "sr"}, scope)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Viewers may read the project but not start work in it
+	w = projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/projects/"+project.ID+"/members", ProjectMemberRequest{
+		UserID: "viewer-1",
+		Role:   storage.ProjectRoleViewer,
+	}, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	viewerToken, err := authService.GenerateToken("viewer-1", "viewer", nil)
+	require.NoError(t, err)
+
+	for _, path := range []string{"/api/v1/preparation/analyze", "/api/v1/translate", "/api/v1/translate/ebook"} {
+		w = projectRequest(t, router, viewerToken, http.MethodPost, path,
+			map[string]string{"input_path": inputPath, "target_language": "sr", "text": "книга"}, scope)
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+
+	// Unknown projects are reported as missing
+	w = projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/preparation/analyze",
+		map[string]string{"input_path": inputPath, "target_language": "sr"}, map[string]string{ProjectHeader: "missing"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Members get results stored under the project
+	w = projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/preparation/analyze",
+		map[string]string{"input_path": inputPath, "target_language": "sr"}, scope)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var analysis struct {
+		SessionID string `json:"session_id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &analysis))
+
+	w = projectRequest(t, router, ownerToken, http.MethodGet, "/api/v1/preparation/result/"+analysis.SessionID, nil, scope)
+	require.Equal(t, http.StatusOK, w.Code)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, project.ID, result["project_id"])
+
+	// Outside of the project scope the result is not visible
+	w = projectRequest(t, router, ownerToken, http.MethodGet, "/api/v1/preparation/result/"+analysis.SessionID, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListEventsProjectScope(t *testing.T) {
+	router, authService := setupProjectTestRouter(t)
+
+	ownerToken, err := authService.GenerateToken("owner-1", "owner", nil)
+	require.NoError(t, err)
+
+	w := projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/projects", CreateProjectRequest{Name: "Scoped"}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var project storage.Project
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	scope := map[string]string{ProjectHeader: project.ID}
+
+	w = projectRequest(t, router, ownerToken, http.MethodPost, "/api/v1/preparation/analyze",
+		map[string]string{"input_path": t.TempDir(), "target_language": "sr"}, scope)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var analysis struct {
+		SessionID string `json:"session_id"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &analysis))
+	eventsPath := "/api/v1/events?session_id=" + analysis.SessionID
+
+	w = projectRequest(t, router, ownerToken, http.MethodGet, eventsPath, nil, scope)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var listing struct {
+		Events []events.Event `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	assert.NotEmpty(t, listing.Events)
+
+	// Outside of the project scope the session's events are not visible
+	w = projectRequest(t, router, ownerToken, http.MethodGet, eventsPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Events without a session cannot be listed
+	w = projectRequest(t, router, ownerToken, http.MethodGet, "/api/v1/events", nil, scope)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Status and the WebSocket are scoped the same way
+	statusPath := "/api/v1/status/" + analysis.SessionID
+	w = projectRequest(t, router, ownerToken, http.MethodGet, statusPath, nil, scope)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = projectRequest(t, router, ownerToken, http.MethodGet, statusPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	wsPath := "/ws?session_id=" + analysis.SessionID
+	w = projectRequest(t, router, ownerToken, http.MethodGet, wsPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = projectRequest(t, router, "", http.MethodGet, wsPath, nil, scope)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = projectRequest(t, router, ownerToken, http.MethodGet, "/ws", nil, scope)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
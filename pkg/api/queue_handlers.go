@@ -0,0 +1,109 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"digital.vasic.translator/pkg/distributed"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPullWait bounds how long a pull request is held open
+const maxPullWait = 60 * time.Second
+
+// RegisterQueueRoutes registers the routes pull workers use to fetch jobs
+func (h *Handler) RegisterQueueRoutes(rg *gin.RouterGroup) {
+	queue := rg.Group("/distributed/queue")
+	queue.Use(h.workerTokenAuth())
+	{
+		queue.POST("/workers", h.registerQueueWorker)
+		queue.POST("/workers/:worker_id/pull", h.pullQueueJob)
+		queue.POST("/workers/:worker_id/leases/:lease_id/renew", h.renewQueueLease)
+		queue.POST("/workers/:worker_id/leases/:lease_id/complete", h.completeQueueLease)
+	}
+}
+
+// workerTokenAuth rejects pull workers without the configured shared secret; with
+// no secret configured the queue is closed to everyone
+func (h *Handler) workerTokenAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := h.config.Distributed.Queue.Token
+		if token == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader(distributed.WorkerTokenHeader)), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid worker token"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// registerQueueWorker registers a pull worker and its capabilities
+func (h *Handler) registerQueueWorker(c *gin.Context) {
+	var reg distributed.WorkerRegistration
+	if err := c.ShouldBindJSON(&reg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service().RegisterQueueWorker(c.Request.Context(), reg); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Worker registered", "worker_id": reg.WorkerID})
+}
+
+// pullQueueJob long-polls for a job, responding with 204 when none arrived in time
+func (h *Handler) pullQueueJob(c *gin.Context) {
+	wait := 30 * time.Second
+	if seconds, err := strconv.Atoi(c.Query("wait_seconds")); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+	if wait > maxPullWait {
+		wait = maxPullWait
+	}
+
+	job, err := h.service().PullQueueJob(c.Request.Context(), c.Param("worker_id"), wait)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+	if job == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// renewQueueLease extends the lease of a job a pull worker is translating
+func (h *Handler) renewQueueLease(c *gin.Context) {
+	if err := h.service().RenewQueueLease(c.Request.Context(), c.Param("worker_id"), c.Param("lease_id")); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// completeQueueLease records the results of a lease
+func (h *Handler) completeQueueLease(c *gin.Context) {
+	var req struct {
+		Messages []distributed.JobMessage `json:"messages"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.service().CompleteQueueLease(c.Request.Context(), c.Param("worker_id"), c.Param("lease_id"), req.Messages)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
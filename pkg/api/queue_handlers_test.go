@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/distributed"
+	"digital.vasic.translator/pkg/ebook"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+	"digital.vasic.translator/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queueTestTranslator prefixes the text it translates and passes the text and
+// its context to record
+type queueTestTranslator struct {
+	record func(text, contextHint string)
+}
+
+func (t queueTestTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	t.record(text, contextHint)
+	return "prevod " + text, nil
+}
+
+func (t queueTestTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return t.Translate(ctx, text, contextHint)
+}
+
+func (queueTestTranslator) GetStats() translator.TranslationStats {
+	return translator.TranslationStats{}
+}
+
+func (queueTestTranslator) GetName() string {
+	return "queue-test"
+}
+
+func setupQueueTestServer(t *testing.T) (*httptest.Server, *distributed.DistributedManager) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.DefaultConfig()
+	cfg.Distributed.Queue.Enabled = true
+	cfg.Distributed.Queue.Token = "worker-secret"
+
+	eventBus := events.NewEventBus()
+	dm := distributed.NewDistributedManager(cfg, eventBus, nil)
+	require.NoError(t, dm.Initialize(nil))
+	t.Cleanup(func() { dm.Close() })
+
+	authService := security.NewUserAuthService("test-secret-key-16-chars", time.Hour, models.NewInMemoryUserRepository())
+	handler := NewHandler(cfg, eventBus, cache.NewCache(time.Hour, true), authService, websocket.NewHub(eventBus), dm)
+
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server, dm
+}
+
+func TestQueueRoutes_PullWorker(t *testing.T) {
+	server, dm := setupQueueTestServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Workers without the shared secret are turned away
+	resp, err := http.Post(server.URL+distributed.QueuePath+"/workers", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	client := distributed.NewHTTPQueueClient(server.URL, "worker-secret", nil)
+	_, err = client.Pull(ctx, "unregistered", 0)
+	assert.ErrorIs(t, err, distributed.ErrUnknownQueueWorker)
+
+	var mu sync.Mutex
+	var pairs []string
+	hints := make(map[string]string)
+	worker := distributed.NewQueueWorker(client, distributed.WorkerRegistration{WorkerID: "behind-nat", Tags: []string{"gpu"}},
+		func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			pairs = append(pairs, sourceLang+"-"+targetLang)
+			return queueTestTranslator{record: func(text, contextHint string) {
+				mu.Lock()
+				defer mu.Unlock()
+				hints[text] = contextHint
+			}}, nil
+		})
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	defer stopWorker()
+	go worker.Run(workerCtx)
+
+	require.Eventually(t, func() bool {
+		return dm.Queue().HasWorkers(distributed.WorkRequirements{Tags: []string{"gpu"}})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	job := &distributed.TranslationJob{
+		Glossary: []distributed.GlossaryTerm{{Source: "книга", Target: "knjiga"}},
+		Segments: []distributed.JobSegment{{ID: "s1", Text: "книга"}, {ID: "s2", Text: "глава"}},
+	}
+	results, err := dm.TranslateJob(ctx, job, []string{"gpu"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"s1": "prevod книга", "s2": "prevod глава"}, results)
+
+	translated, err := dm.TranslateDistributed(ctx, "реч", "")
+	require.NoError(t, err)
+	assert.Equal(t, "prevod реч", translated)
+
+	// Books are shipped as one job per chapter, with the languages, style
+	// guide and glossary of their project and the neighbouring paragraphs
+	book := &ebook.Book{
+		Metadata: ebook.Metadata{Title: "Роман"},
+		Chapters: []ebook.Chapter{{
+			Title: "Глава",
+			Sections: []ebook.Section{{
+				Content:     "Текст",
+				Subsections: []ebook.Section{{Title: "Часть", Content: "Еще"}},
+			}},
+		}, {}},
+	}
+	svc := service.New(config.DefaultConfig(), events.NewEventBus(), nil, dm, nil)
+	project := &storage.Project{
+		DefaultSourceLanguage: "ru",
+		DefaultTargetLanguage: "en",
+		StyleGuide:            "Formal",
+		Glossary:              []storage.GlossaryEntry{{Source: "текст", Target: "text"}},
+	}
+	require.NoError(t, svc.TranslateBook(ctx, book, project, "session-1"))
+	assert.Equal(t, "prevod Роман", book.Metadata.Title)
+	assert.Equal(t, "prevod Глава", book.Chapters[0].Title)
+	assert.Equal(t, "prevod Текст", book.Chapters[0].Sections[0].Content)
+	assert.Equal(t, ebook.Section{Title: "prevod Часть", Content: "prevod Еще"}, book.Chapters[0].Sections[0].Subsections[0])
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"ru-en", "ru-en"}, pairs[len(pairs)-2:])
+	assert.Equal(t, "Style guide: Formal\nPrevious paragraph:\nГлава\nNext paragraph:\nЧасть\nGlossary (use these translations): текст = text", hints["Текст"])
+	assert.Equal(t, "Style guide: Formal\nSection title\nPrevious paragraph:\nТекст\nNext paragraph:\nЕще", hints["Часть"])
+}
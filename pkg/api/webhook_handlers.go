@@ -0,0 +1,164 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhookRequest represents a webhook subscription request
+type CreateWebhookRequest struct {
+	URL        string             `json:"url" binding:"required"`
+	ProjectID  string             `json:"project_id,omitempty"`
+	EventTypes []events.EventType `json:"event_types,omitempty"`
+	Secret     string             `json:"secret,omitempty"`
+}
+
+// SetWebhooks sets the dispatcher that delivers events to webhook subscriptions
+func (h *Handler) SetWebhooks(dispatcher *webhooks.Dispatcher) {
+	h.webhooks = dispatcher
+}
+
+// RegisterWebhookRoutes registers webhook subscription routes; they require an
+// authenticated caller, who only sees their own subscriptions
+func (h *Handler) RegisterWebhookRoutes(rg *gin.RouterGroup) {
+	hooks := rg.Group("/webhooks")
+	hooks.Use(h.requireWebhooks())
+	{
+		hooks.POST("", h.createWebhook)
+		hooks.GET("", h.listWebhooks)
+		hooks.GET("/:webhook_id", h.getWebhook)
+		hooks.DELETE("/:webhook_id", h.deleteWebhook)
+		hooks.GET("/:webhook_id/deliveries", h.listWebhookDeliveries)
+		hooks.GET("/:webhook_id/dead-letters", h.listWebhookDeadLetters)
+		hooks.POST("/:webhook_id/dead-letters/:delivery_id/redeliver", h.redeliverWebhook)
+	}
+}
+
+// requireWebhooks rejects requests when webhooks are not enabled
+func (h *Handler) requireWebhooks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.webhooks == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Webhooks not enabled"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// createWebhook subscribes a URL to the caller's events or to those of a project
+func (h *Handler) createWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if req.ProjectID != "" {
+		if h.storage == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Project storage not available"})
+			return
+		}
+		project, err := h.storage.GetProject(c.Request.Context(), req.ProjectID)
+		if errors.Is(err, storage.ErrProjectNotFound) || (err == nil && !project.IsMember(userID)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load project"})
+			return
+		}
+	}
+
+	sub, err := h.webhooks.CreateSubscription(&webhooks.Subscription{
+		OwnerID:    userID,
+		ProjectID:  req.ProjectID,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// The secret is only ever returned here
+	c.JSON(http.StatusCreated, sub)
+}
+
+// listWebhooks lists the caller's subscriptions
+func (h *Handler) listWebhooks(c *gin.Context) {
+	subs := h.webhooks.ListSubscriptions(c.GetString("user_id"))
+	c.JSON(http.StatusOK, gin.H{"webhooks": subs, "count": len(subs)})
+}
+
+// getWebhook returns one of the caller's subscriptions
+func (h *Handler) getWebhook(c *gin.Context) {
+	sub, err := h.webhooks.GetSubscription(c.GetString("user_id"), c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// deleteWebhook removes one of the caller's subscriptions
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	if err := h.webhooks.DeleteSubscription(c.GetString("user_id"), c.Param("webhook_id")); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted", "webhook_id": c.Param("webhook_id")})
+}
+
+// listWebhookDeliveries returns the delivery log of a subscription, newest first
+func (h *Handler) listWebhookDeliveries(c *gin.Context) {
+	limit := 100
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhooks.Deliveries(c.GetString("user_id"), c.Param("webhook_id"), limit)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries, "count": len(deliveries)})
+}
+
+// listWebhookDeadLetters returns the deliveries of a subscription that failed every attempt
+func (h *Handler) listWebhookDeadLetters(c *gin.Context) {
+	letters, err := h.webhooks.DeadLetters(c.GetString("user_id"), c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dead_letters": letters, "count": len(letters)})
+}
+
+// redeliverWebhook delivers a dead letter again
+func (h *Handler) redeliverWebhook(c *gin.Context) {
+	if err := h.webhooks.Redeliver(c.GetString("user_id"), c.Param("webhook_id"), c.Param("delivery_id")); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Redelivery scheduled", "delivery_id": c.Param("delivery_id")})
+}
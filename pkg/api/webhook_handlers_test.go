@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/models"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/webhooks"
+	"digital.vasic.translator/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWebhookTestRouter(t *testing.T, enabled bool) (*gin.Engine, *security.UserAuthService) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			EnableAuth: true,
+			JWTSecret:  "test-secret-key-16-chars",
+		},
+	}
+
+	eventBus := events.NewEventBus()
+	authService := security.NewUserAuthService(cfg.Security.JWTSecret, time.Hour, models.NewInMemoryUserRepository())
+	handler := NewHandler(cfg, eventBus, cache.NewCache(time.Hour, true), authService, websocket.NewHub(eventBus), nil)
+
+	store, err := storage.NewSQLiteStorage(&storage.Config{
+		Type:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "webhooks.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	handler.SetStorage(store)
+
+	if enabled {
+		dispatcher, err := webhooks.NewDispatcher(webhooks.NewStorageResolver(store), webhooks.Options{})
+		require.NoError(t, err)
+		dispatcher.Start(eventBus)
+		t.Cleanup(dispatcher.Close)
+		handler.SetWebhooks(dispatcher)
+	}
+
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	return router, authService
+}
+
+func TestWebhookLifecycle(t *testing.T) {
+	router, authService := setupWebhookTestRouter(t, true)
+
+	ownerToken, err := authService.GenerateToken("owner-1", "owner", []string{"user"})
+	require.NoError(t, err)
+	otherToken, err := authService.GenerateToken("other-1", "other", []string{"user"})
+	require.NoError(t, err)
+
+	// Requires authentication
+	w := projectRequest(t, router, "", "GET", "/api/v1/webhooks", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Invalid URL
+	w = projectRequest(t, router, ownerToken, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: "not a url"}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Internal addresses are refused
+	w = projectRequest(t, router, ownerToken, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: "http://169.254.169.254/latest/meta-data"}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Project subscriptions require membership
+	w = projectRequest(t, router, ownerToken, "POST", "/api/v1/projects", CreateProjectRequest{Name: "Novel"}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var project storage.Project
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+
+	w = projectRequest(t, router, otherToken, "POST", "/api/v1/webhooks", CreateWebhookRequest{URL: "https://203.0.113.10/hook", ProjectID: project.ID}, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = projectRequest(t, router, ownerToken, "POST", "/api/v1/webhooks", CreateWebhookRequest{
+		URL:        "https://203.0.113.10/hook",
+		ProjectID:  project.ID,
+		EventTypes: []events.EventType{events.EventTranslationCompleted},
+	}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created webhooks.Subscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.ID)
+	assert.NotEmpty(t, created.Secret)
+	assert.Equal(t, "owner-1", created.OwnerID)
+
+	// Listing hides the secret and other users' subscriptions
+	w = projectRequest(t, router, ownerToken, "GET", "/api/v1/webhooks", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed struct {
+		Webhooks []webhooks.Subscription `json:"webhooks"`
+		Count    int                     `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Equal(t, 1, listed.Count)
+	assert.Empty(t, listed.Webhooks[0].Secret)
+
+	w = projectRequest(t, router, otherToken, "GET", "/api/v1/webhooks/"+created.ID, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = projectRequest(t, router, ownerToken, "GET", "/api/v1/webhooks/"+created.ID+"/deliveries?limit=10", nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = projectRequest(t, router, ownerToken, "GET", "/api/v1/webhooks/"+created.ID+"/deliveries?limit=0", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = projectRequest(t, router, ownerToken, "GET", "/api/v1/webhooks/"+created.ID+"/dead-letters", nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":0`)
+
+	w = projectRequest(t, router, ownerToken, "POST", "/api/v1/webhooks/"+created.ID+"/dead-letters/missing/redeliver", nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = projectRequest(t, router, otherToken, "DELETE", "/api/v1/webhooks/"+created.ID, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = projectRequest(t, router, ownerToken, "DELETE", "/api/v1/webhooks/"+created.ID, nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = projectRequest(t, router, ownerToken, "GET", "/api/v1/webhooks/"+created.ID, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebhooksDisabled(t *testing.T) {
+	router, authService := setupWebhookTestRouter(t, false)
+
+	token, err := authService.GenerateToken("owner-1", "owner", []string{"user"})
+	require.NoError(t, err)
+
+	w := projectRequest(t, router, token, "GET", "/api/v1/webhooks", nil, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
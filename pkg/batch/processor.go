@@ -13,6 +13,7 @@ import (
 	"digital.vasic.translator/pkg/events"
 	"digital.vasic.translator/pkg/format"
 	"digital.vasic.translator/pkg/language"
+	"digital.vasic.translator/pkg/localization"
 	"digital.vasic.translator/pkg/translator"
 )
 
@@ -392,6 +393,17 @@ func (bp *BatchProcessor) processFilesParallel(ctx context.Context, files []stri
 
 // processFile processes a single file
 func (bp *BatchProcessor) processFile(ctx context.Context, inputPath, outputPath string) (*ProcessingResult, error) {
+	// Subtitles are translated cue by cue and stay subtitles
+	detectedFormat, err := format.NewDetector().DetectFile(inputPath)
+	if err == nil && detectedFormat.IsSubtitle() {
+		return bp.processSubtitles(ctx, inputPath, outputPath)
+	}
+
+	// Localization files are translated string by string in their format
+	if err == nil && detectedFormat.IsLocalization() {
+		return bp.processLocalization(ctx, inputPath, outputPath)
+	}
+
 	// Parse the ebook
 	parser := ebook.NewUniversalParser()
 	book, err := parser.Parse(inputPath)
@@ -419,6 +431,72 @@ func (bp *BatchProcessor) processFile(ctx context.Context, inputPath, outputPath
 	}, nil
 }
 
+// processSubtitles translates a subtitle file with the translator of the
+// options, keeping its timing and styling
+func (bp *BatchProcessor) processSubtitles(ctx context.Context, inputPath, outputPath string) (*ProcessingResult, error) {
+	if bp.options.Translator == nil {
+		return nil, fmt.Errorf("no translator configured for subtitles")
+	}
+
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return bp.options.Translator.TranslateWithProgress(ctx, text, context, bp.options.EventBus, bp.options.SessionID)
+	}
+	if _, err := ebook.NewSubtitleTranslator(translate).Translate(ctx, inputPath, outputPath); err != nil {
+		return nil, fmt.Errorf("failed to translate subtitles: %w", err)
+	}
+
+	return &ProcessingResult{
+		InputPath:  inputPath,
+		OutputPath: outputPath,
+		Success:    true,
+		Error:      nil,
+	}, nil
+}
+
+// processLocalization translates a localization file with the translator
+// of the options, keeping its placeholders and plural forms
+func (bp *BatchProcessor) processLocalization(ctx context.Context, inputPath, outputPath string) (*ProcessingResult, error) {
+	if bp.options.Translator == nil {
+		return nil, fmt.Errorf("no translator configured for localization files")
+	}
+
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return bp.options.Translator.TranslateWithProgress(ctx, text, context, bp.options.EventBus, bp.options.SessionID)
+	}
+	if _, err := localization.NewTranslator(translate, bp.options.TargetLanguage.Code).Translate(ctx, inputPath, outputPath); err != nil {
+		return nil, fmt.Errorf("failed to translate localization file: %w", err)
+	}
+
+	return &ProcessingResult{
+		InputPath:  inputPath,
+		OutputPath: outputPath,
+		Success:    true,
+		Error:      nil,
+	}, nil
+}
+
+// outputFormat returns the extension of the output of a file: the output
+// format of the options, EPUB by default. Subtitles are written in the
+// output format only when it is a subtitle format, else in their own, and
+// localization files always in their own, templates as PO files.
+func (bp *BatchProcessor) outputFormat(inputPath string) string {
+	outputFormat := bp.options.OutputFormat
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(inputPath)), ".")
+	if detected, err := format.NewDetector().DetectFile(inputPath); err == nil && detected.IsLocalization() {
+		if ext == "pot" {
+			return "po"
+		}
+		return ext
+	}
+	if format.ParseFormat(ext).IsSubtitle() && !format.ParseFormat(outputFormat).IsSubtitle() {
+		return ext
+	}
+	if outputFormat == "" {
+		return "epub"
+	}
+	return outputFormat
+}
+
 // computeOutputPath computes the output path preserving directory structure
 func (bp *BatchProcessor) computeOutputPath(inputPath string) (string, error) {
 	if bp.options.OutputPath == "" {
@@ -426,11 +504,7 @@ func (bp *BatchProcessor) computeOutputPath(inputPath string) (string, error) {
 		ext := filepath.Ext(inputPath)
 		base := strings.TrimSuffix(inputPath, ext)
 		lang := bp.options.TargetLanguage.Code
-		outputFormat := bp.options.OutputFormat
-		if outputFormat == "" {
-			outputFormat = "epub"
-		}
-		return fmt.Sprintf("%s_%s.%s", base, lang, outputFormat), nil
+		return fmt.Sprintf("%s_%s.%s", base, lang, bp.outputFormat(inputPath)), nil
 	}
 
 	// Check if output is a directory
@@ -457,12 +531,8 @@ func (bp *BatchProcessor) computeOutputPath(inputPath string) (string, error) {
 	ext := filepath.Ext(relPath)
 	base := strings.TrimSuffix(relPath, ext)
 	lang := bp.options.TargetLanguage.Code
-	outputFormat := bp.options.OutputFormat
-	if outputFormat == "" {
-		outputFormat = "epub"
-	}
 
-	outputFile := fmt.Sprintf("%s_%s.%s", base, lang, outputFormat)
+	outputFile := fmt.Sprintf("%s_%s.%s", base, lang, bp.outputFormat(inputPath))
 	outputPath := filepath.Join(bp.options.OutputPath, outputFile)
 
 	// Create output directory if needed
@@ -518,3 +518,129 @@ func TestProcessingResult(t *testing.T) {
 		t.Errorf("Expected error to be nil, got %v", result.Error)
 	}
 }
+
+func TestBatchProcessor_ProcessSubtitles(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputDir := filepath.Join(tmpDir, "input")
+	outputDir := filepath.Join(tmpDir, "output")
+	for _, dir := range []string{inputDir, outputDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	srt := "1\n00:00:01,000 --> 00:00:03,000\n<i>Hello</i> there\n\n2\n00:00:04,000 --> 00:00:06,000\nGoodbye\n"
+	if err := os.WriteFile(filepath.Join(inputDir, "episode.srt"), []byte(srt), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	options := &ProcessingOptions{
+		InputType:      InputTypeDirectory,
+		InputPath:      inputDir,
+		OutputPath:     outputDir,
+		OutputFormat:   "epub",
+		TargetLanguage: language.Serbian,
+		Translator: &MockTranslator{translateFunc: func(ctx context.Context, text string, contextStr string) (string, error) {
+			return strings.ReplaceAll(strings.ToUpper(text), "<X", "<x"), nil
+		}},
+	}
+
+	results, err := NewBatchProcessor(options).Process(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("Expected one successful result, got %+v", results)
+	}
+
+	expectedPath := filepath.Join(outputDir, "episode_sr.srt")
+	if results[0].OutputPath != expectedPath {
+		t.Errorf("Expected output path %s, got %s", expectedPath, results[0].OutputPath)
+	}
+	data, err := os.ReadFile(expectedPath)
+	if err != nil {
+		t.Fatalf("Output file was not created: %v", err)
+	}
+	expected := "1\n00:00:01,000 --> 00:00:03,000\n<i>HELLO</i> THERE\n\n2\n00:00:04,000 --> 00:00:06,000\nGOODBYE\n"
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, data)
+	}
+}
+
+func TestBatchProcessor_ProcessSubtitlesNeedsTranslator(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "episode.vtt")
+	if err := os.WriteFile(inputFile, []byte("WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	options := &ProcessingOptions{
+		InputType:  InputTypeFile,
+		InputPath:  inputFile,
+		OutputPath: filepath.Join(tmpDir, "episode_sr.vtt"),
+	}
+	if _, err := NewBatchProcessor(options).Process(context.Background()); err == nil {
+		t.Error("Expected an error without a translator")
+	}
+}
+
+func TestBatchProcessor_ProcessLocalization(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputDir := filepath.Join(tmpDir, "input")
+	outputDir := filepath.Join(tmpDir, "output")
+	for _, dir := range []string{filepath.Join(inputDir, "values"), outputDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	files := map[string]string{
+		"messages.pot":       "msgid \"Hello, %s\"\nmsgstr \"\"\n",
+		"values/strings.xml": "<resources>\n    <string name=\"save\">Save</string>\n</resources>\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(inputDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	options := &ProcessingOptions{
+		InputType:      InputTypeDirectory,
+		InputPath:      inputDir,
+		OutputPath:     outputDir,
+		OutputFormat:   "epub",
+		Recursive:      true,
+		TargetLanguage: language.Serbian,
+		Translator: &MockTranslator{translateFunc: func(ctx context.Context, text string, contextStr string) (string, error) {
+			return strings.ReplaceAll(strings.ToUpper(text), "<X", "<x"), nil
+		}},
+	}
+
+	results, err := NewBatchProcessor(options).Process(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected two results, got %+v", results)
+	}
+	for _, result := range results {
+		if !result.Success {
+			t.Errorf("Expected %s to succeed: %v", result.InputPath, result.Error)
+		}
+	}
+
+	expected := map[string]string{
+		"messages_sr.po":        "msgid \"Hello, %s\"\nmsgstr \"HELLO, %s\"\n",
+		"values/strings_sr.xml": "<resources>\n    <string name=\"save\">SAVE</string>\n</resources>\n",
+	}
+	for name, content := range expected {
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Errorf("Output file was not created: %v", err)
+			continue
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s:\n%s\nGot:\n%s", name, content, data)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package distributed
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// caValidity is the lifetime of the built-in certificate authority
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// issuedCertValidity is the lifetime of certificates issued to workers and the coordinator
+	issuedCertValidity = 365 * 24 * time.Hour
+
+	caCertFileName = "ca.pem"
+	caKeyFileName  = "ca-key.pem"
+)
+
+// CertificateAuthority is a small built-in CA that issues the certificates used
+// for mutual TLS between the coordinator and its workers
+type CertificateAuthority struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     *ecdsa.PrivateKey
+}
+
+// IssuedCertificate is a certificate and private key issued by the CA
+type IssuedCertificate struct {
+	CertPEM     []byte
+	KeyPEM      []byte
+	Fingerprint string
+	NotAfter    time.Time
+}
+
+// NewCertificateAuthority creates a new self-signed certificate authority
+func NewCertificateAuthority(commonName string) (*CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Translator"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	return &CertificateAuthority{
+		cert:    cert,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:     key,
+	}, nil
+}
+
+// LoadCertificateAuthority loads a CA from PEM encoded certificate and key files
+func LoadCertificateAuthority(certFile, keyFile string) (*CertificateAuthority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA key: %w", err)
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("invalid CA certificate file %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("certificate in %s is not a CA certificate", certFile)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("invalid CA key file %s", keyFile)
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("CA key does not match CA certificate")
+	}
+
+	return &CertificateAuthority{cert: cert, certPEM: certPEM, key: key}, nil
+}
+
+// LoadOrCreateCertificateAuthority loads the CA kept in dir, creating it on first use
+func LoadOrCreateCertificateAuthority(dir string) (*CertificateAuthority, error) {
+	certFile := filepath.Join(dir, caCertFileName)
+	keyFile := filepath.Join(dir, caKeyFileName)
+
+	if _, err := os.Stat(certFile); err == nil {
+		return LoadCertificateAuthority(certFile, keyFile)
+	}
+
+	ca, err := NewCertificateAuthority("Translator Worker CA")
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Save(certFile, keyFile); err != nil {
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+// Save writes the CA certificate and key as PEM files; the key is only readable by the owner
+func (ca *CertificateAuthority) Save(certFile, keyFile string) error {
+	keyDER, err := x509.MarshalECPrivateKey(ca.key)
+	if err != nil {
+		return fmt.Errorf("failed to encode CA key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(certFile), 0700); err != nil {
+		return fmt.Errorf("failed to create CA directory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+		return fmt.Errorf("failed to create CA directory: %w", err)
+	}
+
+	if err := os.WriteFile(certFile, ca.certPEM, 0644); err != nil {
+		return fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return fmt.Errorf("failed to write CA key: %w", err)
+	}
+
+	return nil
+}
+
+// CertPEM returns the PEM encoded CA certificate
+func (ca *CertificateAuthority) CertPEM() []byte {
+	return ca.certPEM
+}
+
+// CertPool returns a pool containing only the CA certificate
+func (ca *CertificateAuthority) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// IssueCertificate issues a certificate usable for both TLS server and client
+// authentication. hosts become the DNS or IP subject alternative names.
+func (ca *CertificateAuthority) IssueCertificate(commonName string, hosts []string) (*IssuedCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(issuedCertValidity)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Translator"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if host != "" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	return &IssuedCertificate{
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		Fingerprint: fingerprintDER(der),
+		NotAfter:    notAfter,
+	}, nil
+}
+
+// TLSCertificate returns the issued certificate as a TLS certificate
+func (ic *IssuedCertificate) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(ic.CertPEM, ic.KeyPEM)
+}
+
+// CertificateFingerprint returns the hex encoded SHA-256 fingerprint of a certificate
+func CertificateFingerprint(cert *x509.Certificate) string {
+	return fingerprintDER(cert.Raw)
+}
+
+func fingerprintDER(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
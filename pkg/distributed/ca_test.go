@@ -0,0 +1,116 @@
+package distributed
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCertificateAuthority_IssueCertificate(t *testing.T) {
+	ca, err := NewCertificateAuthority("Test CA")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	issued, err := ca.IssueCertificate("worker-1", []string{"127.0.0.1", "worker.local"})
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+
+	tlsCert, err := issued.TLSCertificate()
+	if err != nil {
+		t.Fatalf("Issued certificate is not a valid key pair: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	if err != nil {
+		t.Fatalf("Failed to parse issued certificate: %v", err)
+	}
+
+	if cert.Subject.CommonName != "worker-1" {
+		t.Errorf("Expected common name worker-1, got %s", cert.Subject.CommonName)
+	}
+	if len(cert.IPAddresses) != 1 || cert.IPAddresses[0].String() != "127.0.0.1" {
+		t.Errorf("Expected IP SAN 127.0.0.1, got %v", cert.IPAddresses)
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "worker.local" {
+		t.Errorf("Expected DNS SAN worker.local, got %v", cert.DNSNames)
+	}
+	if CertificateFingerprint(cert) != issued.Fingerprint {
+		t.Error("Expected fingerprint to match the issued certificate")
+	}
+	if !issued.NotAfter.After(time.Now()) {
+		t.Error("Expected issued certificate to be valid")
+	}
+
+	// Certificate verifies against the CA for both server and client use
+	for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth} {
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:     ca.CertPool(),
+			KeyUsages: []x509.ExtKeyUsage{usage},
+		})
+		if err != nil {
+			t.Errorf("Expected certificate to verify for usage %v: %v", usage, err)
+		}
+	}
+
+	// Certificates from another CA do not verify
+	other, err := NewCertificateAuthority("Other CA")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: other.CertPool()}); err == nil {
+		t.Error("Expected verification against another CA to fail")
+	}
+}
+
+func TestLoadOrCreateCertificateAuthority(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ca")
+
+	created, err := LoadOrCreateCertificateAuthority(dir)
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, caKeyFileName))
+	if err != nil {
+		t.Fatalf("Expected CA key file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected CA key mode 0600, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := LoadOrCreateCertificateAuthority(dir)
+	if err != nil {
+		t.Fatalf("Failed to load CA: %v", err)
+	}
+	if string(loaded.CertPEM()) != string(created.CertPEM()) {
+		t.Error("Expected the existing CA to be loaded")
+	}
+
+	// The loaded CA issues certificates trusted by the original one
+	issued, err := loaded.IssueCertificate("worker", nil)
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+	tlsCert, err := issued.TLSCertificate()
+	if err != nil {
+		t.Fatalf("Invalid issued certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(tlsCert.Certificate[0])
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: created.CertPool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		t.Errorf("Expected certificate from loaded CA to verify: %v", err)
+	}
+
+	// Mismatched key is rejected
+	other, _ := NewCertificateAuthority("Other CA")
+	otherDir := t.TempDir()
+	if err := other.Save(filepath.Join(otherDir, caCertFileName), filepath.Join(otherDir, caKeyFileName)); err != nil {
+		t.Fatalf("Failed to save CA: %v", err)
+	}
+	if _, err := LoadCertificateAuthority(filepath.Join(dir, caCertFileName), filepath.Join(otherDir, caKeyFileName)); err == nil {
+		t.Error("Expected error for mismatched CA key")
+	}
+}
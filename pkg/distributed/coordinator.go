@@ -2,7 +2,6 @@ package distributed
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,8 +12,11 @@ import (
 
 	"digital.vasic.translator/pkg/deployment"
 	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/tracing"
 	"digital.vasic.translator/pkg/translator"
 	"digital.vasic.translator/pkg/translator/llm"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RemoteLLMInstance represents a remote LLM instance
@@ -39,6 +41,7 @@ type DistributedCoordinator struct {
 	versionManager   *VersionManager
 	eventBus         *events.EventBus
 	apiLogger        *deployment.APICommunicationLogger
+	scheduler        *WorkerScheduler
 	currentIndex     int
 	maxRetries       int
 	retryDelay       time.Duration
@@ -64,6 +67,7 @@ func NewDistributedCoordinator(
 		versionManager:   versionManager,
 		eventBus:         eventBus,
 		apiLogger:        apiLogger,
+		scheduler:        NewWorkerScheduler(),
 		currentIndex:     0,
 		maxRetries:       3,
 		retryDelay:       2 * time.Second,
@@ -144,22 +148,17 @@ func (dc *DistributedCoordinator) queryRemoteProviders(ctx context.Context, serv
 		return nil, err
 	}
 
+	client, err := dc.pairingManager.ClientFor(service, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
 	// Log outgoing request if logger is available
 	var logEntry *deployment.APICommunicationLog
 	if dc.apiLogger != nil {
 		logEntry = dc.apiLogger.LogRequest(service.Host, 8443, service.Host, service.Port, "GET", "/api/v1/providers", 0)
 	}
 
-	// Use HTTP client that accepts self-signed certificates
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
 	startTime := time.Now()
 	resp, err := client.Do(req)
 	duration := time.Since(startTime)
@@ -260,6 +259,17 @@ func (dc *DistributedCoordinator) TranslateWithDistributedRetry(
 	text string,
 	contextHint string,
 ) (string, error) {
+	return dc.TranslateWithRequirements(ctx, text, contextHint, WorkRequirements{})
+}
+
+// TranslateWithRequirements translates using distributed instances whose workers
+// satisfy req, with the same fallbacks as TranslateWithDistributedRetry
+func (dc *DistributedCoordinator) TranslateWithRequirements(
+	ctx context.Context,
+	text string,
+	contextHint string,
+	req WorkRequirements,
+) (string, error) {
 
 	var result string
 	var resultMu sync.Mutex
@@ -269,7 +279,7 @@ func (dc *DistributedCoordinator) TranslateWithDistributedRetry(
 		{
 			Name: "remote_instances",
 			Function: func() error {
-				translated, err := dc.translateWithRemoteInstances(ctx, text, contextHint)
+				translated, err := dc.translateWithRemoteInstances(ctx, text, contextHint, req)
 				if err != nil {
 					return err
 				}
@@ -357,10 +367,10 @@ func (dc *DistributedCoordinator) TranslateWithDistributedRetry(
 					}
 				}
 
-				result := strings.Join(translatedWords, " ")
+				reduced := strings.Join(translatedWords, " ")
 
 				resultMu.Lock()
-				result = result
+				result = reduced
 				resultMu.Unlock()
 				return nil
 			},
@@ -371,7 +381,7 @@ func (dc *DistributedCoordinator) TranslateWithDistributedRetry(
 	// Use FallbackManager for comprehensive fallback handling
 	componentID := "distributed_translator"
 	err := dc.fallbackManager.ExecuteWithFallback(ctx, componentID, func() error {
-		translated, err := dc.translateWithRemoteInstances(ctx, text, contextHint)
+		translated, err := dc.translateWithRemoteInstances(ctx, text, contextHint, req)
 		if err != nil {
 			return err
 		}
@@ -397,29 +407,32 @@ func (dc *DistributedCoordinator) translateWithRemoteInstances(
 	ctx context.Context,
 	text string,
 	contextHint string,
+	req WorkRequirements,
 ) (string, error) {
 
-	if len(dc.remoteInstances) == 0 {
+	if dc.GetRemoteInstanceCount() == 0 {
 		return "", fmt.Errorf("no remote instances available")
 	}
 
+	if req.Size == 0 {
+		req.Size = len([]rune(text))
+	}
+
 	var lastErr error
 	triedInstances := make(map[string]bool)
 
-	for attempt := 0; attempt < dc.maxRetries*len(dc.remoteInstances); attempt++ {
-		instance := dc.getNextRemoteInstance()
+	for attempt := 0; attempt < dc.maxRetries*dc.GetRemoteInstanceCount(); attempt++ {
+		instance := dc.getNextRemoteInstance(req, triedInstances)
 		if instance == nil {
 			break
 		}
 
-		if triedInstances[instance.ID] {
-			continue
-		}
-
 		triedInstances[instance.ID] = true
+		startTime := time.Now()
 
 		// Validate worker version before attempting translation
 		if err := dc.validateWorkerForWork(ctx, instance.WorkerID); err != nil {
+			dc.scheduler.Release(instance.WorkerID, req.Size, time.Since(startTime), err, ctx.Err() == nil)
 			dc.emitWarning(fmt.Sprintf("Worker %s validation failed: %v", instance.WorkerID, err))
 			continue
 		}
@@ -437,8 +450,15 @@ func (dc *DistributedCoordinator) translateWithRemoteInstances(
 		})
 
 		result, err := dc.translateWithRemoteInstance(ctx, instance, text, contextHint)
-		if err == nil && result != "" {
+		if err == nil && result == "" {
+			err = fmt.Errorf("empty translation from %s", instance.ID)
+		}
+		dc.scheduler.Release(instance.WorkerID, req.Size, time.Since(startTime), err, ctx.Err() == nil)
+
+		if err == nil {
+			instance.mu.Lock()
 			instance.LastUsed = time.Now()
+			instance.mu.Unlock()
 			dc.emitEvent(events.Event{
 				Type:      "distributed_translation_success",
 				SessionID: "system",
@@ -455,6 +475,10 @@ func (dc *DistributedCoordinator) translateWithRemoteInstances(
 		dc.emitWarning(fmt.Sprintf("Distributed translation attempt %d failed: %v", attempt+1, err))
 	}
 
+	if lastErr == nil {
+		return "", fmt.Errorf("no remote instance available for tags %v", req.Tags)
+	}
+
 	return "", fmt.Errorf("all distributed translation attempts failed, last error: %w", lastErr)
 }
 
@@ -476,8 +500,11 @@ func (dc *DistributedCoordinator) validateWorkerForWork(ctx context.Context, wor
 	return dc.versionManager.ValidateWorkerForWork(ctx, service)
 }
 
-// getNextRemoteInstance returns the next remote instance in round-robin fashion
-func (dc *DistributedCoordinator) getNextRemoteInstance() *RemoteLLMInstance {
+// getNextRemoteInstance reserves a slot on the best remote instance for req,
+// skipping excluded instances, workers at capacity and workers whose circuit
+// breaker is open. Equally good instances are used in rotation. The slot must
+// be released through dc.scheduler.Release.
+func (dc *DistributedCoordinator) getNextRemoteInstance(req WorkRequirements, exclude map[string]bool) *RemoteLLMInstance {
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
@@ -485,20 +512,42 @@ func (dc *DistributedCoordinator) getNextRemoteInstance() *RemoteLLMInstance {
 		return nil
 	}
 
-	// Use round-robin selection
-	instance := dc.remoteInstances[dc.currentIndex]
-	dc.currentIndex = (dc.currentIndex + 1) % len(dc.remoteInstances)
+	instance, index := dc.scheduler.Acquire(dc.remoteInstances, dc.currentIndex, dc.workerConfigs(), req, exclude)
+	if instance == nil {
+		return nil
+	}
+	dc.currentIndex = (index + 1) % len(dc.remoteInstances)
 
 	return instance
 }
 
+// workerConfigs returns the configured workers, providing capacity and tags for scheduling
+func (dc *DistributedCoordinator) workerConfigs() map[string]*WorkerConfig {
+	if dc.sshPool == nil {
+		return nil
+	}
+	return dc.sshPool.GetWorkers()
+}
+
+// GetWorkerLoad returns the scheduler's view of each worker
+func (dc *DistributedCoordinator) GetWorkerLoad() map[string]WorkerLoad {
+	return dc.scheduler.Load(dc.workerConfigs())
+}
+
 // translateWithRemoteInstance performs translation using a specific remote instance
 func (dc *DistributedCoordinator) translateWithRemoteInstance(
 	ctx context.Context,
 	instance *RemoteLLMInstance,
 	text string,
 	contextHint string,
-) (string, error) {
+) (translated string, err error) {
+	ctx, span := tracing.Start(ctx, "distributed.translate",
+		attribute.String("worker.id", instance.WorkerID),
+		attribute.String("llm.provider", instance.Provider),
+		attribute.String("llm.model", instance.Model),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Get the service for this worker
 	services := dc.pairingManager.GetPairedServices()
 	service, exists := services[instance.WorkerID]
@@ -527,6 +576,12 @@ func (dc *DistributedCoordinator) translateWithRemoteInstance(
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	tracing.Inject(ctx, req.Header)
+
+	client, err := dc.pairingManager.ClientFor(service, 60*time.Second)
+	if err != nil {
+		return "", err
+	}
 
 	// Log outgoing request
 	var logEntry *deployment.APICommunicationLog
@@ -534,16 +589,6 @@ func (dc *DistributedCoordinator) translateWithRemoteInstance(
 		logEntry = dc.apiLogger.LogRequest(service.Host, 8443, service.Host, service.Port, "POST", "/api/v1/translate", int64(len(jsonData)))
 	}
 
-	// Use HTTP client that accepts self-signed certificates
-	client := &http.Client{
-		Timeout: 60 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
 	startTime := time.Now()
 	resp, err := client.Do(req)
 	duration := time.Since(startTime)
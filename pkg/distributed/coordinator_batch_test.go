@@ -31,6 +31,7 @@ func TestDistributedCoordinator_translateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"test text",
 			"test context",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -84,6 +85,7 @@ func TestDistributedCoordinator_translateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"test text",
 			"test context",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -134,6 +136,7 @@ func TestDistributedCoordinator_translateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"test text",
 			"test context",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -186,6 +189,7 @@ func TestDistributedCoordinator_translateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"test text",
 			"test context",
+			WorkRequirements{},
 		)
 		
 		// Should fail at network level, not at validation level
@@ -149,6 +149,7 @@ func TestDistributedCoordinator_TranslateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"hello world",
 			"",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -167,6 +168,7 @@ func TestDistributedCoordinator_TranslateWithRemoteInstances(t *testing.T) {
 			nil,
 			"hello world",
 			"",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -182,6 +184,7 @@ func TestDistributedCoordinator_TranslateWithRemoteInstances(t *testing.T) {
 			context.Background(),
 			"",
 			"",
+			WorkRequirements{},
 		)
 		
 		if err == nil {
@@ -235,7 +238,7 @@ func TestDistributedCoordinator_GetNextRemoteInstance(t *testing.T) {
 	coordinator := createTestCoordinator()
 	
 	t.Run("NoInstances", func(t *testing.T) {
-		instance := coordinator.getNextRemoteInstance()
+		instance := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance != nil {
 			t.Error("Expected nil when no instances available")
 		}
@@ -253,7 +256,7 @@ func TestDistributedCoordinator_GetNextRemoteInstance(t *testing.T) {
 			},
 		}
 		
-		instance := coordinator.getNextRemoteInstance()
+		instance := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance == nil {
 			t.Error("Expected instance when one is available")
 		}
@@ -261,6 +264,7 @@ func TestDistributedCoordinator_GetNextRemoteInstance(t *testing.T) {
 		if instance.ID != "instance1" {
 			t.Errorf("Expected instance1, got %s", instance.ID)
 		}
+		coordinator.scheduler.Release(instance.WorkerID, 0, 0, nil, false)
 	})
 	
 	t.Run("MultipleInstancesRoundRobin", func(t *testing.T) {
@@ -287,22 +291,25 @@ func TestDistributedCoordinator_GetNextRemoteInstance(t *testing.T) {
 		coordinator.currentIndex = 0
 		
 		// First call should return instance1
-		instance1 := coordinator.getNextRemoteInstance()
+		instance1 := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance1.ID != "instance1" {
 			t.Errorf("Expected instance1 on first call, got %s", instance1.ID)
 		}
+		coordinator.scheduler.Release(instance1.WorkerID, 0, 0, nil, false)
 		
 		// Second call should return instance2
-		instance2 := coordinator.getNextRemoteInstance()
+		instance2 := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance2.ID != "instance2" {
 			t.Errorf("Expected instance2 on second call, got %s", instance2.ID)
 		}
+		coordinator.scheduler.Release(instance2.WorkerID, 0, 0, nil, false)
 		
-		// Third call should return instance1 again (round robin)
-		instance3 := coordinator.getNextRemoteInstance()
+		// Third call should return instance1 again (rotation among equally loaded workers)
+		instance3 := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance3.ID != "instance1" {
 			t.Errorf("Expected instance1 on third call, got %s", instance3.ID)
 		}
+		coordinator.scheduler.Release(instance3.WorkerID, 0, 0, nil, false)
 	})
 }
 
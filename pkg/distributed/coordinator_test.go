@@ -151,7 +151,7 @@ func TestDistributedCoordinator_getNextRemoteInstance(t *testing.T) {
 		)
 		
 		// With no instances, should return nil
-		instance := coordinator.getNextRemoteInstance()
+		instance := coordinator.getNextRemoteInstance(WorkRequirements{}, nil)
 		if instance != nil {
 			t.Error("Expected nil when no remote instances")
 		}
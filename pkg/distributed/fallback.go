@@ -9,6 +9,13 @@ import (
 	"digital.vasic.translator/pkg/events"
 )
 
+// EventBusInterface defines the interface for EventBus used in FallbackManager
+type EventBusInterface interface {
+	Publish(event events.Event)
+	Subscribe(eventType events.EventType, handler events.EventHandler)
+	SubscribeAll(handler events.EventHandler)
+}
+
 // FallbackConfig holds fallback and recovery configuration
 type FallbackConfig struct {
 	// Graceful Degradation
@@ -10,13 +10,6 @@ import (
 	"digital.vasic.translator/pkg/events"
 )
 
-// EventBusInterface defines the interface for EventBus used in FallbackManager
-type EventBusInterface interface {
-	Publish(event events.Event)
-	Subscribe(eventType events.EventType, handler events.EventHandler)
-	SubscribeAll(handler events.EventHandler)
-}
-
 // mockLogger for testing
 type mockLogger struct {
 	logs []map[string]interface{}
@@ -0,0 +1,446 @@
+package distributed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"digital.vasic.translator/pkg/deployment"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/tracing"
+	"digital.vasic.translator/pkg/translator"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// DefaultJobLease is how long a worker may stay silent before its job is reassigned
+	DefaultJobLease = 2 * time.Minute
+
+	// JobPath is the worker endpoint accepting translation jobs
+	JobPath = "/api/v1/distributed/jobs"
+
+	// maxJobMessageSize bounds a single line of a job result stream
+	maxJobMessageSize = 16 * 1024 * 1024
+)
+
+// Job stream message types
+const (
+	JobMessageResult    = "result"
+	JobMessageHeartbeat = "heartbeat"
+	JobMessageDone      = "done"
+)
+
+// JobSegment is a piece of text translated as part of a job
+type JobSegment struct {
+	ID      string `json:"id"`
+	Text    string `json:"text"`
+	Context string `json:"context,omitempty"`
+}
+
+// GlossaryTerm is a fixed translation for a term
+type GlossaryTerm struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// TranslationJob is a batch of segments, such as a chapter, shipped to a worker in one request
+type TranslationJob struct {
+	ID             string         `json:"id"`
+	LeaseID        string         `json:"lease_id"`
+	LeaseSeconds   int            `json:"lease_seconds"`
+	Provider       string         `json:"provider,omitempty"`
+	Model          string         `json:"model,omitempty"`
+	SourceLanguage string         `json:"source_language,omitempty"`
+	TargetLanguage string         `json:"target_language,omitempty"`
+	Context        string         `json:"context,omitempty"`
+	Glossary       []GlossaryTerm `json:"glossary,omitempty"`
+	Segments       []JobSegment   `json:"segments"`
+}
+
+// JobMessage is one line of the newline-delimited JSON stream a worker sends back
+type JobMessage struct {
+	Type       string `json:"type"`
+	JobID      string `json:"job_id"`
+	LeaseID    string `json:"lease_id"`
+	SegmentID  string `json:"segment_id,omitempty"`
+	Translated string `json:"translated,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Lease returns the lease duration of the job
+func (job *TranslationJob) Lease() time.Duration {
+	if job.LeaseSeconds <= 0 {
+		return DefaultJobLease
+	}
+	return time.Duration(job.LeaseSeconds) * time.Second
+}
+
+// SegmentContext builds the context hint for a segment from the job context,
+// the segment's own context and the glossary terms that occur in it
+func (job *TranslationJob) SegmentContext(segment JobSegment) string {
+	var parts []string
+	if job.Context != "" {
+		parts = append(parts, job.Context)
+	}
+	if segment.Context != "" {
+		parts = append(parts, segment.Context)
+	}
+
+	var terms []string
+	lower := strings.ToLower(segment.Text)
+	for _, term := range job.Glossary {
+		if term.Source != "" && strings.Contains(lower, strings.ToLower(term.Source)) {
+			terms = append(terms, fmt.Sprintf("%s = %s", term.Source, term.Target))
+		}
+	}
+	if len(terms) > 0 {
+		parts = append(parts, "Glossary (use these translations): "+strings.Join(terms, "; "))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// Validate checks that a job can be processed
+func (job *TranslationJob) Validate() error {
+	if job.ID == "" || job.LeaseID == "" {
+		return fmt.Errorf("job id and lease id are required")
+	}
+	return validateSegments(job.Segments)
+}
+
+// validateSegments checks that segments are present and have unique IDs
+func validateSegments(segments []JobSegment) error {
+	if len(segments) == 0 {
+		return fmt.Errorf("job has no segments")
+	}
+
+	seen := make(map[string]bool, len(segments))
+	for _, segment := range segments {
+		if segment.ID == "" {
+			return fmt.Errorf("segment id is required")
+		}
+		if seen[segment.ID] {
+			return fmt.Errorf("duplicate segment id %s", segment.ID)
+		}
+		seen[segment.ID] = true
+	}
+
+	return nil
+}
+
+// RunJob translates the segments of a job locally and streams a result message
+// per segment to w, followed by a done message. Heartbeats are sent while long
+// segments are translated so the coordinator keeps the lease alive. flush is
+// called after every message and may be nil.
+func RunJob(ctx context.Context, job *TranslationJob, trans translator.Translator, w io.Writer, flush func()) error {
+	encoder := json.NewEncoder(w)
+	return runJob(ctx, job, trans, func(msg JobMessage) error {
+		if err := encoder.Encode(msg); err != nil {
+			return err
+		}
+		if flush != nil {
+			flush()
+		}
+		return nil
+	})
+}
+
+// runJob translates the segments of a job and passes result, heartbeat and
+// done messages to send, which is never called concurrently
+func runJob(ctx context.Context, job *TranslationJob, trans translator.Translator, send func(JobMessage) error) error {
+	var mu sync.Mutex
+	sendLocked := func(msg JobMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		msg.JobID = job.ID
+		msg.LeaseID = job.LeaseID
+		return send(msg)
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+
+	go func() {
+		ticker := time.NewTicker(job.Lease() / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if sendLocked(JobMessage{Type: JobMessageHeartbeat}) != nil {
+					return
+				}
+			case <-heartbeatCtx.Done():
+				return
+			}
+		}
+	}()
+
+	for _, segment := range job.Segments {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg := JobMessage{Type: JobMessageResult, SegmentID: segment.ID}
+		translated, err := trans.Translate(ctx, segment.Text, job.SegmentContext(segment))
+		if err != nil {
+			msg.Error = err.Error()
+		} else {
+			msg.Translated = translated
+		}
+
+		if err := sendLocked(msg); err != nil {
+			return err
+		}
+	}
+
+	stopHeartbeat()
+	return sendLocked(JobMessage{Type: JobMessageDone})
+}
+
+// TranslateJob ships the segments of a job to remote workers and returns the
+// translations by segment ID. Each attempt leases the remaining segments to one
+// worker; if the worker goes silent for longer than the lease, fails or leaves
+// segments untranslated, the rest of the job is reassigned to another worker.
+func (dc *DistributedCoordinator) TranslateJob(ctx context.Context, job *TranslationJob, req WorkRequirements) (map[string]string, error) {
+	if err := validateSegments(job.Segments); err != nil {
+		return nil, err
+	}
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	results := make(map[string]string, len(job.Segments))
+	remaining := job.Segments
+	excluded := make(map[string]bool)
+
+	var lastErr error
+	maxAttempts := dc.maxRetries * (dc.GetRemoteInstanceCount() + 1)
+
+	for attempt := 0; len(remaining) > 0 && attempt < maxAttempts; attempt++ {
+		req.Size = segmentsSize(remaining)
+		instance := dc.getNextRemoteInstance(req, excluded)
+		if instance == nil {
+			break
+		}
+
+		lease := *job
+		lease.LeaseID = uuid.New().String()
+		lease.Segments = remaining
+
+		startTime := time.Now()
+		received, err := dc.shipJob(ctx, instance, &lease)
+		for id, translated := range received {
+			results[id] = translated
+		}
+
+		if err == nil && len(received) < len(remaining) {
+			err = fmt.Errorf("worker %s left %d segments untranslated", instance.WorkerID, len(remaining)-len(received))
+		}
+		dc.scheduler.Release(instance.WorkerID, segmentsSize(remaining), time.Since(startTime), err, ctx.Err() == nil)
+
+		remaining = untranslatedSegments(remaining, results)
+		if err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+
+		lastErr = err
+		dc.excludeWorker(instance.WorkerID, excluded)
+
+		if len(remaining) > 0 {
+			dc.emitEvent(events.Event{
+				Type:      "distributed_job_reassigned",
+				SessionID: "system",
+				Message:   fmt.Sprintf("Reassigning %d segments of job %s from worker %s", len(remaining), job.ID, instance.WorkerID),
+				Data: map[string]interface{}{
+					"job_id":    job.ID,
+					"worker_id": instance.WorkerID,
+					"lease_id":  lease.LeaseID,
+					"remaining": len(remaining),
+					"error":     err.Error(),
+				},
+			})
+		}
+	}
+
+	if len(remaining) > 0 {
+		if lastErr == nil {
+			return results, fmt.Errorf("no remote instance available for job %s", job.ID)
+		}
+		return results, fmt.Errorf("job %s incomplete, %d segments untranslated, last error: %w", job.ID, len(remaining), lastErr)
+	}
+
+	return results, nil
+}
+
+// shipJob sends a leased job to the worker of instance and collects the streamed
+// results. The request is cancelled when no message arrives within the lease.
+func (dc *DistributedCoordinator) shipJob(ctx context.Context, instance *RemoteLLMInstance, job *TranslationJob) (map[string]string, error) {
+	ctx, span := tracing.Start(ctx, "distributed.job",
+		attribute.String("worker.id", instance.WorkerID),
+		attribute.String("job.id", job.ID),
+		attribute.String("job.lease_id", job.LeaseID),
+		attribute.Int("job.segments", len(job.Segments)),
+	)
+	results, err := dc.streamJob(ctx, instance, job)
+	span.SetAttributes(attribute.Int("job.results", len(results)))
+	tracing.End(span, err)
+	return results, err
+}
+
+// streamJob performs the request of shipJob
+func (dc *DistributedCoordinator) streamJob(ctx context.Context, instance *RemoteLLMInstance, job *TranslationJob) (map[string]string, error) {
+	services := dc.pairingManager.GetPairedServices()
+	service, exists := services[instance.WorkerID]
+	if !exists {
+		return nil, fmt.Errorf("service not found for worker %s", instance.WorkerID)
+	}
+
+	if job.Provider == "" {
+		job.Provider = instance.Provider
+		job.Model = instance.Model
+	}
+	if job.LeaseSeconds <= 0 {
+		job.LeaseSeconds = int(DefaultJobLease / time.Second)
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	// Streams have no overall timeout; the lease bounds silence instead
+	client, err := dc.pairingManager.ClientFor(service, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var expired atomic.Bool
+	leaseTimer := time.AfterFunc(job.Lease(), func() {
+		expired.Store(true)
+		cancel()
+	})
+	defer leaseTimer.Stop()
+
+	url := fmt.Sprintf("%s://%s:%d%s", service.Protocol, service.Host, service.Port, JobPath)
+	httpReq, err := http.NewRequestWithContext(jobCtx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	tracing.Inject(jobCtx, httpReq.Header)
+
+	var logEntry *deployment.APICommunicationLog
+	if dc.apiLogger != nil {
+		logEntry = dc.apiLogger.LogRequest(service.Host, 8443, service.Host, service.Port, "POST", JobPath, int64(len(body)))
+	}
+
+	startTime := time.Now()
+	results := make(map[string]string, len(job.Segments))
+	streamErr := func() error {
+		resp, err := client.Do(httpReq)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return fmt.Errorf("job rejected with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), maxJobMessageSize)
+		for scanner.Scan() {
+			leaseTimer.Reset(job.Lease())
+
+			var msg JobMessage
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				return fmt.Errorf("invalid job message: %w", err)
+			}
+			if msg.LeaseID != job.LeaseID {
+				return fmt.Errorf("job message for unknown lease %s", msg.LeaseID)
+			}
+
+			switch msg.Type {
+			case JobMessageResult:
+				// Results for another job or for segments outside the lease are dropped
+				if msg.JobID != job.ID || !hasSegment(job.Segments, msg.SegmentID) {
+					continue
+				}
+				if msg.Error == "" && msg.Translated != "" {
+					results[msg.SegmentID] = msg.Translated
+				}
+			case JobMessageDone:
+				return nil
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("job stream ended before completion")
+	}()
+
+	if streamErr != nil && expired.Load() {
+		streamErr = fmt.Errorf("lease %s on worker %s expired", job.LeaseID, instance.WorkerID)
+	}
+
+	if dc.apiLogger != nil && logEntry != nil {
+		statusCode := http.StatusOK
+		if streamErr != nil {
+			statusCode = 0
+		}
+		dc.apiLogger.LogResponse(logEntry, statusCode, 0, time.Since(startTime), streamErr)
+	}
+
+	return results, streamErr
+}
+
+// excludeWorker adds all instances of a worker to excluded
+func (dc *DistributedCoordinator) excludeWorker(workerID string, excluded map[string]bool) {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	for _, instance := range dc.remoteInstances {
+		if instance.WorkerID == workerID {
+			excluded[instance.ID] = true
+		}
+	}
+}
+
+// untranslatedSegments returns the segments without a result
+func untranslatedSegments(segments []JobSegment, results map[string]string) []JobSegment {
+	var remaining []JobSegment
+	for _, segment := range segments {
+		if _, done := results[segment.ID]; !done {
+			remaining = append(remaining, segment)
+		}
+	}
+	return remaining
+}
+
+// segmentsSize returns the number of characters in segments
+func segmentsSize(segments []JobSegment) int {
+	size := 0
+	for _, segment := range segments {
+		size += len([]rune(segment.Text))
+	}
+	return size
+}
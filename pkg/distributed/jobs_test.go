@@ -0,0 +1,289 @@
+package distributed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/translator"
+)
+
+// jobTranslator upper-cases text and records the context hints it receives
+type jobTranslator struct {
+	contexts []string
+}
+
+func (t *jobTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	t.contexts = append(t.contexts, contextHint)
+	return strings.ToUpper(text), nil
+}
+
+func (t *jobTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return t.Translate(ctx, text, contextHint)
+}
+
+func (t *jobTranslator) GetStats() translator.TranslationStats {
+	return translator.TranslationStats{}
+}
+
+func (t *jobTranslator) GetName() string {
+	return "job"
+}
+
+func testJob() *TranslationJob {
+	return &TranslationJob{
+		ID:       "chapter-1",
+		LeaseID:  "lease-1",
+		Context:  "Chapter one",
+		Glossary: []GlossaryTerm{{Source: "Holmes", Target: "Holms"}},
+		Segments: []JobSegment{
+			{ID: "p1", Text: "holmes smiled"},
+			{ID: "p2", Text: "it rained", Context: "after p1"},
+			{ID: "p3", Text: "the end"},
+		},
+	}
+}
+
+func TestTranslationJob_Validate(t *testing.T) {
+	if err := testJob().Validate(); err != nil {
+		t.Fatalf("expected valid job, got %v", err)
+	}
+
+	job := testJob()
+	job.LeaseID = ""
+	if job.Validate() == nil {
+		t.Error("expected error for missing lease id")
+	}
+
+	job = testJob()
+	job.Segments[1].ID = "p1"
+	if job.Validate() == nil {
+		t.Error("expected error for duplicate segment id")
+	}
+
+	job = testJob()
+	job.Segments = nil
+	if job.Validate() == nil {
+		t.Error("expected error for empty job")
+	}
+}
+
+func TestTranslationJob_SegmentContext(t *testing.T) {
+	job := testJob()
+
+	got := job.SegmentContext(job.Segments[0])
+	want := "Chapter one\nGlossary (use these translations): Holmes = Holms"
+	if got != want {
+		t.Errorf("SegmentContext() = %q, want %q", got, want)
+	}
+
+	got = job.SegmentContext(job.Segments[1])
+	if got != "Chapter one\nafter p1" {
+		t.Errorf("SegmentContext() = %q", got)
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	trans := &jobTranslator{}
+	var out bytes.Buffer
+
+	if err := RunJob(context.Background(), testJob(), trans, &out, nil); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+
+	var messages []JobMessage
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var msg JobMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("invalid message %q: %v", scanner.Text(), err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if len(messages) != 4 {
+		t.Fatalf("expected 3 results and done, got %d messages", len(messages))
+	}
+	if messages[0].SegmentID != "p1" || messages[0].Translated != "HOLMES SMILED" || messages[0].LeaseID != "lease-1" {
+		t.Errorf("unexpected first result: %+v", messages[0])
+	}
+	if messages[3].Type != JobMessageDone {
+		t.Errorf("expected done message last, got %s", messages[3].Type)
+	}
+	if len(trans.contexts) != 3 || !strings.Contains(trans.contexts[0], "Holms") {
+		t.Errorf("unexpected context hints: %v", trans.contexts)
+	}
+}
+
+// newJobWorker serves JobPath; translated segments are passed to handle, which
+// may stop the stream early by returning false
+func newJobWorker(t *testing.T, handle func(w http.ResponseWriter, job *TranslationJob) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != JobPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		var job TranslationJob
+		if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+			t.Errorf("invalid job: %v", err)
+			return
+		}
+		if !handle(w, &job) {
+			return
+		}
+		if err := RunJob(r.Context(), &job, &jobTranslator{}, w, w.(http.Flusher).Flush); err != nil {
+			t.Errorf("RunJob failed: %v", err)
+		}
+	}))
+}
+
+// newJobCoordinator pairs a coordinator with servers, preferring earlier ones
+func newJobCoordinator(t *testing.T, workerIDs []string, servers ...*httptest.Server) *DistributedCoordinator {
+	pairingManager := NewPairingManager(NewSSHPool(), nil)
+	t.Cleanup(func() { pairingManager.Close() })
+
+	coordinator := NewDistributedCoordinator(nil, nil, pairingManager, nil, nil, events.NewEventBus(), nil)
+
+	priority := 10
+	for i, server := range servers {
+		workerID := workerIDs[i]
+		u, _ := url.Parse(server.URL)
+		port, _ := strconv.Atoi(u.Port())
+		pairingManager.services[workerID] = &RemoteService{
+			WorkerID: workerID,
+			Host:     u.Hostname(),
+			Port:     port,
+			Protocol: "http",
+			Status:   "paired",
+		}
+		coordinator.remoteInstances = append(coordinator.remoteInstances, &RemoteLLMInstance{
+			ID:       workerID + "-1",
+			WorkerID: workerID,
+			Provider: "ollama",
+			Model:    "llama3:8b",
+			Priority: priority,
+		})
+		priority--
+	}
+
+	return coordinator
+}
+
+func TestDistributedCoordinator_TranslateJob(t *testing.T) {
+	worker := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		if job.Provider != "ollama" || job.LeaseSeconds <= 0 {
+			t.Errorf("unexpected job settings: %s %d", job.Provider, job.LeaseSeconds)
+		}
+		return true
+	})
+	defer worker.Close()
+
+	coordinator := newJobCoordinator(t, []string{"w1"}, worker)
+
+	results, err := coordinator.TranslateJob(context.Background(), testJob(), WorkRequirements{})
+	if err != nil {
+		t.Fatalf("TranslateJob failed: %v", err)
+	}
+	if len(results) != 3 || results["p2"] != "IT RAINED" {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
+
+func TestDistributedCoordinator_TranslateJob_Reassign(t *testing.T) {
+	// The first worker translates one segment and then dies
+	flaky := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		msg := JobMessage{Type: JobMessageResult, JobID: job.ID, LeaseID: job.LeaseID, SegmentID: job.Segments[0].ID, Translated: "FIRST"}
+		json.NewEncoder(w).Encode(msg)
+		return false
+	})
+	defer flaky.Close()
+
+	var reassigned []string
+	healthy := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		for _, segment := range job.Segments {
+			reassigned = append(reassigned, segment.ID)
+		}
+		return true
+	})
+	defer healthy.Close()
+
+	coordinator := newJobCoordinator(t, []string{"flaky", "healthy"}, flaky, healthy)
+
+	results, err := coordinator.TranslateJob(context.Background(), testJob(), WorkRequirements{})
+	if err != nil {
+		t.Fatalf("TranslateJob failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+	if results["p1"] != "FIRST" || results["p3"] != "THE END" {
+		t.Errorf("unexpected results: %v", results)
+	}
+	if strings.Join(reassigned, ",") != "p2,p3" {
+		t.Errorf("expected only untranslated segments to be reassigned, got %v", reassigned)
+	}
+}
+
+func TestDistributedCoordinator_TranslateJob_ForeignResults(t *testing.T) {
+	// The first worker reports results for another job and for a segment
+	// outside its lease, and then dies
+	forger := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		encoder := json.NewEncoder(w)
+		encoder.Encode(JobMessage{Type: JobMessageResult, JobID: "other", LeaseID: job.LeaseID, SegmentID: "p1", Translated: "FORGED"})
+		encoder.Encode(JobMessage{Type: JobMessageResult, JobID: job.ID, LeaseID: job.LeaseID, SegmentID: "p9", Translated: "EXTRA"})
+		return false
+	})
+	defer forger.Close()
+
+	healthy := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		return true
+	})
+	defer healthy.Close()
+
+	coordinator := newJobCoordinator(t, []string{"forger", "healthy"}, forger, healthy)
+
+	results, err := coordinator.TranslateJob(context.Background(), testJob(), WorkRequirements{})
+	if err != nil {
+		t.Fatalf("TranslateJob failed: %v", err)
+	}
+	if len(results) != 3 || results["p1"] != "HOLMES SMILED" {
+		t.Errorf("expected foreign results to be dropped, got %v", results)
+	}
+}
+
+func TestDistributedCoordinator_TranslateJob_LeaseExpiry(t *testing.T) {
+	silent := newJobWorker(t, func(w http.ResponseWriter, job *TranslationJob) bool {
+		w.(http.Flusher).Flush()
+		time.Sleep(3 * time.Second)
+		return false
+	})
+	defer silent.Close()
+
+	coordinator := newJobCoordinator(t, []string{"silent"}, silent)
+	coordinator.maxRetries = 1
+
+	job := testJob()
+	job.LeaseSeconds = 1
+
+	start := time.Now()
+	_, err := coordinator.TranslateJob(context.Background(), job, WorkRequirements{})
+	if err == nil {
+		t.Fatal("expected error from silent worker")
+	}
+	if !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected lease expiry error, got %v", err)
+	}
+	if time.Since(start) > 2500*time.Millisecond {
+		t.Errorf("lease did not bound the silent worker: %v", time.Since(start))
+	}
+}
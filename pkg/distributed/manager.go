@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"sync"
 	"time"
 
@@ -27,6 +28,7 @@ type DistributedManager struct {
 	distributedCoord *DistributedCoordinator
 	fallbackManager  *FallbackManager
 	versionManager   *VersionManager
+	queue            *JobQueue
 	eventBus         *events.EventBus
 	mu               sync.RWMutex
 	initialized      bool
@@ -47,6 +49,12 @@ func NewDistributedManager(cfg *config.Config, eventBus *events.EventBus, apiLog
 	// Create distributed coordinator (will be initialized with local coordinator later)
 	distributedCoord := NewDistributedCoordinator(nil, sshPool, pairingManager, fallbackManager, versionManager, eventBus, apiLogger)
 
+	// Create the job queue for workers that connect to us
+	var queue *JobQueue
+	if cfg.Distributed.Queue.Enabled {
+		queue = NewJobQueue(time.Duration(cfg.Distributed.Queue.LeaseSeconds)*time.Second, eventBus)
+	}
+
 	return &DistributedManager{
 		config:           cfg,
 		sshPool:          sshPool,
@@ -54,6 +62,7 @@ func NewDistributedManager(cfg *config.Config, eventBus *events.EventBus, apiLog
 		distributedCoord: distributedCoord,
 		fallbackManager:  fallbackManager,
 		versionManager:   versionManager,
+		queue:            queue,
 		eventBus:         eventBus,
 		initialized:      false,
 	}
@@ -96,6 +105,13 @@ func (dm *DistributedManager) Initialize(localCoordinator interface{}) error {
 		dm.sshPool.AddWorker(distWorkerCfg)
 	}
 
+	// Issue worker certificates during pairing and require mutual TLS
+	if dm.config.Distributed.MutualTLS.Enabled {
+		if err := dm.enableMutualTLS(); err != nil {
+			return err
+		}
+	}
+
 	dm.initialized = true
 
 	dm.emitEvent(events.Event{
@@ -214,21 +230,68 @@ func (dm *DistributedManager) ensureWorkerVersion(ctx context.Context, service *
 	return nil
 }
 
-// TranslateDistributed performs distributed translation
+// TranslateDistributed translates text as a single-segment job on the workers
 func (dm *DistributedManager) TranslateDistributed(
 	ctx context.Context,
 	text string,
 	contextHint string,
 ) (string, error) {
+	return dm.TranslateDistributedWithTags(ctx, text, contextHint, nil)
+}
+
+// TranslateDistributedWithTags translates text as a single-segment job using
+// only workers that have all of the given tags, e.g. "gpu", "lang:sr" or "model:qwen"
+func (dm *DistributedManager) TranslateDistributedWithTags(
+	ctx context.Context,
+	text string,
+	contextHint string,
+	tags []string,
+) (string, error) {
+
+	job := &TranslationJob{
+		Context:  contextHint,
+		Segments: []JobSegment{{ID: "text", Text: text}},
+	}
+
+	results, err := dm.TranslateJob(ctx, job, tags)
+	if err != nil {
+		return "", err
+	}
+	return results["text"], nil
+}
+
+// TranslateJob translates a batch of segments, such as a chapter, by shipping
+// it to workers that have all of the given tags, or queueing it for pull
+// workers when no paired worker is available. Results are keyed by segment ID.
+func (dm *DistributedManager) TranslateJob(
+	ctx context.Context,
+	job *TranslationJob,
+	tags []string,
+) (map[string]string, error) {
 
 	dm.mu.RLock()
 	if !dm.initialized {
 		dm.mu.RUnlock()
-		return "", fmt.Errorf("distributed manager not initialized")
+		return nil, fmt.Errorf("distributed manager not initialized")
 	}
 	dm.mu.RUnlock()
 
-	return dm.distributedCoord.TranslateWithDistributedRetry(ctx, text, contextHint)
+	req := WorkRequirements{Tags: tags}
+	if dm.usePullWorkers(req) {
+		return dm.queue.Submit(ctx, job, req)
+	}
+	return dm.distributedCoord.TranslateJob(ctx, job, req)
+}
+
+// Queue returns the job queue for pull workers, or nil when it is disabled
+func (dm *DistributedManager) Queue() *JobQueue {
+	return dm.queue
+}
+
+// usePullWorkers reports whether work for req goes to the pull queue, which
+// is the case when no paired worker is known but a pull worker can serve it
+func (dm *DistributedManager) usePullWorkers(req WorkRequirements) bool {
+	return dm.queue != nil && dm.distributedCoord.GetRemoteInstanceCount() == 0 && dm.queue.HasWorkers(req)
 }
 
 // GetStatus returns the status of all workers and instances
@@ -239,6 +302,8 @@ func (dm *DistributedManager) GetStatus() map[string]interface{} {
 	workers := dm.sshPool.GetWorkers()
 	pairedServices := dm.pairingManager.GetPairedServices()
 
+	load := dm.distributedCoord.GetWorkerLoad()
+
 	workerStatuses := make(map[string]interface{})
 	for workerID, worker := range workers {
 		status := "configured"
@@ -246,15 +311,24 @@ func (dm *DistributedManager) GetStatus() map[string]interface{} {
 			status = service.Status
 		}
 
-		workerStatuses[workerID] = map[string]interface{}{
+		workerStatus := map[string]interface{}{
 			"name":     worker.Name,
 			"enabled":  worker.Enabled,
 			"status":   status,
 			"capacity": worker.MaxCapacity,
+			"tags":     worker.Tags,
+		}
+		if workerLoad, exists := load[workerID]; exists {
+			workerStatus["in_flight"] = workerLoad.InFlight
+			workerStatus["latency_ms"] = workerLoad.Latency.Milliseconds()
+			workerStatus["throughput"] = workerLoad.Throughput
+			workerStatus["circuit"] = workerLoad.Circuit
 		}
+
+		workerStatuses[workerID] = workerStatus
 	}
 
-	return map[string]interface{}{
+	status := map[string]interface{}{
 		"initialized":        dm.initialized,
 		"enabled":            dm.config.Distributed.Enabled,
 		"workers":            workerStatuses,
@@ -262,6 +336,13 @@ func (dm *DistributedManager) GetStatus() map[string]interface{} {
 		"remote_instances":   dm.distributedCoord.GetRemoteInstanceCount(),
 		"paired_workers":     len(pairedServices),
 	}
+
+	if dm.queue != nil {
+		status["pull_workers"] = dm.queue.Workers()
+		status["queued_jobs"] = dm.queue.QueuedJobs()
+	}
+
+	return status
 }
 
 // AddWorker adds a new worker dynamically
@@ -372,6 +453,9 @@ func (dm *DistributedManager) Close() error {
 
 	dm.pairingManager.Close()
 	dm.sshPool.Close()
+	if dm.queue != nil {
+		dm.queue.Close()
+	}
 
 	dm.emitEvent(events.Event{
 		Type:      "distributed_manager_shutdown",
@@ -517,3 +601,23 @@ func (dm *DistributedManager) AddAlertChannel(channel AlertChannel) {
 
 	dm.versionManager.AddAlertChannel(channel)
 }
+
+// enableMutualTLS loads the worker CA and switches worker communication to pinned mutual TLS clients
+func (dm *DistributedManager) enableMutualTLS() error {
+	ca, err := LoadOrCreateCertificateAuthority(dm.config.Distributed.MutualTLS.CADir)
+	if err != nil {
+		return fmt.Errorf("failed to load worker CA: %w", err)
+	}
+
+	workerTLS, err := NewWorkerTLS(ca, dm.pairingManager.security)
+	if err != nil {
+		return err
+	}
+
+	dm.pairingManager.EnableMutualTLS(workerTLS)
+	dm.versionManager.SetClientProvider(func(service *RemoteService) (*http.Client, error) {
+		return dm.pairingManager.ClientFor(service, 30*time.Second)
+	})
+
+	return nil
+}
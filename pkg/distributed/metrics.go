@@ -0,0 +1,105 @@
+package distributed
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsNamespace matches the namespace of the server's other metrics
+const metricsNamespace = "translator"
+
+var (
+	queueDepthDesc = prometheus.NewDesc(metricsNamespace+"_queue_depth",
+		"Jobs waiting in the queue for pull workers.", nil, nil)
+	pullWorkersDesc = prometheus.NewDesc(metricsNamespace+"_pull_workers",
+		"Pull workers registered with the queue.", nil, nil)
+	workerUpDesc = prometheus.NewDesc(metricsNamespace+"_worker_up",
+		"Whether a worker accepts work, i.e. its circuit is not open.", []string{"worker_id"}, nil)
+	workerCircuitDesc = prometheus.NewDesc(metricsNamespace+"_worker_circuit_state",
+		"Circuit breaker state of a worker; 1 for the current state.", []string{"worker_id", "state"}, nil)
+	workerInFlightDesc = prometheus.NewDesc(metricsNamespace+"_worker_in_flight",
+		"Requests a worker is processing.", []string{"worker_id"}, nil)
+	workerLatencyDesc = prometheus.NewDesc(metricsNamespace+"_worker_latency_seconds",
+		"Smoothed request latency measured for a worker.", []string{"worker_id"}, nil)
+	workerRequestsDesc = prometheus.NewDesc(metricsNamespace+"_worker_requests_total",
+		"Requests completed by a worker by result (success or error).", []string{"worker_id", "result"}, nil)
+	versionWorkersDesc = prometheus.NewDesc(metricsNamespace+"_version_workers",
+		"Workers by version state found by the last drift check.", []string{"state"}, nil)
+	fallbackDegradedDesc = prometheus.NewDesc(metricsNamespace+"_fallback_degraded",
+		"Whether distributed work runs in degraded mode.", nil, nil)
+	fallbackFailureRateDesc = prometheus.NewDesc(metricsNamespace+"_fallback_failure_rate",
+		"Failure rate of a component tracked by the fallback manager.", []string{"component"}, nil)
+)
+
+var circuitStates = []string{"closed", "open", "half_open"}
+
+// managerCollector reports the state of a distributed manager on every scrape
+type managerCollector struct {
+	dm *DistributedManager
+}
+
+// Collector returns a Prometheus collector for queue depth and worker health
+func (dm *DistributedManager) Collector() prometheus.Collector {
+	return &managerCollector{dm: dm}
+}
+
+// Describe sends the descriptors of all metrics
+func (c *managerCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		queueDepthDesc, pullWorkersDesc, workerUpDesc, workerCircuitDesc, workerInFlightDesc,
+		workerLatencyDesc, workerRequestsDesc, versionWorkersDesc, fallbackDegradedDesc, fallbackFailureRateDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect reads the current state of the manager
+func (c *managerCollector) Collect(ch chan<- prometheus.Metric) {
+	dm := c.dm
+
+	if queue := dm.Queue(); queue != nil {
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(queue.QueuedJobs()))
+		ch <- prometheus.MustNewConstMetric(pullWorkersDesc, prometheus.GaugeValue, float64(len(queue.Workers())))
+	}
+
+	for workerID, load := range dm.distributedCoord.GetWorkerLoad() {
+		up := 1.0
+		if load.Circuit == "open" {
+			up = 0
+		}
+		ch <- prometheus.MustNewConstMetric(workerUpDesc, prometheus.GaugeValue, up, workerID)
+		for _, state := range circuitStates {
+			value := 0.0
+			if load.Circuit == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(workerCircuitDesc, prometheus.GaugeValue, value, workerID, state)
+		}
+		ch <- prometheus.MustNewConstMetric(workerInFlightDesc, prometheus.GaugeValue, float64(load.InFlight), workerID)
+		ch <- prometheus.MustNewConstMetric(workerLatencyDesc, prometheus.GaugeValue, load.Latency.Seconds(), workerID)
+		ch <- prometheus.MustNewConstMetric(workerRequestsDesc, prometheus.CounterValue, float64(load.Completed), workerID, "success")
+		ch <- prometheus.MustNewConstMetric(workerRequestsDesc, prometheus.CounterValue, float64(load.Failed), workerID, "error")
+	}
+
+	if versionMetrics := dm.GetVersionMetrics(); versionMetrics != nil {
+		ch <- prometheus.MustNewConstMetric(versionWorkersDesc, prometheus.GaugeValue, float64(versionMetrics.WorkersUpToDate), "up_to_date")
+		ch <- prometheus.MustNewConstMetric(versionWorkersDesc, prometheus.GaugeValue, float64(versionMetrics.WorkersOutdated), "outdated")
+		ch <- prometheus.MustNewConstMetric(versionWorkersDesc, prometheus.GaugeValue, float64(versionMetrics.WorkersUnhealthy), "unhealthy")
+	}
+
+	if dm.fallbackManager != nil {
+		status := dm.fallbackManager.GetStatus()
+		degraded := 0.0
+		if value, _ := status["degraded_mode"].(bool); value {
+			degraded = 1
+		}
+		ch <- prometheus.MustNewConstMetric(fallbackDegradedDesc, prometheus.GaugeValue, degraded)
+
+		components, _ := status["components"].(map[string]interface{})
+		for component, details := range components {
+			fields, _ := details.(map[string]interface{})
+			if rate, ok := fields["failure_rate"].(float64); ok {
+				ch <- prometheus.MustNewConstMetric(fallbackFailureRateDesc, prometheus.GaugeValue, rate, component)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package distributed
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/deployment"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestDistributedManager_Collector(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Distributed.Queue.Enabled = true
+	manager := NewDistributedManager(cfg, nil, &deployment.APICommunicationLogger{})
+	defer manager.Close()
+
+	if err := manager.Queue().Register(context.Background(), WorkerRegistration{WorkerID: "nat-gpu", Capacity: 1}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	expected := `
+# HELP translator_pull_workers Pull workers registered with the queue.
+# TYPE translator_pull_workers gauge
+translator_pull_workers 1
+# HELP translator_queue_depth Jobs waiting in the queue for pull workers.
+# TYPE translator_queue_depth gauge
+translator_queue_depth 0
+`
+	if err := testutil.CollectAndCompare(manager.Collector(), strings.NewReader(expected),
+		"translator_pull_workers", "translator_queue_depth"); err != nil {
+		t.Error(err)
+	}
+
+	problems, err := testutil.CollectAndLint(manager.Collector())
+	if err != nil || len(problems) > 0 {
+		t.Errorf("lint failed: %v %v", err, problems)
+	}
+}
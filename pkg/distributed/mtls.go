@@ -0,0 +1,304 @@
+package distributed
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Worker certificate files installed during pairing, relative to the worker's home directory
+const (
+	WorkerTLSDir          = ".translator/tls"
+	WorkerCertFileName    = "worker.pem"
+	WorkerKeyFileName     = "worker-key.pem"
+	WorkerCAFileName      = "ca.pem"
+	coordinatorCommonName = "translator-coordinator"
+)
+
+// WorkerTLS holds the coordinator's mutual TLS identity and builds HTTP clients
+// that only trust the certificate pinned for each worker
+type WorkerTLS struct {
+	ca       *CertificateAuthority
+	security *SecurityConfig
+	identity tls.Certificate
+
+	mu         sync.Mutex
+	transports map[string]*http.Transport // workerID|fingerprint -> transport
+}
+
+// NewWorkerTLS issues the coordinator's client certificate from ca. security
+// provides the TLS versions and cipher suites; nil uses DefaultSecurityConfig.
+func NewWorkerTLS(ca *CertificateAuthority, security *SecurityConfig) (*WorkerTLS, error) {
+	if security == nil {
+		security = DefaultSecurityConfig()
+	}
+
+	issued, err := ca.IssueCertificate(coordinatorCommonName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue coordinator certificate: %w", err)
+	}
+
+	identity, err := issued.TLSCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load coordinator certificate: %w", err)
+	}
+
+	return &WorkerTLS{
+		ca:         ca,
+		security:   security,
+		identity:   identity,
+		transports: make(map[string]*http.Transport),
+	}, nil
+}
+
+// CA returns the certificate authority issuing worker certificates
+func (wt *WorkerTLS) CA() *CertificateAuthority {
+	return wt.ca
+}
+
+// ClientTLSConfig returns the TLS configuration for connecting to a worker whose
+// certificate has the given fingerprint. The worker certificate must be issued
+// by the CA and match the pin; the coordinator presents its own certificate.
+func (wt *WorkerTLS) ClientTLSConfig(fingerprint string) (*tls.Config, error) {
+	if fingerprint == "" {
+		return nil, fmt.Errorf("no pinned worker certificate")
+	}
+
+	// Client certificate and trust roots come from the built-in CA
+	sc := *wt.security
+	sc.RequireMutualTLS = false
+	sc.TLSCertVerification = true
+	sc.TLSCAFile = ""
+
+	tlsConfig, err := sc.SecureTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.RootCAs = wt.ca.CertPool()
+	tlsConfig.Certificates = []tls.Certificate{wt.identity}
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("worker presented no certificate")
+		}
+		if CertificateFingerprint(cs.PeerCertificates[0]) != fingerprint {
+			return fmt.Errorf("worker certificate does not match pinned fingerprint")
+		}
+		return nil
+	}
+
+	return tlsConfig, nil
+}
+
+// HTTPClient returns a client for a paired worker, using its pinned certificate
+func (wt *WorkerTLS) HTTPClient(service *RemoteService, timeout time.Duration) (*http.Client, error) {
+	if service.CertFingerprint == "" {
+		return nil, fmt.Errorf("worker %s has no pinned certificate", service.WorkerID)
+	}
+
+	key := service.WorkerID + "|" + service.CertFingerprint
+
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+
+	transport, exists := wt.transports[key]
+	if !exists {
+		tlsConfig, err := wt.ClientTLSConfig(service.CertFingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		transport = &http.Transport{
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+			TLSClientConfig:     tlsConfig,
+			ForceAttemptHTTP2:   true,
+		}
+		wt.transports[key] = transport
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// Forget drops cached connections to a worker, e.g. after unpairing
+func (wt *WorkerTLS) Forget(workerID string) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+
+	for key, transport := range wt.transports {
+		if strings.HasPrefix(key, workerID+"|") {
+			transport.CloseIdleConnections()
+			delete(wt.transports, key)
+		}
+	}
+}
+
+// Paths an unpaired worker serves to clients without a certificate, so the
+// coordinator can discover it before pairing
+var workerBootstrapPaths = map[string]bool{
+	"/health":           true,
+	"/api/v1/providers": true,
+}
+
+// WorkerServerTLSConfig returns the TLS configuration for a worker's API server.
+// Once pairing has installed the certificate files, only the coordinator's
+// certificate issued by the CA in caFile is accepted; certificates of other
+// workers from the same CA are rejected. Until then the worker serves a
+// temporary self-signed certificate without client authentication, and
+// WorkerBootstrapGuard limits those connections to the discovery endpoints.
+// The files are re-read when they change, so certificates installed during
+// pairing take effect without restarting the worker.
+func WorkerServerTLSConfig(security *SecurityConfig, certFile, keyFile, caFile string) (*tls.Config, error) {
+	if security == nil {
+		security = DefaultSecurityConfig()
+	}
+
+	files := &workerCertFiles{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if _, _, err := files.load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	bootstrap, err := bootstrapCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   security.TLSMinVersion,
+		MaxVersion:   security.TLSMaxVersion,
+		CipherSuites: security.TLSCipherSuites,
+		NextProtos:   []string{"h2", "http/1.1"},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+
+	// Settings changed by the caller on the returned config, such as NextProtos, still apply per connection
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		config := tlsConfig.Clone()
+		config.GetConfigForClient = nil
+
+		cert, pool, err := files.load()
+		if errors.Is(err, fs.ErrNotExist) {
+			config.Certificates = []tls.Certificate{bootstrap}
+			config.ClientAuth = tls.NoClientCert
+			return config, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+		config.ClientCAs = pool
+		config.VerifyConnection = verifyCoordinatorPeer
+		return config, nil
+	}
+
+	return tlsConfig, nil
+}
+
+// WorkerBootstrapGuard wraps a worker's API handler so that connections without
+// a verified coordinator certificate, which only an unpaired worker accepts, can
+// reach nothing but the discovery endpoints
+func WorkerBootstrapGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paired := r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+		if !paired && !workerBootstrapPaths[r.URL.Path] {
+			http.Error(w, "worker is not paired with a coordinator", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// verifyCoordinatorPeer accepts only the coordinator's certificate, not those
+// the CA issued to workers
+func verifyCoordinatorPeer(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 || cs.PeerCertificates[0].Subject.CommonName != coordinatorCommonName {
+		return fmt.Errorf("client certificate does not belong to the coordinator")
+	}
+	return nil
+}
+
+// bootstrapCertificate creates the throwaway certificate an unpaired worker serves
+func bootstrapCertificate() (tls.Certificate, error) {
+	ca, err := NewCertificateAuthority("Translator Worker Bootstrap")
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create bootstrap certificate: %w", err)
+	}
+	issued, err := ca.IssueCertificate("translator-worker-bootstrap", nil)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create bootstrap certificate: %w", err)
+	}
+	return issued.TLSCertificate()
+}
+
+// DiscoveryClient returns a client for reading the capabilities of a worker that
+// is not paired yet. It presents no certificate and does not verify the worker's
+// temporary one, so it must not carry anything but discovery requests; trust is
+// established by pairing, which installs certificates over SSH and pins them.
+func (wt *WorkerTLS) DiscoveryClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			TLSClientConfig: &tls.Config{
+				MinVersion:         wt.security.TLSMinVersion,
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+}
+
+// workerCertFiles caches a worker's certificate and client CA, reloading them when the files change
+type workerCertFiles struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	cert     tls.Certificate
+	pool     *x509.CertPool
+}
+
+func (f *workerCertFiles) load() (tls.Certificate, *x509.CertPool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var modTimes [3]time.Time
+	for i, name := range []string{f.certFile, f.keyFile, f.caFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return tls.Certificate{}, nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	if f.pool != nil && modTimes == f.modTimes {
+		return f.cert, f.pool, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load worker certificate: %w", err)
+	}
+
+	caPEM, err := os.ReadFile(f.caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to parse CA certificate")
+	}
+
+	f.cert, f.pool, f.modTimes = cert, pool, modTimes
+	return cert, pool, nil
+}
@@ -0,0 +1,356 @@
+package distributed
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+)
+
+// mtlsWorker is an in-process worker API server requiring client certificates
+type mtlsWorker struct {
+	server   *httptest.Server
+	host     string
+	port     int
+	certFile string
+	keyFile  string
+	caFile   string
+	writes   int
+}
+
+// writeFiles stores an issued certificate the way pairing installs it on a worker
+func (w *mtlsWorker) writeFiles(t *testing.T, cert *IssuedCertificate, caPEM []byte) {
+	t.Helper()
+
+	// Move modification times forward so reloads are detected on coarse clocks
+	w.writes++
+	modTime := time.Now().Add(time.Duration(w.writes) * time.Second)
+	for name, data := range map[string][]byte{w.certFile: cert.CertPEM, w.keyFile: cert.KeyPEM, w.caFile: caPEM} {
+		if err := os.WriteFile(name, data, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		if err := os.Chtimes(name, modTime, modTime); err != nil {
+			t.Fatalf("Failed to touch %s: %v", name, err)
+		}
+	}
+}
+
+// startUnpairedMTLSWorker starts a worker whose certificate files pairing has not installed yet
+func startUnpairedMTLSWorker(t *testing.T) *mtlsWorker {
+	t.Helper()
+
+	dir := t.TempDir()
+	worker := &mtlsWorker{
+		certFile: filepath.Join(dir, WorkerCertFileName),
+		keyFile:  filepath.Join(dir, WorkerKeyFileName),
+		caFile:   filepath.Join(dir, WorkerCAFileName),
+	}
+
+	tlsConfig, err := WorkerServerTLSConfig(nil, worker.certFile, worker.keyFile, worker.caFile)
+	if err != nil {
+		t.Fatalf("Failed to create worker TLS config: %v", err)
+	}
+
+	worker.server = httptest.NewUnstartedServer(WorkerBootstrapGuard(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/health":
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "healthy"})
+		case "/api/v1/providers":
+			json.NewEncoder(w).Encode(map[string]interface{}{"providers": []string{"openai"}})
+		case "/api/v1/translate":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"translated_text": "prevod: " + req["text"].(string)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})))
+	worker.server.TLS = tlsConfig
+	worker.server.StartTLS()
+	t.Cleanup(worker.server.Close)
+
+	host, port, _ := net.SplitHostPort(worker.server.Listener.Addr().String())
+	worker.host = host
+	worker.port, _ = strconv.Atoi(port)
+
+	return worker
+}
+
+func startMTLSWorker(t *testing.T, ca *CertificateAuthority) *mtlsWorker {
+	t.Helper()
+
+	worker := startUnpairedMTLSWorker(t)
+
+	// Certificate from an earlier pairing
+	initial, err := ca.IssueCertificate("worker-1", []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+	worker.writeFiles(t, initial, ca.CertPEM())
+
+	return worker
+}
+
+func newMTLSPairingManager(t *testing.T, worker *mtlsWorker, ca *CertificateAuthority) *PairingManager {
+	t.Helper()
+
+	wt, err := NewWorkerTLS(ca, nil)
+	if err != nil {
+		t.Fatalf("Failed to create worker TLS: %v", err)
+	}
+
+	sshPool := NewSSHPool()
+	t.Cleanup(sshPool.Close)
+
+	pm := NewPairingManager(sshPool, events.NewEventBus())
+	t.Cleanup(pm.Close)
+
+	pm.EnableMutualTLS(wt)
+	pm.SetCertificateInstaller(func(ctx context.Context, workerID string, cert *IssuedCertificate, caPEM []byte) error {
+		worker.writeFiles(t, cert, caPEM)
+		return nil
+	})
+	pm.services["worker-1"] = &RemoteService{
+		WorkerID: "worker-1",
+		Name:     "Worker 1",
+		Host:     worker.host,
+		Port:     worker.port,
+		Protocol: "https",
+		Status:   "online",
+	}
+
+	return pm
+}
+
+func TestPairingManager_PairWithServiceMutualTLS(t *testing.T) {
+	ca, err := NewCertificateAuthority("Test CA")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+	worker := startMTLSWorker(t, ca)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	if err := pm.PairWithService("worker-1"); err != nil {
+		t.Fatalf("Failed to pair: %v", err)
+	}
+
+	service := pm.services["worker-1"]
+	if service.Status != "paired" {
+		t.Errorf("Expected status paired, got %s", service.Status)
+	}
+	if service.CertFingerprint == "" {
+		t.Fatal("Expected pinned certificate fingerprint")
+	}
+
+	// The worker now serves the certificate issued during pairing
+	client, err := pm.ClientFor(service, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Failed to get client: %v", err)
+	}
+	resp, err := client.Get(worker.server.URL + "/health")
+	if err != nil {
+		t.Fatalf("Pinned request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.TLS == nil || CertificateFingerprint(resp.TLS.PeerCertificates[0]) != service.CertFingerprint {
+		t.Error("Expected worker to present the pinned certificate")
+	}
+
+	// Unpairing drops the pin
+	if err := pm.UnpairService("worker-1"); err != nil {
+		t.Fatalf("Failed to unpair: %v", err)
+	}
+	if service.CertFingerprint != "" {
+		t.Error("Expected pin to be cleared after unpairing")
+	}
+	if _, err := pm.ClientFor(service, time.Second); err == nil {
+		t.Error("Expected error for unpinned worker")
+	}
+}
+
+func TestPairingManager_PairWithServiceMutualTLSInstallFailure(t *testing.T) {
+	ca, _ := NewCertificateAuthority("Test CA")
+	worker := startMTLSWorker(t, ca)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	// Worker keeps serving its old certificate, so the new pin cannot be verified
+	pm.SetCertificateInstaller(func(ctx context.Context, workerID string, cert *IssuedCertificate, caPEM []byte) error {
+		return nil
+	})
+
+	if err := pm.PairWithService("worker-1"); err == nil {
+		t.Fatal("Expected pairing to fail when the worker does not serve the issued certificate")
+	}
+	if service := pm.services["worker-1"]; service.Status == "paired" || service.CertFingerprint != "" {
+		t.Error("Expected worker to stay unpaired and unpinned")
+	}
+}
+
+func TestWorkerTLS_RejectsUnverifiedPeers(t *testing.T) {
+	ca, _ := NewCertificateAuthority("Test CA")
+	worker := startMTLSWorker(t, ca)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	if err := pm.PairWithService("worker-1"); err != nil {
+		t.Fatalf("Failed to pair: %v", err)
+	}
+	service := pm.services["worker-1"]
+
+	t.Run("WrongPin", func(t *testing.T) {
+		pinned := *service
+		pinned.CertFingerprint = "0000"
+		client, err := pm.ClientFor(&pinned, 5*time.Second)
+		if err != nil {
+			t.Fatalf("Failed to get client: %v", err)
+		}
+		if resp, err := client.Get(worker.server.URL + "/health"); err == nil {
+			resp.Body.Close()
+			t.Error("Expected request with wrong pin to fail")
+		}
+	})
+
+	t.Run("NoClientCertificate", func(t *testing.T) {
+		client := &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.CertPool()},
+			},
+		}
+		if resp, err := client.Get(worker.server.URL + "/health"); err == nil {
+			resp.Body.Close()
+			t.Error("Expected request without client certificate to fail")
+		}
+	})
+
+	t.Run("OtherWorkerCertificate", func(t *testing.T) {
+		issued, err := ca.IssueCertificate("worker-2", []string{"127.0.0.1"})
+		if err != nil {
+			t.Fatalf("Failed to issue certificate: %v", err)
+		}
+		cert, err := issued.TLSCertificate()
+		if err != nil {
+			t.Fatalf("Failed to load certificate: %v", err)
+		}
+		client := &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.CertPool(), Certificates: []tls.Certificate{cert}},
+			},
+		}
+		if resp, err := client.Get(worker.server.URL + "/health"); err == nil {
+			resp.Body.Close()
+			t.Error("Expected request with another worker's certificate to fail")
+		}
+	})
+
+	t.Run("OtherCA", func(t *testing.T) {
+		other, _ := NewCertificateAuthority("Other CA")
+		wt, err := NewWorkerTLS(other, nil)
+		if err != nil {
+			t.Fatalf("Failed to create worker TLS: %v", err)
+		}
+		client, err := wt.HTTPClient(service, 5*time.Second)
+		if err != nil {
+			t.Fatalf("Failed to get client: %v", err)
+		}
+		if resp, err := client.Get(worker.server.URL + "/health"); err == nil {
+			resp.Body.Close()
+			t.Error("Expected request from a client of another CA to fail")
+		}
+	})
+}
+
+func TestDistributedCoordinator_translateWithRemoteInstanceMutualTLS(t *testing.T) {
+	ca, _ := NewCertificateAuthority("Test CA")
+	worker := startMTLSWorker(t, ca)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	if err := pm.PairWithService("worker-1"); err != nil {
+		t.Fatalf("Failed to pair: %v", err)
+	}
+
+	coordinator := NewDistributedCoordinator(nil, pm.sshPool, pm, nil, nil, events.NewEventBus(), nil)
+	instance := &RemoteLLMInstance{ID: "worker-1-openai-1", WorkerID: "worker-1", Provider: "openai", Available: true}
+
+	translated, err := coordinator.translateWithRemoteInstance(context.Background(), instance, "книга", "")
+	if err != nil {
+		t.Fatalf("Translation over mutual TLS failed: %v", err)
+	}
+	if translated != "prevod: книга" {
+		t.Errorf("Unexpected translation: %s", translated)
+	}
+}
+
+func TestWorkerServerTLSConfig_Bootstrap(t *testing.T) {
+	ca, _ := NewCertificateAuthority("Test CA")
+	worker := startUnpairedMTLSWorker(t)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	// Before pairing, only discovery endpoints answer clients without a certificate
+	discovery := pm.workerTLS.DiscoveryClient(5 * time.Second)
+	resp, err := discovery.Get(worker.server.URL + "/health")
+	if err != nil {
+		t.Fatalf("Discovery request to unpaired worker failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 from /health, got %d", resp.StatusCode)
+	}
+
+	resp, err = discovery.Post(worker.server.URL+"/api/v1/translate", "application/json", strings.NewReader(`{"text":"x"}`))
+	if err != nil {
+		t.Fatalf("Request to unpaired worker failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 for translation on unpaired worker, got %d", resp.StatusCode)
+	}
+
+	// Pairing installs the certificate, after which mutual TLS is required
+	if err := pm.PairWithService("worker-1"); err != nil {
+		t.Fatalf("Failed to pair: %v", err)
+	}
+	if resp, err := discovery.Get(worker.server.URL + "/health"); err == nil {
+		resp.Body.Close()
+		t.Error("Expected requests without a client certificate to fail after pairing")
+	}
+
+	// Paired workers are queried over the pinned connection
+	config := &WorkerConfig{ID: "worker-1", Name: "Worker 1", SSH: SSHConfig{Host: worker.host}, MaxCapacity: 2, Enabled: true}
+	pm.sshPool.configs["worker-1"] = config
+	pm.sshPool.connections["worker-1"] = &SSHConnection{Config: config}
+
+	service, err := pm.queryServiceInfo("worker-1")
+	if err != nil {
+		t.Fatalf("Failed to query paired worker: %v", err)
+	}
+	if service.Port != worker.port || len(service.Capabilities.Providers) != 1 || service.Capabilities.Providers[0] != "openai" {
+		t.Errorf("Unexpected service info: %+v", service)
+	}
+}
+
+func TestPairingManager_RejectsCoordinatorWorkerID(t *testing.T) {
+	ca, _ := NewCertificateAuthority("Test CA")
+	worker := startUnpairedMTLSWorker(t)
+	pm := newMTLSPairingManager(t, worker, ca)
+
+	service := pm.services["worker-1"]
+	delete(pm.services, "worker-1")
+	service.WorkerID = coordinatorCommonName
+	pm.services[coordinatorCommonName] = service
+
+	if err := pm.PairWithService(coordinatorCommonName); err == nil {
+		t.Error("Expected pairing to refuse a worker named like the coordinator")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
@@ -25,6 +26,9 @@ type RemoteService struct {
 	Version      VersionInfo         `json:"version"`
 	LastSeen     time.Time           `json:"last_seen"`
 	PairedAt     *time.Time          `json:"paired_at,omitempty"`
+
+	// CertFingerprint pins the SHA-256 fingerprint of the certificate issued to the worker during pairing
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
 }
 
 // ServiceCapabilities represents what the remote service can do
@@ -46,12 +50,18 @@ type VersionInfo struct {
 	LastUpdated     time.Time         `json:"last_updated"`
 }
 
+// CertificateInstaller installs a certificate issued during pairing on a worker
+type CertificateInstaller func(ctx context.Context, workerID string, cert *IssuedCertificate, caPEM []byte) error
+
 // PairingManager manages pairing with remote services
 type PairingManager struct {
 	services      map[string]*RemoteService
 	sshPool       *SSHPool
 	eventBus      *events.EventBus
 	httpClient    *http.Client
+	security      *SecurityConfig
+	workerTLS     *WorkerTLS
+	installCert   CertificateInstaller
 	checkInterval time.Duration
 	ctx           context.Context
 	cancel        context.CancelFunc
@@ -61,28 +71,19 @@ type PairingManager struct {
 func NewPairingManager(sshPool *SSHPool, eventBus *events.EventBus) *PairingManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Create HTTP client with reasonable timeouts and TLS config for self-signed certs
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			MaxIdleConns:        10,
-			MaxIdleConnsPerHost: 2,
-			IdleConnTimeout:     90 * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, // Accept self-signed certificates
-			},
-		},
-	}
+	security := DefaultSecurityConfig()
 
 	manager := &PairingManager{
 		services:      make(map[string]*RemoteService),
 		sshPool:       sshPool,
 		eventBus:      eventBus,
-		httpClient:    httpClient,
+		httpClient:    newSecureHTTPClient(security, 30*time.Second),
+		security:      security,
 		checkInterval: 30 * time.Second,
 		ctx:           ctx,
 		cancel:        cancel,
 	}
+	manager.installCert = manager.installCertificateSSH
 
 	// Start health check routine
 	go manager.healthCheckLoop()
@@ -90,6 +91,51 @@ func NewPairingManager(sshPool *SSHPool, eventBus *events.EventBus) *PairingMana
 	return manager
 }
 
+// newSecureHTTPClient creates an HTTP client that verifies server certificates according to security
+func newSecureHTTPClient(security *SecurityConfig, timeout time.Duration) *http.Client {
+	tlsConfig, err := security.SecureTLSConfig()
+	if err != nil {
+		// Keep verification on even if the configured CA file cannot be loaded
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        10,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+			TLSClientConfig:     tlsConfig,
+		},
+	}
+}
+
+// EnableMutualTLS makes pairing issue worker certificates from the CA in wt and
+// restricts all communication with paired workers to mutual TLS
+func (pm *PairingManager) EnableMutualTLS(wt *WorkerTLS) {
+	pm.workerTLS = wt
+}
+
+// SetCertificateInstaller replaces how issued certificates are delivered to workers
+func (pm *PairingManager) SetCertificateInstaller(installer CertificateInstaller) {
+	pm.installCert = installer
+}
+
+// ClientFor returns the HTTP client to use for a remote service. Workers are
+// verified against the configured CA; with mutual TLS enabled, the client
+// presents the coordinator certificate and only accepts the certificate pinned
+// for the service.
+func (pm *PairingManager) ClientFor(service *RemoteService, timeout time.Duration) (*http.Client, error) {
+	if pm == nil {
+		return newSecureHTTPClient(DefaultSecurityConfig(), timeout), nil
+	}
+	if pm.workerTLS != nil {
+		return pm.workerTLS.HTTPClient(service, timeout)
+	}
+
+	return &http.Client{Timeout: timeout, Transport: pm.httpClient.Transport}, nil
+}
+
 // DiscoverService discovers a remote service via SSH
 func (pm *PairingManager) DiscoverService(ctx context.Context, workerID string) (*RemoteService, error) {
 	conn, err := pm.sshPool.GetConnection(workerID)
@@ -110,6 +156,10 @@ func (pm *PairingManager) DiscoverService(ctx context.Context, workerID string)
 
 	// Try to get service info via HTTP
 	service, err := pm.queryServiceInfo(workerID)
+	if err != nil && pm.workerTLS != nil {
+		// Guessed capabilities cannot be paired over mutual TLS anyway
+		return nil, fmt.Errorf("failed to query worker %s: %w", workerID, err)
+	}
 	if err != nil {
 		// Fallback: create basic service info
 		config := conn.Config
@@ -129,6 +179,13 @@ func (pm *PairingManager) DiscoverService(ctx context.Context, workerID string)
 		}
 	}
 
+	// Rediscovering a paired worker keeps its pairing and pinned certificate
+	if known, exists := pm.services[workerID]; exists && known.CertFingerprint != "" {
+		service.Status = known.Status
+		service.PairedAt = known.PairedAt
+		service.CertFingerprint = known.CertFingerprint
+	}
+
 	pm.services[workerID] = service
 	return service, nil
 }
@@ -141,6 +198,7 @@ func (pm *PairingManager) queryServiceInfo(workerID string) (*RemoteService, err
 	}
 
 	config := conn.Config
+	client := pm.httpClient
 
 	// Try different ports and protocols
 	endpoints := []struct {
@@ -153,10 +211,23 @@ func (pm *PairingManager) queryServiceInfo(workerID string) (*RemoteService, err
 		{config.SSH.Host, 8443, "http"},
 	}
 
+	// With mutual TLS, workers only speak HTTPS: paired workers are queried with
+	// the pinned client, unpaired ones answer discovery requests without one
+	if pm.workerTLS != nil {
+		endpoints = endpoints[:1]
+		client = pm.workerTLS.DiscoveryClient(pm.httpClient.Timeout)
+		if known, exists := pm.services[workerID]; exists && known.CertFingerprint != "" {
+			endpoints[0].port = known.Port
+			if client, err = pm.workerTLS.HTTPClient(known, pm.httpClient.Timeout); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	for _, endpoint := range endpoints {
 		url := fmt.Sprintf("%s://%s:%d/api/v1/providers", endpoint.proto, endpoint.host, endpoint.port)
 
-		resp, err := pm.httpClient.Get(url)
+		resp, err := client.Get(url)
 		if err != nil {
 			continue
 		}
@@ -179,7 +250,7 @@ func (pm *PairingManager) queryServiceInfo(workerID string) (*RemoteService, err
 
 		// Get health check info
 		healthURL := fmt.Sprintf("%s://%s:%d/health", endpoint.proto, endpoint.host, endpoint.port)
-		healthResp, err := pm.httpClient.Get(healthURL)
+		healthResp, err := client.Get(healthURL)
 		if err != nil {
 			continue
 		}
@@ -227,6 +298,12 @@ func (pm *PairingManager) PairWithService(workerID string) error {
 		return fmt.Errorf("service %s not discovered", workerID)
 	}
 
+	if pm.workerTLS != nil {
+		if err := pm.pairMutualTLS(service); err != nil {
+			return err
+		}
+	}
+
 	now := time.Now()
 	service.Status = "paired"
 	service.PairedAt = &now
@@ -256,6 +333,10 @@ func (pm *PairingManager) UnpairService(workerID string) error {
 
 	service.Status = "online"
 	service.PairedAt = nil
+	service.CertFingerprint = ""
+	if pm.workerTLS != nil {
+		pm.workerTLS.Forget(workerID)
+	}
 
 	// Emit unpairing event
 	pm.emitEvent(events.Event{
@@ -271,6 +352,80 @@ func (pm *PairingManager) UnpairService(workerID string) error {
 	return nil
 }
 
+// pairMutualTLS issues a certificate for the worker, installs it and verifies
+// that the worker serves it before the certificate is pinned
+func (pm *PairingManager) pairMutualTLS(service *RemoteService) error {
+	if service.WorkerID == coordinatorCommonName {
+		return fmt.Errorf("worker ID %s is reserved for the coordinator", service.WorkerID)
+	}
+	ca := pm.workerTLS.CA()
+
+	issued, err := ca.IssueCertificate(service.WorkerID, []string{service.Host})
+	if err != nil {
+		return fmt.Errorf("failed to issue certificate for worker %s: %w", service.WorkerID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(pm.ctx, 30*time.Second)
+	defer cancel()
+
+	if err := pm.installCert(ctx, service.WorkerID, issued, ca.CertPEM()); err != nil {
+		return fmt.Errorf("failed to install certificate on worker %s: %w", service.WorkerID, err)
+	}
+
+	pinned := *service
+	pinned.Protocol = "https"
+	pinned.CertFingerprint = issued.Fingerprint
+
+	client, err := pm.workerTLS.HTTPClient(&pinned, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://%s:%d/health", pinned.Host, pinned.Port)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		pm.workerTLS.Forget(service.WorkerID)
+		return fmt.Errorf("worker %s failed mutual TLS verification: %w", service.WorkerID, err)
+	}
+	resp.Body.Close()
+
+	service.Protocol = pinned.Protocol
+	service.CertFingerprint = pinned.CertFingerprint
+	return nil
+}
+
+// installCertificateSSH writes the issued certificate, its key and the CA
+// certificate to the worker's TLS directory over SSH. File contents travel over
+// stdin so the private key never shows up in process lists or shell history.
+func (pm *PairingManager) installCertificateSSH(ctx context.Context, workerID string, cert *IssuedCertificate, caPEM []byte) error {
+	conn, err := pm.sshPool.GetConnection(workerID)
+	if err != nil {
+		return fmt.Errorf("failed to get SSH connection: %w", err)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{WorkerCAFileName, caPEM},
+		{WorkerCertFileName, cert.CertPEM},
+		{WorkerKeyFileName, cert.KeyPEM},
+	}
+
+	for _, file := range files {
+		if err := conn.WriteFile(ctx, path.Join(WorkerTLSDir, file.name), file.data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	return nil
+}
+
 // GetPairedServices returns all paired services
 func (pm *PairingManager) GetPairedServices() map[string]*RemoteService {
 	paired := make(map[string]*RemoteService)
@@ -321,7 +476,12 @@ func (pm *PairingManager) performHealthChecks() {
 func (pm *PairingManager) checkServiceHealth(workerID string, service *RemoteService) {
 	url := fmt.Sprintf("%s://%s:%d/health", service.Protocol, service.Host, service.Port)
 
-	resp, err := pm.httpClient.Get(url)
+	client, err := pm.ClientFor(service, pm.httpClient.Timeout)
+	if err != nil {
+		return
+	}
+
+	resp, err := client.Get(url)
 	if err != nil {
 		// Service is unreachable
 		if service.Status != "offline" {
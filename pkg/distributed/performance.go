@@ -462,6 +462,68 @@ func (cb *CircuitBreaker) GetState() CircuitState {
 	return cb.state
 }
 
+// Allow reports whether a call may proceed, moving an open breaker to half-open
+// once the recovery timeout has passed. Use with RecordResult for calls that
+// should not hold the breaker while running.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == StateOpen {
+		if time.Since(cb.lastFailure) < cb.recoveryTimeout {
+			return false
+		}
+		cb.state = StateHalfOpen
+		cb.successes = 0
+	}
+	return true
+}
+
+// ready reports whether Allow would admit a call, without changing the state
+func (cb *CircuitBreaker) ready() bool {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+	return cb.state != StateOpen || time.Since(cb.lastFailure) >= cb.recoveryTimeout
+}
+
+// RecordResult records the outcome of a call admitted by Allow
+func (cb *CircuitBreaker) RecordResult(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case StateHalfOpen:
+		if err != nil {
+			cb.failures++
+			cb.lastFailure = time.Now()
+			cb.state = StateOpen
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.successThreshold {
+			cb.state = StateClosed
+			cb.failures = 0
+		}
+
+	case StateClosed:
+		if err != nil {
+			cb.failures++
+			if cb.failures >= cb.failureThreshold {
+				cb.state = StateOpen
+				cb.lastFailure = time.Now()
+			}
+			return
+		}
+		cb.failures = 0
+
+	case StateOpen:
+		// Calls admitted before the breaker opened keep it open
+		if err != nil {
+			cb.lastFailure = time.Now()
+		}
+	}
+}
+
 // BatchProcessor handles request batching for improved performance
 type BatchProcessor struct {
 	batchSize int
@@ -0,0 +1,591 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// QueuePath is the coordinator endpoint prefix used by pull workers
+	QueuePath = "/api/v1/distributed/queue"
+
+	// defaultQueueAttempts bounds how often a job is leased before it fails
+	defaultQueueAttempts = 5
+)
+
+var (
+	// ErrUnknownQueueWorker is returned for workers that have not registered or were dropped
+	ErrUnknownQueueWorker = errors.New("unknown queue worker")
+
+	// ErrLeaseNotFound is returned for leases that expired, were reassigned or completed
+	ErrLeaseNotFound = errors.New("lease not found or expired")
+
+	// ErrQueueClosed is returned once the queue has been closed
+	ErrQueueClosed = errors.New("job queue closed")
+
+	// ErrNoQueueWorkers is returned for jobs no registered pull worker can serve
+	ErrNoQueueWorkers = errors.New("no pull worker can serve the job")
+)
+
+// WorkerRegistration describes a pull worker and the work it accepts
+type WorkerRegistration struct {
+	WorkerID string   `json:"worker_id"`
+	Name     string   `json:"name,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Capacity int      `json:"capacity"` // concurrent leases, 0 means 1
+	Provider string   `json:"provider,omitempty"`
+	Model    string   `json:"model,omitempty"`
+}
+
+// QueueWorkerStatus is a snapshot of a registered pull worker
+type QueueWorkerStatus struct {
+	WorkerRegistration
+	InFlight     int       `json:"in_flight"`
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// queueWorker is the queue's bookkeeping for a pull worker
+type queueWorker struct {
+	registration WorkerRegistration
+	inFlight     int
+	polling      int
+	registeredAt time.Time
+	lastSeen     time.Time
+}
+
+// queuedJob is a submitted job with its results so far
+type queuedJob struct {
+	job      *TranslationJob
+	req      WorkRequirements
+	results  map[string]string
+	lease    *queueLease
+	attempts int
+	queuedAt time.Time
+	err      error
+	done     chan struct{}
+}
+
+// queueLease is a job handed to a pull worker until it completes or expires
+type queueLease struct {
+	id       string
+	workerID string
+	job      *queuedJob
+	segments []JobSegment
+	expires  time.Time
+}
+
+// JobQueue hands translation jobs to pull workers, which connect to the
+// coordinator instead of being reached through SSH and HTTP. Workers register,
+// long-poll for jobs, renew their lease with heartbeats and report results.
+// Segments of a lease that expires or fails are queued again, so each segment
+// is delivered at least once.
+type JobQueue struct {
+	workers       map[string]*queueWorker
+	jobs          map[string]*queuedJob
+	queue         []*queuedJob
+	leases        map[string]*queueLease
+	lease         time.Duration
+	workerTimeout time.Duration
+	maxAttempts   int
+	notify        chan struct{}
+	eventBus      *events.EventBus
+	closed        bool
+	stop          chan struct{}
+	mu            sync.Mutex
+}
+
+// NewJobQueue creates a queue granting leases of the given duration and starts
+// expiring leases and silent workers. Close must be called to stop it.
+func NewJobQueue(lease time.Duration, eventBus *events.EventBus) *JobQueue {
+	if lease <= 0 {
+		lease = DefaultJobLease
+	}
+
+	q := &JobQueue{
+		workers:       make(map[string]*queueWorker),
+		jobs:          make(map[string]*queuedJob),
+		leases:        make(map[string]*queueLease),
+		lease:         lease,
+		workerTimeout: 2 * lease,
+		maxAttempts:   defaultQueueAttempts,
+		notify:        make(chan struct{}),
+		eventBus:      eventBus,
+		stop:          make(chan struct{}),
+	}
+
+	go q.reap(lease / 4)
+
+	return q
+}
+
+// Close stops the queue and fails all jobs that have not finished
+func (q *JobQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.stop)
+
+	for _, job := range q.jobs {
+		q.finish(job, ErrQueueClosed)
+	}
+	q.signal()
+}
+
+// Register adds a pull worker or updates its registration
+func (q *JobQueue) Register(ctx context.Context, reg WorkerRegistration) error {
+	if reg.WorkerID == "" {
+		return fmt.Errorf("worker id is required")
+	}
+	if reg.Capacity <= 0 {
+		reg.Capacity = 1
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	now := time.Now()
+	worker, exists := q.workers[reg.WorkerID]
+	if !exists {
+		worker = &queueWorker{registeredAt: now}
+		q.workers[reg.WorkerID] = worker
+	}
+	worker.registration = reg
+	worker.lastSeen = now
+
+	q.emitEvent(events.Event{
+		Type:      "distributed_queue_worker_registered",
+		SessionID: "system",
+		Message:   fmt.Sprintf("Pull worker %s registered", reg.WorkerID),
+		Data: map[string]interface{}{
+			"worker_id": reg.WorkerID,
+			"capacity":  reg.Capacity,
+			"tags":      reg.Tags,
+		},
+	})
+
+	// Queued jobs may have been waiting for a worker with these tags
+	q.signal()
+	return nil
+}
+
+// Pull leases the next job the worker can serve, waiting up to wait for one
+// to be submitted. It returns nil without error when no job arrived in time.
+func (q *JobQueue) Pull(ctx context.Context, workerID string, wait time.Duration) (*TranslationJob, error) {
+	deadline := time.Now().Add(wait)
+
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return nil, ErrQueueClosed
+		}
+		worker, exists := q.workers[workerID]
+		if !exists {
+			q.mu.Unlock()
+			return nil, ErrUnknownQueueWorker
+		}
+		worker.lastSeen = time.Now()
+
+		if job := q.leaseNext(worker); job != nil {
+			q.mu.Unlock()
+			return job, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			q.mu.Unlock()
+			return nil, nil
+		}
+		notify := q.notify
+		worker.polling++
+		q.mu.Unlock()
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-notify:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+
+		q.mu.Lock()
+		worker.polling--
+		worker.lastSeen = time.Now()
+		q.mu.Unlock()
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// RenewLease extends a lease held by the worker
+func (q *JobQueue) RenewLease(ctx context.Context, workerID, leaseID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	worker, exists := q.workers[workerID]
+	if !exists {
+		return ErrUnknownQueueWorker
+	}
+	worker.lastSeen = time.Now()
+
+	lease, exists := q.leases[leaseID]
+	if !exists || lease.workerID != workerID {
+		return ErrLeaseNotFound
+	}
+	lease.expires = time.Now().Add(q.lease)
+
+	return nil
+}
+
+// Complete records the result messages of a lease and ends it. Only results for
+// the segments of the worker's own lease are accepted; segments the worker failed
+// or left out are queued again. A lease that expired or belongs to another
+// worker yields ErrLeaseNotFound and its results are dropped.
+func (q *JobQueue) Complete(ctx context.Context, workerID, leaseID string, messages []JobMessage) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if worker, exists := q.workers[workerID]; exists {
+		worker.lastSeen = time.Now()
+	}
+
+	lease, exists := q.leases[leaseID]
+	if !exists || lease.workerID != workerID {
+		return ErrLeaseNotFound
+	}
+	job := lease.job
+
+	var failure string
+	for _, msg := range messages {
+		if msg.Type != JobMessageResult || msg.JobID != job.job.ID || !hasSegment(lease.segments, msg.SegmentID) {
+			continue
+		}
+		if msg.Error != "" {
+			failure = msg.Error
+			continue
+		}
+		if msg.Translated != "" {
+			job.results[msg.SegmentID] = msg.Translated
+		}
+	}
+
+	q.endLease(lease)
+
+	if len(q.untranslated(job)) == 0 {
+		q.finish(job, nil)
+		return nil
+	}
+
+	err := fmt.Errorf("worker %s left %d segments untranslated", workerID, len(untranslatedSegments(lease.segments, job.results)))
+	if failure != "" {
+		err = fmt.Errorf("worker %s: %s", workerID, failure)
+	}
+	q.requeue(job, err)
+
+	return nil
+}
+
+// Submit queues a job for pull workers that satisfy req and waits for all of
+// its segments to be translated. Results are keyed by segment ID; on failure
+// the segments translated so far are returned with the error. A job that no
+// registered worker has been able to serve for as long as workers may stay
+// silent fails with ErrNoQueueWorkers, so losing the last matching worker does
+// not leave it waiting forever.
+func (q *JobQueue) Submit(ctx context.Context, job *TranslationJob, req WorkRequirements) (map[string]string, error) {
+	if err := validateSegments(job.Segments); err != nil {
+		return nil, err
+	}
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil, ErrQueueClosed
+	}
+	if _, exists := q.jobs[job.ID]; exists {
+		q.mu.Unlock()
+		return nil, fmt.Errorf("job %s already queued", job.ID)
+	}
+
+	queued := &queuedJob{
+		job:      job,
+		req:      req,
+		results:  make(map[string]string, len(job.Segments)),
+		queuedAt: time.Now(),
+		done:     make(chan struct{}),
+	}
+	q.jobs[job.ID] = queued
+	q.queue = append(q.queue, queued)
+	q.signal()
+	q.mu.Unlock()
+
+	select {
+	case <-queued.done:
+	case <-ctx.Done():
+		q.mu.Lock()
+		q.finish(queued, ctx.Err())
+		q.mu.Unlock()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	results := make(map[string]string, len(queued.results))
+	for id, translated := range queued.results {
+		results[id] = translated
+	}
+	return results, queued.err
+}
+
+// HasWorkers reports whether a registered worker can serve req
+func (q *JobQueue) HasWorkers(req WorkRequirements) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.served(req)
+}
+
+// served reports whether a registered worker can serve req. Callers hold q.mu.
+func (q *JobQueue) served(req WorkRequirements) bool {
+	for _, worker := range q.workers {
+		if worker.accepts(req) {
+			return true
+		}
+	}
+	return false
+}
+
+// Workers returns the registered pull workers
+func (q *JobQueue) Workers() []QueueWorkerStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	workers := make([]QueueWorkerStatus, 0, len(q.workers))
+	for _, worker := range q.workers {
+		workers = append(workers, QueueWorkerStatus{
+			WorkerRegistration: worker.registration,
+			InFlight:           worker.inFlight,
+			RegisteredAt:       worker.registeredAt,
+			LastSeen:           worker.lastSeen,
+		})
+	}
+	return workers
+}
+
+// QueuedJobs returns the number of jobs waiting for a worker
+func (q *JobQueue) QueuedJobs() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.queue)
+}
+
+// leaseNext removes the first job the worker can serve from the queue and
+// leases its untranslated segments to the worker. Callers hold q.mu.
+func (q *JobQueue) leaseNext(worker *queueWorker) *TranslationJob {
+	if worker.inFlight >= worker.registration.Capacity {
+		return nil
+	}
+
+	for i, job := range q.queue {
+		if !worker.accepts(job.req) {
+			continue
+		}
+		q.queue = append(q.queue[:i:i], q.queue[i+1:]...)
+
+		lease := &queueLease{
+			id:       uuid.New().String(),
+			workerID: worker.registration.WorkerID,
+			job:      job,
+			segments: q.untranslated(job),
+			expires:  time.Now().Add(q.lease),
+		}
+		q.leases[lease.id] = lease
+		job.lease = lease
+		job.attempts++
+		worker.inFlight++
+
+		leased := *job.job
+		leased.LeaseID = lease.id
+		leased.LeaseSeconds = int(q.lease / time.Second)
+		leased.Segments = lease.segments
+		if leased.Provider == "" {
+			leased.Provider = worker.registration.Provider
+			leased.Model = worker.registration.Model
+		}
+		return &leased
+	}
+
+	return nil
+}
+
+// untranslated returns the segments of a job without a result. Callers hold q.mu.
+func (q *JobQueue) untranslated(job *queuedJob) []JobSegment {
+	return untranslatedSegments(job.job.Segments, job.results)
+}
+
+// endLease removes a lease and frees its worker slot. Callers hold q.mu.
+func (q *JobQueue) endLease(lease *queueLease) {
+	delete(q.leases, lease.id)
+	if lease.job.lease == lease {
+		lease.job.lease = nil
+	}
+	if worker, exists := q.workers[lease.workerID]; exists && worker.inFlight > 0 {
+		worker.inFlight--
+	}
+	q.signal()
+}
+
+// requeue puts a job back at the front of the queue, or fails it once it has
+// been leased too often. Callers hold q.mu.
+func (q *JobQueue) requeue(job *queuedJob, err error) {
+	if job.attempts >= q.maxAttempts {
+		q.finish(job, fmt.Errorf("job %s incomplete after %d attempts, %d segments untranslated, last error: %w",
+			job.job.ID, job.attempts, len(q.untranslated(job)), err))
+		return
+	}
+
+	q.queue = append([]*queuedJob{job}, q.queue...)
+	q.signal()
+
+	q.emitEvent(events.Event{
+		Type:      "distributed_job_reassigned",
+		SessionID: "system",
+		Message:   fmt.Sprintf("Requeueing %d segments of job %s", len(q.untranslated(job)), job.job.ID),
+		Data: map[string]interface{}{
+			"job_id":    job.job.ID,
+			"remaining": len(q.untranslated(job)),
+			"attempts":  job.attempts,
+			"error":     err.Error(),
+		},
+	})
+}
+
+// finish ends a job, dropping it from the queue and its lease. Callers hold q.mu.
+func (q *JobQueue) finish(job *queuedJob, err error) {
+	if _, exists := q.jobs[job.job.ID]; !exists || q.jobs[job.job.ID] != job {
+		return
+	}
+	delete(q.jobs, job.job.ID)
+
+	for i, queued := range q.queue {
+		if queued == job {
+			q.queue = append(q.queue[:i:i], q.queue[i+1:]...)
+			break
+		}
+	}
+	if job.lease != nil {
+		q.endLease(job.lease)
+	}
+
+	job.err = err
+	close(job.done)
+}
+
+// reap periodically expires leases and drops workers that stopped polling
+func (q *JobQueue) reap(interval time.Duration) {
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			q.expire(now)
+		case <-q.stop:
+			return
+		}
+	}
+}
+
+// expire requeues the jobs of expired leases, drops silent idle workers and
+// fails the queued jobs no worker has been able to serve for workerTimeout
+func (q *JobQueue) expire(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, lease := range q.leases {
+		if now.Before(lease.expires) {
+			continue
+		}
+		q.endLease(lease)
+		q.requeue(lease.job, fmt.Errorf("lease %s on worker %s expired", lease.id, lease.workerID))
+	}
+
+	for workerID, worker := range q.workers {
+		if worker.polling > 0 || worker.inFlight > 0 || now.Sub(worker.lastSeen) < q.workerTimeout {
+			continue
+		}
+		delete(q.workers, workerID)
+
+		q.emitEvent(events.Event{
+			Type:      "distributed_queue_worker_lost",
+			SessionID: "system",
+			Message:   fmt.Sprintf("Pull worker %s stopped polling", workerID),
+			Data: map[string]interface{}{
+				"worker_id": workerID,
+				"last_seen": worker.lastSeen,
+			},
+		})
+	}
+
+	// Leased jobs are left to their lease, which requeues them when it expires
+	for _, job := range append([]*queuedJob(nil), q.queue...) {
+		if now.Sub(job.queuedAt) >= q.workerTimeout && !q.served(job.req) {
+			q.finish(job, fmt.Errorf("job %s: %w", job.job.ID, ErrNoQueueWorkers))
+		}
+	}
+}
+
+// signal wakes all waiting pulls. Callers hold q.mu.
+func (q *JobQueue) signal() {
+	close(q.notify)
+	q.notify = make(chan struct{})
+}
+
+// emitEvent emits an event if event bus is available
+func (q *JobQueue) emitEvent(event events.Event) {
+	if q.eventBus != nil {
+		q.eventBus.Publish(event)
+	}
+}
+
+// accepts reports whether the worker satisfies req
+func (w *queueWorker) accepts(req WorkRequirements) bool {
+	instance := &RemoteLLMInstance{
+		WorkerID: w.registration.WorkerID,
+		Provider: w.registration.Provider,
+		Model:    w.registration.Model,
+	}
+	return matchesRequirements(instance, &WorkerConfig{ID: w.registration.WorkerID, Tags: w.registration.Tags}, req.Tags)
+}
+
+// hasSegment reports whether segments contain the ID
+func hasSegment(segments []JobSegment, id string) bool {
+	for _, segment := range segments {
+		if segment.ID == id {
+			return true
+		}
+	}
+	return false
+}
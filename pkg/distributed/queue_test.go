@@ -0,0 +1,266 @@
+package distributed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/translator"
+)
+
+func jobTranslators(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+	return &jobTranslator{}, nil
+}
+
+// startQueueWorker runs an in-process pull worker until the test ends
+func startQueueWorker(t *testing.T, q *JobQueue, reg WorkerRegistration) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	worker := NewQueueWorker(q, reg, jobTranslators)
+	worker.pollWait = 50 * time.Millisecond
+	worker.retryDelay = 10 * time.Millisecond
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		worker.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+}
+
+func TestJobQueue_InProcessWorker(t *testing.T) {
+	q := NewJobQueue(time.Second, nil)
+	defer q.Close()
+
+	startQueueWorker(t, q, WorkerRegistration{WorkerID: "nat-gpu", Tags: []string{"gpu"}, Capacity: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := q.Submit(ctx, testJob(), WorkRequirements{Tags: []string{"gpu"}})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(results) != 3 || results["p1"] != "HOLMES SMILED" {
+		t.Errorf("unexpected results: %v", results)
+	}
+
+	workers := q.Workers()
+	if len(workers) != 1 || workers[0].WorkerID != "nat-gpu" || workers[0].InFlight != 0 {
+		t.Errorf("unexpected workers: %+v", workers)
+	}
+}
+
+func TestJobQueue_Tags(t *testing.T) {
+	q := NewJobQueue(time.Second, nil)
+	defer q.Close()
+
+	ctx := context.Background()
+	if err := q.Register(ctx, WorkerRegistration{WorkerID: "cpu", Tags: []string{"lang:sr"}}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if q.HasWorkers(WorkRequirements{Tags: []string{"gpu"}}) {
+		t.Error("cpu worker must not serve gpu work")
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	go q.Submit(submitCtx, testJob(), WorkRequirements{Tags: []string{"gpu"}})
+
+	job, err := q.Pull(ctx, "cpu", 100*time.Millisecond)
+	if err != nil || job != nil {
+		t.Errorf("expected no job for cpu worker, got %v, %v", job, err)
+	}
+
+	if _, err := q.Pull(ctx, "unknown", 0); !errors.Is(err, ErrUnknownQueueWorker) {
+		t.Errorf("expected ErrUnknownQueueWorker, got %v", err)
+	}
+}
+
+func TestJobQueue_LeaseExpiry(t *testing.T) {
+	q := NewJobQueue(100*time.Millisecond, nil)
+	defer q.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A worker that takes a job and disappears
+	if err := q.Register(ctx, WorkerRegistration{WorkerID: "vanishing"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	type submitResult struct {
+		results map[string]string
+		err     error
+	}
+	done := make(chan submitResult, 1)
+	go func() {
+		results, err := q.Submit(ctx, testJob(), WorkRequirements{})
+		done <- submitResult{results, err}
+	}()
+
+	lost, err := q.Pull(ctx, "vanishing", time.Second)
+	if err != nil || lost == nil {
+		t.Fatalf("expected a job, got %v, %v", lost, err)
+	}
+
+	// The lease expires and the job moves to a healthy worker
+	startQueueWorker(t, q, WorkerRegistration{WorkerID: "healthy"})
+
+	result := <-done
+	if result.err != nil {
+		t.Fatalf("Submit failed: %v", result.err)
+	}
+	if len(result.results) != 3 {
+		t.Errorf("expected 3 results, got %v", result.results)
+	}
+
+	// Late heartbeats are rejected once the lease has been reassigned
+	if err := q.RenewLease(ctx, "vanishing", lost.LeaseID); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("expected ErrLeaseNotFound for expired lease, got %v", err)
+	}
+}
+
+func TestJobQueue_FailsJobsWithoutWorkers(t *testing.T) {
+	q := NewJobQueue(100*time.Millisecond, nil)
+	defer q.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Without a worker registering in time the job fails
+	if _, err := q.Submit(ctx, testJob(), WorkRequirements{}); !errors.Is(err, ErrNoQueueWorkers) {
+		t.Fatalf("expected ErrNoQueueWorkers without workers, got %v", err)
+	}
+
+	// The only worker takes the job and stops polling
+	if err := q.Register(ctx, WorkerRegistration{WorkerID: "vanishing"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.Submit(ctx, testJob(), WorkRequirements{})
+		done <- err
+	}()
+	if job, err := q.Pull(ctx, "vanishing", time.Second); err != nil || job == nil {
+		t.Fatalf("expected a job, got %v, %v", job, err)
+	}
+
+	// Once the lease expires and the worker is dropped, the job fails instead of waiting forever
+	if err := <-done; !errors.Is(err, ErrNoQueueWorkers) {
+		t.Errorf("expected ErrNoQueueWorkers after losing the worker, got %v", err)
+	}
+	if q.QueuedJobs() != 0 {
+		t.Errorf("expected an empty queue, got %d jobs", q.QueuedJobs())
+	}
+}
+
+func TestJobQueue_CompleteRequeuesFailedSegments(t *testing.T) {
+	q := NewJobQueue(time.Second, nil)
+	defer q.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := q.Register(ctx, WorkerRegistration{WorkerID: "w1"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	done := make(chan map[string]string, 1)
+	go func() {
+		results, _ := q.Submit(ctx, testJob(), WorkRequirements{})
+		done <- results
+	}()
+
+	first, err := q.Pull(ctx, "w1", time.Second)
+	if err != nil || first == nil {
+		t.Fatalf("expected a job, got %v, %v", first, err)
+	}
+	err = q.Complete(ctx, "w1", first.LeaseID, []JobMessage{
+		{Type: JobMessageResult, JobID: first.ID, SegmentID: "p1", Translated: "ONE"},
+		{Type: JobMessageResult, JobID: first.ID, SegmentID: "p2", Error: "model overloaded"},
+	})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	second, err := q.Pull(ctx, "w1", time.Second)
+	if err != nil || second == nil {
+		t.Fatalf("expected the job again, got %v, %v", second, err)
+	}
+	if len(second.Segments) != 2 || second.Segments[0].ID != "p2" {
+		t.Errorf("expected p2 and p3 to be requeued, got %+v", second.Segments)
+	}
+
+	// Results are idempotent, so a duplicate delivery does not hurt
+	messages := []JobMessage{
+		{Type: JobMessageResult, JobID: second.ID, SegmentID: "p2", Translated: "TWO"},
+		{Type: JobMessageResult, JobID: second.ID, SegmentID: "p3", Translated: "THREE"},
+	}
+	if err := q.Complete(ctx, "w1", second.LeaseID, messages); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if err := q.Complete(ctx, "w1", second.LeaseID, messages); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("expected ErrLeaseNotFound for duplicate completion, got %v", err)
+	}
+
+	results := <-done
+	if results["p1"] != "ONE" || results["p2"] != "TWO" || results["p3"] != "THREE" {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
+
+func TestJobQueue_CompleteRejectsForeignResults(t *testing.T) {
+	q := NewJobQueue(time.Second, nil)
+	defer q.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, id := range []string{"honest", "rogue"} {
+		if err := q.Register(ctx, WorkerRegistration{WorkerID: id}); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	done := make(chan map[string]string, 1)
+	go func() {
+		results, _ := q.Submit(ctx, testJob(), WorkRequirements{})
+		done <- results
+	}()
+
+	leased, err := q.Pull(ctx, "honest", time.Second)
+	if err != nil || leased == nil {
+		t.Fatalf("expected a job, got %v, %v", leased, err)
+	}
+
+	// A worker cannot complete a lease it does not hold
+	forged := []JobMessage{{Type: JobMessageResult, JobID: leased.ID, SegmentID: "p1", Translated: "FORGED"}}
+	if err := q.Complete(ctx, "rogue", leased.LeaseID, forged); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("expected ErrLeaseNotFound for a foreign lease, got %v", err)
+	}
+
+	// Results for other jobs or unknown segments are ignored
+	err = q.Complete(ctx, "honest", leased.LeaseID, []JobMessage{
+		{Type: JobMessageResult, JobID: leased.ID, SegmentID: "p1", Translated: "ONE"},
+		{Type: JobMessageResult, JobID: leased.ID, SegmentID: "p2", Translated: "TWO"},
+		{Type: JobMessageResult, JobID: leased.ID, SegmentID: "p3", Translated: "THREE"},
+		{Type: JobMessageResult, JobID: "other-job", SegmentID: "p1", Translated: "OTHER"},
+		{Type: JobMessageResult, JobID: leased.ID, SegmentID: "p9", Translated: "EXTRA"},
+	})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	results := <-done
+	if len(results) != 3 || results["p1"] != "ONE" {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
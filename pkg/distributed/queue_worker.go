@@ -0,0 +1,279 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"digital.vasic.translator/pkg/translator"
+)
+
+const (
+	// WorkerTokenHeader carries the shared secret of pull workers
+	WorkerTokenHeader = "X-Worker-Token"
+
+	// defaultPullWait is how long a pull waits for a job before it is repeated
+	defaultPullWait = 30 * time.Second
+)
+
+// QueueClient is the coordinator's job queue as seen by a pull worker. JobQueue
+// implements it for in-process workers and HTTPQueueClient for remote ones.
+type QueueClient interface {
+	Register(ctx context.Context, reg WorkerRegistration) error
+	Pull(ctx context.Context, workerID string, wait time.Duration) (*TranslationJob, error)
+	RenewLease(ctx context.Context, workerID, leaseID string) error
+	Complete(ctx context.Context, workerID, leaseID string, messages []JobMessage) error
+}
+
+// TranslatorFactory creates a translator for a provider and model between two
+// languages; empty languages select the worker's defaults
+type TranslatorFactory func(provider, model, sourceLang, targetLang string) (translator.Translator, error)
+
+// QueueWorker pulls jobs from a coordinator's queue and translates them
+// locally. It is used by workers that cannot be reached by the coordinator,
+// e.g. behind NAT, since all connections are made by the worker.
+type QueueWorker struct {
+	client       QueueClient
+	registration WorkerRegistration
+	translators  TranslatorFactory
+	pollWait     time.Duration
+	retryDelay   time.Duration
+}
+
+// NewQueueWorker creates a worker that registers with reg and runs
+// reg.Capacity jobs at a time using translators from the factory
+func NewQueueWorker(client QueueClient, reg WorkerRegistration, translators TranslatorFactory) *QueueWorker {
+	if reg.Capacity <= 0 {
+		reg.Capacity = 1
+	}
+
+	return &QueueWorker{
+		client:       client,
+		registration: reg,
+		translators:  translators,
+		pollWait:     defaultPullWait,
+		retryDelay:   5 * time.Second,
+	}
+}
+
+// Run registers the worker and processes jobs until ctx is cancelled.
+// Connection errors are retried; the worker registers again when the
+// coordinator has forgotten it.
+func (w *QueueWorker) Run(ctx context.Context) error {
+	if err := w.register(ctx); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.registration.Capacity; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// register registers the worker, retrying until it succeeds or ctx is done
+func (w *QueueWorker) register(ctx context.Context) error {
+	for {
+		err := w.client.Register(ctx, w.registration)
+		if err == nil {
+			return nil
+		}
+		log.Printf("Pull worker %s failed to register: %v", w.registration.WorkerID, err)
+
+		if !w.sleep(ctx) {
+			return ctx.Err()
+		}
+	}
+}
+
+// loop pulls and processes jobs until ctx is done
+func (w *QueueWorker) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := w.client.Pull(ctx, w.registration.WorkerID, w.pollWait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, ErrUnknownQueueWorker) {
+				if w.register(ctx) != nil {
+					return
+				}
+				continue
+			}
+			log.Printf("Pull worker %s failed to pull: %v", w.registration.WorkerID, err)
+			w.sleep(ctx)
+			continue
+		}
+		if job == nil {
+			continue
+		}
+
+		w.process(ctx, job)
+	}
+}
+
+// process translates a leased job, renewing the lease on every heartbeat, and
+// reports the results. The job is abandoned when the lease is lost.
+func (w *QueueWorker) process(ctx context.Context, job *TranslationJob) {
+	workerID := w.registration.WorkerID
+	var messages []JobMessage
+
+	trans, err := w.translators(job.Provider, job.Model, job.SourceLanguage, job.TargetLanguage)
+	if err != nil {
+		for _, segment := range job.Segments {
+			messages = append(messages, JobMessage{Type: JobMessageResult, JobID: job.ID, LeaseID: job.LeaseID, SegmentID: segment.ID, Error: err.Error()})
+		}
+	} else {
+		jobCtx, cancel := context.WithCancel(ctx)
+		err = runJob(jobCtx, job, trans, func(msg JobMessage) error {
+			switch msg.Type {
+			case JobMessageHeartbeat:
+				if err := w.client.RenewLease(jobCtx, workerID, job.LeaseID); errors.Is(err, ErrLeaseNotFound) {
+					cancel()
+				}
+			case JobMessageResult:
+				messages = append(messages, msg)
+			}
+			return nil
+		})
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Pull worker %s abandoned job %s: %v", workerID, job.ID, err)
+		}
+	}
+
+	// Report what was translated even for abandoned jobs; results are idempotent
+	if err := w.client.Complete(ctx, workerID, job.LeaseID, messages); err != nil && !errors.Is(err, ErrLeaseNotFound) {
+		log.Printf("Pull worker %s failed to report job %s: %v", workerID, job.ID, err)
+	}
+}
+
+// sleep waits for the retry delay and reports whether ctx is still active
+func (w *QueueWorker) sleep(ctx context.Context) bool {
+	timer := time.NewTimer(w.retryDelay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// HTTPQueueClient reaches a coordinator's job queue over HTTP long polling
+type HTTPQueueClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewHTTPQueueClient creates a client for the coordinator at baseURL, e.g.
+// "https://coordinator:8443". client may be nil to use a default client; it
+// must not have a timeout shorter than the pull wait.
+func NewHTTPQueueClient(baseURL, token string, client *http.Client) *HTTPQueueClient {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &HTTPQueueClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+// Register registers the worker with the coordinator
+func (c *HTTPQueueClient) Register(ctx context.Context, reg WorkerRegistration) error {
+	return c.post(ctx, "/workers", reg, nil)
+}
+
+// Pull waits up to wait for a job; it returns nil when none arrived in time
+func (c *HTTPQueueClient) Pull(ctx context.Context, workerID string, wait time.Duration) (*TranslationJob, error) {
+	path := fmt.Sprintf("/workers/%s/pull?wait_seconds=%s", url.PathEscape(workerID), strconv.Itoa(int(wait/time.Second)))
+
+	var job TranslationJob
+	found := false
+	if err := c.post(ctx, path, nil, func(body io.Reader) error {
+		found = true
+		return json.NewDecoder(body).Decode(&job)
+	}); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// RenewLease extends a lease held by the worker
+func (c *HTTPQueueClient) RenewLease(ctx context.Context, workerID, leaseID string) error {
+	return c.post(ctx, fmt.Sprintf("/workers/%s/leases/%s/renew", url.PathEscape(workerID), url.PathEscape(leaseID)), nil, nil)
+}
+
+// Complete reports the results of a lease
+func (c *HTTPQueueClient) Complete(ctx context.Context, workerID, leaseID string, messages []JobMessage) error {
+	body := map[string]interface{}{"messages": messages}
+	return c.post(ctx, fmt.Sprintf("/workers/%s/leases/%s/complete", url.PathEscape(workerID), url.PathEscape(leaseID)), body, nil)
+}
+
+// post sends a request below QueuePath and passes a 200 response body to
+// decode. 204 responses are not decoded; error statuses map to queue errors.
+func (c *HTTPQueueClient) post(ctx context.Context, path string, payload interface{}, decode func(io.Reader) error) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+QueuePath+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set(WorkerTokenHeader, c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if decode != nil {
+			return decode(resp.Body)
+		}
+		return nil
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrUnknownQueueWorker
+	case http.StatusConflict:
+		return ErrLeaseNotFound
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("queue request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+}
@@ -0,0 +1,298 @@
+package distributed
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// latencySmoothing is the weight of a new sample in the latency and throughput averages
+	latencySmoothing = 0.3
+
+	// defaultExpectedLatency is assumed for workers without measurements
+	defaultExpectedLatency = time.Second
+
+	// Circuit breaker settings for each worker
+	workerFailureThreshold = 3
+	workerRecoveryTimeout  = 30 * time.Second
+	workerSuccessThreshold = 1
+)
+
+// WorkRequirements restricts which remote instances may serve a request
+type WorkRequirements struct {
+	// Tags must all be present on the worker, e.g. "gpu" or "lang:sr".
+	// "provider:<name>" and "model:<prefix>" also match the instance itself.
+	Tags []string
+
+	// Size is the request size in characters, used to estimate duration from throughput
+	Size int
+}
+
+// WorkerLoad is a snapshot of the scheduler's view of a worker
+type WorkerLoad struct {
+	WorkerID   string        `json:"worker_id"`
+	InFlight   int           `json:"in_flight"`
+	Capacity   int           `json:"capacity"` // 0 means unlimited
+	Tags       []string      `json:"tags,omitempty"`
+	Latency    time.Duration `json:"latency"`
+	Throughput float64       `json:"throughput"` // characters per second
+	Completed  int64         `json:"completed"`
+	Failed     int64         `json:"failed"`
+	Circuit    string        `json:"circuit"` // closed, open, half_open
+}
+
+// workerState is the scheduler's bookkeeping for one worker
+type workerState struct {
+	inFlight   int
+	latency    time.Duration
+	throughput float64
+	completed  int64
+	failed     int64
+	breaker    *CircuitBreaker
+}
+
+// WorkerScheduler picks remote instances based on worker capacity, tags,
+// measured latency and throughput, and per-worker circuit breakers
+type WorkerScheduler struct {
+	workers map[string]*workerState
+	mu      sync.Mutex
+}
+
+// NewWorkerScheduler creates a new scheduler
+func NewWorkerScheduler() *WorkerScheduler {
+	return &WorkerScheduler{
+		workers: make(map[string]*workerState),
+	}
+}
+
+// state returns the state of a worker, creating it on first use. Callers hold s.mu.
+func (s *WorkerScheduler) state(workerID string) *workerState {
+	state, exists := s.workers[workerID]
+	if !exists {
+		state = &workerState{
+			breaker: NewCircuitBreaker(workerFailureThreshold, workerRecoveryTimeout, workerSuccessThreshold),
+		}
+		s.workers[workerID] = state
+	}
+	return state
+}
+
+// Acquire selects the best instance that satisfies req and reserves a slot on
+// its worker. Instances are considered in rotation from start so equally good
+// instances share the load; the index of the chosen instance is returned.
+// configs provides capacity and tags per worker and may be nil. Release must
+// be called once the work finishes.
+func (s *WorkerScheduler) Acquire(
+	instances []*RemoteLLMInstance,
+	start int,
+	configs map[string]*WorkerConfig,
+	req WorkRequirements,
+	exclude map[string]bool,
+) (*RemoteLLMInstance, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(instances) == 0 {
+		return nil, -1
+	}
+
+	fallbackLatency := s.averageLatency()
+
+	var best *RemoteLLMInstance
+	bestIndex := -1
+	var bestCost float64
+
+	for i := 0; i < len(instances); i++ {
+		index := (start + i) % len(instances)
+		instance := instances[index]
+
+		if exclude[instance.ID] {
+			continue
+		}
+
+		config := configs[instance.WorkerID]
+		if !matchesRequirements(instance, config, req.Tags) {
+			continue
+		}
+
+		state := s.state(instance.WorkerID)
+		capacity := workerCapacity(config)
+		if capacity > 0 && state.inFlight >= capacity {
+			continue
+		}
+		if !state.breaker.ready() {
+			continue
+		}
+
+		cost := s.cost(state, capacity, req.Size, fallbackLatency)
+		if best == nil || cost < bestCost || (cost == bestCost && instance.Priority > best.Priority) {
+			best, bestIndex, bestCost = instance, index, cost
+		}
+	}
+
+	if best == nil {
+		return nil, -1
+	}
+
+	state := s.state(best.WorkerID)
+	if !state.breaker.Allow() {
+		return nil, -1
+	}
+	state.inFlight++
+
+	return best, bestIndex
+}
+
+// Release frees the slot reserved by Acquire and records the outcome. size is
+// the request size in characters. Errors count towards the worker's circuit
+// breaker unless countFailure is false, e.g. when the caller cancelled.
+func (s *WorkerScheduler) Release(workerID string, size int, duration time.Duration, err error, countFailure bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state(workerID)
+	if state.inFlight > 0 {
+		state.inFlight--
+	}
+
+	if err != nil {
+		if countFailure {
+			state.failed++
+			state.breaker.RecordResult(err)
+		}
+		return
+	}
+
+	state.completed++
+	state.breaker.RecordResult(nil)
+
+	if state.latency == 0 {
+		state.latency = duration
+	} else {
+		state.latency = time.Duration(latencySmoothing*float64(duration) + (1-latencySmoothing)*float64(state.latency))
+	}
+
+	if size > 0 && duration > 0 {
+		throughput := float64(size) / duration.Seconds()
+		if state.throughput == 0 {
+			state.throughput = throughput
+		} else {
+			state.throughput = latencySmoothing*throughput + (1-latencySmoothing)*state.throughput
+		}
+	}
+}
+
+// Load returns a snapshot of every worker the scheduler has seen
+func (s *WorkerScheduler) Load(configs map[string]*WorkerConfig) map[string]WorkerLoad {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loads := make(map[string]WorkerLoad, len(s.workers))
+	for workerID, state := range s.workers {
+		load := WorkerLoad{
+			WorkerID:   workerID,
+			InFlight:   state.inFlight,
+			Latency:    state.latency,
+			Throughput: state.throughput,
+			Completed:  state.completed,
+			Failed:     state.failed,
+			Circuit:    circuitName(state.breaker.GetState()),
+		}
+		if config := configs[workerID]; config != nil {
+			load.Capacity = config.MaxCapacity
+			load.Tags = config.Tags
+		}
+		loads[workerID] = load
+	}
+
+	return loads
+}
+
+// cost estimates how long a request would take on a worker, including the wait
+// caused by work already in flight. Callers hold s.mu.
+func (s *WorkerScheduler) cost(state *workerState, capacity, size int, fallbackLatency time.Duration) float64 {
+	expected := float64(state.latency)
+	if expected == 0 {
+		expected = float64(fallbackLatency)
+	}
+	if size > 0 && state.throughput > 0 {
+		expected = float64(size) / state.throughput * float64(time.Second)
+	}
+
+	slots := float64(capacity)
+	if capacity <= 0 {
+		slots = 1
+	}
+
+	return expected * (1 + float64(state.inFlight)/slots)
+}
+
+// averageLatency is the mean measured latency, used for workers without measurements
+// so they are tried alongside known ones. Callers hold s.mu.
+func (s *WorkerScheduler) averageLatency() time.Duration {
+	var total time.Duration
+	count := 0
+	for _, state := range s.workers {
+		if state.latency > 0 {
+			total += state.latency
+			count++
+		}
+	}
+	if count == 0 {
+		return defaultExpectedLatency
+	}
+	return total / time.Duration(count)
+}
+
+// matchesRequirements reports whether an instance has all required tags
+func matchesRequirements(instance *RemoteLLMInstance, config *WorkerConfig, tags []string) bool {
+	for _, tag := range tags {
+		if !hasTag(instance, config, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTag(instance *RemoteLLMInstance, config *WorkerConfig, tag string) bool {
+	if config != nil {
+		for _, workerTag := range config.Tags {
+			if strings.EqualFold(workerTag, tag) {
+				return true
+			}
+		}
+	}
+
+	key, value, found := strings.Cut(tag, ":")
+	if !found {
+		return false
+	}
+
+	switch strings.ToLower(key) {
+	case "provider":
+		return strings.EqualFold(instance.Provider, value)
+	case "model":
+		return strings.HasPrefix(strings.ToLower(instance.Model), strings.ToLower(value))
+	}
+	return false
+}
+
+// workerCapacity returns the configured capacity of a worker, 0 when unlimited
+func workerCapacity(config *WorkerConfig) int {
+	if config == nil || config.MaxCapacity < 0 {
+		return 0
+	}
+	return config.MaxCapacity
+}
+
+func circuitName(state CircuitState) string {
+	switch state {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
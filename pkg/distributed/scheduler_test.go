@@ -0,0 +1,197 @@
+package distributed
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func schedulerInstances() []*RemoteLLMInstance {
+	return []*RemoteLLMInstance{
+		{ID: "cpu-ollama", WorkerID: "cpu", Provider: "ollama", Model: "llama3:8b", Priority: 5},
+		{ID: "gpu-ollama", WorkerID: "gpu", Provider: "ollama", Model: "qwen2.5:14b", Priority: 5},
+		{ID: "gpu-openai", WorkerID: "gpu", Provider: "openai", Model: "gpt-4", Priority: 10},
+	}
+}
+
+func schedulerConfigs() map[string]*WorkerConfig {
+	return map[string]*WorkerConfig{
+		"cpu": {ID: "cpu", MaxCapacity: 1, Tags: []string{"lang:sr"}},
+		"gpu": {ID: "gpu", MaxCapacity: 2, Tags: []string{"gpu", "lang:sr", "lang:ru"}},
+	}
+}
+
+func TestWorkerScheduler_Capacity(t *testing.T) {
+	scheduler := NewWorkerScheduler()
+	instances := schedulerInstances()[:2]
+	configs := schedulerConfigs()
+
+	var acquired []*RemoteLLMInstance
+	for i := 0; i < 3; i++ {
+		instance, _ := scheduler.Acquire(instances, 0, configs, WorkRequirements{}, nil)
+		if instance == nil {
+			t.Fatalf("Expected an instance for request %d", i+1)
+		}
+		acquired = append(acquired, instance)
+	}
+
+	// cpu holds one request, gpu two
+	counts := map[string]int{}
+	for _, instance := range acquired {
+		counts[instance.WorkerID]++
+	}
+	if counts["cpu"] != 1 || counts["gpu"] != 2 {
+		t.Errorf("Expected 1 request on cpu and 2 on gpu, got %v", counts)
+	}
+
+	if instance, _ := scheduler.Acquire(instances, 0, configs, WorkRequirements{}, nil); instance != nil {
+		t.Errorf("Expected no instance when all workers are at capacity, got %s", instance.ID)
+	}
+
+	scheduler.Release("cpu", 0, 0, nil, true)
+	instance, _ := scheduler.Acquire(instances, 0, configs, WorkRequirements{}, nil)
+	if instance == nil || instance.WorkerID != "cpu" {
+		t.Errorf("Expected freed cpu slot to be used, got %v", instance)
+	}
+
+	load := scheduler.Load(configs)
+	if load["gpu"].InFlight != 2 || load["gpu"].Capacity != 2 {
+		t.Errorf("Unexpected gpu load: %+v", load["gpu"])
+	}
+}
+
+func TestWorkerScheduler_Tags(t *testing.T) {
+	scheduler := NewWorkerScheduler()
+	instances := schedulerInstances()
+	configs := schedulerConfigs()
+
+	tests := []struct {
+		tags     []string
+		expected string
+	}{
+		{[]string{"gpu"}, "gpu"},
+		{[]string{"lang:ru"}, "gpu"},
+		{[]string{"model:qwen"}, "gpu-ollama"},
+		{[]string{"provider:openai", "lang:sr"}, "gpu-openai"},
+		{[]string{"model:llama", "lang:sr"}, "cpu-ollama"},
+		{[]string{"model:llama", "gpu"}, ""},
+		{[]string{"lang:de"}, ""},
+	}
+
+	for _, test := range tests {
+		instance, _ := scheduler.Acquire(instances, 0, configs, WorkRequirements{Tags: test.tags}, nil)
+		switch {
+		case test.expected == "" && instance != nil:
+			t.Errorf("Expected no instance for tags %v, got %s", test.tags, instance.ID)
+		case test.expected != "" && instance == nil:
+			t.Errorf("Expected an instance for tags %v", test.tags)
+		case instance != nil && instance.ID != test.expected && instance.WorkerID != test.expected:
+			t.Errorf("Expected %s for tags %v, got %s", test.expected, test.tags, instance.ID)
+		}
+		if instance != nil {
+			scheduler.Release(instance.WorkerID, 0, 0, nil, true)
+		}
+	}
+}
+
+func TestWorkerScheduler_LatencyAndThroughput(t *testing.T) {
+	scheduler := NewWorkerScheduler()
+	instances := []*RemoteLLMInstance{
+		{ID: "slow-1", WorkerID: "slow"},
+		{ID: "fast-1", WorkerID: "fast"},
+	}
+	configs := map[string]*WorkerConfig{
+		"slow": {ID: "slow", MaxCapacity: 4},
+		"fast": {ID: "fast", MaxCapacity: 4},
+	}
+
+	// 1000 characters in 4s against 1000 characters in 500ms
+	scheduler.Acquire(instances, 0, configs, WorkRequirements{}, map[string]bool{"fast-1": true})
+	scheduler.Release("slow", 1000, 4*time.Second, nil, true)
+	scheduler.Acquire(instances, 0, configs, WorkRequirements{}, map[string]bool{"slow-1": true})
+	scheduler.Release("fast", 1000, 500*time.Millisecond, nil, true)
+
+	instance, _ := scheduler.Acquire(instances, 0, configs, WorkRequirements{Size: 1000}, nil)
+	if instance == nil || instance.WorkerID != "fast" {
+		t.Fatalf("Expected fast worker, got %v", instance)
+	}
+
+	// The fast worker stays preferred while its queue is short
+	for i := 0; i < 3; i++ {
+		instance, _ = scheduler.Acquire(instances, 0, configs, WorkRequirements{Size: 1000}, nil)
+		if instance == nil || instance.WorkerID != "fast" {
+			t.Fatalf("Expected fast worker for request %d, got %v", i+2, instance)
+		}
+	}
+
+	// Once it is full, work spills over to the slow worker
+	instance, _ = scheduler.Acquire(instances, 0, configs, WorkRequirements{Size: 1000}, nil)
+	if instance == nil || instance.WorkerID != "slow" {
+		t.Errorf("Expected slow worker when fast is at capacity, got %v", instance)
+	}
+
+	load := scheduler.Load(configs)
+	if load["fast"].Throughput <= load["slow"].Throughput {
+		t.Errorf("Expected fast worker to have higher throughput: %+v %+v", load["fast"], load["slow"])
+	}
+}
+
+func TestWorkerScheduler_CircuitBreaker(t *testing.T) {
+	scheduler := NewWorkerScheduler()
+	instances := []*RemoteLLMInstance{
+		{ID: "flaky-1", WorkerID: "flaky"},
+		{ID: "stable-1", WorkerID: "stable"},
+	}
+
+	for i := 0; i < workerFailureThreshold; i++ {
+		instance, _ := scheduler.Acquire(instances, 0, nil, WorkRequirements{}, map[string]bool{"stable-1": true})
+		if instance == nil {
+			t.Fatalf("Expected flaky worker to be available for attempt %d", i+1)
+		}
+		scheduler.Release("flaky", 0, time.Second, errors.New("connection refused"), true)
+	}
+
+	if load := scheduler.Load(nil); load["flaky"].Circuit != "open" || load["flaky"].Failed != int64(workerFailureThreshold) {
+		t.Fatalf("Expected open circuit after %d failures, got %+v", workerFailureThreshold, load["flaky"])
+	}
+
+	// Open workers are skipped
+	for i := 0; i < 3; i++ {
+		instance, _ := scheduler.Acquire(instances, 0, nil, WorkRequirements{}, nil)
+		if instance == nil || instance.WorkerID != "stable" {
+			t.Fatalf("Expected stable worker while flaky circuit is open, got %v", instance)
+		}
+		scheduler.Release("stable", 0, time.Second, nil, true)
+	}
+	if instance, _ := scheduler.Acquire(instances, 0, nil, WorkRequirements{}, map[string]bool{"stable-1": true}); instance != nil {
+		t.Errorf("Expected no instance when the only remaining worker is open, got %s", instance.ID)
+	}
+
+	// Cancelled requests do not count as failures
+	instance, _ := scheduler.Acquire(instances, 0, nil, WorkRequirements{}, nil)
+	scheduler.Release(instance.WorkerID, 0, 0, errors.New("context canceled"), false)
+	if load := scheduler.Load(nil); load["stable"].Circuit != "closed" || load["stable"].Failed != 0 {
+		t.Errorf("Expected cancelled request to leave the circuit closed, got %+v", load["stable"])
+	}
+}
+
+func TestCircuitBreaker_AllowRecordResult(t *testing.T) {
+	breaker := NewCircuitBreaker(1, 20*time.Millisecond, 1)
+
+	if !breaker.Allow() {
+		t.Fatal("Expected closed breaker to allow calls")
+	}
+	breaker.RecordResult(errors.New("failed"))
+	if breaker.GetState() != StateOpen || breaker.Allow() {
+		t.Fatal("Expected breaker to open and reject calls")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !breaker.Allow() || breaker.GetState() != StateHalfOpen {
+		t.Fatal("Expected half-open breaker after recovery timeout")
+	}
+	breaker.RecordResult(nil)
+	if breaker.GetState() != StateClosed {
+		t.Errorf("Expected breaker to close after a successful probe, got %v", breaker.GetState())
+	}
+}
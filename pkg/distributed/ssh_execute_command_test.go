@@ -70,4 +70,26 @@ func TestSSHConnection_ExecuteCommand(t *testing.T) {
 			t.Error("Expected LastUsed to be updated even with nil client")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestSSHConnection_WriteFile(t *testing.T) {
+	conn := &SSHConnection{Client: nil}
+
+	err := conn.WriteFile(context.Background(), ".translator/tls/worker-key.pem", []byte("secret"), 0600)
+	if err == nil || err.Error() != "SSH client is not initialized" {
+		t.Errorf("Expected 'SSH client is not initialized', got: %v", err)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		".translator/tls": `'.translator/tls'`,
+		"it's":            `'it'\''s'`,
+		"$(rm -rf ~)":     `'$(rm -rf ~)'`,
+	}
+	for input, expected := range tests {
+		if got := shellQuote(input); got != expected {
+			t.Errorf("shellQuote(%q) = %s, expected %s", input, got, expected)
+		}
+	}
+}
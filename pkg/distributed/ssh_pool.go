@@ -1,10 +1,12 @@
 package distributed
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"os"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -295,6 +297,53 @@ func (conn *SSHConnection) ExecuteCommand(ctx context.Context, command string) (
 	}
 }
 
+// WriteFile writes data to a file under the remote user's home directory. The
+// content is streamed over the session's stdin, so it never appears in the
+// command line, and the file is restricted to mode before any data is written.
+func (conn *SSHConnection) WriteFile(ctx context.Context, relPath string, data []byte, mode os.FileMode) error {
+	conn.mu.Lock()
+	conn.LastUsed = time.Now()
+	conn.mu.Unlock()
+
+	if conn.Client == nil {
+		return fmt.Errorf("SSH client is not initialized")
+	}
+
+	session, err := conn.Client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	target := fmt.Sprintf("\"$HOME\"/%s", shellQuote(relPath))
+	dir := path.Dir(relPath)
+	command := fmt.Sprintf("umask 077 && mkdir -p \"$HOME\"/%s && : > %s && chmod %o %s && cat > %s",
+		shellQuote(dir), target, mode.Perm(), target, target)
+	session.Stdin = bytes.NewReader(data)
+
+	errChan := make(chan error, 1)
+	go func() {
+		if output, err := session.CombinedOutput(command); err != nil {
+			errChan <- fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+			return
+		}
+		errChan <- nil
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		session.Signal(ssh.SIGKILL)
+		return ctx.Err()
+	}
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // Close closes the SSH connection
 func (conn *SSHConnection) Close() error {
 	conn.mu.Lock()
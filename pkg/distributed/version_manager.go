@@ -7,7 +7,6 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -430,6 +429,7 @@ type VersionManager struct {
 	versionCache map[string]*VersionCacheEntry // workerID -> cached version info
 	cacheTTL     time.Duration
 	baseURL      string // For testing: override the URL construction
+	clients      func(service *RemoteService) (*http.Client, error)
 }
 
 // NewVersionManager creates a new version manager
@@ -438,12 +438,7 @@ func NewVersionManager(eventBus *events.EventBus) *VersionManager {
 	localVersion := getLocalVersionInfo()
 
 	// Create HTTP client for version checks and downloads
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-	}
+	httpClient := newSecureHTTPClient(DefaultSecurityConfig(), 30*time.Second)
 
 	return &VersionManager{
 		localVersion: localVersion,
@@ -460,6 +455,20 @@ func NewVersionManager(eventBus *events.EventBus) *VersionManager {
 	}
 }
 
+// SetClientProvider sets how HTTP clients for workers are obtained, e.g. to use
+// the pinned mutual TLS clients of the pairing manager
+func (vm *VersionManager) SetClientProvider(clients func(service *RemoteService) (*http.Client, error)) {
+	vm.clients = clients
+}
+
+// clientFor returns the HTTP client for talking to a worker
+func (vm *VersionManager) clientFor(service *RemoteService) (*http.Client, error) {
+	if vm.clients == nil {
+		return vm.httpClient, nil
+	}
+	return vm.clients(service)
+}
+
 // getLocalVersionInfo retrieves version information for the local codebase
 func getLocalVersionInfo() VersionInfo {
 	version := VersionInfo{
@@ -590,7 +599,12 @@ func (vm *VersionManager) CheckWorkerVersion(ctx context.Context, service *Remot
 		return false, fmt.Errorf("failed to create version request: %w", err)
 	}
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return false, fmt.Errorf("failed to query worker version: %w", err)
 	}
@@ -814,7 +828,12 @@ func (vm *VersionManager) uploadUpdatePackage(ctx context.Context, service *Remo
 	req.Header.Set("Content-Type", "application/octet-stream")
 	req.Header.Set("X-Update-Version", vm.localVersion.CodebaseVersion)
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to upload update package: %w", err)
 	}
@@ -844,7 +863,12 @@ func (vm *VersionManager) triggerWorkerUpdate(ctx context.Context, service *Remo
 
 	req.Header.Set("X-Update-Version", vm.localVersion.CodebaseVersion)
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to trigger update: %w", err)
 	}
@@ -904,7 +928,12 @@ func (vm *VersionManager) ValidateWorkerForWork(ctx context.Context, service *Re
 		return fmt.Errorf("failed to create health check request: %w", err)
 	}
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
@@ -1000,7 +1029,12 @@ func (vm *VersionManager) rollbackWorkerUpdate(ctx context.Context, service *Rem
 
 	req.Header.Set("X-Backup-ID", backup.BackupID)
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to trigger rollback: %w", err)
 	}
@@ -1729,7 +1763,12 @@ func (vm *VersionManager) uploadFileToWorker(ctx context.Context, service *Remot
 	req.Header.Set("X-File-Type", fileType)
 	req.Header.Set("X-Update-Version", vm.localVersion.CodebaseVersion)
 
-	resp, err := vm.httpClient.Do(req)
+	client, err := vm.clientFor(service)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to upload file: %w", err)
 	}
@@ -0,0 +1,392 @@
+package ebook
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BilingualLayout places the original and the translation of a book
+type BilingualLayout string
+
+const (
+	// BilingualInterleaved puts each translated paragraph below its original
+	BilingualInterleaved BilingualLayout = "interleaved"
+
+	// BilingualSideBySide puts original and translation in two columns
+	BilingualSideBySide BilingualLayout = "side-by-side"
+)
+
+// ParseBilingualLayout parses a layout name
+func ParseBilingualLayout(name string) (BilingualLayout, error) {
+	switch layout := BilingualLayout(strings.ToLower(strings.TrimSpace(name))); layout {
+	case BilingualInterleaved, BilingualSideBySide:
+		return layout, nil
+	case "columns", "parallel":
+		return BilingualSideBySide, nil
+	}
+	return "", fmt.Errorf("unknown bilingual layout %q (use interleaved or side-by-side)", name)
+}
+
+// BilingualOptions configures a BilingualWriter
+type BilingualOptions struct {
+	Layout         BilingualLayout
+	SourceLanguage string // language code of the original
+	TargetLanguage string // language code of the translation
+	SourceLabel    string // column and toggle label of the original, the code when empty
+	TargetLabel    string // column and toggle label of the translation, the code when empty
+}
+
+// BilingualWriter writes a book and its translation as parallel text.
+// Paragraphs are paired by segment ID and carry it as their anchor. HTML
+// and EPUB output interleave the paragraphs or set them side by side, with
+// a toggle showing one language only; DOCX and Markdown output is a two
+// column table.
+type BilingualWriter struct {
+	options BilingualOptions
+}
+
+// NewBilingualWriter creates a bilingual writer; the layout defaults to
+// interleaved
+func NewBilingualWriter(options BilingualOptions) *BilingualWriter {
+	if options.Layout == "" {
+		options.Layout = BilingualInterleaved
+	}
+	if options.SourceLanguage == "" {
+		options.SourceLanguage = "und"
+	}
+	if options.TargetLanguage == "" {
+		options.TargetLanguage = "und"
+	}
+	if options.SourceLabel == "" {
+		options.SourceLabel = options.SourceLanguage
+	}
+	if options.TargetLabel == "" {
+		options.TargetLabel = options.TargetLanguage
+	}
+	return &BilingualWriter{options: options}
+}
+
+// chapters groups the aligned segments of the books by chapter
+func (w *BilingualWriter) chapters(original, translated *Book) [][]SegmentPair {
+	count := len(original.Chapters)
+	if len(translated.Chapters) > count {
+		count = len(translated.Chapters)
+	}
+
+	chapters := make([][]SegmentPair, count)
+	for _, pair := range AlignSegments(original, translated) {
+		number, _, _ := strings.Cut(strings.TrimPrefix(pair.ID, "c"), "-")
+		if i, err := strconv.Atoi(number); err == nil && i >= 1 && i <= count {
+			chapters[i-1] = append(chapters[i-1], pair)
+		}
+	}
+	return chapters
+}
+
+// bilingualStylesheet lays out the pairs and hides a language when the
+// toggle asks for it, without scripts
+const bilingualStylesheet = `.show { position: absolute; opacity: 0; }
+.toggle { margin-bottom: 1em; }
+.toggle label { cursor: pointer; margin-right: 1em; text-decoration: underline; }
+#show-source:checked ~ .parallel .target,
+#show-target:checked ~ .parallel .source { display: none; }
+.pair { position: relative; margin-bottom: 1em; }
+.pair p { margin: 0 0 0.5em 0; }
+.anchor { position: absolute; left: -1.2em; opacity: 0.3; text-decoration: none; }
+.interleaved .target { color: #555; }
+.side-by-side .pair { display: flex; gap: 2em; }
+.side-by-side .pair > .source, .side-by-side .pair > .target { flex: 1; margin-top: 0; }
+`
+
+// toggle returns the controls showing both languages or one of them
+func (w *BilingualWriter) toggle() string {
+	return fmt.Sprintf(`  <input type="radio" name="show" id="show-both" class="show" checked="checked"/>
+  <input type="radio" name="show" id="show-source" class="show"/>
+  <input type="radio" name="show" id="show-target" class="show"/>
+  <div class="toggle">
+    <label for="show-both">%s + %s</label>
+    <label for="show-source">%s</label>
+    <label for="show-target">%s</label>
+  </div>
+`,
+		escapeXML(w.options.SourceLabel), escapeXML(w.options.TargetLabel),
+		escapeXML(w.options.SourceLabel), escapeXML(w.options.TargetLabel))
+}
+
+// parallelHTML returns the pairs of a chapter as XHTML
+func (w *BilingualWriter) parallelHTML(pairs []SegmentPair) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  <div class=\"parallel %s\">\n", w.options.Layout))
+	for _, pair := range pairs {
+		id := escapeXML(pair.ID)
+		sb.WriteString(fmt.Sprintf("    <div class=\"pair\" id=\"%s\">\n", id))
+		sb.WriteString(fmt.Sprintf("      <a class=\"anchor\" href=\"#%s\">¶</a>\n", id))
+		for _, side := range []struct{ class, lang, text string }{
+			{"source", w.options.SourceLanguage, pair.Source},
+			{"target", w.options.TargetLanguage, pair.Translation},
+		} {
+			attributes := htmlLanguageAttributes(side.lang)
+			if pair.Kind == SegmentParagraph {
+				sb.WriteString(fmt.Sprintf("      <div class=\"%s\"%s>\n", side.class, attributes))
+				for _, para := range splitParagraphs(side.text) {
+					sb.WriteString(fmt.Sprintf("        <p>%s</p>\n", escapeXML(para)))
+				}
+				sb.WriteString("      </div>\n")
+				continue
+			}
+			level := pair.Level
+			if level < 1 || level > 6 {
+				level = 6
+			}
+			sb.WriteString(fmt.Sprintf("      <h%d class=\"%s\"%s>%s</h%d>\n", level, side.class, attributes, escapeXML(side.text), level))
+		}
+		sb.WriteString("    </div>\n")
+	}
+	sb.WriteString("  </div>\n")
+	return sb.String()
+}
+
+// WriteHTML writes the books as a single HTML page
+func (w *BilingualWriter) WriteHTML(original, translated *Book, filename string) error {
+	var body strings.Builder
+	body.WriteString(w.toggle())
+	for _, pairs := range w.chapters(original, translated) {
+		body.WriteString(w.parallelHTML(pairs))
+	}
+
+	page := fmt.Sprintf(`<!DOCTYPE html>
+<html%s>
+<head>
+  <meta charset="utf-8"/>
+  <title>%s</title>
+  <style>
+%s  </style>
+</head>
+<body>
+%s</body>
+</html>
+`,
+		htmlLanguageAttributes(w.options.TargetLanguage),
+		escapeXML(bookTitle(translated)),
+		bilingualStylesheet,
+		body.String())
+
+	if err := os.WriteFile(filename, []byte(page), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML: %w", err)
+	}
+	return nil
+}
+
+// WriteEPUB writes the books as an EPUB with a chapter per chapter of the
+// translation, validating the result
+func (w *BilingualWriter) WriteEPUB(original, translated *Book, filename string) error {
+	chapters := w.chapters(original, translated)
+
+	// The package takes metadata and chapter titles from the translation
+	book := *translated
+	book.Metadata.Language = w.options.TargetLanguage
+	book.Chapters = make([]Chapter, len(chapters))
+	for i := range chapters {
+		if i < len(translated.Chapters) {
+			book.Chapters[i].Title = translated.Chapters[i].Title
+		} else if i < len(original.Chapters) {
+			book.Chapters[i].Title = original.Chapters[i].Title
+		}
+	}
+
+	writer := &EPUBWriter{
+		stylesheet: bilingualStylesheet,
+		chapterBody: func(i int) string {
+			return w.toggle() + w.parallelHTML(chapters[i])
+		},
+	}
+	return writer.Write(&book, filename)
+}
+
+// WriteMarkdown writes the books as Markdown with a two column table per
+// chapter
+func (w *BilingualWriter) WriteMarkdown(original, translated *Book, filename string) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n", markdownCell(bookTitle(translated))))
+
+	for _, pairs := range w.chapters(original, translated) {
+		sb.WriteString(fmt.Sprintf("\n| %s | %s |\n| --- | --- |\n",
+			markdownCell(w.options.SourceLabel), markdownCell(w.options.TargetLabel)))
+		for _, pair := range pairs {
+			source, target := markdownCell(pair.Source), markdownCell(pair.Translation)
+			if pair.Kind != SegmentParagraph {
+				if source != "" {
+					source = "**" + source + "**"
+				}
+				if target != "" {
+					target = "**" + target + "**"
+				}
+			}
+			sb.WriteString(fmt.Sprintf("| <a id=\"%s\"></a>%s | %s |\n", pair.ID, source, target))
+		}
+	}
+
+	if err := os.WriteFile(filename, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
+
+// markdownCell escapes text for a table cell, which cannot hold line breaks
+func markdownCell(text string) string {
+	var paragraphs []string
+	for _, para := range splitParagraphs(text) {
+		paragraphs = append(paragraphs, strings.Join(strings.Fields(para), " "))
+	}
+	return strings.ReplaceAll(strings.Join(paragraphs, "<br><br>"), "|", `\|`)
+}
+
+// WriteDOCX writes the books as a Word document holding a two column table
+// with a bookmark per segment
+func (w *BilingualWriter) WriteDOCX(original, translated *Book, filename string) error {
+	// The header row is repeated on every page
+	var rows strings.Builder
+	rows.WriteString(`<w:tr><w:trPr><w:tblHeader/></w:trPr>`)
+	rows.WriteString(docxCell([]string{w.options.SourceLabel}, w.options.SourceLanguage, docxTitleSize(0), nil))
+	rows.WriteString(docxCell([]string{w.options.TargetLabel}, w.options.TargetLanguage, docxTitleSize(0), nil))
+	rows.WriteString("</w:tr>\n")
+
+	bookmarks := 0
+	for _, pairs := range w.chapters(original, translated) {
+		for _, pair := range pairs {
+			size := 0
+			if pair.Kind != SegmentParagraph {
+				size = docxTitleSize(pair.Level)
+			}
+			bookmarks++
+			rows.WriteString("<w:tr>")
+			rows.WriteString(docxCell(splitParagraphs(pair.Source), w.options.SourceLanguage, size,
+				&docxBookmark{id: bookmarks, name: docxBookmarkName(pair.ID)}))
+			rows.WriteString(docxCell(splitParagraphs(pair.Translation), w.options.TargetLanguage, size, nil))
+			rows.WriteString("</w:tr>\n")
+		}
+	}
+
+	document := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:tbl>
+<w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/></w:tblBorders><w:tblLayout w:type="fixed"/></w:tblPr>
+<w:tblGrid><w:gridCol w:w="4819"/><w:gridCol w:w="4819"/></w:tblGrid>
+%s</w:tbl>
+<w:p/>
+<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="709" w:footer="709" w:gutter="0"/></w:sectPr>
+</w:body>
+</w:document>`, rows.String())
+
+	core := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>%s</dc:title><dc:language>%s</dc:language></cp:coreProperties>`,
+		escapeXML(bookTitle(translated)), escapeXML(w.options.TargetLanguage))
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, part := range []struct{ name, data string }{
+		{"[Content_Types].xml", docxParallelContentTypes},
+		{"_rels/.rels", docxParallelRels},
+		{"word/document.xml", document},
+		{"docProps/core.xml", core},
+	} {
+		writer, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte(part.data)); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+const docxParallelContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxParallelRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+// docxTitleSize returns the font size in half points of a title of the
+// given level, or of the header row for level 0
+func docxTitleSize(level int) int {
+	switch level {
+	case 0:
+		return 22
+	case 1:
+		return 32
+	case 2:
+		return 28
+	}
+	return 24
+}
+
+// docxCell returns a table cell with a paragraph per text. Text is bold at
+// size half points when size is set, and a bookmark is put at the start of
+// the cell when bookmark is given.
+func docxCell(texts []string, lang string, size int, bookmark *docxBookmark) string {
+	props := "<w:rPr>"
+	if size > 0 {
+		props += fmt.Sprintf(`<w:b/><w:sz w:val="%d"/>`, size)
+	}
+	paraProps := ""
+	if isRightToLeft(lang) {
+		props += fmt.Sprintf(`<w:rtl/><w:lang w:bidi="%s"/>`, escapeXML(lang))
+		paraProps = "<w:pPr><w:bidi/></w:pPr>"
+	} else {
+		props += fmt.Sprintf(`<w:lang w:val="%s"/>`, escapeXML(lang))
+	}
+	props += "</w:rPr>"
+
+	if len(texts) == 0 {
+		texts = []string{""}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<w:tc><w:tcPr><w:tcW w:w="2500" w:type="pct"/></w:tcPr>`)
+	for i, text := range texts {
+		sb.WriteString("<w:p>" + paraProps)
+		if i == 0 && bookmark != nil {
+			sb.WriteString(fmt.Sprintf(`<w:bookmarkStart w:id="%d" w:name="%s"/><w:bookmarkEnd w:id="%d"/>`,
+				bookmark.id, escapeXML(bookmark.name), bookmark.id))
+		}
+		if text != "" {
+			sb.WriteString(fmt.Sprintf(`<w:r>%s<w:t xml:space="preserve">%s</w:t></w:r>`, props, escapeText(text)))
+		}
+		sb.WriteString("</w:p>")
+	}
+	sb.WriteString("</w:tc>")
+	return sb.String()
+}
+
+// docxBookmark is a bookmark of a Word document
+type docxBookmark struct {
+	id   int
+	name string
+}
+
+// docxBookmarkName turns a segment ID into a bookmark name, which may only
+// hold letters, digits and underscores
+func docxBookmarkName(id string) string {
+	return strings.NewReplacer("-", "_", ".", "_").Replace(id)
+}
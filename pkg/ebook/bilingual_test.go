@@ -0,0 +1,159 @@
+package ebook
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func bilingualTestBooks() (*Book, *Book) {
+	original := &Book{
+		Metadata: Metadata{Title: "Book", Language: "en"},
+		Chapters: []Chapter{{Title: "One", Sections: []Section{{Content: "Hello | world.\n\nBye."}}}},
+	}
+	translated := &Book{
+		Metadata: Metadata{Title: "Knjiga", Language: "sr"},
+		Chapters: []Chapter{{Title: "Jedan", Sections: []Section{{Content: "Zdravo | svete.\n\nĆao."}}}},
+	}
+	return original, translated
+}
+
+func TestParseBilingualLayout(t *testing.T) {
+	for name, want := range map[string]BilingualLayout{
+		"interleaved":  BilingualInterleaved,
+		"Side-By-Side": BilingualSideBySide,
+		"columns":      BilingualSideBySide,
+	} {
+		if got, err := ParseBilingualLayout(name); err != nil || got != want {
+			t.Errorf("ParseBilingualLayout(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ParseBilingualLayout("diagonal"); err == nil {
+		t.Error("expected error for unknown layout")
+	}
+}
+
+func TestBilingualWriter_WriteHTML(t *testing.T) {
+	original, translated := bilingualTestBooks()
+	filename := filepath.Join(t.TempDir(), "book.html")
+
+	writer := NewBilingualWriter(BilingualOptions{
+		Layout:         BilingualSideBySide,
+		SourceLanguage: "en",
+		TargetLanguage: "ar",
+		SourceLabel:    "English",
+	})
+	if err := writer.WriteHTML(original, translated, filename); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, want := range []string{
+		`<div class="parallel side-by-side">`,
+		`<div class="pair" id="c1-s1-p1">`,
+		`<a class="anchor" href="#c1-s1-p1">¶</a>`,
+		`<h1 class="source" xml:lang="en" lang="en">One</h1>`,
+		`<div class="target" xml:lang="ar" lang="ar" dir="rtl">`,
+		`<p>Zdravo | svete.</p>`,
+		`<label for="show-source">English</label>`,
+		`<label for="show-target">ar</label>`,
+		`#show-source:checked ~ .parallel .target`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML lacks %s", want)
+		}
+	}
+}
+
+func TestBilingualWriter_WriteEPUB(t *testing.T) {
+	original, translated := bilingualTestBooks()
+	filename := filepath.Join(t.TempDir(), "book.epub")
+
+	writer := NewBilingualWriter(BilingualOptions{SourceLanguage: "en", TargetLanguage: "sr"})
+	if err := writer.WriteEPUB(original, translated, filename); err != nil {
+		t.Fatalf("WriteEPUB failed: %v", err)
+	}
+
+	contents, _ := readEntries(t, filename)
+	chapter := string(contents["OEBPS/chapter1.xhtml"])
+	for _, want := range []string{
+		`<link rel="stylesheet" type="text/css" href="style.css"/>`,
+		`<div class="parallel interleaved">`,
+		`<div class="pair" id="c1-s1-p2">`,
+		`<p>Ćao.</p>`,
+	} {
+		if !strings.Contains(chapter, want) {
+			t.Errorf("chapter lacks %s:\n%s", want, chapter)
+		}
+	}
+	if !strings.Contains(string(contents["OEBPS/content.opf"]), `href="style.css" media-type="text/css"`) {
+		t.Error("stylesheet missing from the manifest")
+	}
+	if !strings.Contains(string(contents["OEBPS/nav.xhtml"]), ">Jedan</a>") {
+		t.Error("navigation should use the translated chapter titles")
+	}
+}
+
+func TestBilingualWriter_WriteMarkdown(t *testing.T) {
+	original, translated := bilingualTestBooks()
+	filename := filepath.Join(t.TempDir(), "book.md")
+
+	writer := NewBilingualWriter(BilingualOptions{SourceLanguage: "en", TargetLanguage: "sr", SourceLabel: "English", TargetLabel: "Serbian"})
+	if err := writer.WriteMarkdown(original, translated, filename); err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Knjiga
+
+| English | Serbian |
+| --- | --- |
+| <a id="c1"></a>**One** | **Jedan** |
+| <a id="c1-s1-p1"></a>Hello \| world. | Zdravo \| svete. |
+| <a id="c1-s1-p2"></a>Bye. | Ćao. |
+`
+	if string(data) != want {
+		t.Errorf("unexpected Markdown:\n%s", data)
+	}
+}
+
+func TestBilingualWriter_WriteDOCX(t *testing.T) {
+	original, translated := bilingualTestBooks()
+	filename := filepath.Join(t.TempDir(), "book.docx")
+
+	writer := NewBilingualWriter(BilingualOptions{SourceLanguage: "en", TargetLanguage: "he"})
+	if err := writer.WriteDOCX(original, translated, filename); err != nil {
+		t.Fatalf("WriteDOCX failed: %v", err)
+	}
+
+	contents, _ := readEntries(t, filename)
+	for name, data := range contents {
+		if err := xml.Unmarshal(data, new(interface{})); err != nil {
+			t.Errorf("%s is not well-formed: %v", name, err)
+		}
+	}
+
+	document := string(contents["word/document.xml"])
+	for _, want := range []string{
+		`<w:tblHeader/>`,
+		`<w:bookmarkStart w:id="2" w:name="c1_s1_p1"/>`,
+		`<w:rPr><w:b/><w:sz w:val="32"/><w:lang w:val="en"/></w:rPr><w:t xml:space="preserve">One</w:t>`,
+		`<w:pPr><w:bidi/></w:pPr><w:r><w:rPr><w:rtl/><w:lang w:bidi="he"/></w:rPr><w:t xml:space="preserve">Ćao.</w:t>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("document.xml lacks %s", want)
+		}
+	}
+	if !strings.Contains(string(contents["docProps/core.xml"]), "<dc:language>he</dc:language>") {
+		t.Error("core properties lack the language")
+	}
+}
@@ -0,0 +1,596 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DOCXInPlaceStats reports the work of a DOCX translation
+type DOCXInPlaceStats struct {
+	Parts      int // document, header, footer, note and comment parts rewritten
+	Paragraphs int // paragraphs translated with their run formatting
+	Merged     int // paragraphs whose formatting was lost in translation, set in their main run
+}
+
+// DOCXInPlaceTranslator translates a Word document without rebuilding it.
+// The text of every paragraph is translated as a whole, with runs of
+// different formatting marked by placeholders, and written back into the
+// original runs. Styles, numbering, tables, images, fields and everything
+// else in the package are left as they are.
+//
+// The parts are rewritten directly rather than through unioffice, which
+// needs a license key to read or save documents.
+type DOCXInPlaceTranslator struct {
+	translate      TranslateFunc
+	targetLanguage string
+
+	// OnProgress, when set, is called after each translated paragraph
+	OnProgress func(done, total int)
+}
+
+// NewDOCXInPlaceTranslator creates an in-place translator writing
+// targetLanguage to the run and document language properties
+func NewDOCXInPlaceTranslator(translate TranslateFunc, targetLanguage string) *DOCXInPlaceTranslator {
+	return &DOCXInPlaceTranslator{
+		translate:      translate,
+		targetLanguage: targetLanguage,
+	}
+}
+
+// Translate translates the DOCX at inputPath and writes it to outputPath
+func (t *DOCXInPlaceTranslator) Translate(ctx context.Context, inputPath, outputPath string) (*DOCXInPlaceStats, error) {
+	r, err := zip.OpenReader(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DOCX: %w", err)
+	}
+	defer r.Close()
+
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+	names, err := wordPackageParts(files)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make(map[string]*wordPart)
+	var paragraphs []*wordParagraph
+	for _, f := range r.File {
+		if !names.text[f.Name] {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		part, err := parseWordPart(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		parts[f.Name] = part
+		paragraphs = append(paragraphs, part.paragraphs...)
+	}
+
+	stats := &DOCXInPlaceStats{Parts: len(parts)}
+	cache := make(map[string]string)
+	for i, para := range paragraphs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		kept, err := para.translate(ctx, t.translate, cache)
+		if err != nil {
+			return nil, err
+		}
+		if kept {
+			stats.Paragraphs++
+		} else {
+			stats.Merged++
+		}
+		if t.OnProgress != nil {
+			t.OnProgress(i+1, len(paragraphs))
+		}
+	}
+
+	err = writeInPlaceArchive(r.File, outputPath, func(name string) ([]byte, bool) {
+		if part, ok := parts[name]; ok {
+			return setWordLanguage(part.render(), t.targetLanguage), true
+		}
+		if t.targetLanguage == "" {
+			return nil, false
+		}
+
+		var set func([]byte, string) []byte
+		switch name {
+		case names.styles:
+			set = setWordLanguage
+		case names.core:
+			set = setCoreLanguage
+		default:
+			return nil, false
+		}
+		data, err := readZipFile(files[name])
+		if err != nil {
+			return nil, false
+		}
+		return set(data, t.targetLanguage), true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// wordParts names the parts of a package a translation changes
+type wordParts struct {
+	text   map[string]bool // main document, headers, footers, notes and comments
+	styles string
+	core   string
+}
+
+// Relationship types of the parts holding translatable text
+var wordTextRelationships = map[string]bool{
+	"header": true, "footer": true, "footnotes": true, "endnotes": true, "comments": true,
+}
+
+// wordPackageParts finds the parts of a package through its relationships
+func wordPackageParts(files map[string]*zip.File) (*wordParts, error) {
+	rels, err := readRelationships(files, "_rels/.rels", "")
+	if err != nil {
+		return nil, err
+	}
+
+	parts := &wordParts{text: make(map[string]bool)}
+	main := ""
+	for _, rel := range rels {
+		switch path.Base(rel.kind) {
+		case "officeDocument":
+			main = rel.target
+		case "core-properties":
+			parts.core = rel.target
+		}
+	}
+	if main == "" || files[main] == nil {
+		return nil, fmt.Errorf("not a DOCX document: main document part not found")
+	}
+	parts.text[main] = true
+
+	dir := path.Dir(main)
+	rels, err = readRelationships(files, path.Join(dir, "_rels", path.Base(main)+".rels"), dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range rels {
+		kind := path.Base(rel.kind)
+		switch {
+		case wordTextRelationships[kind] && files[rel.target] != nil:
+			parts.text[rel.target] = true
+		case kind == "styles":
+			parts.styles = rel.target
+		}
+	}
+	return parts, nil
+}
+
+// relationship is an entry of a relationships part
+type relationship struct {
+	kind   string
+	target string // part name in the archive
+}
+
+// readRelationships reads a relationships part, resolving targets against
+// dir; a missing part has no relationships
+func readRelationships(files map[string]*zip.File, name, dir string) ([]relationship, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, nil
+	}
+	data, err := readZipFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels struct {
+		Relationship []struct {
+			Type       string `xml:"Type,attr"`
+			Target     string `xml:"Target,attr"`
+			TargetMode string `xml:"TargetMode,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var result []relationship
+	for _, rel := range rels.Relationship {
+		if rel.TargetMode == "External" {
+			continue
+		}
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(dir, target)
+		}
+		result = append(result, relationship{kind: rel.Type, target: target})
+	}
+	return result, nil
+}
+
+// wordPart is a WordprocessingML part with the text of its paragraphs
+type wordPart struct {
+	data       []byte
+	texts      []*wordText
+	paragraphs []*wordParagraph
+}
+
+// wordText is a w:t element
+type wordText struct {
+	tagStart, tagEnd int // the start tag
+	end              int // the end of the character data
+	text             string
+	replacement      *string
+}
+
+// wordGroup is a stretch of text of a paragraph in one formatting, which is
+// translated between a pair of placeholders
+type wordGroup struct {
+	key   string // run properties without the language
+	texts []*wordText
+}
+
+func (g *wordGroup) text() string {
+	var sb strings.Builder
+	for _, t := range g.texts {
+		sb.WriteString(t.text)
+	}
+	return sb.String()
+}
+
+// set puts text into the first w:t of the group and empties the others
+func (g *wordGroup) set(text string) {
+	for i, t := range g.texts {
+		value := ""
+		if i == 0 {
+			value = text
+		}
+		t.replacement = &value
+	}
+}
+
+// wordParagraph is a w:p element
+type wordParagraph struct {
+	groups []*wordGroup
+}
+
+// Elements of a paragraph which do not interrupt its text; any other element
+// ends a formatting group, so that text never moves across tabs, breaks,
+// fields, hyperlinks, tracked changes or drawings
+var neutralWordElements = map[string]bool{
+	"r": true, "t": true, "rPr": true, "lastRenderedPageBreak": true, "softHyphen": true,
+	"proofErr": true, "bookmarkStart": true, "bookmarkEnd": true,
+	"commentRangeStart": true, "commentRangeEnd": true, "permStart": true, "permEnd": true,
+}
+
+// wordLang matches the language element of run properties
+var wordLang = regexp.MustCompile(`<w:lang\b[^>]*>`)
+
+// paragraphBuilder collects the groups of a paragraph being parsed
+type paragraphBuilder struct {
+	para    *wordParagraph
+	current *wordGroup
+}
+
+// wordRun is a w:r element being parsed
+type wordRun struct {
+	key        string
+	propsStart int
+}
+
+// parseWordPart finds the paragraphs of a part and the text of their runs.
+// Paragraphs nest inside text boxes, so both are kept on stacks.
+func parseWordPart(data []byte) (*wordPart, error) {
+	part := &wordPart{data: data}
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var paras []*paragraphBuilder
+	var runs []*wordRun
+	var names []string
+	var text *wordText
+	start := 0
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := int(d.InputOffset())
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			name, parent := tok.Name.Local, ""
+			if len(names) > 0 {
+				parent = names[len(names)-1]
+			}
+			names = append(names, name)
+			if tok.Name.Space != "w" || parent == "rPr" {
+				break
+			}
+			switch name {
+			case "p":
+				paras = append(paras, &paragraphBuilder{para: &wordParagraph{}})
+			case "r":
+				runs = append(runs, &wordRun{})
+			case "rPr":
+				if parent == "r" && len(runs) > 0 {
+					runs[len(runs)-1].propsStart = start
+				}
+			case "t":
+				text = &wordText{tagStart: start, tagEnd: end, end: end}
+			}
+			if len(paras) > 0 && !neutralWordElements[name] {
+				paras[len(paras)-1].current = nil
+			}
+		case xml.EndElement:
+			name, parent := tok.Name.Local, ""
+			if len(names) > 0 {
+				names = names[:len(names)-1]
+			}
+			if len(names) > 0 {
+				parent = names[len(names)-1]
+			}
+			if tok.Name.Space != "w" || parent == "rPr" {
+				break
+			}
+			switch name {
+			case "p":
+				if len(paras) > 0 {
+					if b := paras[len(paras)-1]; len(b.para.groups) > 0 {
+						part.paragraphs = append(part.paragraphs, b.para)
+					}
+					paras = paras[:len(paras)-1]
+				}
+			case "r":
+				if len(runs) > 0 {
+					runs = runs[:len(runs)-1]
+				}
+			case "rPr":
+				if parent == "r" && len(runs) > 0 {
+					run := runs[len(runs)-1]
+					run.key = wordLang.ReplaceAllString(string(data[run.propsStart:end]), "")
+				}
+			case "t":
+				if text != nil && text.text != "" && len(paras) > 0 {
+					key := ""
+					if len(runs) > 0 {
+						key = runs[len(runs)-1].key
+					}
+					b := paras[len(paras)-1]
+					if b.current == nil || b.current.key != key {
+						b.current = &wordGroup{key: key}
+						b.para.groups = append(b.para.groups, b.current)
+					}
+					b.current.texts = append(b.current.texts, text)
+					part.texts = append(part.texts, text)
+				}
+				text = nil
+			}
+			if len(paras) > 0 && !neutralWordElements[name] {
+				paras[len(paras)-1].current = nil
+			}
+		case xml.CharData:
+			if text != nil {
+				text.text += string(tok)
+				text.end = end
+			}
+		}
+		start = end
+	}
+	return part, nil
+}
+
+// wordPlaceholder matches the placeholders of a translated paragraph
+var wordPlaceholder = regexp.MustCompile(`<(/?)g(\d+)>`)
+
+// source returns the text of the paragraph, with every group between
+// placeholders when there is more than one
+func (p *wordParagraph) source() string {
+	if len(p.groups) == 1 {
+		return p.groups[0].text()
+	}
+	var sb strings.Builder
+	for i, g := range p.groups {
+		fmt.Fprintf(&sb, "<g%d>%s</g%d>", i+1, g.text(), i+1)
+	}
+	return sb.String()
+}
+
+// translate translates the paragraph and writes the translation into its
+// runs. When the placeholders do not come back in order, the whole text
+// goes to the group holding most of the source text; it reports whether the
+// formatting was kept.
+func (p *wordParagraph) translate(ctx context.Context, translate TranslateFunc, cache map[string]string) (bool, error) {
+	source := p.source()
+	lead, text, trail := splitSpace(source)
+	if text == "" {
+		return true, nil
+	}
+
+	context := TextContext
+	if len(p.groups) > 1 {
+		context = MarkupContext
+	}
+	translated, err := cachedTranslate(ctx, translate, cache, text, context)
+	if err != nil {
+		return false, err
+	}
+	translated = lead + translated + trail
+
+	if len(p.groups) == 1 {
+		p.groups[0].set(translated)
+		return true, nil
+	}
+	if texts, ok := p.distribute(translated); ok {
+		for i, g := range p.groups {
+			g.set(texts[i])
+		}
+		return true, nil
+	}
+
+	main := 0
+	for i, g := range p.groups {
+		if utf8.RuneCountInString(g.text()) > utf8.RuneCountInString(p.groups[main].text()) {
+			main = i
+		}
+	}
+	plain := wordPlaceholder.ReplaceAllString(translated, "")
+	for i, g := range p.groups {
+		if i == main {
+			g.set(plain)
+		} else {
+			g.set("")
+		}
+	}
+	return false, nil
+}
+
+// distribute splits a translated paragraph into the text of each group. The
+// groups must come back in their order without nesting; a missing group
+// ends up empty, and text outside the placeholders joins the group before it.
+func (p *wordParagraph) distribute(translated string) ([]string, bool) {
+	texts := make([]string, len(p.groups))
+	current := -1 // group whose placeholder is open
+	last := -1    // group text outside placeholders joins
+	pos := 0
+	for _, m := range wordPlaceholder.FindAllStringSubmatchIndex(translated, -1) {
+		between := translated[pos:m[0]]
+		pos = m[1]
+
+		closing := m[3] > m[2]
+		id, _ := strconv.Atoi(translated[m[4]:m[5]])
+		id--
+		if id < 0 || id >= len(p.groups) {
+			return nil, false
+		}
+
+		switch {
+		case current >= 0:
+			texts[current] += between
+			if !closing || id != current {
+				return nil, false
+			}
+			last, current = current, -1
+		case closing || id <= last:
+			return nil, false
+		default:
+			if last >= 0 {
+				texts[last] += between
+			} else {
+				texts[id] = between
+			}
+			current = id
+		}
+	}
+	if current >= 0 {
+		return nil, false
+	}
+
+	rest := translated[pos:]
+	if last < 0 {
+		return nil, false
+	}
+	texts[last] += rest
+	return texts, true
+}
+
+// xmlSpace matches an xml:space attribute
+var xmlSpace = regexp.MustCompile(`\sxml:space\s*=`)
+
+// render writes the part with the translated text of its w:t elements,
+// preserving space in those whose text now starts or ends with it
+func (part *wordPart) render() []byte {
+	var out bytes.Buffer
+	out.Grow(len(part.data))
+	pos := 0
+	for _, t := range part.texts {
+		if t.replacement == nil {
+			continue
+		}
+		value := *t.replacement
+		tag := part.data[t.tagStart:t.tagEnd]
+		if lead, _, trail := splitSpace(value); (lead != "" || trail != "") && !xmlSpace.Match(tag) {
+			out.Write(part.data[pos:t.tagStart])
+			out.Write(tag[:len(tag)-1])
+			out.WriteString(` xml:space="preserve">`)
+		} else {
+			out.Write(part.data[pos:t.tagEnd])
+		}
+		out.WriteString(escapeText(value))
+		pos = t.end
+	}
+	out.Write(part.data[pos:])
+	return out.Bytes()
+}
+
+// wordLangAttribute matches one attribute of a w:lang element
+var wordLangAttribute = regexp.MustCompile(`\s(w:(?:val|eastAsia|bidi))\s*=\s*("[^"]*"|'[^']*')`)
+
+// setWordLanguage sets the language of the w:lang elements of a part: the
+// w:eastAsia attribute for Chinese, Japanese and Korean, w:bidi for right to
+// left languages and w:val for all others
+func setWordLanguage(data []byte, lang string) []byte {
+	if lang == "" {
+		return data
+	}
+	attr := "w:val"
+	switch {
+	case isRightToLeft(lang):
+		attr = "w:bidi"
+	case map[string]bool{"zh": true, "ja": true, "ko": true}[primarySubtag(lang)]:
+		attr = "w:eastAsia"
+	}
+
+	value := attr + `="` + escapeXML(lang) + `"`
+	return wordLang.ReplaceAllFunc(data, func(tag []byte) []byte {
+		found := false
+		tag = wordLangAttribute.ReplaceAllFunc(tag, func(match []byte) []byte {
+			if string(wordLangAttribute.FindSubmatch(match)[1]) != attr {
+				return match
+			}
+			found = true
+			return []byte(" " + value)
+		})
+		if found {
+			return tag
+		}
+		end := len(tag) - 1
+		if bytes.HasSuffix(tag, []byte("/>")) {
+			end--
+		}
+		return []byte(string(tag[:end]) + " " + value + string(tag[end:]))
+	})
+}
+
+// setCoreLanguage sets dc:language in the core properties, adding it when
+// the document has none
+func setCoreLanguage(data []byte, lang string) []byte {
+	if dcLanguage.Match(data) {
+		return replaceDCLanguage(data, lang)
+	}
+	i := bytes.LastIndex(data, []byte("</cp:coreProperties>"))
+	if i < 0 || !bytes.Contains(data, []byte("xmlns:dc=")) {
+		return data
+	}
+	return []byte(string(data[:i]) + "<dc:language>" + escapeXML(lang) + "</dc:language>" + string(data[i:]))
+}
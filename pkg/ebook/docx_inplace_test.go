@@ -0,0 +1,228 @@
+package ebook
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/word/header1.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.header+xml"/>
+<Override PartName="/word/footnotes.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.footnotes+xml"/>
+<Override PartName="/word/comments.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+const docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/header" Target="header1.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes" Target="footnotes.xml"/>
+<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="comments.xml"/>
+</Relationships>`
+
+const docxNamespace = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+const docxDocument = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + docxNamespace + `><w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Chapter</w:t></w:r><w:r><w:t xml:space="preserve"> one</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Plain </w:t></w:r><w:r><w:rPr><w:b/><w:lang w:val="en-US"/></w:rPr><w:t>bold</w:t></w:r><w:r><w:t xml:space="preserve"> and </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t>italic</w:t></w:r><w:r><w:t>.</w:t></w:r></w:p>
+<w:p><w:r><w:t>Name</w:t></w:r><w:r><w:tab/><w:t>Value</w:t></w:r><w:r><w:rPr><w:vertAlign w:val="superscript"/></w:rPr><w:footnoteReference w:id="1"/></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>cell &amp; text</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+<w:p><w:commentRangeStart w:id="0"/><w:r><w:t>Commented</w:t></w:r><w:commentRangeEnd w:id="0"/><w:r><w:commentReference w:id="0"/></w:r></w:p>
+<w:p><w:r><w:fldChar w:fldCharType="begin"/></w:r><w:r><w:instrText xml:space="preserve"> PAGE </w:instrText></w:r><w:r><w:fldChar w:fldCharType="end"/></w:r></w:p>
+<w:sectPr><w:headerReference w:type="default" r:id="rId2"/></w:sectPr>
+</w:body></w:document>`
+
+const docxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:hdr ` + docxNamespace + `><w:p><w:r><w:t>Running head</w:t></w:r></w:p></w:hdr>`
+
+const docxFootnotes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:footnotes ` + docxNamespace + `>
+<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>
+<w:footnote w:id="1"><w:p><w:r><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> A note.</w:t></w:r></w:p></w:footnote>
+</w:footnotes>`
+
+const docxComments = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:comments ` + docxNamespace + `><w:comment w:id="0" w:author="Editor"><w:p><w:r><w:t>Check this</w:t></w:r></w:p></w:comment></w:comments>`
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles ` + docxNamespace + `><w:docDefaults><w:rPrDefault><w:rPr><w:lang w:val="en-US" w:eastAsia="en-US" w:bidi="ar-SA"/></w:rPr></w:rPrDefault></w:docDefaults></w:styles>`
+
+const docxCore = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Manuscript</dc:title></cp:coreProperties>`
+
+func writeTestDOCX(t *testing.T) string {
+	t.Helper()
+	return writeTestZip(t, []testZipEntry{
+		{"[Content_Types].xml", docxContentTypes, zip.Deflate},
+		{"_rels/.rels", docxRels, zip.Deflate},
+		{"word/_rels/document.xml.rels", docxDocumentRels, zip.Deflate},
+		{"word/document.xml", docxDocument, zip.Deflate},
+		{"word/header1.xml", docxHeader, zip.Deflate},
+		{"word/footnotes.xml", docxFootnotes, zip.Deflate},
+		{"word/comments.xml", docxComments, zip.Deflate},
+		{"word/styles.xml", docxStyles, zip.Deflate},
+		{"docProps/core.xml", docxCore, zip.Deflate},
+	})
+}
+
+func TestDOCXInPlaceTranslator_Translate(t *testing.T) {
+	input := writeTestDOCX(t)
+	output := filepath.Join(t.TempDir(), "out.docx")
+
+	var sources []string
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		sources = append(sources, text)
+		return upperTranslate(ctx, text, context)
+	}
+
+	var progress []int
+	translator := NewDOCXInPlaceTranslator(translate, "de")
+	translator.OnProgress = func(done, total int) {
+		progress = append(progress, done)
+	}
+
+	stats, err := translator.Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Parts != 4 || stats.Paragraphs != 8 || stats.Merged != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if len(progress) != 8 {
+		t.Errorf("expected 8 progress calls, got %v", progress)
+	}
+
+	wantSources := []string{
+		"Chapter one",
+		"<g1>Plain </g1><g2>bold</g2><g3> and </g3><g4>italic</g4><g5>.</g5>",
+		"<g1>Name</g1><g2>Value</g2>",
+		"cell & text",
+	}
+	for i, want := range wantSources {
+		if i >= len(sources) || sources[i] != want {
+			t.Errorf("source %d of %q, want %q", i, sources, want)
+		}
+	}
+
+	contents, _ := readEntries(t, output)
+	document := string(contents["word/document.xml"])
+	for _, want := range []string{
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>CHAPTER ONE</w:t></w:r><w:r><w:t xml:space="preserve"></w:t></w:r>`,
+		`<w:t xml:space="preserve">PLAIN </w:t></w:r><w:r><w:rPr><w:b/><w:lang w:val="de"/></w:rPr><w:t>BOLD</w:t>`,
+		`<w:t xml:space="preserve"> AND </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t>ITALIC</w:t></w:r><w:r><w:t>.</w:t>`,
+		`<w:t>NAME</w:t></w:r><w:r><w:tab/><w:t>VALUE</w:t>`,
+		`<w:footnoteReference w:id="1"/>`,
+		`<w:t>CELL &amp; TEXT</w:t>`,
+		`<w:commentRangeStart w:id="0"/><w:r><w:t>COMMENTED</w:t>`,
+		`<w:instrText xml:space="preserve"> PAGE </w:instrText>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("document.xml lacks %s:\n%s", want, document)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"word/header1.xml":   `<w:t>RUNNING HEAD</w:t>`,
+		"word/footnotes.xml": `<w:t xml:space="preserve"> A NOTE.</w:t>`,
+		"word/comments.xml":  `<w:t>CHECK THIS</w:t>`,
+		"word/styles.xml":    `<w:lang w:val="de" w:eastAsia="en-US" w:bidi="ar-SA"/>`,
+		"docProps/core.xml":  `<dc:title>Manuscript</dc:title><dc:language>de</dc:language>`,
+	} {
+		if !strings.Contains(string(contents[name]), want) {
+			t.Errorf("%s lacks %s:\n%s", name, want, contents[name])
+		}
+	}
+
+	for name, data := range contents {
+		if err := xml.Unmarshal(data, new(interface{})); strings.HasSuffix(name, ".xml") && err != nil {
+			t.Errorf("%s is not well-formed: %v", name, err)
+		}
+	}
+}
+
+func TestDOCXInPlaceTranslator_LostPlaceholders(t *testing.T) {
+	input := writeTestDOCX(t)
+	output := filepath.Join(t.TempDir(), "out.docx")
+
+	// A translator that drops the markup and reorders the text
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		if strings.Contains(text, "italic") {
+			return "Kursiv und fett.", nil
+		}
+		return text, nil
+	}
+
+	stats, err := NewDOCXInPlaceTranslator(translate, "de").Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Merged != 1 {
+		t.Errorf("expected one merged paragraph, got %+v", stats)
+	}
+
+	contents, _ := readEntries(t, output)
+	document := string(contents["word/document.xml"])
+	// The text goes to the run holding most of the source text
+	want := `<w:t xml:space="preserve">Kursiv und fett.</w:t></w:r><w:r><w:rPr><w:b/><w:lang w:val="de"/></w:rPr><w:t></w:t>`
+	if !strings.Contains(document, want) {
+		t.Errorf("document.xml lacks %s:\n%s", want, document)
+	}
+}
+
+func TestDOCXInPlaceTranslator_Errors(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.docx")
+	if _, err := NewDOCXInPlaceTranslator(upperTranslate, "de").Translate(context.Background(), filepath.Join(t.TempDir(), "missing.docx"), output); err == nil {
+		t.Error("expected error for missing file")
+	}
+	notDOCX := writeTestZip(t, []testZipEntry{{"mimetype", "application/epub+zip", zip.Store}})
+	if _, err := NewDOCXInPlaceTranslator(upperTranslate, "de").Translate(context.Background(), notDOCX, output); err == nil {
+		t.Error("expected error for a package without a document")
+	}
+
+	failure := errors.New("service unavailable")
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return "", failure
+	}
+	if _, err := NewDOCXInPlaceTranslator(translate, "de").Translate(context.Background(), writeTestDOCX(t), output); !errors.Is(err, failure) {
+		t.Errorf("expected translation error, got %v", err)
+	}
+}
+
+func TestWordParagraph_Distribute(t *testing.T) {
+	para := &wordParagraph{groups: make([]*wordGroup, 3)}
+	tests := []struct {
+		translated string
+		want       []string
+	}{
+		{"<g1>a</g1><g2>b</g2><g3>c</g3>", []string{"a", "b", "c"}},
+		{"x <g1>a</g1> y <g3>c</g3> z", []string{"x a y ", "", "c z"}},
+		{"<g2>b</g2><g1>a</g1>", nil},
+		{"<g1>a<g2>b</g2></g1>", nil},
+		{"<g1>a</g1><g4>d</g4>", nil},
+		{"no markup", nil},
+	}
+
+	for _, tt := range tests {
+		got, ok := para.distribute(tt.translated)
+		if ok != (tt.want != nil) || strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("distribute(%q) = %q, %v; want %q", tt.translated, got, ok, tt.want)
+		}
+	}
+}
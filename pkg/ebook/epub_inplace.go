@@ -0,0 +1,817 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TranslateFunc translates one block of text; context describes the text to
+// the translator
+type TranslateFunc func(ctx context.Context, text, context string) (string, error)
+
+// Translation contexts passed to TranslateFunc
+const (
+	// MarkupContext is passed for blocks holding inline markup placeholders
+	MarkupContext = "Book text with inline markup: keep every <gN>, </gN> and <xN/> tag, translating the text between them"
+
+	// TextContext is passed for plain blocks and text fragments
+	TextContext = "Book text"
+)
+
+// EPUBInPlaceStats reports the work of an in-place translation
+type EPUBInPlaceStats struct {
+	Documents int // XHTML and NCX documents rewritten
+	Blocks    int // blocks translated as a whole
+	Fragments int // blocks translated text node by text node because their markup was lost
+
+	Attributes   int // alt and title attributes of images translated
+	ImageTexts   int // images whose recognized text was added as a caption or note
+	Unrecognized int // images the recognizer failed on
+
+	// Validation of the written EPUB. Issues are reported rather than
+	// returned, as the source book may have had them already.
+	Validation *ValidationReport
+}
+
+// EPUBInPlaceTranslator translates an EPUB without rebuilding it: every spine
+// document, the navigation document and the NCX keep their markup, and only
+// their text and the language attributes change. All other entries of the
+// archive are copied byte for byte.
+type EPUBInPlaceTranslator struct {
+	translate      TranslateFunc
+	targetLanguage string
+
+	// OnProgress, when set, is called after each translated block
+	OnProgress func(done, total int)
+
+	// Recognizer, when set, extracts the text of every image; the text is
+	// translated and added as the caption of a figure without one, or as a
+	// note after the image
+	Recognizer ImageTextRecognizer
+}
+
+// NewEPUBInPlaceTranslator creates an in-place translator writing
+// targetLanguage to dc:language and the lang attributes
+func NewEPUBInPlaceTranslator(translate TranslateFunc, targetLanguage string) *EPUBInPlaceTranslator {
+	return &EPUBInPlaceTranslator{
+		translate:      translate,
+		targetLanguage: targetLanguage,
+	}
+}
+
+// inPlaceDocument is an archive entry whose text is translated
+type inPlaceDocument struct {
+	doc    *markupDocument
+	output []byte
+}
+
+// Translate translates the EPUB at inputPath and writes it to outputPath
+func (t *EPUBInPlaceTranslator) Translate(ctx context.Context, inputPath, outputPath string) (*EPUBInPlaceStats, error) {
+	r, err := zip.OpenReader(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open EPUB: %w", err)
+	}
+	defer r.Close()
+
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+
+	container, ok := files["META-INF/container.xml"]
+	if !ok {
+		return nil, fmt.Errorf("container.xml not found")
+	}
+	opfPath, err := NewEPUBParser().parseContainer(container)
+	if err != nil {
+		return nil, err
+	}
+	opfFile, ok := files[opfPath]
+	if !ok {
+		return nil, fmt.Errorf("package document %s not found", opfPath)
+	}
+	opfData, err := readZipFile(opfFile)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, sourceLanguage, err := inPlaceTargets(opfData, path.Dir(opfPath))
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse every document first so that progress can report a total
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	documents := make(map[string]*inPlaceDocument)
+	var blocks []*markupBlock
+	var images []archiveImage
+	for _, name := range names {
+		ncx := targets[name]
+		f, ok := files[name]
+		if !ok {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := parseMarkupDocument(data, ncx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		documents[name] = &inPlaceDocument{doc: doc}
+		blocks = append(blocks, doc.blocks...)
+		for _, image := range doc.images {
+			images = append(images, archiveImage{image: image, name: name, doc: doc})
+		}
+	}
+
+	// Image texts come first, as translated blocks copy the image tags
+	total := len(blocks)
+	for _, image := range images {
+		total += len(image.image.attributes)
+		if t.Recognizer != nil {
+			total++
+		}
+	}
+	done := 0
+	progress := func() {
+		done++
+		if t.OnProgress != nil {
+			t.OnProgress(done, total)
+		}
+	}
+
+	stats := &EPUBInPlaceStats{Documents: len(documents)}
+	cache := make(map[string]string)
+	recognized := make(map[string]string)
+	for _, image := range images {
+		if err := t.translateImage(ctx, image, files, cache, recognized, stats, progress); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, block := range blocks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		whole, err := block.translate(ctx, t.translate, cache)
+		if err != nil {
+			return nil, err
+		}
+		if whole {
+			stats.Blocks++
+		} else {
+			stats.Fragments++
+		}
+		progress()
+	}
+
+	for _, d := range documents {
+		d.output = d.doc.render(sourceLanguage, t.targetLanguage)
+	}
+	opfOutput := replaceDCLanguage(opfData, t.targetLanguage)
+
+	err = writeInPlaceArchive(r.File, outputPath, func(name string) ([]byte, bool) {
+		if name == opfPath {
+			return opfOutput, true
+		}
+		if d, ok := documents[name]; ok {
+			return d.output, true
+		}
+		return nil, false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Validation, err = NewEPUBValidator().Validate(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate EPUB: %w", err)
+	}
+	return stats, nil
+}
+
+// inPlaceTargets returns the archive paths of the documents to translate,
+// mapped to whether they are NCX files, and the language of the book
+func inPlaceTargets(opfData []byte, opfDir string) (map[string]bool, string, error) {
+	var pkg struct {
+		Metadata struct {
+			Language []string `xml:"language"`
+		} `xml:"metadata"`
+		Manifest struct {
+			Item []struct {
+				ID         string `xml:"id,attr"`
+				Href       string `xml:"href,attr"`
+				MediaType  string `xml:"media-type,attr"`
+				Properties string `xml:"properties,attr"`
+			} `xml:"item"`
+		} `xml:"manifest"`
+		Spine struct {
+			Toc     string `xml:"toc,attr"`
+			Itemref []struct {
+				Idref string `xml:"idref,attr"`
+			} `xml:"itemref"`
+		} `xml:"spine"`
+	}
+	if err := xml.Unmarshal(opfData, &pkg); err != nil {
+		return nil, "", fmt.Errorf("failed to parse package document: %w", err)
+	}
+
+	inSpine := make(map[string]bool)
+	for _, ref := range pkg.Spine.Itemref {
+		inSpine[ref.Idref] = true
+	}
+
+	targets := make(map[string]bool)
+	for _, item := range pkg.Manifest.Item {
+		href, err := url.PathUnescape(item.Href)
+		if err != nil {
+			href = item.Href
+		}
+		name := path.Join(opfDir, href)
+
+		switch {
+		case item.MediaType == "application/x-dtbncx+xml" || item.ID == pkg.Spine.Toc && pkg.Spine.Toc != "":
+			targets[name] = true
+		case item.MediaType == "application/xhtml+xml" || item.MediaType == "text/html":
+			if inSpine[item.ID] || strings.Contains(item.Properties, "nav") {
+				targets[name] = false
+			}
+		}
+	}
+
+	sourceLanguage := ""
+	if len(pkg.Metadata.Language) > 0 {
+		sourceLanguage = strings.TrimSpace(pkg.Metadata.Language[0])
+	}
+	return targets, sourceLanguage, nil
+}
+
+// dcLanguage matches the first dc:language element of a package document
+var dcLanguage = regexp.MustCompile(`(<dc:language(?:\s[^>]*)?>)[^<]*(</dc:language>)`)
+
+// replaceDCLanguage sets the first dc:language of a package document,
+// leaving every other byte as it was
+func replaceDCLanguage(opf []byte, lang string) []byte {
+	done := false
+	return dcLanguage.ReplaceAllFunc(opf, func(match []byte) []byte {
+		if done {
+			return match
+		}
+		done = true
+		sub := dcLanguage.FindSubmatch(match)
+		return append(append(append([]byte{}, sub[1]...), escapeXML(lang)...), sub[2]...)
+	})
+}
+
+// writeInPlaceArchive copies the entries of a ZIP package to filename in their
+// original order, taking the content of replaced entries from replace. All
+// other entries are copied without recompressing them.
+func writeInPlaceArchive(entries []*zip.File, filename string, replace func(name string) ([]byte, bool)) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, f := range entries {
+		if data, ok := replace(f.Name); ok {
+			w, err := zw.CreateHeader(&zip.FileHeader{
+				Name:     f.Name,
+				Method:   f.Method,
+				Modified: f.Modified,
+				Comment:  f.Comment,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, err := f.OpenRaw()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		header := f.FileHeader
+		w, err := zw.CreateRaw(&header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, raw); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", f.Name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// Markup documents
+
+// blockElements end a run of inline content
+var blockElements = map[string]bool{
+	"html": true, "head": true, "title": true, "body": true, "p": true, "div": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"blockquote": true, "section": true, "article": true, "aside": true, "header": true,
+	"footer": true, "nav": true, "main": true, "figure": true, "figcaption": true,
+	"table": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "td": true,
+	"th": true, "caption": true, "hr": true, "address": true, "details": true,
+	"summary": true, "center": true, "hgroup": true,
+}
+
+// ncxBlockElements end a run in an NCX file
+var ncxBlockElements = map[string]bool{"text": true}
+
+// opaqueElements are never translated; inside a block they are kept as a unit
+var opaqueElements = map[string]bool{
+	"script": true, "style": true, "code": true, "pre": true, "kbd": true,
+	"samp": true, "var": true, "svg": true, "math": true,
+}
+
+// markupToken is a token of a document with its byte range
+type markupToken struct {
+	kind       int // one of the token kinds below
+	name       string
+	start, end int
+	text       string // decoded character data
+	mate       int    // index of the matching start or end tag, -1 if none
+}
+
+const (
+	tokenOther = iota
+	tokenStart
+	tokenEnd
+	tokenText
+)
+
+// markupDocument is an XHTML or NCX document split into tokens and blocks
+type markupDocument struct {
+	data   []byte
+	tokens []markupToken
+	blocks []*markupBlock
+	images []*markupImage
+
+	// Start tags rewritten with translated attributes, and markup inserted
+	// before or after tokens
+	tags          map[int][]byte
+	before, after map[int][]byte
+}
+
+// markupBlock is a run of inline content translated as one unit
+type markupBlock struct {
+	doc         *markupDocument
+	first, last int // token range, inclusive
+
+	items []blockItem // inline content in order
+
+	replacement []byte // set when the whole block was translated
+	fragments   map[int]string
+}
+
+// blockItem is a text node, an inline tag or an opaque element of a block
+type blockItem struct {
+	kind  int // tokenText, tokenStart, tokenEnd or tokenOther for void and opaque items
+	first int // first token
+	last  int // last token
+	id    int // placeholder number of tags and opaque items
+}
+
+// parseMarkupDocument tokenizes data and groups its inline content into blocks
+func parseMarkupDocument(data []byte, ncx bool) (*markupDocument, error) {
+	doc := &markupDocument{data: data}
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var stack []int
+	start := 0
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := int(d.InputOffset())
+
+		mt := markupToken{kind: tokenOther, start: start, end: end, mate: -1}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			mt.kind = tokenStart
+			mt.name = strings.ToLower(tok.Name.Local)
+			stack = append(stack, len(doc.tokens))
+		case xml.EndElement:
+			mt.kind = tokenEnd
+			mt.name = strings.ToLower(tok.Name.Local)
+			// Pair with the innermost open element of the same name
+			for i := len(stack) - 1; i >= 0; i-- {
+				if doc.tokens[stack[i]].name == mt.name {
+					mt.mate = stack[i]
+					doc.tokens[stack[i]].mate = len(doc.tokens)
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			mt.kind = tokenText
+			mt.text = string(tok)
+		}
+		doc.tokens = append(doc.tokens, mt)
+		start = end
+	}
+	if start != len(data) {
+		return nil, fmt.Errorf("tokens end at %d of %d bytes", start, len(data))
+	}
+
+	blocks := blockElements
+	if ncx {
+		blocks = ncxBlockElements
+	}
+	doc.collectBlocks(blocks)
+	if !ncx {
+		doc.collectImages()
+	}
+	return doc, nil
+}
+
+// collectBlocks groups inline content between block boundaries
+func (doc *markupDocument) collectBlocks(blocks map[string]bool) {
+	var current *markupBlock
+	flush := func() {
+		if current != nil && current.hasText() {
+			doc.blocks = append(doc.blocks, current)
+		}
+		current = nil
+	}
+	add := func(item blockItem) {
+		if current == nil {
+			current = &markupBlock{doc: doc, first: item.first}
+		}
+		current.items = append(current.items, item)
+		current.last = item.last
+	}
+
+	for i := 0; i < len(doc.tokens); i++ {
+		tok := doc.tokens[i]
+		switch {
+		case tok.kind == tokenText:
+			add(blockItem{kind: tokenText, first: i, last: i})
+		case (tok.kind == tokenStart || tok.kind == tokenEnd) && blocks[tok.name]:
+			flush()
+		case tok.kind == tokenStart && opaqueElements[tok.name]:
+			last := i
+			if tok.mate > i {
+				last = tok.mate
+			}
+			add(blockItem{kind: tokenOther, first: i, last: last})
+			i = last
+		case tok.kind == tokenStart && (tok.mate < 0 || doc.tokens[tok.mate].start == doc.tokens[tok.mate].end):
+			// Void elements such as <br/> and <img/> end right where they start
+			last := i
+			if tok.mate == i+1 {
+				last = i + 1
+			}
+			add(blockItem{kind: tokenOther, first: i, last: last})
+			i = last
+		case tok.kind == tokenStart || tok.kind == tokenEnd:
+			add(blockItem{kind: tok.kind, first: i, last: i})
+		default:
+			// Comments, processing instructions and directives end a block
+			flush()
+		}
+	}
+	flush()
+
+	for _, block := range doc.blocks {
+		block.number()
+	}
+}
+
+// hasText reports whether the block holds any text to translate
+func (b *markupBlock) hasText() bool {
+	for _, item := range b.items {
+		if item.kind == tokenText && strings.TrimSpace(b.doc.tokens[item.first].text) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// number assigns placeholder numbers to tags and opaque items
+func (b *markupBlock) number() {
+	ids := make(map[int]int) // start token → id
+	next := 1
+	for i := range b.items {
+		item := &b.items[i]
+		switch item.kind {
+		case tokenStart, tokenOther:
+			item.id = next
+			ids[item.first] = next
+			next++
+		case tokenEnd:
+			item.id = ids[b.doc.tokens[item.first].mate]
+		}
+	}
+}
+
+// balanced reports whether every inline tag of the block is closed inside it
+func (b *markupBlock) balanced() bool {
+	open := 0
+	for _, item := range b.items {
+		switch item.kind {
+		case tokenStart:
+			open++
+		case tokenEnd:
+			if item.id == 0 {
+				return false
+			}
+			open--
+		}
+	}
+	return open == 0
+}
+
+// source returns the text of the block with inline markup as placeholders
+func (b *markupBlock) source() string {
+	var sb strings.Builder
+	for _, item := range b.items {
+		switch item.kind {
+		case tokenText:
+			sb.WriteString(b.doc.tokens[item.first].text)
+		case tokenStart:
+			fmt.Fprintf(&sb, "<g%d>", item.id)
+		case tokenEnd:
+			fmt.Fprintf(&sb, "</g%d>", item.id)
+		default:
+			fmt.Fprintf(&sb, "<x%d/>", item.id)
+		}
+	}
+	return sb.String()
+}
+
+// whitespace matches runs of whitespace, collapsed before translation
+var whitespace = regexp.MustCompile(`\s+`)
+
+// translate translates the block as a whole, or text node by text node when
+// the block is not balanced or the translation lost its markup. It reports
+// whether the block was translated as a whole.
+func (b *markupBlock) translate(ctx context.Context, translate TranslateFunc, cache map[string]string) (bool, error) {
+	if b.balanced() {
+		source := b.source()
+		lead, text, trail := splitSpace(source)
+		text = whitespace.ReplaceAllString(text, " ")
+
+		context := TextContext
+		if len(b.items) > 1 || b.items[0].kind != tokenText {
+			context = MarkupContext
+		}
+		translated, err := cachedTranslate(ctx, translate, cache, text, context)
+		if err != nil {
+			return false, err
+		}
+		if body, ok := b.rebuild(translated); ok {
+			b.replacement = append(append([]byte(escapeText(lead)), body...), escapeText(trail)...)
+			return true, nil
+		}
+	}
+
+	b.fragments = make(map[int]string)
+	for _, item := range b.items {
+		if item.kind != tokenText {
+			continue
+		}
+		lead, text, trail := splitSpace(b.doc.tokens[item.first].text)
+		if text == "" {
+			continue
+		}
+		translated, err := cachedTranslate(ctx, translate, cache, whitespace.ReplaceAllString(text, " "), TextContext)
+		if err != nil {
+			return false, err
+		}
+		b.fragments[item.first] = lead + translated + trail
+	}
+	return false, nil
+}
+
+func cachedTranslate(ctx context.Context, translate TranslateFunc, cache map[string]string, text, context string) (string, error) {
+	if translated, ok := cache[text]; ok {
+		return translated, nil
+	}
+	translated, err := translate(ctx, text, context)
+	if err != nil {
+		return "", err
+	}
+	cache[text] = translated
+	return translated, nil
+}
+
+// placeholder matches the markup placeholders of a translated block
+var placeholder = regexp.MustCompile(`<(/?)([gx])(\d+)(/?)>`)
+
+// rebuild turns a translated block back into markup, using the original
+// bytes of every tag. It fails unless every placeholder comes back exactly
+// once and the tags still nest.
+func (b *markupBlock) rebuild(translated string) ([]byte, bool) {
+	starts := make(map[int]blockItem)
+	ends := make(map[int]blockItem)
+	others := make(map[int]blockItem)
+	for _, item := range b.items {
+		switch item.kind {
+		case tokenStart:
+			starts[item.id] = item
+		case tokenEnd:
+			ends[item.id] = item
+		case tokenOther:
+			others[item.id] = item
+		}
+	}
+
+	var out bytes.Buffer
+	var stack []int
+	used := make(map[string]bool)
+	pos := 0
+	for _, m := range placeholder.FindAllStringSubmatchIndex(translated, -1) {
+		out.WriteString(escapeText(translated[pos:m[0]]))
+		pos = m[1]
+
+		closing := m[3] > m[2]
+		kind := translated[m[4]:m[5]]
+		id, _ := strconv.Atoi(translated[m[6]:m[7]])
+		selfClosing := m[9] > m[8]
+
+		key := translated[m[0]:m[1]]
+		if used[key] {
+			return nil, false
+		}
+		used[key] = true
+
+		var item blockItem
+		var ok bool
+		switch {
+		case kind == "x" && !closing && selfClosing:
+			item, ok = others[id]
+		case kind == "g" && !closing && !selfClosing:
+			item, ok = starts[id]
+			stack = append(stack, id)
+		case kind == "g" && closing && !selfClosing:
+			item, ok = ends[id]
+			if len(stack) == 0 || stack[len(stack)-1] != id {
+				return nil, false
+			}
+			stack = stack[:len(stack)-1]
+		}
+		if !ok {
+			return nil, false
+		}
+		out.Write(b.doc.span(item.first, item.last))
+	}
+	out.WriteString(escapeText(translated[pos:]))
+
+	if len(stack) != 0 || len(used) != len(starts)+len(ends)+len(others) {
+		return nil, false
+	}
+	return out.Bytes(), true
+}
+
+// span returns the bytes of tokens first to last, with rewritten tags and
+// inserted markup
+func (doc *markupDocument) span(first, last int) []byte {
+	if len(doc.tags) == 0 && len(doc.before) == 0 && len(doc.after) == 0 {
+		return doc.data[doc.tokens[first].start:doc.tokens[last].end]
+	}
+	var out []byte
+	for i := first; i <= last; i++ {
+		out = append(out, doc.raw(i)...)
+	}
+	return out
+}
+
+// raw returns the bytes of token i, with its rewritten tag and inserted markup
+func (doc *markupDocument) raw(i int) []byte {
+	raw := doc.data[doc.tokens[i].start:doc.tokens[i].end]
+	if tag, ok := doc.tags[i]; ok {
+		raw = tag
+	}
+	before, after := doc.before[i], doc.after[i]
+	if before == nil && after == nil {
+		return raw
+	}
+	return append(append(append([]byte{}, before...), raw...), after...)
+}
+
+// langAttribute matches lang and xml:lang attributes of a start tag
+var langAttribute = regexp.MustCompile(`(\s(?:xml:)?lang\s*=\s*)("[^"]*"|'[^']*')`)
+
+// render writes the document with its translated blocks. lang and xml:lang
+// attributes of the root element, and those naming the source language,
+// are set to the target language.
+func (doc *markupDocument) render(sourceLanguage, targetLanguage string) []byte {
+	replaced := make(map[int]*markupBlock)
+	fragments := make(map[int]string)
+	for _, block := range doc.blocks {
+		if block.replacement != nil {
+			replaced[block.first] = block
+		}
+		for i, text := range block.fragments {
+			fragments[i] = text
+		}
+	}
+
+	source := primarySubtag(sourceLanguage)
+	root := true
+
+	var out bytes.Buffer
+	out.Grow(len(doc.data))
+	for i := 0; i < len(doc.tokens); i++ {
+		if block, ok := replaced[i]; ok {
+			// The block keeps its tags, so their lang attributes are not updated
+			out.Write(block.replacement)
+			i = block.last
+			continue
+		}
+
+		tok := doc.tokens[i]
+		raw := doc.raw(i)
+		switch {
+		case tok.kind == tokenText:
+			if text, ok := fragments[i]; ok {
+				out.WriteString(escapeText(text))
+				continue
+			}
+		case tok.kind == tokenStart && targetLanguage != "":
+			isRoot := root
+			root = false
+			raw = langAttribute.ReplaceAllFunc(raw, func(match []byte) []byte {
+				sub := langAttribute.FindSubmatch(match)
+				value := string(sub[2][1 : len(sub[2])-1])
+				if !isRoot && (source == "" || primarySubtag(value) != source) {
+					return match
+				}
+				quote := sub[2][:1]
+				return []byte(string(sub[1]) + string(quote) + escapeXML(targetLanguage) + string(quote))
+			})
+		}
+		out.Write(raw)
+	}
+	return out.Bytes()
+}
+
+// primarySubtag returns the language of a BCP 47 tag, e.g. "sr" for "sr-Latn"
+func primarySubtag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// textEscaper escapes character data, leaving quotes readable
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// splitSpace splits leading and trailing whitespace off s
+func splitSpace(s string) (lead, text, trail string) {
+	text = strings.TrimLeft(s, " \t\r\n")
+	lead = s[:len(s)-len(text)]
+	trimmed := strings.TrimRight(text, " \t\r\n")
+	return lead, trimmed, text[len(trimmed):]
+}
@@ -0,0 +1,277 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const inPlaceOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Книга</dc:title>
+    <dc:language>ru</dc:language>
+    <dc:identifier id="id">urn:uuid:1</dc:identifier>
+    <meta property="dcterms:modified">2024-01-01T00:00:00Z</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ch1" href="Text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="css" href="Styles/book.css" media-type="text/css"/>
+    <item id="img" href="Images/pic.png" media-type="image/png"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+  </manifest>
+  <spine toc="ncx">
+    <itemref idref="ch1"/>
+  </spine>
+</package>`
+
+const inPlaceChapter = `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="ru" lang="ru">
+<head>
+  <title>Глава</title>
+  <link rel="stylesheet" type="text/css" href="../Styles/book.css"/>
+  <style>p { margin: 0 }</style>
+</head>
+<body>
+  <h1 class="chapter" id="c1">Глава <em>первая</em></h1>
+  <p class="first">Он сказал: <a href="#n1" epub:type="noteref">«привет»</a> и ушёл.<br/>Конец&#160;строки.</p>
+  <!-- a comment -->
+  <p>Вызови <code>init()</code> сначала.</p>
+  <p><img src="../Images/pic.png" alt="pic"/></p>
+  <pre>не переводить</pre>
+  <p lang="en">English quote</p>
+  <aside epub:type="footnote" id="n1"><p>Сноска.</p></aside>
+</body>
+</html>`
+
+const inPlaceNCX = `<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <docTitle><text>Книга</text></docTitle>
+  <navMap>
+    <navPoint id="p1" playOrder="1">
+      <navLabel><text>Глава первая</text></navLabel>
+      <content src="Text/chapter%201.xhtml"/>
+    </navPoint>
+  </navMap>
+</ncx>`
+
+const inPlaceNav = `<?xml version="1.0" encoding="utf-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>Оглавление</title></head>
+<body><nav epub:type="toc"><ol><li><a href="Text/chapter%201.xhtml">Глава первая</a></li></ol></nav></body>
+</html>`
+
+var inPlaceCSS = []byte("p { text-indent: 1em; }\n")
+var inPlaceImage = []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0, 0, 0, 0}
+
+func createInPlaceEPUB(t *testing.T) string {
+	t.Helper()
+
+	return writeTestZip(t, []testZipEntry{
+		{"mimetype", "application/epub+zip", zip.Store},
+		{"META-INF/container.xml", validatorContainer, zip.Deflate},
+		{"OEBPS/content.opf", inPlaceOPF, zip.Deflate},
+		{"OEBPS/nav.xhtml", inPlaceNav, zip.Deflate},
+		{"OEBPS/Text/chapter 1.xhtml", inPlaceChapter, zip.Deflate},
+		{"OEBPS/Styles/book.css", string(inPlaceCSS), zip.Deflate},
+		{"OEBPS/Images/pic.png", string(inPlaceImage), zip.Store},
+		{"OEBPS/toc.ncx", inPlaceNCX, zip.Deflate},
+	})
+}
+
+// upperTranslate uppercases text and keeps markup placeholders
+func upperTranslate(ctx context.Context, text, context string) (string, error) {
+	return upperPlaceholder.ReplaceAllStringFunc(strings.ToUpper(text), strings.ToLower), nil
+}
+
+var upperPlaceholder = regexp.MustCompile(`</?[GX]\d+/?>`)
+
+func readEntries(t *testing.T, filename string) (map[string][]byte, []string) {
+	t.Helper()
+
+	r, err := zip.OpenReader(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	contents := make(map[string][]byte)
+	var order []string
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[f.Name] = data
+		order = append(order, f.Name)
+	}
+	return contents, order
+}
+
+func TestEPUBInPlaceTranslator_Translate(t *testing.T) {
+	input := createInPlaceEPUB(t)
+	output := filepath.Join(t.TempDir(), "translated.epub")
+
+	var progress []int
+	translator := NewEPUBInPlaceTranslator(upperTranslate, "sr")
+	translator.OnProgress = func(done, total int) { progress = append(progress, done) }
+
+	stats, err := translator.Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Documents != 3 || stats.Fragments != 0 || stats.Attributes != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if steps := stats.Blocks + stats.Attributes; len(progress) != steps || progress[len(progress)-1] != steps {
+		t.Errorf("progress %v does not match %d blocks and attributes", progress, steps)
+	}
+	if stats.Validation == nil || len(stats.Validation.Issues) != 0 {
+		t.Errorf("unexpected validation of the output: %+v", stats.Validation)
+	}
+
+	original, originalOrder := readEntries(t, input)
+	translated, order := readEntries(t, output)
+
+	if strings.Join(order, ",") != strings.Join(originalOrder, ",") {
+		t.Errorf("entry order changed: %v", order)
+	}
+	for _, name := range []string{"mimetype", "META-INF/container.xml", "OEBPS/Styles/book.css", "OEBPS/Images/pic.png"} {
+		if !bytes.Equal(original[name], translated[name]) {
+			t.Errorf("%s changed", name)
+		}
+	}
+
+	opf := string(translated["OEBPS/content.opf"])
+	if opf != strings.Replace(inPlaceOPF, "<dc:language>ru</dc:language>", "<dc:language>sr</dc:language>", 1) {
+		t.Errorf("package document changed beyond dc:language:\n%s", opf)
+	}
+
+	chapter := string(translated["OEBPS/Text/chapter 1.xhtml"])
+	for _, expected := range []string{
+		`<?xml version="1.0" encoding="utf-8"?>`,
+		`xml:lang="sr" lang="sr"`,
+		`<title>ГЛАВА</title>`,
+		`<link rel="stylesheet" type="text/css" href="../Styles/book.css"/>`,
+		`<style>p { margin: 0 }</style>`,
+		`<h1 class="chapter" id="c1">ГЛАВА <em>ПЕРВАЯ</em></h1>`,
+		`<p class="first">ОН СКАЗАЛ: <a href="#n1" epub:type="noteref">«ПРИВЕТ»</a> И УШЁЛ.<br/>КОНЕЦ` + "\u00a0" + `СТРОКИ.</p>`,
+		`<!-- a comment -->`,
+		`<p>ВЫЗОВИ <code>init()</code> СНАЧАЛА.</p>`,
+		`<p><img src="../Images/pic.png" alt="PIC"/></p>`,
+		`<pre>не переводить</pre>`,
+		`<p lang="en">ENGLISH QUOTE</p>`,
+	} {
+		if !strings.Contains(chapter, expected) {
+			t.Errorf("chapter lacks %q:\n%s", expected, chapter)
+		}
+	}
+
+	ncx := string(translated["OEBPS/toc.ncx"])
+	if !strings.Contains(ncx, "<navLabel><text>ГЛАВА ПЕРВАЯ</text></navLabel>") ||
+		!strings.Contains(ncx, `<content src="Text/chapter%201.xhtml"/>`) {
+		t.Errorf("unexpected NCX:\n%s", ncx)
+	}
+	nav := string(translated["OEBPS/nav.xhtml"])
+	if !strings.Contains(nav, `<a href="Text/chapter%201.xhtml">ГЛАВА ПЕРВАЯ</a>`) {
+		t.Errorf("unexpected navigation document:\n%s", nav)
+	}
+
+	// The result still parses as an EPUB
+	book, err := NewEPUBParser().Parse(output)
+	if err != nil {
+		t.Fatalf("translated EPUB does not parse: %v", err)
+	}
+	if book.Metadata.Language != "sr" {
+		t.Errorf("language = %q", book.Metadata.Language)
+	}
+}
+
+func TestEPUBInPlaceTranslator_LostMarkup(t *testing.T) {
+	input := createInPlaceEPUB(t)
+	output := filepath.Join(t.TempDir(), "translated.epub")
+
+	// A translator dropping the placeholders forces node by node translation
+	drop := regexp.MustCompile(`</?[gx]\d+/?>`)
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return strings.ToUpper(drop.ReplaceAllString(text, "")), nil
+	}
+
+	stats, err := NewEPUBInPlaceTranslator(translate, "sr").Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Fragments == 0 {
+		t.Errorf("expected fragment translation, got %+v", stats)
+	}
+
+	translated, _ := readEntries(t, output)
+	chapter := string(translated["OEBPS/Text/chapter 1.xhtml"])
+	expected := `<p class="first">ОН СКАЗАЛ: <a href="#n1" epub:type="noteref">«ПРИВЕТ»</a> И УШЁЛ.<br/>КОНЕЦ` + "\u00a0" + `СТРОКИ.</p>`
+	if !strings.Contains(chapter, expected) {
+		t.Errorf("chapter lacks %q:\n%s", expected, chapter)
+	}
+}
+
+func TestEPUBInPlaceTranslator_Errors(t *testing.T) {
+	input := createInPlaceEPUB(t)
+	output := filepath.Join(t.TempDir(), "translated.epub")
+
+	failing := func(ctx context.Context, text, context string) (string, error) {
+		return "", errors.New("provider unavailable")
+	}
+	if _, err := NewEPUBInPlaceTranslator(failing, "sr").Translate(context.Background(), input, output); err == nil {
+		t.Error("expected translation error")
+	}
+
+	if _, err := NewEPUBInPlaceTranslator(upperTranslate, "sr").Translate(context.Background(), "missing.epub", output); err == nil {
+		t.Error("expected error for missing input")
+	}
+}
+
+func TestMarkupBlock_Rebuild(t *testing.T) {
+	doc, err := parseMarkupDocument([]byte(`<p>A <b>bold</b> and <i>italic</i><br/> word</p>`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(doc.blocks))
+	}
+	block := doc.blocks[0]
+	if source := block.source(); source != "A <g1>bold</g1> and <g2>italic</g2><x3/> word" {
+		t.Fatalf("unexpected source %q", source)
+	}
+
+	tests := []struct {
+		translated string
+		expected   string
+		ok         bool
+	}{
+		// Translations may reorder tags
+		{"<g2>italique</g2> et <g1>gras</g1> & A<x3/> mot", "<i>italique</i> et <b>gras</b> &amp; A<br/> mot", true},
+		{"<g1>gras</g1> et <g2>italique</g2> mot", "", false}, // lost <x3/>
+		{"<g1>gras <g2>x</g1></g2><x3/>", "", false},          // crossed tags
+		{"<g1>a</g1><g1>b</g1><g2>c</g2><x3/>", "", false},    // duplicated tag
+		{"<g1>a</g1><g2>c</g2><x3/><x4/>", "", false},         // unknown tag
+		{"<g1>a</g1> <g2>c</g2><x3/> 1 < 2", "<b>a</b> <i>c</i><br/> 1 &lt; 2", true},
+	}
+	for _, test := range tests {
+		result, ok := block.rebuild(test.translated)
+		if ok != test.ok || (ok && string(result) != test.expected) {
+			t.Errorf("rebuild(%q) = %q, %v; expected %q, %v", test.translated, result, ok, test.expected, test.ok)
+		}
+	}
+}
@@ -67,16 +67,33 @@ func (p *EPUBParser) Parse(filename string) (*Book, error) {
 		opfDir = opfPath[:idx+1]
 	}
 
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		files[f.Name] = f
+	}
+
 	for _, contentFile := range contentFiles {
 		fullPath := opfDir + contentFile
-		for _, f := range r.File {
-			if f.Name == fullPath {
-				chapter, err := p.parseContentFile(f)
-				if err == nil && chapter != nil {
-					book.Chapters = append(book.Chapters, *chapter)
-				}
-				break
-			}
+		f, ok := files[fullPath]
+		if !ok {
+			continue
+		}
+		chapter, err := p.parseContentFile(f)
+		if err != nil {
+			continue
+		}
+
+		// Figures and images are kept apart from the text
+		var images []Image
+		if data, err := readZipFile(f); err == nil {
+			images = parseChapterImages(data, fullPath, files)
+		}
+		if chapter == nil && len(images) > 0 {
+			chapter = &Chapter{Title: f.Name}
+		}
+		if chapter != nil {
+			chapter.Images = images
+			book.Chapters = append(book.Chapters, *chapter)
 		}
 	}
 
@@ -301,6 +318,9 @@ func (p *EPUBParser) parseContentFile(f *zip.File) (*Chapter, error) {
 	// Remove entire head section including title
 	headRe := regexp.MustCompile(`(?i)<head[^>]*>.*?</head>`)
 	content = headRe.ReplaceAllString(content, " ")
+
+	// Remove figures and images, which are parsed as images of the chapter
+	content = figureElements.ReplaceAllString(content, " ")
 	
 	// Remove tags from remaining content
 	content = removeHTMLTags(content)
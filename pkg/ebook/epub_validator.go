@@ -0,0 +1,559 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidEPUB is returned when validation finds errors in an EPUB
+var ErrInvalidEPUB = errors.New("invalid EPUB")
+
+// Severity is the severity of a validation issue
+type Severity string
+
+const (
+	// SeverityError marks issues that break the book in reading systems or stores
+	SeverityError Severity = "error"
+	// SeverityWarning marks issues reading systems usually tolerate
+	SeverityWarning Severity = "warning"
+)
+
+// Validation rules reported in issues
+const (
+	RuleMimetype    = "mimetype"
+	RuleContainer   = "container"
+	RulePackage     = "package"
+	RuleMetadata    = "metadata"
+	RuleManifest    = "manifest"
+	RuleSpine       = "spine"
+	RuleResource    = "resource"
+	RuleXHTML       = "xhtml"
+	RuleDuplicateID = "duplicate-id"
+	RuleLink        = "link"
+)
+
+// ValidationIssue is a single problem found in an EPUB
+type ValidationIssue struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	File     string   `json:"file,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	if i.File == "" {
+		return fmt.Sprintf("%s [%s] %s", i.Severity, i.Rule, i.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", i.Severity, i.Rule, i.File, i.Message)
+}
+
+// ValidationReport lists the issues found in an EPUB
+type ValidationReport struct {
+	File   string            `json:"file"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// Errors returns the issues with error severity
+func (r *ValidationReport) Errors() []ValidationIssue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the issues with warning severity
+func (r *ValidationReport) Warnings() []ValidationIssue {
+	return r.filter(SeverityWarning)
+}
+
+// Valid reports whether the EPUB has no errors; warnings are allowed
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Err returns an error wrapping ErrInvalidEPUB with the first error found,
+// or nil when the EPUB is valid
+func (r *ValidationReport) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return fmt.Errorf("%w: %s", ErrInvalidEPUB, errs[0])
+	}
+	return fmt.Errorf("%w: %s (and %d more errors)", ErrInvalidEPUB, errs[0], len(errs)-1)
+}
+
+func (r *ValidationReport) filter(severity Severity) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (r *ValidationReport) add(severity Severity, rule, file, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{
+		Severity: severity,
+		Rule:     rule,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// EPUBValidator checks EPUBs against the OCF, package document and content
+// document rules that break books in reading systems and stores
+type EPUBValidator struct{}
+
+// NewEPUBValidator creates a new EPUB validator
+func NewEPUBValidator() *EPUBValidator {
+	return &EPUBValidator{}
+}
+
+// validationPackage is the part of the package document the validator checks
+type validationPackage struct {
+	Version          string `xml:"version,attr"`
+	UniqueIdentifier string `xml:"unique-identifier,attr"`
+	Metadata         struct {
+		Title      []string `xml:"title"`
+		Language   []string `xml:"language"`
+		Identifier []struct {
+			ID    string `xml:"id,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"identifier"`
+		Meta []struct {
+			Property string `xml:"property,attr"`
+			Value    string `xml:",chardata"`
+		} `xml:"meta"`
+	} `xml:"metadata"`
+	Manifest struct {
+		Items []struct {
+			ID         string `xml:"id,attr"`
+			Href       string `xml:"href,attr"`
+			MediaType  string `xml:"media-type,attr"`
+			Properties string `xml:"properties,attr"`
+			Fallback   string `xml:"fallback,attr"`
+		} `xml:"item"`
+	} `xml:"manifest"`
+	Spine struct {
+		Toc      string `xml:"toc,attr"`
+		Itemrefs []struct {
+			Idref string `xml:"idref,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+// contentLink is a reference from a content document to another resource
+type contentLink struct {
+	source string // archive path of the referencing document
+	href   string
+	target string // archive path of the referenced resource
+	id     string // fragment identifier
+}
+
+// validation holds the state of a single validation run
+type validation struct {
+	report  *ValidationReport
+	files   map[string]*zip.File
+	epub3   bool
+	ids     map[string]map[string]bool // document → ids
+	links   []contentLink
+	listed  map[string]bool // archive paths in the manifest
+	opfPath string
+}
+
+// Validate validates the EPUB at filename. The error is only set when the
+// file cannot be read; problems with its contents are reported as issues.
+func (v *EPUBValidator) Validate(filename string) (*ValidationReport, error) {
+	if _, err := os.Stat(filename); err != nil {
+		return nil, err
+	}
+
+	report := &ValidationReport{File: filename}
+	r, err := zip.OpenReader(filename)
+	if err != nil {
+		report.add(SeverityError, RuleContainer, "", "not a ZIP archive: %v", err)
+		return report, nil
+	}
+	defer r.Close()
+
+	val := &validation{
+		report: report,
+		files:  make(map[string]*zip.File, len(r.File)),
+		ids:    make(map[string]map[string]bool),
+		listed: make(map[string]bool),
+	}
+	for _, f := range r.File {
+		val.files[f.Name] = f
+	}
+
+	val.checkMimetype(r.File)
+	pkg := val.checkContainer()
+	if pkg != nil {
+		val.checkPackage(pkg)
+		val.checkLinks()
+		val.checkUnlisted(r.File)
+	}
+
+	return report, nil
+}
+
+// checkMimetype checks that the archive starts with an uncompressed mimetype
+func (val *validation) checkMimetype(files []*zip.File) {
+	if len(files) == 0 || files[0].Name != "mimetype" {
+		if _, ok := val.files["mimetype"]; !ok {
+			val.report.add(SeverityError, RuleMimetype, "mimetype", "mimetype file is missing")
+			return
+		}
+		val.report.add(SeverityError, RuleMimetype, "mimetype", "mimetype must be the first file in the archive")
+	}
+
+	f := val.files["mimetype"]
+	if f.Method != zip.Store {
+		val.report.add(SeverityError, RuleMimetype, "mimetype", "mimetype must be stored without compression")
+	}
+	if len(f.Extra) > 0 {
+		val.report.add(SeverityWarning, RuleMimetype, "mimetype", "mimetype has an extra field in its ZIP header")
+	}
+	data, err := readZipFile(f)
+	if err != nil {
+		val.report.add(SeverityError, RuleMimetype, "mimetype", "cannot read mimetype: %v", err)
+		return
+	}
+	if string(data) != "application/epub+zip" {
+		val.report.add(SeverityError, RuleMimetype, "mimetype", "mimetype must contain exactly application/epub+zip, found %q", data)
+	}
+}
+
+// checkContainer finds and parses the package document
+func (val *validation) checkContainer() *validationPackage {
+	const containerPath = "META-INF/container.xml"
+
+	container, ok := val.files[containerPath]
+	if !ok {
+		val.report.add(SeverityError, RuleContainer, containerPath, "container.xml is missing")
+		return nil
+	}
+	opfPath, err := NewEPUBParser().parseContainer(container)
+	if err != nil || opfPath == "" {
+		val.report.add(SeverityError, RuleContainer, containerPath, "container.xml does not name a package document")
+		return nil
+	}
+	val.opfPath = opfPath
+
+	f, ok := val.files[opfPath]
+	if !ok {
+		val.report.add(SeverityError, RuleContainer, containerPath, "package document %s is missing", opfPath)
+		return nil
+	}
+	data, err := readZipFile(f)
+	if err != nil {
+		val.report.add(SeverityError, RulePackage, opfPath, "cannot read package document: %v", err)
+		return nil
+	}
+
+	var pkg validationPackage
+	if err := xml.Unmarshal(data, &pkg); err != nil {
+		val.report.add(SeverityError, RulePackage, opfPath, "package document is not well-formed: %v", err)
+		return nil
+	}
+	val.epub3 = strings.HasPrefix(pkg.Version, "3")
+	return &pkg
+}
+
+// checkPackage checks metadata, manifest and spine and the content
+// documents they list
+func (val *validation) checkPackage(pkg *validationPackage) {
+	opf := val.opfPath
+	report := val.report
+
+	// Metadata
+	if len(pkg.Metadata.Title) == 0 || strings.TrimSpace(pkg.Metadata.Title[0]) == "" {
+		report.add(SeverityError, RuleMetadata, opf, "dc:title is missing")
+	}
+	if len(pkg.Metadata.Language) == 0 || strings.TrimSpace(pkg.Metadata.Language[0]) == "" {
+		report.add(SeverityError, RuleMetadata, opf, "dc:language is missing")
+	}
+	uniqueIdentifier := false
+	for _, id := range pkg.Metadata.Identifier {
+		if id.ID == pkg.UniqueIdentifier && strings.TrimSpace(id.Value) != "" {
+			uniqueIdentifier = true
+		}
+	}
+	if len(pkg.Metadata.Identifier) == 0 {
+		report.add(SeverityError, RuleMetadata, opf, "dc:identifier is missing")
+	} else if !uniqueIdentifier {
+		report.add(SeverityError, RuleMetadata, opf, "unique-identifier %q does not name a dc:identifier", pkg.UniqueIdentifier)
+	}
+	if val.epub3 {
+		modified := false
+		for _, meta := range pkg.Metadata.Meta {
+			if meta.Property == "dcterms:modified" && strings.TrimSpace(meta.Value) != "" {
+				modified = true
+			}
+		}
+		if !modified {
+			report.add(SeverityError, RuleMetadata, opf, "dcterms:modified is missing")
+		}
+	}
+
+	// Manifest
+	opfDir := path.Dir(opf)
+	items := make(map[string]string) // id → media type
+	hrefs := make(map[string]bool)
+	navDocuments := 0
+	for _, item := range pkg.Manifest.Items {
+		if item.ID == "" {
+			report.add(SeverityError, RuleManifest, opf, "manifest item %q has no id", item.Href)
+		} else if _, ok := items[item.ID]; ok {
+			report.add(SeverityError, RuleManifest, opf, "duplicate manifest id %q", item.ID)
+		}
+		items[item.ID] = item.MediaType
+
+		if item.MediaType == "" {
+			report.add(SeverityError, RuleManifest, opf, "manifest item %q has no media type", item.ID)
+		}
+		if hasProperty(item.Properties, "nav") {
+			navDocuments++
+		}
+
+		if isRemote(item.Href) {
+			continue
+		}
+		target, err := resolveHref(opfDir, item.Href)
+		if err != nil {
+			report.add(SeverityError, RuleManifest, opf, "manifest item %q has an invalid href %q", item.ID, item.Href)
+			continue
+		}
+		if hrefs[target] {
+			report.add(SeverityError, RuleManifest, opf, "%s is listed in the manifest more than once", target)
+		}
+		hrefs[target] = true
+		val.listed[target] = true
+
+		f, ok := val.files[target]
+		if !ok {
+			report.add(SeverityError, RuleResource, opf, "manifest item %q refers to missing file %s", item.ID, target)
+			continue
+		}
+		switch item.MediaType {
+		case "application/xhtml+xml", "image/svg+xml":
+			val.checkContentDocument(f)
+		case "application/x-dtbncx+xml":
+			val.checkNCX(f)
+		}
+	}
+	if val.epub3 && navDocuments != 1 {
+		report.add(SeverityError, RuleManifest, opf, "EPUB 3 needs exactly one navigation document, found %d", navDocuments)
+	}
+
+	// Spine
+	if pkg.Spine.Toc != "" {
+		if mediaType, ok := items[pkg.Spine.Toc]; !ok || mediaType != "application/x-dtbncx+xml" {
+			report.add(SeverityError, RuleSpine, opf, "spine toc %q does not name an NCX manifest item", pkg.Spine.Toc)
+		}
+	} else if !val.epub3 {
+		report.add(SeverityError, RuleSpine, opf, "EPUB 2 spine needs a toc attribute naming the NCX")
+	}
+	if len(pkg.Spine.Itemrefs) == 0 {
+		report.add(SeverityError, RuleSpine, opf, "spine is empty")
+	}
+	fallbacks := make(map[string]bool)
+	for _, item := range pkg.Manifest.Items {
+		fallbacks[item.ID] = item.Fallback != ""
+	}
+	inSpine := make(map[string]bool)
+	for _, ref := range pkg.Spine.Itemrefs {
+		mediaType, ok := items[ref.Idref]
+		if !ok {
+			report.add(SeverityError, RuleSpine, opf, "spine item %q is not in the manifest", ref.Idref)
+			continue
+		}
+		if inSpine[ref.Idref] {
+			report.add(SeverityError, RuleSpine, opf, "spine item %q is listed more than once", ref.Idref)
+		}
+		inSpine[ref.Idref] = true
+		if mediaType != "application/xhtml+xml" && mediaType != "image/svg+xml" && !fallbacks[ref.Idref] {
+			report.add(SeverityError, RuleSpine, opf, "spine item %q is %s, not a content document", ref.Idref, mediaType)
+		}
+	}
+}
+
+// checkContentDocument checks that an XHTML or SVG document is well-formed,
+// has unique ids and collects its links
+func (val *validation) checkContentDocument(f *zip.File) {
+	data, err := readZipFile(f)
+	if err != nil {
+		val.report.add(SeverityError, RuleXHTML, f.Name, "cannot read document: %v", err)
+		return
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if !val.epub3 {
+		// EPUB 2 documents may use the entities of the XHTML 1.1 DTD
+		decoder.Entity = xml.HTMLEntity
+	}
+
+	ids := make(map[string]bool)
+	val.ids[f.Name] = ids
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			val.report.add(SeverityError, RuleXHTML, f.Name, "document is not well-formed: %v", err)
+			return
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, attr := range start.Attr {
+			switch {
+			case attr.Name.Local == "id":
+				if ids[attr.Value] {
+					val.report.add(SeverityError, RuleDuplicateID, f.Name, "id %q is used more than once", attr.Value)
+				}
+				ids[attr.Value] = true
+			case isLinkAttribute(start.Name.Local, attr.Name.Local):
+				val.addLink(f.Name, attr.Value)
+			}
+		}
+	}
+}
+
+// checkNCX collects the links of an NCX
+func (val *validation) checkNCX(f *zip.File) {
+	data, err := readZipFile(f)
+	if err != nil {
+		val.report.add(SeverityError, RuleXHTML, f.Name, "cannot read NCX: %v", err)
+		return
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			val.report.add(SeverityError, RuleXHTML, f.Name, "NCX is not well-formed: %v", err)
+			return
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "content" {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "src" {
+					val.addLink(f.Name, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+// isLinkAttribute reports whether the attribute of the element refers to
+// another resource
+func isLinkAttribute(element, attr string) bool {
+	switch attr {
+	case "href":
+		// xlink:href of SVG has the same local name
+		return element != "base"
+	case "src":
+		return true
+	case "poster":
+		return element == "video"
+	}
+	return false
+}
+
+// addLink records an internal link of a document
+func (val *validation) addLink(source, href string) {
+	href = strings.TrimSpace(href)
+	if href == "" || isRemote(href) {
+		return
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		val.report.add(SeverityError, RuleLink, source, "invalid link %q", href)
+		return
+	}
+
+	target := source
+	if u.Path != "" {
+		target = path.Join(path.Dir(source), u.Path)
+	}
+	val.links = append(val.links, contentLink{source: source, href: href, target: target, id: u.Fragment})
+}
+
+// checkLinks checks that internal links lead to existing files and ids
+func (val *validation) checkLinks() {
+	for _, link := range val.links {
+		if _, ok := val.files[link.target]; !ok {
+			val.report.add(SeverityError, RuleLink, link.source, "broken link %q: %s does not exist", link.href, link.target)
+			continue
+		}
+		if !val.listed[link.target] {
+			val.report.add(SeverityError, RuleLink, link.source, "link %q leads to %s, which is not in the manifest", link.href, link.target)
+			continue
+		}
+		if ids, ok := val.ids[link.target]; ok && link.id != "" && !ids[link.id] {
+			val.report.add(SeverityError, RuleLink, link.source, "broken link %q: %s has no id %q", link.href, link.target, link.id)
+		}
+	}
+}
+
+// checkUnlisted warns about files that are neither OCF files nor listed in
+// the manifest
+func (val *validation) checkUnlisted(files []*zip.File) {
+	var unlisted []string
+	for _, f := range files {
+		name := f.Name
+		if name == "mimetype" || name == val.opfPath || strings.HasPrefix(name, "META-INF/") ||
+			strings.HasSuffix(name, "/") || val.listed[name] {
+			continue
+		}
+		unlisted = append(unlisted, name)
+	}
+	sort.Strings(unlisted)
+	for _, name := range unlisted {
+		val.report.add(SeverityWarning, RuleManifest, name, "file is not listed in the manifest")
+	}
+}
+
+// resolveHref resolves a manifest href relative to the package directory
+func resolveHref(dir, href string) (string, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(dir, u.Path), nil
+}
+
+// isRemote reports whether href leads outside the EPUB
+func isRemote(href string) bool {
+	if strings.HasPrefix(href, "//") {
+		return true
+	}
+	u, err := url.Parse(href)
+	return err == nil && u.Scheme != ""
+}
+
+// hasProperty reports whether the space separated properties contain property
+func hasProperty(properties, property string) bool {
+	for _, p := range strings.Fields(properties) {
+		if p == property {
+			return true
+		}
+	}
+	return false
+}
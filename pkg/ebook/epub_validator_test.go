@@ -0,0 +1,282 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testZipEntry is a file of an archive built by writeTestZip
+type testZipEntry struct {
+	name   string
+	data   string
+	method uint16
+}
+
+func writeTestZip(t *testing.T, entries []testZipEntry) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: e.method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "book.epub")
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+const validatorContainer = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+
+const validatorOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Book</dc:title>
+    <dc:language>en</dc:language>
+    <dc:identifier id="id">urn:uuid:1</dc:identifier>
+    <meta property="dcterms:modified">2024-01-01T00:00:00Z</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ch1" href="Text/one.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="Text/two.xhtml" media-type="application/xhtml+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+  </manifest>
+  <spine>
+    <itemref idref="ch1"/>
+    <itemref idref="ch2"/>
+  </spine>
+</package>`
+
+const validatorNav = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>Contents</title></head>
+<body><nav epub:type="toc" id="toc"><ol>
+  <li><a href="Text/one.xhtml">One</a></li>
+  <li><a href="Text/two.xhtml#part">Two</a></li>
+</ol></nav></body>
+</html>`
+
+const validatorChapterOne = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title>One</title><link rel="stylesheet" href="../style.css"/></head>
+<body><h1 id="top">One</h1><p>See <a href="two.xhtml#part">part two</a> and <a href="https://example.com">the site</a>.</p></body>
+</html>`
+
+const validatorChapterTwo = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title>Two</title></head>
+<body><h1 id="part">Two</h1><p><a href="#part">Up</a></p></body>
+</html>`
+
+// validatorEntries returns the files of a valid EPUB 3 with the given
+// replacements; an empty replacement removes the file
+func validatorEntries(replacements map[string]string) []testZipEntry {
+	entries := []testZipEntry{
+		{"mimetype", "application/epub+zip", zip.Store},
+		{"META-INF/container.xml", validatorContainer, zip.Deflate},
+		{"OEBPS/content.opf", validatorOPF, zip.Deflate},
+		{"OEBPS/nav.xhtml", validatorNav, zip.Deflate},
+		{"OEBPS/Text/one.xhtml", validatorChapterOne, zip.Deflate},
+		{"OEBPS/Text/two.xhtml", validatorChapterTwo, zip.Deflate},
+		{"OEBPS/style.css", "p { margin: 0 }", zip.Deflate},
+	}
+
+	var result []testZipEntry
+	for _, e := range entries {
+		if data, ok := replacements[e.name]; ok {
+			if data == "" {
+				continue
+			}
+			e.data = data
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+func hasIssue(report *ValidationReport, severity Severity, rule, text string) bool {
+	for _, issue := range report.Issues {
+		if issue.Severity == severity && issue.Rule == rule && strings.Contains(issue.Message, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEPUBValidator_ValidBook(t *testing.T) {
+	report, err := NewEPUBValidator().Validate(writeTestZip(t, validatorEntries(nil)))
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !report.Valid() || len(report.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", report.Issues)
+	}
+	if report.Err() != nil {
+		t.Errorf("Err() = %v", report.Err())
+	}
+}
+
+func TestEPUBValidator_Issues(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []testZipEntry
+		severity Severity
+		rule     string
+		message  string
+	}{
+		{
+			name: "mimetype not first",
+			entries: append(validatorEntries(map[string]string{"mimetype": ""}),
+				testZipEntry{"mimetype", "application/epub+zip", zip.Store}),
+			severity: SeverityError, rule: RuleMimetype, message: "first file",
+		},
+		{
+			name: "compressed mimetype",
+			entries: append([]testZipEntry{{"mimetype", "application/epub+zip", zip.Deflate}},
+				validatorEntries(map[string]string{"mimetype": ""})...),
+			severity: SeverityError, rule: RuleMimetype, message: "without compression",
+		},
+		{
+			name:     "missing container",
+			entries:  validatorEntries(map[string]string{"META-INF/container.xml": ""}),
+			severity: SeverityError, rule: RuleContainer, message: "container.xml is missing",
+		},
+		{
+			name:     "missing language",
+			entries:  validatorEntries(map[string]string{"OEBPS/content.opf": strings.Replace(validatorOPF, "<dc:language>en</dc:language>", "", 1)}),
+			severity: SeverityError, rule: RuleMetadata, message: "dc:language",
+		},
+		{
+			name:     "missing modified date",
+			entries:  validatorEntries(map[string]string{"OEBPS/content.opf": strings.Replace(validatorOPF, "dcterms:modified", "dcterms:created", 1)}),
+			severity: SeverityError, rule: RuleMetadata, message: "dcterms:modified",
+		},
+		{
+			name:     "missing resource",
+			entries:  validatorEntries(map[string]string{"OEBPS/style.css": ""}),
+			severity: SeverityError, rule: RuleResource, message: "missing file OEBPS/style.css",
+		},
+		{
+			name:     "spine item not in manifest",
+			entries:  validatorEntries(map[string]string{"OEBPS/content.opf": strings.Replace(validatorOPF, `<itemref idref="ch2"/>`, `<itemref idref="ch3"/>`, 1)}),
+			severity: SeverityError, rule: RuleSpine, message: `"ch3" is not in the manifest`,
+		},
+		{
+			name:     "stylesheet in spine",
+			entries:  validatorEntries(map[string]string{"OEBPS/content.opf": strings.Replace(validatorOPF, `<itemref idref="ch2"/>`, `<itemref idref="css"/>`, 1)}),
+			severity: SeverityError, rule: RuleSpine, message: "not a content document",
+		},
+		{
+			name:     "missing navigation document",
+			entries:  validatorEntries(map[string]string{"OEBPS/content.opf": strings.Replace(validatorOPF, ` properties="nav"`, "", 1)}),
+			severity: SeverityError, rule: RuleManifest, message: "navigation document",
+		},
+		{
+			name:     "malformed XHTML",
+			entries:  validatorEntries(map[string]string{"OEBPS/Text/two.xhtml": strings.Replace(validatorChapterTwo, "</p>", "", 1)}),
+			severity: SeverityError, rule: RuleXHTML, message: "not well-formed",
+		},
+		{
+			name:     "HTML entity in EPUB 3",
+			entries:  validatorEntries(map[string]string{"OEBPS/Text/two.xhtml": strings.Replace(validatorChapterTwo, "Up", "&nbsp;Up", 1)}),
+			severity: SeverityError, rule: RuleXHTML, message: "not well-formed",
+		},
+		{
+			name:     "duplicate id",
+			entries:  validatorEntries(map[string]string{"OEBPS/Text/two.xhtml": strings.Replace(validatorChapterTwo, "<p>", `<p id="part">`, 1)}),
+			severity: SeverityError, rule: RuleDuplicateID, message: `"part"`,
+		},
+		{
+			name:     "link to missing file",
+			entries:  validatorEntries(map[string]string{"OEBPS/Text/one.xhtml": strings.Replace(validatorChapterOne, "two.xhtml#part", "three.xhtml", 1)}),
+			severity: SeverityError, rule: RuleLink, message: "OEBPS/Text/three.xhtml does not exist",
+		},
+		{
+			name:     "link to missing id",
+			entries:  validatorEntries(map[string]string{"OEBPS/nav.xhtml": strings.Replace(validatorNav, "#part", "#nowhere", 1)}),
+			severity: SeverityError, rule: RuleLink, message: `no id "nowhere"`,
+		},
+		{
+			name:     "unlisted file",
+			entries:  append(validatorEntries(nil), testZipEntry{"OEBPS/extra.png", "png", zip.Store}),
+			severity: SeverityWarning, rule: RuleManifest, message: "not listed in the manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewEPUBValidator().Validate(writeTestZip(t, tt.entries))
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if !hasIssue(report, tt.severity, tt.rule, tt.message) {
+				t.Errorf("expected %s [%s] %q, got %v", tt.severity, tt.rule, tt.message, report.Issues)
+			}
+			if report.Valid() != (tt.severity == SeverityWarning) {
+				t.Errorf("Valid() = %v with issues %v", report.Valid(), report.Issues)
+			}
+		})
+	}
+}
+
+func TestEPUBValidator_NotAnEPUB(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "book.epub")
+	if err := os.WriteFile(filename, []byte("plain text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewEPUBValidator().Validate(filename)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !errors.Is(report.Err(), ErrInvalidEPUB) {
+		t.Errorf("Err() = %v, want ErrInvalidEPUB", report.Err())
+	}
+
+	if _, err := NewEPUBValidator().Validate(filepath.Join(t.TempDir(), "missing.epub")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestEPUBValidator_WriterOutput(t *testing.T) {
+	for _, book := range []*Book{
+		{Metadata: Metadata{Title: "Empty"}},
+		{
+			Metadata: Metadata{Title: "Book", Language: "he", Cover: []byte("\x89PNG\r\n\x1a\n")},
+			Chapters: []Chapter{{Title: "One", Sections: []Section{{Title: "<Part>", Content: "A & B\n\nC"}}}},
+		},
+	} {
+		filename := filepath.Join(t.TempDir(), "book.epub")
+		if err := NewEPUBWriter().write(book, filename); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+
+		report, err := NewEPUBValidator().Validate(filename)
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if len(report.Issues) != 0 {
+			t.Errorf("%s: expected no issues, got %v", book.Metadata.Title, report.Issues)
+		}
+	}
+}
@@ -7,18 +7,39 @@ import (
 	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // EPUBWriter writes books to EPUB format
-type EPUBWriter struct{}
+type EPUBWriter struct {
+	// chapterBody, when set, writes the body of chapter i in place of its
+	// sections
+	chapterBody func(i int) string
+
+	// stylesheet, when set, is written to style.css and linked from every
+	// chapter
+	stylesheet string
+}
 
 // NewEPUBWriter creates a new EPUB writer
 func NewEPUBWriter() *EPUBWriter {
 	return &EPUBWriter{}
 }
 
-// Write writes a book to EPUB format
+// Write writes a book to EPUB format and validates the result
 func (w *EPUBWriter) Write(book *Book, filename string) error {
+	if err := w.write(book, filename); err != nil {
+		return err
+	}
+
+	report, err := NewEPUBValidator().Validate(filename)
+	if err != nil {
+		return fmt.Errorf("failed to validate EPUB: %w", err)
+	}
+	return report.Err()
+}
+
+func (w *EPUBWriter) write(book *Book, filename string) error {
 	// Create EPUB file (ZIP)
 	file, err := os.Create(filename)
 	if err != nil {
@@ -44,7 +65,7 @@ func (w *EPUBWriter) Write(book *Book, filename string) error {
 		return err
 	}
 
-	// Write toc.ncx
+	// Write toc.ncx for EPUB 2 reading systems
 	if err := w.writeTOC(zipWriter, book); err != nil {
 		return err
 	}
@@ -56,8 +77,30 @@ func (w *EPUBWriter) Write(book *Book, filename string) error {
 		}
 	}
 
+	// Write chapter images
+	if err := w.writeImages(zipWriter, book); err != nil {
+		return err
+	}
+
+	// Write stylesheet if present
+	if w.stylesheet != "" {
+		writer, err := zipWriter.Create("OEBPS/style.css")
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte(w.stylesheet)); err != nil {
+			return err
+		}
+	}
+
 	// Write chapters
-	if err := w.writeChapters(zipWriter, book); err != nil {
+	pages, err := w.writeChapters(zipWriter, book)
+	if err != nil {
+		return err
+	}
+
+	// Write nav.xhtml
+	if err := w.writeNav(zipWriter, book, pages); err != nil {
 		return err
 	}
 
@@ -110,7 +153,16 @@ func (w *EPUBWriter) writeContentOPF(zw *zip.Writer, book *Book) error {
 	// Add cover to manifest if present
 	hasCover := len(book.Metadata.Cover) > 0
 	if hasCover {
-		manifest.WriteString(`    <item id="cover-image" href="cover.jpg" media-type="image/jpeg" properties="cover-image"/>` + "\n")
+		coverFile, mediaType := coverImageType(book.Metadata.Cover)
+		manifest.WriteString(fmt.Sprintf(`    <item id="cover-image" href="%s" media-type="%s" properties="cover-image"/>%s`,
+			coverFile, mediaType, "\n"))
+	}
+
+	// Add navigation document to manifest; it stands in for the chapters of
+	// books without any, as the spine must not be empty
+	manifest.WriteString(`    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	if len(book.Chapters) == 0 {
+		spine.WriteString(`    <itemref idref="nav"/>` + "\n")
 	}
 
 	for i := range book.Chapters {
@@ -123,14 +175,29 @@ func (w *EPUBWriter) writeContentOPF(zw *zip.Writer, book *Book) error {
 		spine.WriteString(fmt.Sprintf(`    <itemref idref="%s"/>%s`, id, "\n"))
 	}
 
-	// Add NCX to manifest
-	manifest.WriteString(`    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>` + "\n")
+	// Add chapter images to manifest
+	hasImages := false
+	for i, chapter := range book.Chapters {
+		for j := range chapter.Images {
+			file := chapterImageFile(i, j, &chapter.Images[j])
+			if file == "" {
+				continue
+			}
+			hasImages = true
+			_, mediaType := imageType(chapter.Images[j].Data)
+			manifest.WriteString(fmt.Sprintf(`    <item id="image-%d-%d" href="%s" media-type="%s"/>%s`,
+				i+1, j+1, file, mediaType, "\n"))
+		}
+	}
 
-	language := book.Metadata.Language
-	if language == "" {
-		language = "en"
+	// Add NCX and stylesheet to manifest
+	manifest.WriteString(`    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>` + "\n")
+	if w.stylesheet != "" {
+		manifest.WriteString(`    <item id="css" href="style.css" media-type="text/css"/>` + "\n")
 	}
 
+	language := bookLanguage(book)
+
 	// Use book date if available, otherwise current date
 	date := book.Metadata.Date
 	if date == "" {
@@ -145,7 +212,7 @@ func (w *EPUBWriter) writeContentOPF(zw *zip.Writer, book *Book) error {
 
 	// Build metadata section
 	var metadataSection strings.Builder
-	metadataSection.WriteString(fmt.Sprintf("    <dc:title>%s</dc:title>\n", escapeXML(book.Metadata.Title)))
+	metadataSection.WriteString(fmt.Sprintf("    <dc:title>%s</dc:title>\n", escapeXML(bookTitle(book))))
 
 	// Write each author as a separate dc:creator element
 	if len(book.Metadata.Authors) > 0 {
@@ -155,7 +222,7 @@ func (w *EPUBWriter) writeContentOPF(zw *zip.Writer, book *Book) error {
 	} else {
 		metadataSection.WriteString("    <dc:creator>Unknown</dc:creator>\n")
 	}
-	metadataSection.WriteString(fmt.Sprintf("    <dc:language>%s</dc:language>\n", language))
+	metadataSection.WriteString(fmt.Sprintf("    <dc:language>%s</dc:language>\n", escapeXML(language)))
 	metadataSection.WriteString(fmt.Sprintf("    <dc:identifier id=\"BookID\">%s</dc:identifier>\n", escapeXML(identifier)))
 	metadataSection.WriteString(fmt.Sprintf("    <dc:date>%s</dc:date>\n", date))
 
@@ -169,22 +236,36 @@ func (w *EPUBWriter) writeContentOPF(zw *zip.Writer, book *Book) error {
 		metadataSection.WriteString(fmt.Sprintf("    <dc:publisher>%s</dc:publisher>\n", escapeXML(book.Metadata.Publisher)))
 	}
 
-	// Add cover meta tag if present
+	// Last modification, required by EPUB 3
+	metadataSection.WriteString(fmt.Sprintf("    <meta property=\"dcterms:modified\">%s</meta>\n",
+		time.Now().UTC().Format("2006-01-02T15:04:05Z")))
+
+	// Accessibility metadata (schema.org)
+	metadataSection.WriteString(accessibilityMetadata(hasCover || hasImages))
+
+	// Add cover meta tag if present, for EPUB 2 reading systems
 	if hasCover {
 		metadataSection.WriteString(`    <meta name="cover" content="cover-image"/>` + "\n")
 	}
 
+	spineAttributes := ` toc="ncx"`
+	if isRightToLeft(language) {
+		spineAttributes += ` page-progression-direction="rtl"`
+	}
+
 	opf := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
-<package xmlns="http://www.idpf.org/2007/opf" version="2.0" unique-identifier="BookID">
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="BookID" xml:lang="%s">
   <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
 %s  </metadata>
   <manifest>
 %s  </manifest>
-  <spine toc="ncx">
+  <spine%s>
 %s  </spine>
 </package>`,
+		escapeXML(language),
 		metadataSection.String(),
 		manifest.String(),
+		spineAttributes,
 		spine.String())
 
 	_, err = writer.Write([]byte(opf))
@@ -229,20 +310,28 @@ func (w *EPUBWriter) writeTOC(zw *zip.Writer, book *Book) error {
 %s  </navMap>
 </ncx>`,
 		generateUUID(),
-		escapeXML(book.Metadata.Title),
+		escapeXML(bookTitle(book)),
 		navMap.String())
 
 	_, err = writer.Write([]byte(ncx))
 	return err
 }
 
-// writeChapters writes chapter XHTML files
-func (w *EPUBWriter) writeChapters(zw *zip.Writer, book *Book) error {
+// writeChapters writes chapter XHTML files and returns the pages marked in them
+func (w *EPUBWriter) writeChapters(zw *zip.Writer, book *Book) ([]pageBreak, error) {
+	language := bookLanguage(book)
+	p := &paginator{}
+
+	stylesheet := ""
+	if w.stylesheet != "" {
+		stylesheet = `  <link rel="stylesheet" type="text/css" href="style.css"/>` + "\n"
+	}
+
 	for i, chapter := range book.Chapters {
-		filename := fmt.Sprintf("OEBPS/chapter%d.xhtml", i+1)
-		writer, err := zw.Create(filename)
+		p.file = fmt.Sprintf("chapter%d.xhtml", i+1)
+		writer, err := zw.Create("OEBPS/" + p.file)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		title := chapter.Title
@@ -250,37 +339,160 @@ func (w *EPUBWriter) writeChapters(zw *zip.Writer, book *Book) error {
 			title = fmt.Sprintf("Chapter %d", i+1)
 		}
 
+		// Every chapter starts on a new page
 		var content strings.Builder
-		for _, section := range chapter.Sections {
-			content.WriteString(w.formatSection(&section))
+		content.WriteString(p.newPage())
+		if w.chapterBody != nil {
+			content.WriteString(w.chapterBody(i))
+		} else {
+			content.WriteString(fmt.Sprintf("  <h1>%s</h1>\n", escapeXML(title)))
+			for _, section := range chapter.Sections {
+				content.WriteString(w.formatSectionPaged(&section, p))
+			}
+			for j := range chapter.Images {
+				image := &chapter.Images[j]
+				file := chapterImageFile(i, j, image)
+				if file != "" || image.Caption != "" || image.Text != "" {
+					content.WriteString(formatImage(image, file))
+				}
+			}
 		}
 
 		xhtml := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
-<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.1//EN" "http://www.w3.org/TR/xhtml11/DTD/xhtml11.dtd">
-<html xmlns="http://www.w3.org/1999/xhtml">
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops"%s>
 <head>
   <title>%s</title>
-  <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
-</head>
+  <meta charset="utf-8"/>
+%s</head>
 <body>
-  <h1>%s</h1>
-%s
-</body>
+%s</body>
 </html>`,
+			htmlLanguageAttributes(language),
 			escapeXML(title),
-			escapeXML(title),
+			stylesheet,
 			content.String())
 
 		if _, err := writer.Write([]byte(xhtml)); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return p.pages, nil
+}
+
+// writeNav writes OEBPS/nav.xhtml, the EPUB 3 navigation document with the
+// table of contents, landmarks and page list
+func (w *EPUBWriter) writeNav(zw *zip.Writer, book *Book, pages []pageBreak) error {
+	writer, err := zw.Create("OEBPS/nav.xhtml")
+	if err != nil {
+		return err
+	}
+
+	var toc strings.Builder
+	for i, chapter := range book.Chapters {
+		title := chapter.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		toc.WriteString(fmt.Sprintf("        <li><a href=\"chapter%d.xhtml\">%s</a></li>\n", i+1, escapeXML(title)))
+	}
+
+	var landmarks strings.Builder
+	landmarks.WriteString(`        <li><a epub:type="toc" href="nav.xhtml#toc">Table of Contents</a></li>` + "\n")
+	if len(book.Chapters) > 0 {
+		landmarks.WriteString(`        <li><a epub:type="bodymatter" href="chapter1.xhtml">Start of Content</a></li>` + "\n")
+	}
+
+	var pageList strings.Builder
+	for _, page := range pages {
+		pageList.WriteString(fmt.Sprintf("        <li><a href=\"%s#page%d\">%d</a></li>\n", page.file, page.number, page.number))
+	}
+
+	title := book.Metadata.Title
+	if title == "" {
+		title = "Contents"
+	}
+
+	nav := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops"%s>
+<head>
+  <title>%s</title>
+  <meta charset="utf-8"/>
+</head>
+<body>
+  <nav epub:type="toc" id="toc" role="doc-toc">
+    <h1>Table of Contents</h1>
+    <ol>
+%s    </ol>
+  </nav>
+  <nav epub:type="landmarks" id="landmarks" hidden="hidden">
+    <h2>Landmarks</h2>
+    <ol>
+%s    </ol>
+  </nav>
+  <nav epub:type="page-list" id="page-list" role="doc-pagelist" hidden="hidden">
+    <h2>Pages</h2>
+    <ol>
+%s    </ol>
+  </nav>
+</body>
+</html>`,
+		htmlLanguageAttributes(bookLanguage(book)),
+		escapeXML(title),
+		toc.String(),
+		landmarks.String(),
+		pageList.String())
+
+	_, err = writer.Write([]byte(nav))
+	return err
+}
+
+// pageLength is the number of characters after which a page break is marked
+// at the next paragraph, as the books have no print pages to follow
+const pageLength = 2000
+
+// pageBreak is a page break marker in a chapter file
+type pageBreak struct {
+	file   string
+	number int
+}
+
+// paginator marks page breaks while chapters are written
+type paginator struct {
+	file   string
+	pages  []pageBreak
+	length int
+}
+
+// newPage starts a page and returns its marker
+func (p *paginator) newPage() string {
+	number := len(p.pages) + 1
+	p.pages = append(p.pages, pageBreak{file: p.file, number: number})
+	p.length = 0
+	return fmt.Sprintf("  <span epub:type=\"pagebreak\" role=\"doc-pagebreak\" id=\"page%d\" aria-label=\"%d\"></span>\n", number, number)
+}
+
+// add counts text on the current page and returns a page break marker when
+// the page is full
+func (p *paginator) add(text string) string {
+	marker := ""
+	if p.length >= pageLength {
+		marker = p.newPage()
+	}
+	p.length += utf8.RuneCountInString(text)
+	return marker
 }
 
 // formatSection formats a section as HTML
 func (w *EPUBWriter) formatSection(section *Section) string {
+	return w.formatSectionPaged(section, nil)
+}
+
+// formatSectionPaged formats a section as HTML, marking page breaks before
+// paragraphs when a paginator is given
+func (w *EPUBWriter) formatSectionPaged(section *Section, p *paginator) string {
 	var sb strings.Builder
 
 	if section.Title != "" {
@@ -292,13 +504,16 @@ func (w *EPUBWriter) formatSection(section *Section) string {
 	for _, para := range paragraphs {
 		para = strings.TrimSpace(para)
 		if para != "" {
+			if p != nil {
+				sb.WriteString(p.add(para))
+			}
 			sb.WriteString(fmt.Sprintf("  <p>%s</p>\n", escapeXML(para)))
 		}
 	}
 
 	// Process subsections
 	for _, subsection := range section.Subsections {
-		sb.WriteString(w.formatSection(&subsection))
+		sb.WriteString(w.formatSectionPaged(&subsection, p))
 	}
 
 	return sb.String()
@@ -306,7 +521,8 @@ func (w *EPUBWriter) formatSection(section *Section) string {
 
 // writeCover writes the cover image file
 func (w *EPUBWriter) writeCover(zw *zip.Writer, coverData []byte) error {
-	writer, err := zw.Create("OEBPS/cover.jpg")
+	coverFile, _ := coverImageType(coverData)
+	writer, err := zw.Create("OEBPS/" + coverFile)
 	if err != nil {
 		return err
 	}
@@ -315,6 +531,103 @@ func (w *EPUBWriter) writeCover(zw *zip.Writer, coverData []byte) error {
 	return err
 }
 
+// writeImages writes the image files of the chapters
+func (w *EPUBWriter) writeImages(zw *zip.Writer, book *Book) error {
+	for i, chapter := range book.Chapters {
+		for j := range chapter.Images {
+			file := chapterImageFile(i, j, &chapter.Images[j])
+			if file == "" {
+				continue
+			}
+			writer, err := zw.Create("OEBPS/" + file)
+			if err != nil {
+				return err
+			}
+			if _, err := writer.Write(chapter.Images[j].Data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// chapterImageFile returns the file of image j of chapter i, or "" when the
+// image has no data
+func chapterImageFile(i, j int, image *Image) string {
+	if len(image.Data) == 0 {
+		return ""
+	}
+	ext, _ := imageType(image.Data)
+	return fmt.Sprintf("images/chapter%d-%d%s", i+1, j+1, ext)
+}
+
+// coverImageType returns the file name and media type of a cover image from
+// its signature; unknown images are taken for JPEG
+func coverImageType(data []byte) (string, string) {
+	ext, mediaType := imageType(data)
+	return "cover" + ext, mediaType
+}
+
+// accessibilityMetadata returns the schema.org accessibility metadata of the
+// books the writer produces: text in reading order with navigation and
+// synthetic page markers, plus a visual mode for the cover and images when
+// present
+func accessibilityMetadata(hasImages bool) string {
+	var sb strings.Builder
+	sb.WriteString("    <meta property=\"schema:accessMode\">textual</meta>\n")
+	if hasImages {
+		sb.WriteString("    <meta property=\"schema:accessMode\">visual</meta>\n")
+	}
+	sb.WriteString("    <meta property=\"schema:accessModeSufficient\">textual</meta>\n")
+	for _, feature := range []string{"structuralNavigation", "tableOfContents", "readingOrder", "pageNavigation", "pageBreakMarkers"} {
+		sb.WriteString(fmt.Sprintf("    <meta property=\"schema:accessibilityFeature\">%s</meta>\n", feature))
+	}
+	sb.WriteString("    <meta property=\"schema:accessibilityHazard\">none</meta>\n")
+	// Page breaks are generated, not taken from a print edition, which the
+	// summary tells readers as well
+	sb.WriteString(fmt.Sprintf("    <meta property=\"schema:accessibilitySummary\">Text with headings, a table of contents and page markers in reading order. "+
+		"The page numbers are synthetic: a page is marked about every %d characters and does not correspond to any print edition.</meta>\n", pageLength))
+	sb.WriteString("    <meta property=\"a11y:pageBreakSource\">none</meta>\n")
+	return sb.String()
+}
+
+// rightToLeftLanguages are the languages written right to left
+var rightToLeftLanguages = map[string]bool{
+	"ar": true, "he": true, "fa": true, "ur": true, "yi": true,
+}
+
+// isRightToLeft reports whether the language tag names a right to left language
+func isRightToLeft(lang string) bool {
+	primary, _, _ := strings.Cut(strings.ToLower(lang), "-")
+	return rightToLeftLanguages[primary]
+}
+
+// bookTitle returns the title of the book, as dc:title must not be empty
+func bookTitle(book *Book) string {
+	if strings.TrimSpace(book.Metadata.Title) == "" {
+		return "Untitled"
+	}
+	return book.Metadata.Title
+}
+
+// bookLanguage returns the language of the book, English when not set
+func bookLanguage(book *Book) string {
+	if book.Metadata.Language == "" {
+		return "en"
+	}
+	return book.Metadata.Language
+}
+
+// htmlLanguageAttributes returns the language and direction attributes of a
+// content document's html element
+func htmlLanguageAttributes(lang string) string {
+	attributes := fmt.Sprintf(` xml:lang="%s" lang="%s"`, escapeXML(lang), escapeXML(lang))
+	if isRightToLeft(lang) {
+		attributes += ` dir="rtl"`
+	}
+	return attributes
+}
+
 // escapeXML escapes XML special characters
 func escapeXML(s string) string {
 	s = strings.ReplaceAll(s, "&", "&amp;")
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -307,6 +308,145 @@ func TestFormatSection(t *testing.T) {
 	}
 }
 
+func TestEPUBWriter_Write_EPUB3Package(t *testing.T) {
+	writer := NewEPUBWriter()
+
+	paragraph := strings.Repeat("word ", 300)
+	book := &Book{
+		Metadata: Metadata{
+			Title:    "Modern Book",
+			Authors:  []string{"Author"},
+			Language: "de",
+			Cover:    []byte("\x89PNG\r\n\x1a\nrest of the image"),
+		},
+		Chapters: []Chapter{
+			{Title: "One", Sections: []Section{{Content: paragraph + "\n\n" + paragraph + "\n\n" + paragraph}}},
+			{Title: "Two & Three", Sections: []Section{{Content: "Short."}}},
+		},
+		Format: format.FormatEPUB,
+	}
+
+	tmpFile := createTempEPUBWriterFile(t, "test_epub3.epub")
+	defer os.Remove(tmpFile)
+
+	if err := writer.Write(book, tmpFile); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	files, _ := readEntries(t, tmpFile)
+
+	opf := string(files["OEBPS/content.opf"])
+	for _, expected := range []string{
+		`version="3.0"`,
+		`<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>`,
+		`<item id="cover-image" href="cover.png" media-type="image/png" properties="cover-image"/>`,
+		`<meta property="dcterms:modified">`,
+		`<meta property="schema:accessMode">textual</meta>`,
+		`<meta property="schema:accessMode">visual</meta>`,
+		`<meta property="schema:accessModeSufficient">textual</meta>`,
+		`<meta property="schema:accessibilityFeature">tableOfContents</meta>`,
+		`<meta property="schema:accessibilityHazard">none</meta>`,
+		`<meta property="schema:accessibilitySummary">`,
+		`page numbers are synthetic`,
+		`<meta property="a11y:pageBreakSource">none</meta>`,
+		`<spine toc="ncx">`,
+	} {
+		if !strings.Contains(opf, expected) {
+			t.Errorf("content.opf lacks %q", expected)
+		}
+	}
+	if !regexp.MustCompile(`<meta property="dcterms:modified">\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z</meta>`).MatchString(opf) {
+		t.Error("dcterms:modified is not a UTC timestamp")
+	}
+	if _, ok := files["OEBPS/cover.png"]; !ok {
+		t.Error("cover.png not found in EPUB")
+	}
+
+	nav := string(files["OEBPS/nav.xhtml"])
+	for _, expected := range []string{
+		`<nav epub:type="toc" id="toc" role="doc-toc">`,
+		`<li><a href="chapter2.xhtml">Two &amp; Three</a></li>`,
+		`<nav epub:type="landmarks"`,
+		`<a epub:type="bodymatter" href="chapter1.xhtml">`,
+		`<nav epub:type="page-list"`,
+		`<li><a href="chapter1.xhtml#page2">2</a></li>`,
+		`<li><a href="chapter2.xhtml#page3">3</a></li>`,
+	} {
+		if !strings.Contains(nav, expected) {
+			t.Errorf("nav.xhtml lacks %q", expected)
+		}
+	}
+
+	chapter := string(files["OEBPS/chapter1.xhtml"])
+	if !strings.Contains(chapter, `<!DOCTYPE html>`) || !strings.Contains(chapter, `xml:lang="de" lang="de"`) {
+		t.Errorf("chapter is not an EPUB 3 content document:\n%s", chapter)
+	}
+	if strings.Count(chapter, `epub:type="pagebreak"`) != 2 {
+		t.Errorf("expected 2 page breaks in chapter 1:\n%s", chapter)
+	}
+
+	// The book still reads back
+	parsed, err := NewEPUBParser().Parse(tmpFile)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(parsed.Chapters) != 2 {
+		t.Errorf("expected 2 chapters, got %d", len(parsed.Chapters))
+	}
+	if string(parsed.Metadata.Cover) != string(book.Metadata.Cover) {
+		t.Error("cover does not read back")
+	}
+}
+
+func TestEPUBWriter_Write_RightToLeft(t *testing.T) {
+	for _, lang := range []string{"ar", "he", "en"} {
+		t.Run(lang, func(t *testing.T) {
+			book := &Book{
+				Metadata: Metadata{Title: "Book", Language: lang},
+				Chapters: []Chapter{{Title: "One", Sections: []Section{{Content: "Text."}}}},
+			}
+
+			tmpFile := createTempEPUBWriterFile(t, "test_rtl.epub")
+			defer os.Remove(tmpFile)
+
+			if err := NewEPUBWriter().Write(book, tmpFile); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			files, _ := readEntries(t, tmpFile)
+
+			rtl := lang != "en"
+			if got := strings.Contains(string(files["OEBPS/content.opf"]), `page-progression-direction="rtl"`); got != rtl {
+				t.Errorf("page-progression-direction rtl = %v, want %v", got, rtl)
+			}
+			if got := strings.Contains(string(files["OEBPS/chapter1.xhtml"]), `dir="rtl"`); got != rtl {
+				t.Errorf("dir rtl = %v, want %v", got, rtl)
+			}
+		})
+	}
+}
+
+func TestCoverImageType(t *testing.T) {
+	tests := []struct {
+		data      []byte
+		file      string
+		mediaType string
+	}{
+		{[]byte("\x89PNG\r\n\x1a\n...."), "cover.png", "image/png"},
+		{[]byte("RIFF\x00\x00\x00\x00WEBPVP8 "), "cover.webp", "image/webp"},
+		{[]byte("GIF89a...."), "cover.gif", "image/gif"},
+		{[]byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"/>`), "cover.svg", "image/svg+xml"},
+		{[]byte("\xff\xd8\xff\xe0...."), "cover.jpg", "image/jpeg"},
+		{[]byte("unknown"), "cover.jpg", "image/jpeg"},
+	}
+
+	for _, tt := range tests {
+		file, mediaType := coverImageType(tt.data)
+		if file != tt.file || mediaType != tt.mediaType {
+			t.Errorf("coverImageType(%q) = %s, %s; want %s, %s", tt.data, file, mediaType, tt.file, tt.mediaType)
+		}
+	}
+}
+
 // Helper functions
 
 func createTempEPUBWriterFile(t *testing.T, filename string) string {
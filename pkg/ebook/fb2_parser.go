@@ -3,6 +3,7 @@ package ebook
 import (
 	"digital.vasic.translator/pkg/fb2"
 	"digital.vasic.translator/pkg/format"
+	"strings"
 )
 
 // FB2Parser implements Parser for FB2 format
@@ -44,10 +45,15 @@ func (p *FB2Parser) Parse(filename string) (*Book, error) {
 		}
 	}
 
+	// Cover image
+	if binary, ok := fb2Book.GetBinary(fb2Book.Description.TitleInfo.Coverpage.Image.Href); ok {
+		book.Metadata.Cover, _ = binary.Decode()
+	}
+
 	// Convert FB2 body sections to chapters
 	for _, body := range fb2Book.Body {
 		for _, fb2Section := range body.Section {
-			chapter := convertFB2Section(&fb2Section)
+			chapter := convertFB2Section(&fb2Section, fb2Book)
 			book.Chapters = append(book.Chapters, chapter)
 		}
 	}
@@ -55,8 +61,9 @@ func (p *FB2Parser) Parse(filename string) (*Book, error) {
 	return book, nil
 }
 
-// convertFB2Section converts FB2 section to universal Chapter
-func convertFB2Section(fb2Sec *fb2.Section) Chapter {
+// convertFB2Section converts FB2 section to universal Chapter; the images
+// of the section and its subsections become images of the chapter
+func convertFB2Section(fb2Sec *fb2.Section, fb2Book *fb2.FictionBook) Chapter {
 	chapter := Chapter{
 		Sections: make([]Section, 0),
 	}
@@ -77,9 +84,21 @@ func convertFB2Section(fb2Sec *fb2.Section) Chapter {
 
 	chapter.Sections = append(chapter.Sections, section)
 
+	for _, img := range fb2Sec.Image {
+		image := Image{ID: strings.TrimPrefix(img.Href, "#"), Alt: img.Alt, Title: img.Title}
+		if binary, ok := fb2Book.GetBinary(img.Href); ok {
+			if data, err := binary.Decode(); err == nil {
+				image.Data = data
+				image.MediaType = binary.ContentType
+			}
+		}
+		chapter.Images = append(chapter.Images, image)
+	}
+
 	// Convert subsections
 	for _, subSec := range fb2Sec.Section {
-		subChapter := convertFB2Section(&subSec)
+		subChapter := convertFB2Section(&subSec, fb2Book)
+		chapter.Images = append(chapter.Images, subChapter.Images...)
 		// Create subsections from the sub-chapter
 		if len(subChapter.Sections) > 0 {
 			for _, subSection := range subChapter.Sections {
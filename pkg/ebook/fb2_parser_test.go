@@ -355,7 +355,7 @@ func TestConvertFB2Section(t *testing.T) {
 		},
 	}
 
-	chapter := convertFB2Section(fb2Section)
+	chapter := convertFB2Section(fb2Section, &fb2.FictionBook{})
 
 	if chapter.Title != "Test Chapter" {
 		t.Errorf("Chapter title = %s, want Test Chapter", chapter.Title)
@@ -399,7 +399,7 @@ func TestConvertFB2Section_EmptyTitle(t *testing.T) {
 		},
 	}
 
-	chapter := convertFB2Section(fb2Section)
+	chapter := convertFB2Section(fb2Section, &fb2.FictionBook{})
 
 	if chapter.Title != "" {
 		t.Errorf("Chapter title = %s, want empty string", chapter.Title)
@@ -0,0 +1,402 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Image is a picture of a chapter with the text describing it
+type Image struct {
+	ID        string // name of the image in the source book
+	MediaType string
+	Data      []byte
+	Alt       string // alternative text
+	Title     string
+	Caption   string
+	Text      string // text recognized in the image, if any
+}
+
+// Translation contexts of image texts passed to TranslateFunc
+const (
+	// ImageContext is passed for alternative texts, titles and captions
+	ImageContext = "Description of an image in a book"
+
+	// ImageTextContext is passed for text recognized in an image
+	ImageTextContext = "Text shown in an image of a book"
+)
+
+// ImageTextRecognizer extracts the text shown in an image, e.g. by OCR
+type ImageTextRecognizer interface {
+	RecognizeText(ctx context.Context, data []byte, mediaType string) (string, error)
+}
+
+// TesseractRecognizer recognizes text with the tesseract command line tool
+type TesseractRecognizer struct {
+	// Command is the tesseract executable, "tesseract" by default
+	Command string
+
+	// Languages are tesseract language codes joined by "+", e.g.
+	// "eng+rus"; tesseract uses English when empty
+	Languages string
+}
+
+// NewTesseractRecognizer creates a recognizer for the given tesseract
+// languages
+func NewTesseractRecognizer(languages string) *TesseractRecognizer {
+	return &TesseractRecognizer{Command: "tesseract", Languages: languages}
+}
+
+// Available reports whether the tesseract command can be found
+func (t *TesseractRecognizer) Available() bool {
+	_, err := exec.LookPath(t.command())
+	return err == nil
+}
+
+func (t *TesseractRecognizer) command() string {
+	if t.Command == "" {
+		return "tesseract"
+	}
+	return t.Command
+}
+
+// RecognizeText runs tesseract on the image and returns the text found,
+// with lines joined into paragraphs
+func (t *TesseractRecognizer) RecognizeText(ctx context.Context, data []byte, mediaType string) (string, error) {
+	if mediaType == "image/svg+xml" {
+		return "", fmt.Errorf("tesseract cannot read %s images", mediaType)
+	}
+
+	args := []string{"stdin", "stdout"}
+	if t.Languages != "" {
+		args = append(args, "-l", t.Languages)
+	}
+	cmd := exec.CommandContext(ctx, t.command(), args...)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return recognizedParagraphs(stdout.String()), nil
+}
+
+// recognizedParagraphs joins the lines of recognized text into paragraphs
+// separated by blank lines, dropping form feeds between pages
+func recognizedParagraphs(text string) string {
+	var paragraphs []string
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\f", "\n\n"), "\n\n") {
+		if para = strings.Join(strings.Fields(para), " "); para != "" {
+			paragraphs = append(paragraphs, para)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// imageType returns the file extension and media type of an image from its
+// signature; unknown images are taken for JPEG
+func imageType(data []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return ".png", "image/png"
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return ".webp", "image/webp"
+	case bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a")):
+		return ".gif", "image/gif"
+	case bytes.Contains(data[:min(len(data), 512)], []byte("<svg")):
+		return ".svg", "image/svg+xml"
+	default:
+		return ".jpg", "image/jpeg"
+	}
+}
+
+// figureElements matches the figures and images of a chapter document,
+// which become images of the chapter instead of text
+var figureElements = regexp.MustCompile(`(?is)<figure[\s>].*?</figure\s*>|<img\s[^>]*>`)
+
+// parseChapterImages returns the images of the XHTML document name, with
+// their data read from files. A figure caption goes with the first image of
+// its figure.
+func parseChapterImages(data []byte, name string, files map[string]*zip.File) []Image {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	var images []Image
+	var walk func(n *html.Node, figure *html.Node)
+	captioned := make(map[*html.Node]bool)
+	walk = func(n *html.Node, figure *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "figure":
+				figure = n
+			case "img":
+				image := Image{
+					Alt:   htmlAttribute(n, "alt"),
+					Title: htmlAttribute(n, "title"),
+				}
+				if figure != nil && !captioned[figure] {
+					captioned[figure] = true
+					image.Caption = figureCaption(figure)
+				}
+				if src := htmlAttribute(n, "src"); src != "" && !isRemote(src) {
+					image.ID, _ = resolveHref(path.Dir(name), src)
+					if f, ok := files[image.ID]; ok {
+						if image.Data, err = readZipFile(f); err == nil {
+							_, image.MediaType = imageType(image.Data)
+						}
+					}
+				}
+				images = append(images, image)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, figure)
+		}
+	}
+	walk(root, nil)
+	return images
+}
+
+// figureCaption returns the text of the caption of a figure
+func figureCaption(figure *html.Node) string {
+	for c := figure.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "figcaption" {
+			return strings.Join(strings.Fields(htmlText(c)), " ")
+		}
+	}
+	return ""
+}
+
+func htmlText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(htmlText(c))
+		sb.WriteString(" ")
+	}
+	return sb.String()
+}
+
+func htmlAttribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
+
+// formatImage formats an image of a chapter written to file as a figure. A
+// caption is taken from the recognized text when the image has none;
+// otherwise the recognized text follows the figure as a note.
+func formatImage(image *Image, file string) string {
+	caption, note := image.Caption, image.Text
+	if caption == "" {
+		caption, note = note, ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("  <figure>\n")
+	if file != "" {
+		sb.WriteString(fmt.Sprintf("    <img src=\"%s\" alt=\"%s\"", escapeXML(file), escapeXML(image.Alt)))
+		if image.Title != "" {
+			sb.WriteString(fmt.Sprintf(" title=\"%s\"", escapeXML(image.Title)))
+		}
+		sb.WriteString("/>\n")
+	}
+	if caption != "" {
+		sb.WriteString(fmt.Sprintf("    <figcaption>%s</figcaption>\n", escapeXML(caption)))
+	}
+	sb.WriteString("  </figure>\n")
+	if note != "" {
+		sb.WriteString(fmt.Sprintf("  <aside class=\"image-text\" role=\"note\">%s</aside>\n", escapeXML(note)))
+	}
+	return sb.String()
+}
+
+// In-place image texts
+
+// markupImage is an img element of a markup document
+type markupImage struct {
+	token  int // start tag
+	last   int // last token of the element
+	figure int // start tag of the enclosing figure, -1 if none
+	src    string
+
+	attributes []markupAttribute // alt and title attributes with text
+}
+
+// markupAttribute is an attribute value of a start tag
+type markupAttribute struct {
+	start, end int // value range in the tag, without quotes
+	text       string
+}
+
+// imageAttribute matches the attributes of an img tag whose text is
+// translated, and its src
+var imageAttribute = regexp.MustCompile(`\s(alt|title|src)\s*=\s*("[^"]*"|'[^']*')`)
+
+// collectImages finds the img elements of the document
+func (doc *markupDocument) collectImages() {
+	doc.tags = make(map[int][]byte)
+	doc.before = make(map[int][]byte)
+	doc.after = make(map[int][]byte)
+
+	var figures []int
+	for i, tok := range doc.tokens {
+		switch {
+		case tok.name == "figure" && tok.kind == tokenStart:
+			figures = append(figures, i)
+		case tok.name == "figure" && tok.kind == tokenEnd && len(figures) > 0:
+			figures = figures[:len(figures)-1]
+		case tok.name == "img" && tok.kind == tokenStart:
+			image := &markupImage{token: i, last: i, figure: -1}
+			if tok.mate == i+1 {
+				image.last = i + 1
+			}
+			if len(figures) > 0 {
+				image.figure = figures[len(figures)-1]
+			}
+			raw := doc.data[tok.start:tok.end]
+			for _, m := range imageAttribute.FindAllSubmatchIndex(raw, -1) {
+				name := string(raw[m[2]:m[3]])
+				value := html.UnescapeString(string(raw[m[4]+1 : m[5]-1]))
+				switch {
+				case name == "src":
+					image.src = value
+				case strings.TrimSpace(value) != "":
+					image.attributes = append(image.attributes, markupAttribute{start: m[4] + 1, end: m[5] - 1, text: value})
+				}
+			}
+			doc.images = append(doc.images, image)
+		}
+	}
+}
+
+// archiveImage is an image of an archive document
+type archiveImage struct {
+	image *markupImage
+	name  string
+	doc   *markupDocument
+}
+
+// translateImage translates the alt and title attributes of an image and,
+// with a recognizer, adds the translation of the text shown in it
+func (t *EPUBInPlaceTranslator) translateImage(
+	ctx context.Context,
+	image archiveImage,
+	files map[string]*zip.File,
+	cache, recognized map[string]string,
+	stats *EPUBInPlaceStats,
+	progress func(),
+) error {
+	doc, img := image.doc, image.image
+
+	if len(img.attributes) > 0 {
+		tag := doc.data[doc.tokens[img.token].start:doc.tokens[img.token].end]
+		var out []byte
+		pos := 0
+		for _, attr := range img.attributes {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			translated, err := cachedTranslate(ctx, t.translate, cache, whitespace.ReplaceAllString(strings.TrimSpace(attr.text), " "), ImageContext)
+			if err != nil {
+				return err
+			}
+			out = append(append(out, tag[pos:attr.start]...), escapeXML(translated)...)
+			pos = attr.end
+			stats.Attributes++
+			progress()
+		}
+		doc.tags[img.token] = append(out, tag[pos:]...)
+	}
+
+	if t.Recognizer == nil {
+		return nil
+	}
+	defer progress()
+	if img.src == "" || isRemote(img.src) {
+		return nil
+	}
+	name, err := resolveHref(path.Dir(image.name), img.src)
+	if err != nil {
+		return nil
+	}
+	text, ok := recognized[name]
+	if !ok {
+		f, found := files[name]
+		if !found {
+			return nil
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return err
+		}
+		_, mediaType := imageType(data)
+		if text, err = t.Recognizer.RecognizeText(ctx, data, mediaType); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			stats.Unrecognized++
+		}
+		recognized[name] = text
+	}
+	if text == "" {
+		return nil
+	}
+
+	var paragraphs []string
+	for _, para := range strings.Split(text, "\n\n") {
+		translated, err := cachedTranslate(ctx, t.translate, cache, para, ImageTextContext)
+		if err != nil {
+			return err
+		}
+		paragraphs = append(paragraphs, escapeText(translated))
+	}
+	doc.addImageText(img, strings.Join(paragraphs, "<br/>"))
+	stats.ImageTexts++
+	return nil
+}
+
+// addImageText adds the text of an image as the caption of its figure when
+// the figure has none, or as a note after the image
+func (doc *markupDocument) addImageText(img *markupImage, text string) {
+	if img.figure >= 0 && doc.tokens[img.figure].mate > img.figure && !doc.hasCaption(img.figure) {
+		end := doc.tokens[img.figure].mate
+		doc.insert(doc.before, end, []byte(`<figcaption class="image-text">`+text+`</figcaption>`))
+		return
+	}
+	doc.insert(doc.after, img.last, []byte(`<span class="image-text">`+text+`</span>`))
+}
+
+// hasCaption reports whether the figure starting at token figure has a
+// caption, including one added by addImageText
+func (doc *markupDocument) hasCaption(figure int) bool {
+	end := doc.tokens[figure].mate
+	if doc.before[end] != nil {
+		return true
+	}
+	for i := figure + 1; i < end; i++ {
+		if doc.tokens[i].kind == tokenStart && doc.tokens[i].name == "figcaption" {
+			return true
+		}
+	}
+	return false
+}
+
+func (doc *markupDocument) insert(at map[int][]byte, i int, markup []byte) {
+	at[i] = append(at[i], markup...)
+}
@@ -0,0 +1,304 @@
+package ebook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRecognizer returns the text registered for an image's data
+type fakeRecognizer map[string]string
+
+func (r fakeRecognizer) RecognizeText(ctx context.Context, data []byte, mediaType string) (string, error) {
+	text, ok := r[string(data)]
+	if !ok {
+		return "", errors.New("unreadable image")
+	}
+	return text, nil
+}
+
+var (
+	figurePNG = append(append([]byte{}, inPlaceImage...), 'f')
+	signPNG   = append(append([]byte{}, inPlaceImage...), 's')
+	brokenPNG = append(append([]byte{}, inPlaceImage...), 'b')
+)
+
+const imagesOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Slike</dc:title>
+    <dc:language>en</dc:language>
+    <dc:identifier id="id">urn:uuid:2</dc:identifier>
+    <meta property="dcterms:modified">2024-01-01T00:00:00Z</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ch1" href="Text/ch1.xhtml" media-type="application/xhtml+xml"/>
+    <item id="figure" href="Images/figure.png" media-type="image/png"/>
+    <item id="sign" href="Images/sign.png" media-type="image/png"/>
+    <item id="broken" href="Images/broken.png" media-type="image/png"/>
+  </manifest>
+  <spine>
+    <itemref idref="ch1"/>
+  </spine>
+</package>`
+
+const imagesChapter = `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+<head><title>Pictures</title></head>
+<body>
+  <p>Before the map.</p>
+  <figure><img src="../Images/figure.png" alt="A map" title="The &amp; map"/></figure>
+  <figure><img src="../Images/sign.png" alt=""/><figcaption>A <em>road</em> sign</figcaption></figure>
+  <p>See <img src="../Images/broken.png" alt="icon"/> here.</p>
+</body>
+</html>`
+
+const imagesNav = `<?xml version="1.0" encoding="utf-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>Contents</title></head>
+<body><nav epub:type="toc"><ol><li><a href="Text/ch1.xhtml">Pictures</a></li></ol></nav></body>
+</html>`
+
+func createImagesEPUB(t *testing.T) string {
+	t.Helper()
+
+	return writeTestZip(t, []testZipEntry{
+		{"mimetype", "application/epub+zip", zip.Store},
+		{"META-INF/container.xml", validatorContainer, zip.Deflate},
+		{"OEBPS/content.opf", imagesOPF, zip.Deflate},
+		{"OEBPS/nav.xhtml", imagesNav, zip.Deflate},
+		{"OEBPS/Text/ch1.xhtml", imagesChapter, zip.Deflate},
+		{"OEBPS/Images/figure.png", string(figurePNG), zip.Store},
+		{"OEBPS/Images/sign.png", string(signPNG), zip.Store},
+		{"OEBPS/Images/broken.png", string(brokenPNG), zip.Store},
+	})
+}
+
+func TestEPUBInPlaceTranslator_ImageTexts(t *testing.T) {
+	input := createImagesEPUB(t)
+	output := filepath.Join(t.TempDir(), "translated.epub")
+
+	var contexts []string
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		contexts = append(contexts, context)
+		return upperTranslate(ctx, text, context)
+	}
+	translator := NewEPUBInPlaceTranslator(translate, "sr")
+	translator.Recognizer = fakeRecognizer{
+		string(figurePNG): "North\n\nSouth",
+		string(signPNG):   "Stop & go",
+	}
+
+	stats, err := translator.Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Attributes != 3 || stats.ImageTexts != 2 || stats.Unrecognized != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.Validation == nil || len(stats.Validation.Issues) != 0 {
+		t.Errorf("unexpected validation of the output: %+v", stats.Validation)
+	}
+	if contexts[0] != ImageContext {
+		t.Errorf("first context = %q", contexts[0])
+	}
+
+	translated, _ := readEntries(t, output)
+	chapter := string(translated["OEBPS/Text/ch1.xhtml"])
+	for _, expected := range []string{
+		`<figure><img src="../Images/figure.png" alt="A MAP" title="THE &amp; MAP"/><figcaption class="image-text">NORTH<br/>SOUTH</figcaption></figure>`,
+		`<figure><img src="../Images/sign.png" alt=""/><span class="image-text">STOP &amp; GO</span><figcaption>A <em>ROAD</em> SIGN</figcaption></figure>`,
+		`<p>SEE <img src="../Images/broken.png" alt="ICON"/> HERE.</p>`,
+	} {
+		if !strings.Contains(chapter, expected) {
+			t.Errorf("chapter lacks %q:\n%s", expected, chapter)
+		}
+	}
+}
+
+func TestEPUBParser_Images(t *testing.T) {
+	book, err := NewEPUBParser().Parse(createImagesEPUB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Chapters) != 1 {
+		t.Fatalf("got %d chapters", len(book.Chapters))
+	}
+	chapter := book.Chapters[0]
+
+	content := chapter.Sections[0].Content
+	if strings.Contains(content, "road") || strings.Contains(content, "img") || !strings.Contains(content, "Before the map.") {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	expected := []Image{
+		{ID: "OEBPS/Images/figure.png", MediaType: "image/png", Data: figurePNG, Alt: "A map", Title: "The & map"},
+		{ID: "OEBPS/Images/sign.png", MediaType: "image/png", Data: signPNG, Caption: "A road sign"},
+		{ID: "OEBPS/Images/broken.png", MediaType: "image/png", Data: brokenPNG, Alt: "icon"},
+	}
+	if len(chapter.Images) != len(expected) {
+		t.Fatalf("got %d images", len(chapter.Images))
+	}
+	for i, image := range chapter.Images {
+		want := expected[i]
+		if image.ID != want.ID || image.MediaType != want.MediaType || !bytes.Equal(image.Data, want.Data) ||
+			image.Alt != want.Alt || image.Title != want.Title || image.Caption != want.Caption {
+			t.Errorf("image %d = %+v, want %+v", i, image, want)
+		}
+	}
+}
+
+func TestEPUBWriter_Images(t *testing.T) {
+	book := &Book{
+		Metadata: Metadata{Title: "Pictures", Language: "en"},
+		Chapters: []Chapter{{
+			Title:    "One",
+			Sections: []Section{{Content: "Text."}},
+			Images: []Image{
+				{Data: figurePNG, Alt: "A map", Title: "Map", Caption: "The map"},
+				{Data: signPNG, Alt: "A sign", Caption: "Sign", Text: "Stop"},
+				{Data: brokenPNG, Alt: "Icon", Text: "Go"},
+				{Caption: "Lost image"},
+			},
+		}},
+	}
+	filename := filepath.Join(t.TempDir(), "images.epub")
+	if err := NewEPUBWriter().Write(book, filename); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	entries, _ := readEntries(t, filename)
+	if !bytes.Equal(entries["OEBPS/images/chapter1-2.png"], signPNG) {
+		t.Error("image file not written")
+	}
+	opf := string(entries["OEBPS/content.opf"])
+	if !strings.Contains(opf, `<item id="image-1-1" href="images/chapter1-1.png" media-type="image/png"/>`) ||
+		!strings.Contains(opf, `<meta property="schema:accessMode">visual</meta>`) {
+		t.Errorf("unexpected package document:\n%s", opf)
+	}
+
+	chapter := string(entries["OEBPS/chapter1.xhtml"])
+	for _, expected := range []string{
+		"<img src=\"images/chapter1-1.png\" alt=\"A map\" title=\"Map\"/>\n    <figcaption>The map</figcaption>",
+		"<figcaption>Sign</figcaption>\n  </figure>\n  <aside class=\"image-text\" role=\"note\">Stop</aside>",
+		"<img src=\"images/chapter1-3.png\" alt=\"Icon\"/>\n    <figcaption>Go</figcaption>",
+		"<figure>\n    <figcaption>Lost image</figcaption>\n  </figure>",
+	} {
+		if !strings.Contains(chapter, expected) {
+			t.Errorf("chapter lacks %q:\n%s", expected, chapter)
+		}
+	}
+
+	// The images survive another round
+	parsed, err := NewEPUBParser().Parse(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if images := parsed.Chapters[0].Images; len(images) != 3 || images[0].Alt != "A map" || images[0].Caption != "The map" {
+		t.Errorf("unexpected images after parsing %+v", images)
+	}
+}
+
+func TestFB2Parser_Images(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString(figurePNG)
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+  <description>
+    <title-info>
+      <book-title>Pictures</book-title>
+      <coverpage><image l:href="#cover.png"/></coverpage>
+      <lang>en</lang>
+    </title-info>
+  </description>
+  <body>
+    <section>
+      <title><p>One</p></title>
+      <p>Text.</p>
+      <image l:href="#map.png" alt="A map" title="The map"/>
+      <section>
+        <title><p>Two</p></title>
+        <image l:href="#missing.png"/>
+      </section>
+    </section>
+  </body>
+  <binary id="map.png" content-type="image/png">` + data[:8] + "\n  " + data[8:] + `</binary>
+  <binary id="cover.png" content-type="image/png">` + data + `</binary>
+</FictionBook>`
+
+	filename := filepath.Join(t.TempDir(), "images.fb2")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	book, err := NewFB2Parser().Parse(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(book.Metadata.Cover, figurePNG) {
+		t.Error("cover not decoded")
+	}
+	images := book.Chapters[0].Images
+	if len(images) != 2 {
+		t.Fatalf("got %d images", len(images))
+	}
+	if images[0].ID != "map.png" || images[0].Alt != "A map" || images[0].Title != "The map" ||
+		images[0].MediaType != "image/png" || !bytes.Equal(images[0].Data, figurePNG) {
+		t.Errorf("unexpected image %+v", images[0])
+	}
+	if images[1].ID != "missing.png" || images[1].Data != nil {
+		t.Errorf("unexpected image %+v", images[1])
+	}
+}
+
+func TestTesseractRecognizer(t *testing.T) {
+	dir := t.TempDir()
+	command := filepath.Join(dir, "tesseract")
+	script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(dir, "args") + "\ncat > " + filepath.Join(dir, "stdin") +
+		"\nprintf 'Line one\\nline  two\\n\\n\\nSecond\\n\\f'\n"
+	if err := os.WriteFile(command, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	recognizer := &TesseractRecognizer{Command: command, Languages: "eng+rus"}
+	if !recognizer.Available() {
+		t.Fatal("fake tesseract not available")
+	}
+	text, err := recognizer.RecognizeText(context.Background(), figurePNG, "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "Line one line two\n\nSecond" {
+		t.Errorf("text = %q", text)
+	}
+	if args, _ := os.ReadFile(filepath.Join(dir, "args")); string(args) != "stdin stdout -l eng+rus\n" {
+		t.Errorf("args = %q", args)
+	}
+	if stdin, _ := os.ReadFile(filepath.Join(dir, "stdin")); !bytes.Equal(stdin, figurePNG) {
+		t.Error("image not passed on stdin")
+	}
+
+	if _, err := recognizer.RecognizeText(context.Background(), []byte("<svg/>"), "image/svg+xml"); err == nil {
+		t.Error("expected an error for SVG")
+	}
+	if _, err := (&TesseractRecognizer{Command: filepath.Join(dir, "missing")}).RecognizeText(context.Background(), figurePNG, "image/png"); err == nil {
+		t.Error("expected an error for a missing command")
+	}
+}
+
+func TestTesseractRecognizer_Installed(t *testing.T) {
+	recognizer := NewTesseractRecognizer("eng")
+	if !recognizer.Available() {
+		t.Skip("tesseract is not installed")
+	}
+	// Not an image tesseract can read
+	if _, err := recognizer.RecognizeText(context.Background(), []byte("not an image"), "image/png"); err == nil {
+		t.Error("expected an error for data that is not an image")
+	}
+}
@@ -1,8 +1,12 @@
 package ebook
 
 import (
+	"context"
 	"digital.vasic.translator/pkg/format"
+	"digital.vasic.translator/pkg/tracing"
 	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Book represents a universal ebook structure
@@ -29,6 +33,7 @@ type Metadata struct {
 type Chapter struct {
 	Title    string
 	Sections []Section
+	Images   []Image
 }
 
 // Section represents a chapter section
@@ -38,6 +43,35 @@ type Section struct {
 	Subsections []Section
 }
 
+// Clone returns a deep copy of the book, e.g. to keep the original of a
+// book that is translated in place
+func (b *Book) Clone() *Book {
+	clone := *b
+	clone.Metadata.Authors = append([]string(nil), b.Metadata.Authors...)
+	clone.Metadata.Cover = append([]byte(nil), b.Metadata.Cover...)
+	clone.Chapters = make([]Chapter, len(b.Chapters))
+	for i, chapter := range b.Chapters {
+		clone.Chapters[i] = Chapter{
+			Title:    chapter.Title,
+			Sections: cloneSections(chapter.Sections),
+			Images:   append([]Image(nil), chapter.Images...),
+		}
+	}
+	return &clone
+}
+
+func cloneSections(sections []Section) []Section {
+	if sections == nil {
+		return nil
+	}
+	clone := make([]Section, len(sections))
+	for i, section := range sections {
+		clone[i] = section
+		clone[i].Subsections = cloneSections(section.Subsections)
+	}
+	return clone
+}
+
 // Parser interface for different ebook formats
 type Parser interface {
 	Parse(filename string) (*Book, error)
@@ -64,6 +98,9 @@ func NewUniversalParser() *UniversalParser {
 	up.parsers[format.FormatHTML] = NewHTMLParser()
 	up.parsers[format.FormatPDF] = NewPDFParser(nil)
 	up.parsers[format.FormatDOCX] = NewDOCXParser(nil)
+	up.parsers[format.FormatSRT] = NewSubtitleParser(format.FormatSRT)
+	up.parsers[format.FormatVTT] = NewSubtitleParser(format.FormatVTT)
+	up.parsers[format.FormatASS] = NewSubtitleParser(format.FormatASS)
 
 	return up
 }
@@ -79,6 +116,23 @@ func (up *UniversalParser) DebugParsers() map[string]string {
 
 // Parse parses any supported ebook format
 func (up *UniversalParser) Parse(filename string) (*Book, error) {
+	return up.ParseContext(context.Background(), filename)
+}
+
+// ParseContext parses any supported ebook format, recording a span in the
+// trace of ctx
+func (up *UniversalParser) ParseContext(ctx context.Context, filename string) (book *Book, err error) {
+	_, span := tracing.Start(ctx, "ebook.Parse", attribute.String("ebook.file", filename))
+	defer func() {
+		if book != nil {
+			span.SetAttributes(
+				attribute.String("ebook.format", string(book.Format)),
+				attribute.Int("ebook.chapters", len(book.Chapters)),
+			)
+		}
+		tracing.End(span, err)
+	}()
+
 	// Detect format
 	detectedFormat, err := up.detector.DetectFile(filename)
 	if err != nil {
@@ -101,7 +155,7 @@ func (up *UniversalParser) Parse(filename string) (*Book, error) {
 	}
 
 	// Parse the book
-	book, err := parser.Parse(filename)
+	book, err = parser.Parse(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse %s: %w", detectedFormat, err)
 	}
@@ -479,3 +479,23 @@ func TestUniversalParser_Parse_EmptyFile(t *testing.T) {
 		t.Errorf("Expected chapter title 'Content', got %s", book.Chapters[0].Title)
 	}
 }
+
+func TestBook_Clone(t *testing.T) {
+	book := &Book{
+		Metadata: Metadata{Title: "Book", Authors: []string{"Author"}},
+		Chapters: []Chapter{{
+			Title:    "One",
+			Sections: []Section{{Content: "Text", Subsections: []Section{{Content: "Nested"}}}},
+		}},
+	}
+
+	clone := book.Clone()
+	clone.Metadata.Authors[0] = "Other"
+	clone.Chapters[0].Title = "Changed"
+	clone.Chapters[0].Sections[0].Subsections[0].Content = "Changed"
+
+	if book.Metadata.Authors[0] != "Author" || book.Chapters[0].Title != "One" ||
+		book.Chapters[0].Sections[0].Subsections[0].Content != "Nested" {
+		t.Errorf("changing the clone changed the book: %+v", book)
+	}
+}
@@ -0,0 +1,575 @@
+package ebook
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unidoc/unipdf/v3/extractor"
+)
+
+// pdfLine is a line of text on a PDF page. Coordinates are in PDF user
+// space, where y grows upwards.
+type pdfLine struct {
+	text     string
+	left     float64
+	right    float64
+	bottom   float64
+	fontSize float64
+}
+
+// pdfPage holds the lines of a page in reading order
+type pdfPage struct {
+	number int
+	lines  []pdfLine
+}
+
+// pdfOutlineEntry is a top level entry of the PDF outline (bookmarks)
+type pdfOutlineEntry struct {
+	title string
+	page  int // 1-based
+}
+
+const (
+	// edgeLines is how many lines at the top and bottom of a page are
+	// checked for running headers, footers and page numbers
+	edgeLines = 2
+
+	// headingScale is how much larger than body text a heading's font is
+	headingScale = 1.15
+
+	// footnoteScale is how much smaller than body text a footnote's font is
+	footnoteScale = 0.85
+
+	// maxHeadingLength is the longest line taken for a heading
+	maxHeadingLength = 120
+)
+
+var (
+	// pageNumberLine matches lines holding only a page number, like "12",
+	// "- 12 -", "Page 12", "Page xii" or "12 of 300"
+	pageNumberLine = regexp.MustCompile(`(?i)^[\s\-–—]*((page\s+|p\.\s*)?\d+(\s*(/|of)\s*\d+)?|(page\s+|p\.\s*)[ivxlc]+)[\s\-–—]*$`)
+
+	// romanNumeralLine matches lines holding only a roman numeral, like "xii"
+	// or "- XIV -", which may be a page number or a heading
+	romanNumeralLine = regexp.MustCompile(`(?i)^[\s\-–—]*(c{0,3}(?:xc|xl|l?x{0,3})(?:ix|iv|v?i{0,3}))[\s\-–—]*$`)
+
+	// footnoteMarker matches the marker at the start of a footnote
+	footnoteMarker = regexp.MustCompile(`^(\d{1,3}|[*†‡§]+)[\s.)]`)
+
+	// digits are replaced when comparing running headers and footers
+	digits = regexp.MustCompile(`\d+`)
+)
+
+// linesFromMarks groups the text marks of a page into lines; unipdf marks
+// line breaks with meta marks holding a newline
+func linesFromMarks(marks []extractor.TextMark) []pdfLine {
+	var lines []pdfLine
+	var text strings.Builder
+	var line pdfLine
+	started := false
+
+	flush := func() {
+		line.text = strings.TrimSpace(text.String())
+		if started && line.text != "" {
+			lines = append(lines, line)
+		}
+		text.Reset()
+		line = pdfLine{}
+		started = false
+	}
+
+	for _, mark := range marks {
+		if mark.Meta {
+			if strings.Contains(mark.Text, "\n") {
+				flush()
+			} else {
+				text.WriteString(mark.Text)
+			}
+			continue
+		}
+
+		box := mark.BBox
+		if !started {
+			line = pdfLine{left: box.Llx, right: box.Urx, bottom: box.Lly, fontSize: mark.FontSize}
+			started = true
+		}
+		line.left = math.Min(line.left, box.Llx)
+		line.right = math.Max(line.right, box.Urx)
+		line.bottom = math.Min(line.bottom, box.Lly)
+		line.fontSize = math.Max(line.fontSize, mark.FontSize)
+		text.WriteString(mark.Text)
+	}
+	flush()
+
+	return lines
+}
+
+// layoutLine is a line of the document with what layout analysis found out
+// about it
+type layoutLine struct {
+	pdfLine
+	page         int
+	level        int    // heading level, 0 for body text
+	chapterTitle string // set on the first line of an outline chapter
+}
+
+// pdfLayout analyzes the lines of a document
+type pdfLayout struct {
+	pages     []pdfPage
+	bodySize  float64
+	footnotes map[int][]string // page → notes
+}
+
+// analyzePDFLayout turns the pages of a PDF into chapters: running headers,
+// footers and page numbers are dropped, footnotes are moved out of the text,
+// lines are reflowed into paragraphs with hyphenation undone, and chapters
+// follow the outline or, without one, the largest headings
+func analyzePDFLayout(pages []pdfPage, outline []pdfOutlineEntry) []Chapter {
+	layout := &pdfLayout{pages: pages, footnotes: make(map[int][]string)}
+	layout.removeRunningLines()
+	layout.bodySize = layout.bodyFontSize()
+	layout.separateFootnotes()
+
+	lines := layout.classify()
+	if len(outline) > 0 {
+		markOutlineChapters(lines, outline)
+	}
+	return layout.chapters(lines, len(outline) > 0)
+}
+
+// removeRunningLines drops page numbers and lines repeated at the top or
+// bottom of several pages. A roman numeral is only taken for a page number
+// when it is not set larger than body text or continues the numerals of the
+// neighbouring pages, so headings like "II" survive.
+func (l *pdfLayout) removeRunningLines() {
+	bodySize := l.bodyFontSize()
+	numerals := l.romanNumerals()
+
+	repeats := make(map[string]int)
+	for _, page := range l.pages {
+		seen := make(map[string]bool)
+		for _, i := range edgeIndexes(len(page.lines)) {
+			key := runningKey(page.lines[i].text)
+			if !seen[key] {
+				seen[key] = true
+				repeats[key]++
+			}
+		}
+	}
+
+	threshold := 3
+	if len(l.pages) < threshold {
+		threshold = len(l.pages)
+	}
+
+	for p := range l.pages {
+		page := &l.pages[p]
+		drop := make(map[int]bool)
+		for _, i := range edgeIndexes(len(page.lines)) {
+			text := page.lines[i].text
+			if pageNumberLine.MatchString(text) || (threshold >= 2 && repeats[runningKey(text)] >= threshold) {
+				drop[i] = true
+			} else if value := romanValue(text); value > 0 {
+				headingSized := bodySize > 0 && page.lines[i].fontSize > bodySize*headingScale
+				drop[i] = !headingSized || l.romanSequence(numerals, p, value)
+			}
+		}
+
+		kept := page.lines[:0]
+		for i, line := range page.lines {
+			if !drop[i] {
+				kept = append(kept, line)
+			}
+		}
+		page.lines = kept
+	}
+}
+
+// romanNumerals returns the values of the roman numerals among the edge lines
+// of each page
+func (l *pdfLayout) romanNumerals() []map[int]bool {
+	numerals := make([]map[int]bool, len(l.pages))
+	for p, page := range l.pages {
+		numerals[p] = make(map[int]bool)
+		for _, i := range edgeIndexes(len(page.lines)) {
+			if value := romanValue(page.lines[i].text); value > 0 {
+				numerals[p][value] = true
+			}
+		}
+	}
+	return numerals
+}
+
+// romanSequence reports whether the numeral value on page p is one of a
+// running sequence: the previous page carries value-1 or the next value+1
+func (l *pdfLayout) romanSequence(numerals []map[int]bool, p, value int) bool {
+	if p > 0 && l.pages[p-1].number == l.pages[p].number-1 && numerals[p-1][value-1] {
+		return true
+	}
+	return p+1 < len(l.pages) && l.pages[p+1].number == l.pages[p].number+1 && numerals[p+1][value+1]
+}
+
+// romanValue returns the value of a line holding only a roman numeral, 0 otherwise
+func romanValue(text string) int {
+	match := romanNumeralLine.FindStringSubmatch(text)
+	if match == nil || match[1] == "" {
+		return 0
+	}
+
+	values := map[rune]int{'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100}
+	numeral := []rune(strings.ToLower(match[1]))
+	value := 0
+	for i, r := range numeral {
+		if i+1 < len(numeral) && values[r] < values[numeral[i+1]] {
+			value -= values[r]
+		} else {
+			value += values[r]
+		}
+	}
+	return value
+}
+
+// edgeIndexes returns the indexes of the first and last lines of a page
+func edgeIndexes(n int) []int {
+	var indexes []int
+	for i := 0; i < n; i++ {
+		if i < edgeLines || i >= n-edgeLines {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// runningKey normalizes a line for comparing headers and footers, which
+// often carry the page number
+func runningKey(text string) string {
+	return digits.ReplaceAllString(strings.ToLower(strings.Join(strings.Fields(text), " ")), "#")
+}
+
+// bodyFontSize returns the font size most of the text is set in
+func (l *pdfLayout) bodyFontSize() float64 {
+	weights := make(map[float64]int)
+	for _, page := range l.pages {
+		for _, line := range page.lines {
+			weights[math.Round(line.fontSize*2)/2] += utf8.RuneCountInString(line.text)
+		}
+	}
+
+	size, best := 0.0, -1
+	for s, weight := range weights {
+		if weight > best || (weight == best && s < size) {
+			size, best = s, weight
+		}
+	}
+	return size
+}
+
+// separateFootnotes moves the block of small lines starting with a note
+// marker at the bottom of each page out of the text
+func (l *pdfLayout) separateFootnotes() {
+	if l.bodySize == 0 {
+		return
+	}
+
+	for p := range l.pages {
+		page := &l.pages[p]
+		start := len(page.lines)
+		for start > 0 && isFootnoteSize(page.lines[start-1].fontSize, l.bodySize) {
+			start--
+		}
+		// The block must begin with a marker
+		for start < len(page.lines) && !footnoteMarker.MatchString(page.lines[start].text) {
+			start++
+		}
+		if start == len(page.lines) {
+			continue
+		}
+
+		var notes []string
+		for _, line := range page.lines[start:] {
+			if footnoteMarker.MatchString(line.text) || len(notes) == 0 {
+				notes = append(notes, line.text)
+			} else {
+				notes[len(notes)-1] = joinLines(notes[len(notes)-1], line.text)
+			}
+		}
+		l.footnotes[page.number] = notes
+		page.lines = page.lines[:start]
+	}
+}
+
+func isFootnoteSize(size, bodySize float64) bool {
+	return size > 0 && size <= bodySize*footnoteScale
+}
+
+// classify flattens the pages into lines with heading levels; the largest
+// heading font is level 1
+func (l *pdfLayout) classify() []layoutLine {
+	var sizes []float64
+	seen := make(map[float64]bool)
+	var lines []layoutLine
+	for _, page := range l.pages {
+		for _, line := range page.lines {
+			ll := layoutLine{pdfLine: line, page: page.number}
+			if l.isHeading(line) {
+				size := math.Round(line.fontSize*2) / 2
+				if !seen[size] {
+					seen[size] = true
+					sizes = append(sizes, size)
+				}
+				ll.level = -1
+			}
+			lines = append(lines, ll)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+	for i := range lines {
+		if lines[i].level == -1 {
+			size := math.Round(lines[i].fontSize*2) / 2
+			lines[i].level = sort.Search(len(sizes), func(j int) bool { return sizes[j] <= size }) + 1
+		}
+	}
+	return lines
+}
+
+func (l *pdfLayout) isHeading(line pdfLine) bool {
+	return l.bodySize > 0 && line.fontSize >= l.bodySize*headingScale &&
+		utf8.RuneCountInString(line.text) <= maxHeadingLength
+}
+
+// markOutlineChapters marks where the chapters of the outline begin: at the
+// line holding the entry's title on its page, or else at the page's first line
+func markOutlineChapters(lines []layoutLine, outline []pdfOutlineEntry) {
+	for _, entry := range outline {
+		title := strings.TrimSpace(entry.title)
+		first := -1
+		for i := range lines {
+			if lines[i].page != entry.page {
+				continue
+			}
+			if first == -1 {
+				first = i
+			}
+			if strings.EqualFold(strings.Join(strings.Fields(lines[i].text), " "), strings.Join(strings.Fields(title), " ")) {
+				first = i
+				break
+			}
+		}
+		if first != -1 && lines[first].chapterTitle == "" {
+			lines[first].chapterTitle = title
+		}
+	}
+}
+
+// chapterBuilder collects the sections of a chapter
+type chapterBuilder struct {
+	chapter   Chapter
+	section   *Section
+	paragraph string
+	paras     []string
+	footnotes []string
+	prev      *layoutLine
+}
+
+func (b *chapterBuilder) endParagraph() {
+	if b.paragraph != "" {
+		b.paras = append(b.paras, b.paragraph)
+		b.paragraph = ""
+	}
+}
+
+func (b *chapterBuilder) endSection() {
+	b.endParagraph()
+	if b.section != nil && (b.section.Title != "" || len(b.paras) > 0) {
+		b.section.Content = strings.Join(b.paras, "\n\n")
+		b.chapter.Sections = append(b.chapter.Sections, *b.section)
+	}
+	b.section = &Section{}
+	b.paras = nil
+}
+
+func (b *chapterBuilder) finish() (Chapter, bool) {
+	b.endSection()
+	if len(b.footnotes) > 0 {
+		b.chapter.Sections = append(b.chapter.Sections, Section{Title: "Notes", Content: strings.Join(b.footnotes, "\n\n")})
+	}
+	return b.chapter, b.chapter.Title != "" || len(b.chapter.Sections) > 0
+}
+
+// chapters groups the lines into chapters of sections of paragraphs
+func (l *pdfLayout) chapters(lines []layoutLine, byOutline bool) []Chapter {
+	var chapters []Chapter
+	b := &chapterBuilder{section: &Section{}}
+	metrics := l.pageMetrics(lines)
+	lastPage := 0
+
+	for i := range lines {
+		line := &lines[i]
+
+		// The notes of a page go to the chapter open at its end
+		if line.page != lastPage {
+			for p := lastPage; p < line.page; p++ {
+				b.footnotes = append(b.footnotes, l.footnotes[p]...)
+			}
+			lastPage = line.page
+		}
+
+		startsChapter := line.chapterTitle != "" || (!byOutline && line.level == 1)
+		if startsChapter {
+			// Consecutive level 1 lines form one title
+			if !byOutline && b.prev != nil && b.prev.level == 1 && b.prev.page == line.page {
+				b.chapter.Title += " " + line.text
+				b.prev = line
+				continue
+			}
+			if chapter, ok := b.finish(); ok {
+				chapters = append(chapters, chapter)
+			}
+			b = &chapterBuilder{section: &Section{}}
+			if line.chapterTitle != "" {
+				b.chapter.Title = line.chapterTitle
+			} else {
+				b.chapter.Title = line.text
+			}
+			b.prev = line
+			// The heading line of an outline entry is its title
+			if line.level > 0 || strings.EqualFold(line.text, line.chapterTitle) {
+				continue
+			}
+		}
+
+		if line.level > 0 {
+			if b.prev != nil && b.prev.level == line.level && b.section.Title != "" && b.paragraph == "" && len(b.paras) == 0 {
+				b.section.Title += " " + line.text
+			} else {
+				b.endSection()
+				b.section.Title = line.text
+			}
+			b.prev = line
+			continue
+		}
+
+		if b.prev == nil || b.prev.level > 0 || b.paragraph == "" || breaksParagraph(b.prev, line, metrics, l.bodySize) {
+			b.endParagraph()
+			b.paragraph = line.text
+		} else {
+			b.paragraph = joinLines(b.paragraph, line.text)
+		}
+		b.prev = line
+	}
+
+	for p := lastPage; p <= len(l.pages); p++ {
+		b.footnotes = append(b.footnotes, l.footnotes[p]...)
+	}
+	if chapter, ok := b.finish(); ok {
+		chapters = append(chapters, chapter)
+	}
+
+	// Chapters from an outline or headings start with an untitled chapter
+	// for the text before the first one
+	for i := range chapters {
+		if chapters[i].Title == "" {
+			chapters[i].Title = "Document Content"
+		}
+	}
+	return chapters
+}
+
+// pageMetrics holds the geometry of the body text of a page
+type pageMetrics struct {
+	left, right float64
+	spacing     float64 // usual distance between lines
+}
+
+func (l *pdfLayout) pageMetrics(lines []layoutLine) map[int]pageMetrics {
+	metrics := make(map[int]pageMetrics)
+	gaps := make(map[int][]float64)
+	var prev *layoutLine
+	for i := range lines {
+		line := &lines[i]
+		if line.level > 0 {
+			prev = nil
+			continue
+		}
+		m, ok := metrics[line.page]
+		if !ok {
+			m = pageMetrics{left: line.left, right: line.right}
+		}
+		m.left = math.Min(m.left, line.left)
+		m.right = math.Max(m.right, line.right)
+		metrics[line.page] = m
+
+		if prev != nil && prev.page == line.page && prev.bottom > line.bottom {
+			gaps[line.page] = append(gaps[line.page], prev.bottom-line.bottom)
+		}
+		prev = line
+	}
+
+	// The right margin of a page with few lines, like the last page of a
+	// chapter, is taken from the whole document
+	right := 0.0
+	for _, m := range metrics {
+		right = math.Max(right, m.right)
+	}
+	for page, m := range metrics {
+		if g := gaps[page]; len(g) > 0 {
+			sort.Float64s(g)
+			m.spacing = g[len(g)/2]
+		}
+		if len(gaps[page]) < 2 {
+			m.right = right
+		}
+		metrics[page] = m
+	}
+	return metrics
+}
+
+// breaksParagraph reports whether line starts a new paragraph after prev:
+// after a wider gap than usual, at an indented line, or after a short line
+// ending a sentence
+func breaksParagraph(prev, line *layoutLine, metrics map[int]pageMetrics, bodySize float64) bool {
+	m := metrics[line.page]
+	em := math.Max(bodySize, 1)
+
+	if prev.page == line.page && m.spacing > 0 && prev.bottom-line.bottom > m.spacing*1.5 {
+		return true
+	}
+	if line.left > m.left+em*0.8 {
+		return true
+	}
+
+	pm := metrics[prev.page]
+	last, _ := utf8.DecodeLastRuneInString(prev.text)
+	ended := strings.ContainsRune(".!?:…\"»”)", last)
+	return ended && prev.right < pm.right-em*2
+}
+
+// joinLines joins a line to the text before it, undoing hyphenation: a
+// hyphen between letters before a lowercase letter is removed, while
+// compounds like "Jean-Paul" keep theirs
+func joinLines(text, next string) string {
+	if text == "" {
+		return next
+	}
+
+	last, size := utf8.DecodeLastRuneInString(text)
+	if last == '\u00ad' {
+		return text[:len(text)-size] + next
+	}
+	if last == '-' || last == '\u2010' {
+		before, _ := utf8.DecodeLastRuneInString(text[:len(text)-size])
+		first, _ := utf8.DecodeRuneInString(next)
+		if unicode.IsLetter(before) {
+			if unicode.IsLower(first) {
+				return text[:len(text)-size] + next
+			}
+			return text + next
+		}
+	}
+	return text + " " + next
+}
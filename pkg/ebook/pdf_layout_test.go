@@ -0,0 +1,288 @@
+package ebook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unipdf/v3/extractor"
+	"github.com/unidoc/unipdf/v3/model"
+)
+
+// layoutPage builds a page from lines set top to bottom at 14pt spacing;
+// lines are "text", or "size|indent|text" to set the font size and indent
+func layoutPage(number int, lines ...string) pdfPage {
+	page := pdfPage{number: number}
+	y := 800.0
+	for _, spec := range lines {
+		size, indent, text := 10.0, 0.0, spec
+		if parts := strings.SplitN(spec, "|", 3); len(parts) == 3 {
+			switch parts[0] {
+			case "h1":
+				size = 20
+			case "h2":
+				size = 14
+			case "small":
+				size = 8
+			}
+			if parts[1] == ">" {
+				indent = 20
+			}
+			text = parts[2]
+		}
+		if text == "" {
+			// An empty line leaves a gap
+			y -= 14
+			continue
+		}
+
+		right := 72 + indent + float64(len(text))*5
+		if !strings.HasSuffix(text, ".") || len(text) > 60 {
+			right = 500
+		}
+		page.lines = append(page.lines, pdfLine{text: text, left: 72 + indent, right: right, bottom: y, fontSize: size})
+		y -= 14
+	}
+	return page
+}
+
+func chapterTitles(chapters []Chapter) []string {
+	var titles []string
+	for _, chapter := range chapters {
+		titles = append(titles, chapter.Title)
+	}
+	return titles
+}
+
+func TestJoinLines(t *testing.T) {
+	tests := []struct {
+		text, next, want string
+	}{
+		{"", "word", "word"},
+		{"the quick", "brown fox", "the quick brown fox"},
+		{"transla-", "tion works", "translation works"},
+		{"transla\u00ad", "tion works", "translation works"},
+		{"visit Jean-", "Paul today", "visit Jean-Paul today"},
+		{"pages 10 -", "12", "pages 10 - 12"},
+	}
+
+	for _, tt := range tests {
+		if got := joinLines(tt.text, tt.next); got != tt.want {
+			t.Errorf("joinLines(%q, %q) = %q, want %q", tt.text, tt.next, got, tt.want)
+		}
+	}
+}
+
+func TestLinesFromMarks(t *testing.T) {
+	mark := func(text string, x, y float64) extractor.TextMark {
+		return extractor.TextMark{Text: text, FontSize: 10, BBox: model.PdfRectangle{Llx: x, Lly: y, Urx: x + 5, Ury: y + 10}}
+	}
+	marks := []extractor.TextMark{
+		mark("H", 72, 700), mark("i", 77, 700),
+		{Text: " ", Meta: true},
+		mark("a", 87, 700),
+		{Text: "\n", Meta: true},
+		mark("b", 72, 686),
+	}
+
+	lines := linesFromMarks(marks)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %+v", lines)
+	}
+	if lines[0].text != "Hi a" || lines[0].left != 72 || lines[0].right != 92 || lines[0].bottom != 700 {
+		t.Errorf("unexpected first line %+v", lines[0])
+	}
+	if lines[1].text != "b" || lines[1].fontSize != 10 {
+		t.Errorf("unexpected second line %+v", lines[1])
+	}
+}
+
+func TestAnalyzePDFLayout_RemovesHeadersFootersAndPageNumbers(t *testing.T) {
+	var pages []pdfPage
+	for i := 1; i <= 4; i++ {
+		pages = append(pages, layoutPage(i,
+			"The Running Title",
+			"body text on page "+strings.Repeat("i", i)+" that keeps going and going across the line",
+			"and ends "+strings.Repeat("very ", i)+"much here.",
+			"Chapter Notes - page "+strings.Repeat("1", i),
+			[]string{"1", "- 2 -", "Page 3", "iv"}[i-1],
+		))
+	}
+
+	chapters := analyzePDFLayout(pages, nil)
+	if len(chapters) != 1 {
+		t.Fatalf("expected 1 chapter, got %v", chapterTitles(chapters))
+	}
+
+	content := chapters[0].Sections[0].Content
+	for _, unwanted := range []string{"Running Title", "Chapter Notes", "- 2 -", "Page 3", "iv"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("content still contains %q:\n%s", unwanted, content)
+		}
+	}
+	if strings.Count(content, "body text") != 4 {
+		t.Errorf("expected the body of all pages, got:\n%s", content)
+	}
+}
+
+func TestAnalyzePDFLayout_KeepsRomanNumeralHeadings(t *testing.T) {
+	pages := []pdfPage{
+		layoutPage(1, "h1||II", "The second part begins here and the text runs across the line", "and ends here."),
+		layoutPage(2, "Body text of the next page that runs on across the whole line", "and ends here.", "h1||XIV"),
+		layoutPage(3, "h2||v", "Numbered pages set large still count as page numbers when", "they follow each other."),
+		layoutPage(4, "h2||vi", "So the numeral on this page goes as well and the text", "ends here."),
+	}
+
+	chapters := analyzePDFLayout(pages, nil)
+	var content strings.Builder
+	for _, chapter := range chapters {
+		content.WriteString(chapter.Title + "\n")
+		for _, section := range chapter.Sections {
+			content.WriteString(section.Title + "\n" + section.Content + "\n")
+		}
+	}
+
+	for _, heading := range []string{"II", "XIV"} {
+		if !strings.Contains(content.String(), heading) {
+			t.Errorf("heading %q was dropped:\n%s", heading, content.String())
+		}
+	}
+	for _, number := range []string{"v\n", "vi\n"} {
+		if strings.Contains(content.String(), "\n"+number) {
+			t.Errorf("page number %q was kept:\n%s", strings.TrimSpace(number), content.String())
+		}
+	}
+}
+
+func TestRomanValue(t *testing.T) {
+	for text, want := range map[string]int{"i": 1, "iv": 4, "XIV": 14, "- xc -": 90, "xcix": 99, "chapter": 0, "": 0, "—": 0} {
+		if got := romanValue(text); got != want {
+			t.Errorf("romanValue(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestAnalyzePDFLayout_ReflowsParagraphs(t *testing.T) {
+	pages := []pdfPage{
+		layoutPage(1,
+			"This is the first paragraph of the text, which is continued on",
+			"the next line and hyphen-",
+			"ated at the end of a line.",
+			"10|>|A second paragraph starts with an indent and goes on to the",
+			"very end of the page where it does not finish but carries",
+		),
+		layoutPage(2,
+			"over to the following page.",
+			"",
+			"A third paragraph follows a blank line.",
+		),
+	}
+
+	chapters := analyzePDFLayout(pages, nil)
+	if len(chapters) != 1 {
+		t.Fatalf("expected 1 chapter, got %v", chapterTitles(chapters))
+	}
+
+	paragraphs := strings.Split(chapters[0].Sections[0].Content, "\n\n")
+	want := []string{
+		"This is the first paragraph of the text, which is continued on the next line and hyphenated at the end of a line.",
+		"A second paragraph starts with an indent and goes on to the very end of the page where it does not finish but carries over to the following page.",
+		"A third paragraph follows a blank line.",
+	}
+	if len(paragraphs) != len(want) {
+		t.Fatalf("expected %d paragraphs, got %q", len(want), paragraphs)
+	}
+	for i := range want {
+		if paragraphs[i] != want[i] {
+			t.Errorf("paragraph %d = %q, want %q", i, paragraphs[i], want[i])
+		}
+	}
+}
+
+func TestAnalyzePDFLayout_SplitsChaptersByHeadings(t *testing.T) {
+	pages := []pdfPage{
+		layoutPage(1,
+			"h1||Chapter One",
+			"h1||The Beginning",
+			"Opening text of the first chapter that runs to the end of the line",
+			"and stops.",
+			"h2||A Section",
+			"Section text.",
+		),
+		layoutPage(2,
+			"h1||Chapter Two",
+			"Text of the second chapter.",
+		),
+	}
+
+	chapters := analyzePDFLayout(pages, nil)
+	titles := chapterTitles(chapters)
+	if strings.Join(titles, "|") != "Chapter One The Beginning|Chapter Two" {
+		t.Fatalf("unexpected chapters %q", titles)
+	}
+
+	sections := chapters[0].Sections
+	if len(sections) != 2 || sections[0].Title != "" || sections[1].Title != "A Section" || sections[1].Content != "Section text." {
+		t.Errorf("unexpected sections %+v", sections)
+	}
+	if chapters[1].Sections[0].Content != "Text of the second chapter." {
+		t.Errorf("unexpected second chapter %+v", chapters[1].Sections)
+	}
+}
+
+func TestAnalyzePDFLayout_SplitsChaptersByOutline(t *testing.T) {
+	pages := []pdfPage{
+		layoutPage(1, "A preface before the outline starts."),
+		layoutPage(2, "h2||Introduction", "Introduction text."),
+		layoutPage(3, "More introduction text."),
+		layoutPage(4, "Text of the body with no heading."),
+	}
+	outline := []pdfOutlineEntry{{title: "Introduction", page: 2}, {title: "Main Part", page: 4}}
+
+	chapters := analyzePDFLayout(pages, outline)
+	titles := chapterTitles(chapters)
+	if strings.Join(titles, "|") != "Document Content|Introduction|Main Part" {
+		t.Fatalf("unexpected chapters %q", titles)
+	}
+	if got := chapters[1].Sections[0].Content; got != "Introduction text.\n\nMore introduction text." {
+		t.Errorf("unexpected introduction %q", got)
+	}
+	if got := chapters[2].Sections[0].Content; got != "Text of the body with no heading." {
+		t.Errorf("unexpected main part %q", got)
+	}
+}
+
+func TestAnalyzePDFLayout_SeparatesFootnotes(t *testing.T) {
+	pages := []pdfPage{
+		layoutPage(1,
+			"h1||Chapter One",
+			"A claim that needs a source and is made at length over several",
+			"lines of text so that the body font is the most common one.1",
+			"small||1 The source of the claim,",
+			"small||continued.",
+			"small||2 Another note.",
+		),
+		layoutPage(2,
+			"h1||Chapter Two",
+			"Text without notes.",
+		),
+	}
+
+	chapters := analyzePDFLayout(pages, nil)
+	if len(chapters) != 2 {
+		t.Fatalf("expected 2 chapters, got %v", chapterTitles(chapters))
+	}
+
+	sections := chapters[0].Sections
+	if len(sections) != 2 || sections[1].Title != "Notes" {
+		t.Fatalf("expected a notes section, got %+v", sections)
+	}
+	if sections[0].Content != "A claim that needs a source and is made at length over several lines of text so that the body font is the most common one.1" {
+		t.Errorf("unexpected text %q", sections[0].Content)
+	}
+	if sections[1].Content != "1 The source of the claim, continued.\n\n2 Another note." {
+		t.Errorf("unexpected notes %q", sections[1].Content)
+	}
+	if len(chapters[1].Sections) != 1 {
+		t.Errorf("expected no notes in chapter two, got %+v", chapters[1].Sections)
+	}
+}
@@ -78,30 +78,25 @@ func (p *PDFParser) ParseWithContext(ctx context.Context, data []byte) (*Book, e
 		return nil, fmt.Errorf("failed to get page count: %w", err)
 	}
 
-	var allText strings.Builder
+	pages := make([]pdfPage, 0, numPages)
 
-	// Extract text from all pages
+	// Extract the text marks of all pages
 	for i := 1; i <= numPages; i++ {
 		page, err := pdfReader.GetPage(i)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get page %d: %w", i, err)
 		}
 
-		// Extract text from page
 		ex, err := extractor.New(page)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create extractor for page %d: %w", i, err)
 		}
 
-		text, err := ex.ExtractText()
+		pageText, _, _, err := ex.ExtractPageText()
 		if err != nil {
 			return nil, fmt.Errorf("failed to extract text from page %d: %w", i, err)
 		}
-
-		if allText.Len() > 0 {
-			allText.WriteString("\n\n--- Page Break ---\n\n")
-		}
-		allText.WriteString(text)
+		pages = append(pages, pdfPage{number: i, lines: linesFromMarks(pageText.Marks().Elements())})
 
 		// Check for context cancellation
 		if i%5 == 0 {
@@ -113,23 +108,38 @@ func (p *PDFParser) ParseWithContext(ctx context.Context, data []byte) (*Book, e
 		}
 	}
 
-	// Create main content as first chapter
-	mainChapter := Chapter{
-		Title: "Document Content",
-		Sections: []Section{
-			{
-				Title:   "Full Text",
-				Content: allText.String(),
-			},
-		},
+	// Rebuild paragraphs and chapters from the page layout
+	book.Chapters = analyzePDFLayout(pages, p.extractOutline(pdfReader))
+	if len(book.Chapters) == 0 {
+		book.Chapters = []Chapter{{
+			Title:    "Document Content",
+			Sections: []Section{{Title: "Full Text"}},
+		}}
 	}
-	
-	book.Chapters = append(book.Chapters, mainChapter)
 	book.Language = book.Metadata.Language
 
 	return book, nil
 }
 
+// extractOutline returns the top level entries of the document outline;
+// documents without one return nil
+func (p *PDFParser) extractOutline(pdfReader *model.PdfReader) []pdfOutlineEntry {
+	outline, err := pdfReader.GetOutlines()
+	if err != nil || outline == nil {
+		return nil
+	}
+
+	var entries []pdfOutlineEntry
+	for _, item := range outline.Entries {
+		if item == nil || strings.TrimSpace(item.Title) == "" {
+			continue
+		}
+		// Outline destinations count pages from zero
+		entries = append(entries, pdfOutlineEntry{title: item.Title, page: int(item.Dest.Page) + 1})
+	}
+	return entries
+}
+
 func (p *PDFParser) Validate(data []byte) error {
 	// Check PDF signature
 	if len(data) < 5 || !bytes.HasPrefix(data, []byte("%PDF-")) {
@@ -0,0 +1,151 @@
+package ebook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SegmentKind tells what part of a book a segment is
+type SegmentKind string
+
+const (
+	SegmentChapterTitle SegmentKind = "chapter-title"
+	SegmentSectionTitle SegmentKind = "section-title"
+	SegmentParagraph    SegmentKind = "paragraph"
+)
+
+// Segment is a title or paragraph of a book. Its ID follows the position
+// in the book, e.g. "c2-s1.3-p4" for the fourth paragraph of the third
+// subsection of the first section of chapter two, so a book and its
+// translation share IDs.
+type Segment struct {
+	ID      string
+	Kind    SegmentKind
+	Level   int    // 1 for chapter titles, 2 and deeper for section titles
+	Section string // ID of the section holding a paragraph
+	Text    string
+}
+
+// Segments returns the titles and paragraphs of the book in reading order
+func (b *Book) Segments() []Segment {
+	var segments []Segment
+	for i, chapter := range b.Chapters {
+		id := fmt.Sprintf("c%d", i+1)
+		if chapter.Title != "" {
+			segments = append(segments, Segment{ID: id, Kind: SegmentChapterTitle, Level: 1, Text: chapter.Title})
+		}
+		for j := range chapter.Sections {
+			segments = appendSectionSegments(segments, &chapter.Sections[j], fmt.Sprintf("%s-s%d", id, j+1), 2)
+		}
+	}
+	return segments
+}
+
+func appendSectionSegments(segments []Segment, section *Section, id string, level int) []Segment {
+	if section.Title != "" {
+		segments = append(segments, Segment{ID: id, Kind: SegmentSectionTitle, Level: level, Text: section.Title})
+	}
+	for i, para := range splitParagraphs(section.Content) {
+		segments = append(segments, Segment{
+			ID:      fmt.Sprintf("%s-p%d", id, i+1),
+			Kind:    SegmentParagraph,
+			Section: id,
+			Text:    para,
+		})
+	}
+	for i := range section.Subsections {
+		segments = appendSectionSegments(segments, &section.Subsections[i], fmt.Sprintf("%s.%d", id, i+1), level+1)
+	}
+	return segments
+}
+
+// splitParagraphs splits section content at blank lines, as the writers do
+func splitParagraphs(content string) []string {
+	var paragraphs []string
+	for _, para := range strings.Split(content, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			paragraphs = append(paragraphs, para)
+		}
+	}
+	return paragraphs
+}
+
+// SegmentPair is a segment of a book with its translation
+type SegmentPair struct {
+	ID          string
+	Kind        SegmentKind
+	Level       int
+	Source      string
+	Translation string
+}
+
+// AlignSegments pairs the segments of a book with those of its translation
+// by ID. When a section of the translation has a different number of
+// paragraphs, its paragraphs are paired as a whole under the ID of the
+// first one, with paragraphs separated by blank lines. Segments missing
+// from either book are paired with empty text; those only the translation
+// has come last.
+func AlignSegments(original, translated *Book) []SegmentPair {
+	source := original.Segments()
+	target := translated.Segments()
+
+	counts := func(segments []Segment) map[string]int {
+		c := make(map[string]int)
+		for _, s := range segments {
+			if s.Kind == SegmentParagraph {
+				c[s.Section]++
+			}
+		}
+		return c
+	}
+	sourceCounts, targetCounts := counts(source), counts(target)
+
+	byID := make(map[string]Segment, len(target))
+	sections := make(map[string][]string)
+	for _, s := range target {
+		byID[s.ID] = s
+		if s.Kind == SegmentParagraph {
+			sections[s.Section] = append(sections[s.Section], s.Text)
+		}
+	}
+
+	var pairs []SegmentPair
+	seen := make(map[string]bool)
+	merged := make(map[string]int) // section → index of its merged pair
+	for _, s := range source {
+		if s.Kind == SegmentParagraph && sourceCounts[s.Section] != targetCounts[s.Section] {
+			if i, ok := merged[s.Section]; ok {
+				pairs[i].Source += "\n\n" + s.Text
+				continue
+			}
+			merged[s.Section] = len(pairs)
+			pairs = append(pairs, SegmentPair{
+				ID:          s.ID,
+				Kind:        s.Kind,
+				Source:      s.Text,
+				Translation: strings.Join(sections[s.Section], "\n\n"),
+			})
+			for _, t := range target {
+				if t.Kind == SegmentParagraph && t.Section == s.Section {
+					seen[t.ID] = true
+				}
+			}
+			continue
+		}
+
+		pair := SegmentPair{ID: s.ID, Kind: s.Kind, Level: s.Level, Source: s.Text}
+		if t, ok := byID[s.ID]; ok && !seen[s.ID] {
+			pair.Translation = t.Text
+			seen[s.ID] = true
+		}
+		pairs = append(pairs, pair)
+	}
+
+	// Text only the translation has, e.g. a title the original lacked
+	for _, t := range target {
+		if !seen[t.ID] {
+			pairs = append(pairs, SegmentPair{ID: t.ID, Kind: t.Kind, Level: t.Level, Translation: t.Text})
+		}
+	}
+	return pairs
+}
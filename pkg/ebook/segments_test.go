@@ -0,0 +1,70 @@
+package ebook
+
+import (
+	"strings"
+	"testing"
+)
+
+func segmentTestBook(content ...string) *Book {
+	return &Book{
+		Chapters: []Chapter{
+			{
+				Title: "One",
+				Sections: []Section{{
+					Title:       "Part",
+					Content:     content[0],
+					Subsections: []Section{{Content: content[1]}},
+				}},
+			},
+			{Sections: []Section{{Content: content[2]}}},
+		},
+	}
+}
+
+func TestBook_Segments(t *testing.T) {
+	book := segmentTestBook("First.\n\n  \n\nSecond.", "Nested.", "Untitled chapter.")
+
+	var got []string
+	for _, s := range book.Segments() {
+		got = append(got, s.ID+"|"+string(s.Kind)+"|"+s.Text)
+	}
+	want := []string{
+		"c1|chapter-title|One",
+		"c1-s1|section-title|Part",
+		"c1-s1-p1|paragraph|First.",
+		"c1-s1-p2|paragraph|Second.",
+		"c1-s1.1-p1|paragraph|Nested.",
+		"c2-s1-p1|paragraph|Untitled chapter.",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected segments:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestAlignSegments(t *testing.T) {
+	original := segmentTestBook("First.\n\nSecond.", "Nested.", "Last.")
+	translated := segmentTestBook("Prvi.\n\nDrugi.", "Ugnježdeni.", "Poslednji i spojeni.")
+	translated.Chapters[0].Title = "Jedan"
+	translated.Chapters[0].Sections[0].Title = "Deo"
+	translated.Chapters[1].Title = "Dva"
+	original.Chapters[1].Sections[0].Content = "Last\n\nparagraph."
+
+	var got []string
+	for _, p := range AlignSegments(original, translated) {
+		got = append(got, p.ID+"|"+p.Source+"|"+p.Translation)
+	}
+	want := []string{
+		"c1|One|Jedan",
+		"c1-s1|Part|Deo",
+		"c1-s1-p1|First.|Prvi.",
+		"c1-s1-p2|Second.|Drugi.",
+		"c1-s1.1-p1|Nested.|Ugnježdeni.",
+		// The translation merged two paragraphs, so the section is paired whole
+		"c2-s1-p1|Last\n\nparagraph.|Poslednji i spojeni.",
+		// Only the translation has a chapter title
+		"c2||Dva",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected pairs:\n%s", strings.Join(got, "\n"))
+	}
+}
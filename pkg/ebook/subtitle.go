@@ -0,0 +1,458 @@
+package ebook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+// SegmentCue is the segment kind of a subtitle cue
+const SegmentCue SegmentKind = "cue"
+
+// Cue is a subtitle shown from Start to End. Its text keeps the styling
+// tags of its format (<i>, <font>, <c.class>, {\an8}, ASS override blocks);
+// lines are separated by "\n" whatever the format uses.
+type Cue struct {
+	ID    string // SRT index or WebVTT identifier
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// Subtitles is a subtitle file. Everything but the text of the cues is kept
+// byte for byte, so a file written in its own format differs from the
+// original only in the text that was changed.
+type Subtitles struct {
+	Format format.Format
+	Cues   []Cue
+
+	parts   []subtitlePart
+	newline string
+}
+
+// subtitlePart is either text kept as it is or the text of a cue
+type subtitlePart struct {
+	text string
+	cue  int // index of the cue, or -1
+}
+
+// ReadSubtitles reads a subtitle file, detecting its format
+func ReadSubtitles(filename string) (*Subtitles, error) {
+	f, err := format.NewDetector().DetectFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsSubtitle() {
+		return nil, fmt.Errorf("%s is not a subtitle file", filename)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitles: %w", err)
+	}
+	return ParseSubtitles(data, f)
+}
+
+// ParseSubtitles parses SRT, WebVTT or ASS/SSA subtitles
+func ParseSubtitles(data []byte, f format.Format) (*Subtitles, error) {
+	s := &Subtitles{Format: f, newline: "\n"}
+	content := string(data)
+	if i := strings.Index(content, "\n"); i > 0 && content[i-1] == '\r' {
+		s.newline = "\r\n"
+	}
+
+	switch f {
+	case format.FormatSRT, format.FormatVTT:
+		s.parseBlocks(content)
+	case format.FormatASS:
+		if err := s.parseEvents(content); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported subtitle format: %s", f)
+	}
+	return s, nil
+}
+
+// cueTiming matches the timing line of an SRT or WebVTT cue
+var cueTiming = regexp.MustCompile(`^\s*(\S+)\s+-->\s+(\S+)`)
+
+// cueTimestamp matches SRT, WebVTT and ASS timestamps
+var cueTimestamp = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[,.](\d{1,3})$`)
+
+// parseTimestamp parses a timestamp such as 01:02:03,456, 02:03.456 or
+// 1:02:03.45
+func parseTimestamp(s string) (time.Duration, bool) {
+	m := cueTimestamp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	fraction, _ := strconv.Atoi(m[4])
+	for i := len(m[4]); i < 3; i++ {
+		fraction *= 10
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(fraction)*time.Millisecond, true
+}
+
+// parseTiming parses the timing line of an SRT or WebVTT cue
+func parseTiming(line string) (start, end time.Duration, ok bool) {
+	m := cueTiming.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0, false
+	}
+	start, ok1 := parseTimestamp(m[1])
+	end, ok2 := parseTimestamp(m[2])
+	return start, end, ok1 && ok2
+}
+
+// subtitleLine is a line of a subtitle file with its line ending
+type subtitleLine struct {
+	text, ending string
+}
+
+func splitSubtitleLines(content string) []subtitleLine {
+	var lines []subtitleLine
+	for _, raw := range strings.SplitAfter(content, "\n") {
+		if raw == "" {
+			continue
+		}
+		text := strings.TrimRight(raw, "\r\n")
+		lines = append(lines, subtitleLine{text: text, ending: raw[len(text):]})
+	}
+	return lines
+}
+
+// parseBlocks parses SRT and WebVTT files: blocks separated by blank lines,
+// a cue being an optional identifier, a timing line and its text. WebVTT
+// headers, notes, styles and regions are kept as they are.
+func (s *Subtitles) parseBlocks(content string) {
+	lines := splitSubtitleLines(content)
+	var kept strings.Builder
+	flush := func() {
+		if kept.Len() > 0 {
+			s.parts = append(s.parts, subtitlePart{text: kept.String(), cue: -1})
+			kept.Reset()
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		// A block runs to the next blank line
+		end := i
+		for end < len(lines) && strings.TrimSpace(lines[end].text) != "" {
+			end++
+		}
+		if end == i {
+			kept.WriteString(lines[i].text + lines[i].ending)
+			i++
+			continue
+		}
+		block := lines[i:end]
+		i = end
+
+		timing := -1
+		first := strings.TrimPrefix(block[0].text, "\ufeff")
+		isHeader := s.Format == format.FormatVTT && (strings.HasPrefix(first, "WEBVTT") ||
+			strings.HasPrefix(first, "NOTE") || strings.HasPrefix(first, "STYLE") || strings.HasPrefix(first, "REGION"))
+		if !isHeader {
+			for j := 0; j < len(block) && j < 2; j++ {
+				if strings.Contains(block[j].text, "-->") {
+					timing = j
+					break
+				}
+			}
+		}
+		start, stop, ok := time.Duration(0), time.Duration(0), false
+		if timing >= 0 {
+			start, stop, ok = parseTiming(block[timing].text)
+		}
+		if !ok {
+			for _, line := range block {
+				kept.WriteString(line.text + line.ending)
+			}
+			continue
+		}
+
+		cue := Cue{Start: start, End: stop}
+		if timing == 1 {
+			cue.ID = strings.TrimSpace(strings.TrimPrefix(block[0].text, "\ufeff"))
+		}
+		for _, line := range block[:timing+1] {
+			kept.WriteString(line.text + line.ending)
+		}
+		texts := make([]string, 0, len(block)-timing-1)
+		for _, line := range block[timing+1:] {
+			texts = append(texts, line.text)
+		}
+		cue.Text = strings.Join(texts, "\n")
+		flush()
+		s.parts = append(s.parts, subtitlePart{cue: len(s.Cues)})
+		s.Cues = append(s.Cues, cue)
+		if len(texts) > 0 {
+			kept.WriteString(block[len(block)-1].ending)
+		}
+	}
+	flush()
+}
+
+// parseEvents parses the Dialogue lines of the [Events] section of an ASS
+// or SSA script; all other lines are kept as they are
+func (s *Subtitles) parseEvents(content string) error {
+	var kept strings.Builder
+	inEvents := false
+	fields := []string{"layer", "start", "end", "style", "name", "marginl", "marginr", "marginv", "effect", "text"}
+
+	for _, line := range splitSubtitleLines(content) {
+		trimmed := strings.TrimSpace(line.text)
+		if strings.HasPrefix(trimmed, "[") {
+			inEvents = strings.EqualFold(trimmed, "[Events]")
+		}
+		key, value, found := strings.Cut(line.text, ":")
+		if inEvents && found && strings.EqualFold(strings.TrimSpace(key), "Format") {
+			fields = fields[:0]
+			for _, field := range strings.Split(value, ",") {
+				fields = append(fields, strings.ToLower(strings.TrimSpace(field)))
+			}
+		}
+		if !inEvents || !found || !strings.EqualFold(strings.TrimSpace(key), "Dialogue") {
+			kept.WriteString(line.text + line.ending)
+			continue
+		}
+
+		values := strings.SplitN(value, ",", len(fields))
+		if len(values) != len(fields) || fields[len(fields)-1] != "text" {
+			return fmt.Errorf("malformed ASS dialogue line: %s", line.text)
+		}
+		cue := Cue{}
+		for i, field := range fields {
+			switch field {
+			case "start":
+				cue.Start, _ = parseTimestamp(strings.TrimSpace(values[i]))
+			case "end":
+				cue.End, _ = parseTimestamp(strings.TrimSpace(values[i]))
+			}
+		}
+		text := values[len(values)-1]
+		cue.Text = strings.ReplaceAll(text, `\N`, "\n")
+
+		kept.WriteString(line.text[:len(line.text)-len(text)])
+		s.parts = append(s.parts, subtitlePart{text: kept.String(), cue: -1})
+		kept.Reset()
+		s.parts = append(s.parts, subtitlePart{cue: len(s.Cues)})
+		s.Cues = append(s.Cues, cue)
+		kept.WriteString(line.ending)
+	}
+	if kept.Len() > 0 {
+		s.parts = append(s.parts, subtitlePart{text: kept.String(), cue: -1})
+	}
+	return nil
+}
+
+// Segments returns the text of every cue without styling tags, with lines
+// joined by spaces
+func (s *Subtitles) Segments() []Segment {
+	segments := make([]Segment, 0, len(s.Cues))
+	for i, cue := range s.Cues {
+		segments = append(segments, Segment{
+			ID:   fmt.Sprintf("cue%d", i+1),
+			Kind: SegmentCue,
+			Text: plainCueText(cue.Text),
+		})
+	}
+	return segments
+}
+
+// Bytes renders the subtitles in their own format
+func (s *Subtitles) Bytes() []byte {
+	var out strings.Builder
+	for _, part := range s.parts {
+		if part.cue < 0 {
+			out.WriteString(part.text)
+			continue
+		}
+		text := s.Cues[part.cue].Text
+		if s.Format == format.FormatASS {
+			text = strings.ReplaceAll(text, "\n", `\N`)
+		} else {
+			text = strings.ReplaceAll(text, "\n", s.newline)
+		}
+		out.WriteString(text)
+	}
+	return []byte(out.String())
+}
+
+// Render renders the subtitles in format f. Files in their own format keep
+// everything but the text of the cues; conversions keep the timing and map
+// italics, bold and underline between the formats, dropping other styling.
+func (s *Subtitles) Render(f format.Format) ([]byte, error) {
+	if f == s.Format {
+		return s.Bytes(), nil
+	}
+
+	var out strings.Builder
+	switch f {
+	case format.FormatSRT:
+		for i, cue := range s.Cues {
+			fmt.Fprintf(&out, "%d\n%s --> %s\n%s\n\n", i+1,
+				formatTimestamp(cue.Start, ",", 3), formatTimestamp(cue.End, ",", 3),
+				convertCueText(cue.Text, s.Format, f))
+		}
+	case format.FormatVTT:
+		out.WriteString("WEBVTT\n\n")
+		for _, cue := range s.Cues {
+			if cue.ID != "" && s.Format == format.FormatVTT {
+				out.WriteString(cue.ID + "\n")
+			}
+			fmt.Fprintf(&out, "%s --> %s\n%s\n\n",
+				formatTimestamp(cue.Start, ".", 3), formatTimestamp(cue.End, ".", 3),
+				convertCueText(cue.Text, s.Format, f))
+		}
+	case format.FormatASS:
+		out.WriteString(assHeader)
+		for _, cue := range s.Cues {
+			fmt.Fprintf(&out, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+				strings.TrimPrefix(formatTimestamp(cue.Start, ".", 2), "0"),
+				strings.TrimPrefix(formatTimestamp(cue.End, ".", 2), "0"),
+				strings.ReplaceAll(convertCueText(cue.Text, s.Format, f), "\n", `\N`))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported subtitle format: %s", f)
+	}
+	return []byte(out.String()), nil
+}
+
+// WriteFile writes the subtitles to filename in format f
+func (s *Subtitles) WriteFile(filename string, f format.Format) error {
+	data, err := s.Render(f)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write subtitles: %w", err)
+	}
+	return nil
+}
+
+// assHeader starts the ASS scripts converted from other formats
+const assHeader = `[Script Info]
+ScriptType: v4.00+
+WrapStyle: 0
+ScaledBorderAndShadow: yes
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,2,2,10,10,10,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
+
+// formatTimestamp formats a timestamp with two-digit hours and the given
+// number of fraction digits
+func formatTimestamp(d time.Duration, separator string, digits int) string {
+	ms := d.Milliseconds()
+	fraction := ms % 1000
+	if digits == 2 {
+		fraction /= 10
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%0*d", ms/3600000, ms/60000%60, ms/1000%60, separator, digits, fraction)
+}
+
+// cueTag matches the styling tags of a cue: HTML-like tags of SRT and
+// WebVTT, override blocks and escapes of ASS
+var cueTag = regexp.MustCompile(`<[^<>\n]*>|\{[^{}\n]*\}|\\[nh]`)
+
+// plainCueText returns the text of a cue without tags on a single line
+func plainCueText(text string) string {
+	text = cueTag.ReplaceAllStringFunc(text, func(tag string) string {
+		if tag == `\h` || tag == `\n` {
+			return " "
+		}
+		return ""
+	})
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Styling tags that exist in every format
+var (
+	htmlStyles = map[string]string{"<i>": `{\i1}`, "</i>": `{\i0}`, "<b>": `{\b1}`, "</b>": `{\b0}`, "<u>": `{\u1}`, "</u>": `{\u0}`}
+	assStyles  = map[string]string{`{\i1}`: "<i>", `{\i0}`: "</i>", `{\b1}`: "<b>", `{\b0}`: "</b>", `{\u1}`: "<u>", `{\u0}`: "</u>"}
+)
+
+// convertCueText converts the styling tags of a cue from one format to
+// another, dropping those the target format has no equivalent for
+func convertCueText(text string, from, to format.Format) string {
+	return cueTag.ReplaceAllStringFunc(text, func(tag string) string {
+		switch {
+		case tag == `\h` || tag == `\n`:
+			if to == format.FormatASS {
+				return tag
+			}
+			return " "
+		case strings.HasPrefix(tag, "{"):
+			if to == format.FormatASS {
+				return tag
+			}
+			return assStyles[tag]
+		case to == format.FormatASS:
+			return htmlStyles[strings.ToLower(tag)]
+		case from == to || htmlStyles[strings.ToLower(tag)] != "":
+			return tag
+		case to == format.FormatSRT && (strings.HasPrefix(strings.ToLower(tag), "<font") || strings.ToLower(tag) == "</font>"):
+			return tag
+		default:
+			return ""
+		}
+	})
+}
+
+// SubtitleParser implements Parser for subtitle files, giving a book with a
+// paragraph for every cue
+type SubtitleParser struct {
+	format format.Format
+}
+
+// NewSubtitleParser creates a parser for subtitles in format f
+func NewSubtitleParser(f format.Format) *SubtitleParser {
+	return &SubtitleParser{format: f}
+}
+
+// Parse parses a subtitle file into a book
+func (p *SubtitleParser) Parse(filename string) (*Book, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitles: %w", err)
+	}
+	subtitles, err := ParseSubtitles(data, p.format)
+	if err != nil {
+		return nil, err
+	}
+
+	var paragraphs []string
+	for _, segment := range subtitles.Segments() {
+		if segment.Text != "" {
+			paragraphs = append(paragraphs, segment.Text)
+		}
+	}
+	title := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	return &Book{
+		Metadata: Metadata{Title: title},
+		Chapters: []Chapter{{
+			Sections: []Section{{Content: strings.Join(paragraphs, "\n\n")}},
+		}},
+		Format: p.format,
+	}, nil
+}
+
+// GetFormat returns the format
+func (p *SubtitleParser) GetFormat() format.Format {
+	return p.format
+}
@@ -0,0 +1,184 @@
+package ebook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testSRT = "\ufeff1\r\n00:00:01,000 --> 00:00:03,500\r\n<i>Hello</i> there,\r\nmy friend.\r\n\r\n2\r\n00:00:04,000 --> 00:00:06,000 X1:100 X2:200\r\n{\\an8}Goodbye\r\n\r\n"
+
+const testVTT = `WEBVTT - Lesson 1
+
+NOTE written by hand
+
+STYLE
+::cue { color: yellow }
+
+intro
+00:01.000 --> 00:03.000 align:start position:10%
+<v Anna>Welcome to the <c.highlight>course</c></v>
+
+00:00:04.000 --> 00:00:05.250
+Let's start.
+`
+
+const testASS = `[Script Info]
+Title: Lesson
+ScriptType: v4.00+
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,20,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,2,2,10,10,10,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+Comment: 0,0:00:00.00,0:00:01.00,Default,,0,0,0,,Not shown
+Dialogue: 0,0:00:01.00,0:00:02.50,Default,Anna,0,0,0,,{\i1}One, two{\i0}\Nthree
+Dialogue: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,Four
+`
+
+func TestParseSRT(t *testing.T) {
+	subtitles, err := ParseSubtitles([]byte(testSRT), format.FormatSRT)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	if len(subtitles.Cues) != 2 {
+		t.Fatalf("Expected 2 cues, got %d", len(subtitles.Cues))
+	}
+	first := subtitles.Cues[0]
+	if first.ID != "1" || first.Start != time.Second || first.End != 3500*time.Millisecond {
+		t.Errorf("Unexpected first cue %+v", first)
+	}
+	if first.Text != "<i>Hello</i> there,\nmy friend." {
+		t.Errorf("Unexpected text %q", first.Text)
+	}
+	if subtitles.Cues[1].Text != `{\an8}Goodbye` {
+		t.Errorf("Unexpected text %q", subtitles.Cues[1].Text)
+	}
+
+	if string(subtitles.Bytes()) != testSRT {
+		t.Errorf("SRT not kept byte for byte:\n%q", subtitles.Bytes())
+	}
+
+	subtitles.Cues[0].Text = "Hallo,\nFreund."
+	want := strings.Replace(testSRT, "<i>Hello</i> there,\r\nmy friend.", "Hallo,\r\nFreund.", 1)
+	if string(subtitles.Bytes()) != want {
+		t.Errorf("Unexpected rendering:\n%q", subtitles.Bytes())
+	}
+}
+
+func TestParseWebVTT(t *testing.T) {
+	subtitles, err := ParseSubtitles([]byte(testVTT), format.FormatVTT)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	if len(subtitles.Cues) != 2 {
+		t.Fatalf("Expected 2 cues, got %d", len(subtitles.Cues))
+	}
+	if subtitles.Cues[0].ID != "intro" || subtitles.Cues[0].Start != time.Second {
+		t.Errorf("Unexpected first cue %+v", subtitles.Cues[0])
+	}
+	if subtitles.Cues[1].End != 5250*time.Millisecond {
+		t.Errorf("Unexpected end %v", subtitles.Cues[1].End)
+	}
+	if string(subtitles.Bytes()) != testVTT {
+		t.Errorf("WebVTT not kept byte for byte:\n%s", subtitles.Bytes())
+	}
+}
+
+func TestParseASS(t *testing.T) {
+	subtitles, err := ParseSubtitles([]byte(testASS), format.FormatASS)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	if len(subtitles.Cues) != 2 {
+		t.Fatalf("Expected 2 cues, got %d", len(subtitles.Cues))
+	}
+	first := subtitles.Cues[0]
+	if first.Text != "{\\i1}One, two{\\i0}\nthree" || first.Start != time.Second || first.End != 2500*time.Millisecond {
+		t.Errorf("Unexpected first cue %+v", first)
+	}
+	if string(subtitles.Bytes()) != testASS {
+		t.Errorf("ASS not kept byte for byte:\n%s", subtitles.Bytes())
+	}
+
+	if _, err := ParseSubtitles([]byte("[Events]\nFormat: Start, Text, End\nDialogue: 0:00:01.00,Hi,0:00:02.00\n"), format.FormatASS); err == nil {
+		t.Error("Expected an error when Text is not the last field")
+	}
+}
+
+func TestSubtitlesRender(t *testing.T) {
+	ass, err := ParseSubtitles([]byte(testASS), format.FormatASS)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+	srt, err := ass.Render(format.FormatSRT)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	want := "1\n00:00:01,000 --> 00:00:02,500\n<i>One, two</i>\nthree\n\n2\n00:00:03,000 --> 00:00:04,000\nFour\n\n"
+	if string(srt) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, srt)
+	}
+
+	vtt, err := ParseSubtitles([]byte(testVTT), format.FormatVTT)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+	converted, err := vtt.Render(format.FormatASS)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.HasPrefix(string(converted), "[Script Info]") ||
+		!strings.Contains(string(converted), "Dialogue: 0,0:00:01.00,0:00:03.00,Default,,0,0,0,,Welcome to the course\n") {
+		t.Errorf("Unexpected ASS:\n%s", converted)
+	}
+
+	if _, err := vtt.Render(format.FormatEPUB); err == nil {
+		t.Error("Expected an error for a non-subtitle format")
+	}
+}
+
+func TestSubtitleSegments(t *testing.T) {
+	subtitles, err := ParseSubtitles([]byte(testSRT), format.FormatSRT)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	segments := subtitles.Segments()
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(segments))
+	}
+	if segments[0].ID != "cue1" || segments[0].Kind != SegmentCue || segments[0].Text != "Hello there, my friend." {
+		t.Errorf("Unexpected segment %+v", segments[0])
+	}
+	if segments[1].Text != "Goodbye" {
+		t.Errorf("Unexpected segment %+v", segments[1])
+	}
+}
+
+func TestUniversalParserSubtitles(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "lesson.vtt")
+	if err := os.WriteFile(filename, []byte(testVTT), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := NewUniversalParser().Parse(filename)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if book.Format != format.FormatVTT || book.Metadata.Title != "lesson" {
+		t.Errorf("Unexpected book %s %q", book.Format, book.Metadata.Title)
+	}
+	if content := book.Chapters[0].Sections[0].Content; content != "Welcome to the course\n\nLet's start." {
+		t.Errorf("Unexpected content %q", content)
+	}
+}
@@ -0,0 +1,349 @@
+package ebook
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+// SubtitleContext is passed for subtitle cues, followed by the length limit
+// and the neighbouring cues
+const SubtitleContext = "Subtitle cue with styling tags: keep every <xN/> tag, translating the text to be read on screen"
+
+// SubtitleStats reports the work of a subtitle translation
+type SubtitleStats struct {
+	Cues      int // cues translated
+	Merged    int // cues whose styling tags were lost in translation, put around the text
+	Condensed int // cues translated again to fit the line and reading-speed limits
+	TooLong   int // cues still over a limit after condensing
+}
+
+// SubtitleTranslator translates subtitles cue by cue. Every cue is sent
+// with its styling tags as placeholders and the cues around it as context,
+// then wrapped to the line length. A translation too long for the lines of
+// a cue or for the time it is shown is translated again, asking for a
+// shorter text. Timing, numbering, styles and positioning are kept.
+type SubtitleTranslator struct {
+	translate TranslateFunc
+
+	// MaxLineLength is the number of characters per line; MaxLines the
+	// number of lines per cue
+	MaxLineLength int
+	MaxLines      int
+
+	// MaxCharsPerSecond is the reading speed a cue must not exceed; zero
+	// disables the check. Cues are never held to less than their source.
+	MaxCharsPerSecond float64
+
+	// ContextCues is the number of cues before and after a cue passed as
+	// context
+	ContextCues int
+
+	// OnProgress, when set, is called after each translated cue
+	OnProgress func(done, total int)
+}
+
+// NewSubtitleTranslator creates a subtitle translator with the common
+// limits of two lines of 42 characters and 17 characters per second
+func NewSubtitleTranslator(translate TranslateFunc) *SubtitleTranslator {
+	return &SubtitleTranslator{
+		translate:         translate,
+		MaxLineLength:     42,
+		MaxLines:          2,
+		MaxCharsPerSecond: 17,
+		ContextCues:       2,
+	}
+}
+
+// Translate translates the subtitles at inputPath and writes them to
+// outputPath, in the format its extension names or else in their own
+func (t *SubtitleTranslator) Translate(ctx context.Context, inputPath, outputPath string) (*SubtitleStats, error) {
+	subtitles, err := ReadSubtitles(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := t.TranslateSubtitles(ctx, subtitles)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFormat := format.ParseFormat(strings.TrimPrefix(filepath.Ext(outputPath), "."))
+	if !outputFormat.IsSubtitle() {
+		outputFormat = subtitles.Format
+	}
+	if err := subtitles.WriteFile(outputPath, outputFormat); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// TranslateSubtitles translates the text of every cue
+func (t *SubtitleTranslator) TranslateSubtitles(ctx context.Context, subtitles *Subtitles) (*SubtitleStats, error) {
+	sources := make([]string, len(subtitles.Cues))
+	for i, cue := range subtitles.Cues {
+		sources[i] = plainCueText(cue.Text)
+	}
+
+	stats := &SubtitleStats{}
+	for i := range subtitles.Cues {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if sources[i] != "" {
+			if err := t.translateCue(ctx, &subtitles.Cues[i], sources, i, stats); err != nil {
+				return nil, err
+			}
+		}
+		if t.OnProgress != nil {
+			t.OnProgress(i+1, len(subtitles.Cues))
+		}
+	}
+	return stats, nil
+}
+
+func (t *SubtitleTranslator) translateCue(ctx context.Context, cue *Cue, sources []string, i int, stats *SubtitleStats) error {
+	source := newCueSource(cue.Text)
+	limit := t.limit(cue, utf8.RuneCountInString(sources[i]))
+	context := t.context(sources, i, limit)
+
+	translated, err := t.translate(ctx, source.text, context)
+	if err != nil {
+		return err
+	}
+	result, kept := source.rebuild(translated)
+
+	if limit > 0 && visibleLength(result) > limit {
+		shorter, err := t.translate(ctx, source.text,
+			fmt.Sprintf("%s\nThe translation was too long: shorten it to at most %d characters", context, limit))
+		if err != nil {
+			return err
+		}
+		if condensed, ok := source.rebuild(shorter); visibleLength(condensed) < visibleLength(result) {
+			result, kept = condensed, ok
+			stats.Condensed++
+		}
+		if visibleLength(result) > limit {
+			stats.TooLong++
+		}
+	}
+
+	if !kept {
+		stats.Merged++
+	}
+	cue.Text = t.wrap(result)
+	stats.Cues++
+	return nil
+}
+
+// limit returns the number of characters a cue may hold, or 0 for none
+func (t *SubtitleTranslator) limit(cue *Cue, sourceLength int) int {
+	limit := 0
+	if t.MaxLineLength > 0 && t.MaxLines > 0 {
+		limit = t.MaxLineLength * t.MaxLines
+	}
+	if t.MaxCharsPerSecond > 0 && cue.End > cue.Start {
+		if speed := int(t.MaxCharsPerSecond * (cue.End - cue.Start).Seconds()); limit == 0 || speed < limit {
+			limit = speed
+		}
+	}
+	if limit > 0 && limit < sourceLength {
+		limit = sourceLength
+	}
+	return limit
+}
+
+// context describes a cue with its limit and the cues around it
+func (t *SubtitleTranslator) context(sources []string, i, limit int) string {
+	var b strings.Builder
+	b.WriteString(SubtitleContext)
+	if limit > 0 {
+		fmt.Fprintf(&b, ", in at most %d characters", limit)
+	}
+
+	neighbours := func(title string, from, to int) {
+		var lines []string
+		for j := max(from, 0); j < min(to, len(sources)); j++ {
+			if sources[j] != "" {
+				lines = append(lines, sources[j])
+			}
+		}
+		if len(lines) > 0 {
+			b.WriteString("\n" + title + ":\n" + strings.Join(lines, "\n"))
+		}
+	}
+	neighbours("Previous cues", i-t.ContextCues, i)
+	neighbours("Next cues", i+1, i+1+t.ContextCues)
+	return b.String()
+}
+
+// cueSource is the text of a cue sent for translation, with its tags as
+// placeholders
+type cueSource struct {
+	text string
+	tags []string // "\n" for the line breaks of dialogue lines
+	lead int      // tags before the text, such as position overrides
+}
+
+// newCueSource replaces the tags of a cue by placeholders and joins its
+// lines. The lines of dialogue, each starting with a dash, are kept apart.
+func newCueSource(text string) *cueSource {
+	lines := strings.Split(text, "\n")
+	dialogue := len(lines) > 1
+	for _, line := range lines {
+		dialogue = dialogue && strings.HasPrefix(plainCueText(line), "-")
+	}
+
+	source := &cueSource{}
+	var b strings.Builder
+	seenText := false
+	placeholder := func(tag string) {
+		source.tags = append(source.tags, tag)
+		fmt.Fprintf(&b, "<x%d/>", len(source.tags))
+	}
+	for i, line := range lines {
+		if i > 0 {
+			if dialogue {
+				placeholder("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		pos := 0
+		for _, m := range cueTag.FindAllStringIndex(line, -1) {
+			b.WriteString(line[pos:m[0]])
+			seenText = seenText || strings.TrimSpace(line[pos:m[0]]) != ""
+			if !seenText {
+				source.lead++
+			}
+			placeholder(line[m[0]:m[1]])
+			pos = m[1]
+		}
+		b.WriteString(line[pos:])
+		seenText = seenText || strings.TrimSpace(line[pos:]) != ""
+	}
+	source.text = strings.TrimSpace(b.String())
+	return source
+}
+
+// cuePlaceholder matches the placeholders of a cue
+var cuePlaceholder = regexp.MustCompile(`(?i)<x(\d+) ?/>`)
+
+// rebuild puts the tags back into a translated cue. When the placeholders
+// do not come back once each and in order, the tags that preceded the text
+// go before the translation and the others after it; it reports whether
+// the tags were kept in place.
+func (s *cueSource) rebuild(translated string) (string, bool) {
+	translated = strings.Join(strings.Fields(translated), " ")
+
+	var out strings.Builder
+	pos, next := 0, 1
+	ok := true
+	for _, m := range cuePlaceholder.FindAllStringSubmatchIndex(translated, -1) {
+		id, _ := strconv.Atoi(translated[m[2]:m[3]])
+		if id != next {
+			ok = false
+			break
+		}
+		out.WriteString(translated[pos:m[0]])
+		out.WriteString(s.tags[id-1])
+		pos = m[1]
+		next++
+	}
+	if ok && next == len(s.tags)+1 {
+		out.WriteString(translated[pos:])
+		return tidyLines(out.String()), true
+	}
+
+	out.Reset()
+	for i, tag := range s.tags {
+		if i < s.lead {
+			out.WriteString(tag)
+		}
+	}
+	out.WriteString(strings.Join(strings.Fields(cuePlaceholder.ReplaceAllString(translated, " ")), " "))
+	for i, tag := range s.tags {
+		if i >= s.lead && tag != "\n" {
+			out.WriteString(tag)
+		}
+	}
+	return out.String(), false
+}
+
+// tidyLines removes the spaces around line breaks
+var lineBreakSpaces = regexp.MustCompile(` *\n *`)
+
+func tidyLines(text string) string {
+	return lineBreakSpaces.ReplaceAllString(text, "\n")
+}
+
+// visibleLength counts the characters of a cue shown on screen
+func visibleLength(text string) int {
+	return utf8.RuneCountInString(plainCueText(text))
+}
+
+// wrap breaks a cue into lines of at most MaxLineLength characters, as
+// evenly as possible for two lines. Cues that already have line breaks,
+// as dialogue does, are left as they are.
+func (t *SubtitleTranslator) wrap(text string) string {
+	if t.MaxLineLength <= 0 || strings.Contains(text, "\n") {
+		return text
+	}
+
+	// Spaces outside tags, with the characters before them
+	type breakPoint struct{ pos, before int }
+	var breaks []breakPoint
+	visible := 0
+	tags := cueTag.FindAllStringIndex(text, -1)
+	for i := 0; i < len(text); {
+		if len(tags) > 0 && tags[0][0] == i {
+			i = tags[0][1]
+			tags = tags[1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == ' ' {
+			breaks = append(breaks, breakPoint{i, visible})
+		}
+		visible++
+		i += size
+	}
+	if visible <= t.MaxLineLength || len(breaks) == 0 {
+		return text
+	}
+
+	var cuts []breakPoint
+	if visible <= 2*t.MaxLineLength {
+		best := breaks[0]
+		for _, b := range breaks[1:] {
+			if max(b.before, visible-b.before-1) < max(best.before, visible-best.before-1) {
+				best = b
+			}
+		}
+		cuts = append(cuts, best)
+	} else {
+		lineStart, prev := 0, -1
+		for j, b := range breaks {
+			if b.before-lineStart > t.MaxLineLength && prev >= 0 && breaks[prev].before >= lineStart {
+				cuts = append(cuts, breaks[prev])
+				lineStart = breaks[prev].before + 1
+			}
+			prev = j
+		}
+		if visible-lineStart > t.MaxLineLength && breaks[prev].before >= lineStart {
+			cuts = append(cuts, breaks[prev])
+		}
+	}
+
+	wrapped := []byte(text)
+	for _, cut := range cuts {
+		wrapped[cut.pos] = '\n'
+	}
+	return tidyLines(string(wrapped))
+}
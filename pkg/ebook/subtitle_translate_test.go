@@ -0,0 +1,161 @@
+package ebook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+func TestSubtitleTranslatorKeepsTagsAndPassesContext(t *testing.T) {
+	subtitles, err := ParseSubtitles([]byte(testSRT), format.FormatSRT)
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	var texts, contexts []string
+	translator := NewSubtitleTranslator(func(ctx context.Context, text, context string) (string, error) {
+		texts = append(texts, text)
+		contexts = append(contexts, context)
+		return "DE " + text, nil
+	})
+	stats, err := translator.TranslateSubtitles(context.Background(), subtitles)
+	if err != nil {
+		t.Fatalf("TranslateSubtitles failed: %v", err)
+	}
+
+	if texts[0] != "<x1/>Hello<x2/> there, my friend." || texts[1] != "<x1/>Goodbye" {
+		t.Errorf("Unexpected texts %q", texts)
+	}
+	if !strings.HasPrefix(contexts[0], SubtitleContext) || !strings.Contains(contexts[0], "Next cues:\nGoodbye") {
+		t.Errorf("Unexpected context %q", contexts[0])
+	}
+	if !strings.Contains(contexts[1], "Previous cues:\nHello there, my friend.") {
+		t.Errorf("Unexpected context %q", contexts[1])
+	}
+
+	if subtitles.Cues[0].Text != "DE <i>Hello</i> there, my friend." || subtitles.Cues[1].Text != `DE {\an8}Goodbye` {
+		t.Errorf("Unexpected cues %q, %q", subtitles.Cues[0].Text, subtitles.Cues[1].Text)
+	}
+	if stats.Cues != 2 || stats.Merged != 0 || stats.Condensed != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if !strings.Contains(string(subtitles.Bytes()), "00:00:04,000 --> 00:00:06,000 X1:100 X2:200\r\n") {
+		t.Error("Timing line was not kept")
+	}
+}
+
+func TestSubtitleTranslatorWrapsLines(t *testing.T) {
+	translator := NewSubtitleTranslator(nil)
+	translator.MaxLineLength = 20
+
+	tests := []struct {
+		text, expected string
+	}{
+		{"Short line", "Short line"},
+		{"This sentence needs two lines", "This sentence\nneeds two lines"},
+		{"<i>This sentence</i> needs two lines", "<i>This sentence</i>\nneeds two lines"},
+		{"One two three four five six seven eight nine ten eleven", "One two three four\nfive six seven eight\nnine ten eleven"},
+		{"- Yes?\n- No.", "- Yes?\n- No."},
+	}
+	for _, tt := range tests {
+		if wrapped := translator.wrap(tt.text); wrapped != tt.expected {
+			t.Errorf("wrap(%q) = %q, want %q", tt.text, wrapped, tt.expected)
+		}
+	}
+}
+
+func TestSubtitleTranslatorCondensesFastCues(t *testing.T) {
+	subtitles := &Subtitles{Format: format.FormatSRT, Cues: []Cue{
+		{Start: 0, End: time.Second, Text: "Go now!"},
+	}}
+
+	var contexts []string
+	translator := NewSubtitleTranslator(func(ctx context.Context, text, context string) (string, error) {
+		contexts = append(contexts, context)
+		if strings.Contains(context, "shorten") {
+			return "Los jetzt!", nil
+		}
+		return "Du musst jetzt sofort gehen!", nil
+	})
+	stats, err := translator.TranslateSubtitles(context.Background(), subtitles)
+	if err != nil {
+		t.Fatalf("TranslateSubtitles failed: %v", err)
+	}
+
+	if len(contexts) != 2 || !strings.Contains(contexts[1], "at most 17 characters") {
+		t.Errorf("Unexpected contexts %q", contexts)
+	}
+	if subtitles.Cues[0].Text != "Los jetzt!" || stats.Condensed != 1 || stats.TooLong != 0 {
+		t.Errorf("Unexpected result %q, %+v", subtitles.Cues[0].Text, stats)
+	}
+}
+
+func TestSubtitleTranslatorDialogueAndLostTags(t *testing.T) {
+	subtitles := &Subtitles{Format: format.FormatSRT, Cues: []Cue{
+		{Start: 0, End: 5 * time.Second, Text: "- Ready?\n- <b>Always</b>."},
+		{Start: 5 * time.Second, End: 10 * time.Second, Text: "{\\an8}<i>Somewhere</i> far"},
+	}}
+
+	translator := NewSubtitleTranslator(func(ctx context.Context, text, context string) (string, error) {
+		switch text {
+		case "- Ready?<x1/>- <x2/>Always<x3/>.":
+			return "- Bereit?<x1/>- <x2/>Immer<x3/>.", nil
+		default:
+			return "Irgendwo weit weg", nil
+		}
+	})
+	stats, err := translator.TranslateSubtitles(context.Background(), subtitles)
+	if err != nil {
+		t.Fatalf("TranslateSubtitles failed: %v", err)
+	}
+
+	if subtitles.Cues[0].Text != "- Bereit?\n- <b>Immer</b>." {
+		t.Errorf("Unexpected dialogue %q", subtitles.Cues[0].Text)
+	}
+	if subtitles.Cues[1].Text != "{\\an8}<i>Irgendwo weit weg</i>" || stats.Merged != 1 {
+		t.Errorf("Unexpected cue %q, %+v", subtitles.Cues[1].Text, stats)
+	}
+}
+
+func TestSubtitleTranslatorConvertsFiles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "lesson.ass")
+	if err := os.WriteFile(input, []byte(testASS), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	translator := NewSubtitleTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return strings.ReplaceAll(strings.ReplaceAll(text, "One, two", "Eins, zwei"), "Four", "Vier"), nil
+	})
+
+	output := filepath.Join(dir, "lesson_de.ass")
+	if _, err := translator.Translate(context.Background(), input, output); err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The short lines of the first cue fit on one line once translated
+	want := strings.NewReplacer("One, two{\\i0}\\N", "Eins, zwei{\\i0} ", ",,Four", ",,Vier").Replace(testASS)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+
+	output = filepath.Join(dir, "lesson_de.vtt")
+	if _, err := translator.Translate(context.Background(), input, output); err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	data, err = os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\n<i>Eins, zwei</i> three\n") {
+		t.Errorf("Unexpected WebVTT:\n%s", data)
+	}
+}
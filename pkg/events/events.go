@@ -1,6 +1,7 @@
 package events
 
 import (
+	"log"
 	"sync"
 	"time"
 )
@@ -17,6 +18,10 @@ const (
 	EventConversionProgress   EventType = "conversion_progress"
 	EventConversionCompleted  EventType = "conversion_completed"
 	EventConversionError      EventType = "conversion_error"
+	EventVerificationStarted  EventType = "verification_started"
+	EventVerificationProgress EventType = "verification_progress"
+	EventVerificationComplete EventType = "verification_completed"
+	EventVerificationWarning  EventType = "verification_warning"
 )
 
 // Event represents a system event
@@ -27,69 +32,137 @@ type Event struct {
 	Message   string                 `json:"message"`
 	Data      map[string]interface{} `json:"data,omitempty"`
 	SessionID string                 `json:"session_id,omitempty"`
+
+	// Sequence is assigned by EventBus.Publish; it starts at 1 and increases
+	// by one per session, events without a session share one sequence
+	Sequence int64 `json:"sequence,omitempty"`
 }
 
 // EventHandler is a function that processes events
 type EventHandler func(event Event)
 
-// EventBus manages event distribution
+// EventBus manages event distribution. Each subscriber receives events in
+// publish order from its own bounded queue, so a slow handler does not hold
+// up the others.
 type EventBus struct {
 	mu        sync.RWMutex
-	handlers  map[EventType][]EventHandler
-	allEvents []EventHandler
+	handlers  map[EventType][]*Subscription
+	allEvents []*Subscription
+
+	// publishMu orders publishing, sequence numbers and the event log
+	publishMu sync.Mutex
+	sequences map[string]int64
+	log       EventLog
 }
 
 // NewEventBus creates a new event bus
 func NewEventBus() *EventBus {
 	return &EventBus{
-		handlers:  make(map[EventType][]EventHandler),
-		allEvents: make([]EventHandler, 0),
+		handlers:  make(map[EventType][]*Subscription),
+		allEvents: make([]*Subscription, 0),
+		sequences: make(map[string]int64),
 	}
 }
 
+// SetLog makes the bus append every published event to log, which also
+// serves EventsSince. Sequence numbers continue from the ones in the log.
+func (eb *EventBus) SetLog(eventLog EventLog) {
+	eb.publishMu.Lock()
+	defer eb.publishMu.Unlock()
+
+	eb.log = eventLog
+	eb.sequences = make(map[string]int64)
+}
+
 // Subscribe adds a handler for a specific event type
 func (eb *EventBus) Subscribe(eventType EventType, handler EventHandler) {
-	eb.mu.Lock()
-	defer eb.mu.Unlock()
-	eb.handlers[eventType] = append(eb.handlers[eventType], handler)
+	eb.SubscribeWithOptions(handler, SubscriberOptions{Types: []EventType{eventType}})
 }
 
 // SubscribeAll adds a handler for all events
 func (eb *EventBus) SubscribeAll(handler EventHandler) {
-	eb.mu.Lock()
-	defer eb.mu.Unlock()
-	eb.allEvents = append(eb.allEvents, handler)
+	eb.SubscribeWithOptions(handler, SubscriberOptions{})
 }
 
-// Publish sends an event to all subscribed handlers
+// Publish assigns the next sequence number of the event's session, appends
+// the event to the log and queues it for every matching subscriber
 func (eb *EventBus) Publish(event Event) {
+	eb.publishMu.Lock()
+	defer eb.publishMu.Unlock()
+
+	if event.ID == "" {
+		event.ID = generateEventID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	event.Sequence = eb.nextSequence(event.SessionID)
+
+	if eb.log != nil {
+		if err := eb.log.Append(event); err != nil {
+			log.Printf("events: failed to append %s event to log: %v", event.Type, err)
+		}
+	}
+
 	eb.mu.RLock()
-	defer eb.mu.RUnlock()
-
-	// Send to specific handlers
-	if handlers, ok := eb.handlers[event.Type]; ok {
-		for _, handler := range handlers {
-			go func(h EventHandler) {
-				defer func() {
-					if r := recover(); r != nil {
-						// Handler panicked, but we continue
-					}
-				}()
-				h(event)
-			}(handler)
+	subscriptions := make([]*Subscription, 0, len(eb.handlers[event.Type])+len(eb.allEvents))
+	subscriptions = append(subscriptions, eb.handlers[event.Type]...)
+	subscriptions = append(subscriptions, eb.allEvents...)
+	eb.mu.RUnlock()
+
+	for _, subscription := range subscriptions {
+		subscription.enqueue(event)
+	}
+}
+
+// EventsSince returns up to limit logged events of a session with a sequence
+// greater than afterSequence; limit <= 0 returns all of them
+func (eb *EventBus) EventsSince(sessionID string, afterSequence int64, limit int) ([]Event, error) {
+	eb.publishMu.Lock()
+	eventLog := eb.log
+	eb.publishMu.Unlock()
+
+	if eventLog == nil {
+		return nil, ErrNoEventLog
+	}
+	return eventLog.Since(sessionID, afterSequence, limit)
+}
+
+// nextSequence returns the next sequence number of a session. Callers hold eb.publishMu.
+func (eb *EventBus) nextSequence(sessionID string) int64 {
+	last, known := eb.sequences[sessionID]
+	if !known && eb.log != nil {
+		var err error
+		if last, err = eb.log.LastSequence(sessionID); err != nil {
+			log.Printf("events: failed to read last sequence of session %q: %v", sessionID, err)
+		}
+	}
+
+	eb.sequences[sessionID] = last + 1
+	return last + 1
+}
+
+// remove drops a subscription from the bus
+func (eb *EventBus) remove(subscription *Subscription) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	without := func(subscriptions []*Subscription) []*Subscription {
+		kept := make([]*Subscription, 0, len(subscriptions))
+		for _, s := range subscriptions {
+			if s != subscription {
+				kept = append(kept, s)
+			}
 		}
+		return kept
 	}
 
-	// Send to all-event handlers
-	for _, handler := range eb.allEvents {
-		go func(h EventHandler) {
-			defer func() {
-				if r := recover(); r != nil {
-					// Handler panicked, but we continue
-				}
-			}()
-			h(event)
-		}(handler)
+	if len(subscription.options.Types) == 0 {
+		eb.allEvents = without(eb.allEvents)
+		return
+	}
+	for _, eventType := range subscription.options.Types {
+		eb.handlers[eventType] = without(eb.handlers[eventType])
 	}
 }
 
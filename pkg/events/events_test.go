@@ -349,3 +349,117 @@ func BenchmarkNewEvent(b *testing.B) {
 		_ = NewEvent(EventTranslationProgress, "Benchmark", data)
 	}
 }
+
+// TestEventBus_OrderedDelivery tests that a subscriber sees events in publish order
+func TestEventBus_OrderedDelivery(t *testing.T) {
+	bus := NewEventBus()
+	received := make(chan Event, 100)
+
+	bus.SubscribeAll(func(event Event) {
+		received <- event
+	})
+
+	for i := 0; i < 100; i++ {
+		event := NewEvent(EventTranslationProgress, "Test", map[string]interface{}{"i": i})
+		event.SessionID = "session-1"
+		bus.Publish(event)
+	}
+
+	for i := 0; i < 100; i++ {
+		select {
+		case event := <-received:
+			assert.Equal(t, i, event.Data["i"])
+			assert.Equal(t, int64(i+1), event.Sequence)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+}
+
+// TestEventBus_SequencePerSession tests that sequences are counted per session
+func TestEventBus_SequencePerSession(t *testing.T) {
+	bus := NewEventBus()
+	received := make(chan Event, 3)
+
+	sub := bus.SubscribeWithOptions(func(event Event) {
+		received <- event
+	}, SubscriberOptions{SessionID: "b"})
+	defer sub.Unsubscribe()
+
+	for _, sessionID := range []string{"a", "b", "a", "b"} {
+		bus.Publish(Event{Type: EventTranslationProgress, SessionID: sessionID})
+	}
+
+	first := <-received
+	second := <-received
+	assert.Equal(t, "b", first.SessionID)
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.Timestamp.IsZero())
+}
+
+// TestSubscription_DropPolicies tests bounded queues of slow subscribers
+func TestSubscription_DropPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		policy DropPolicy
+		want   []int64
+	}{
+		{DropOldest, []int64{1, 4, 5}},
+		{DropNewest, []int64{1, 2, 3}},
+	} {
+		bus := NewEventBus()
+		release := make(chan struct{})
+		var mu sync.Mutex
+		var got []int64
+
+		sub := bus.SubscribeWithOptions(func(event Event) {
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, event.Sequence)
+		}, SubscriberOptions{BufferSize: 2, Policy: tc.policy})
+
+		// The first event is taken by the handler, two fit in the queue
+		bus.Publish(Event{Type: EventTranslationProgress})
+		require.Eventually(t, func() bool { return len(sub.queue) == 0 }, time.Second, time.Millisecond)
+		for i := 0; i < 4; i++ {
+			bus.Publish(Event{Type: EventTranslationProgress})
+		}
+		close(release)
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(got) == 3
+		}, time.Second, time.Millisecond)
+		mu.Lock()
+		assert.Equal(t, tc.want, got, "policy %d", tc.policy)
+		mu.Unlock()
+		assert.Equal(t, int64(2), sub.Dropped())
+		sub.Unsubscribe()
+	}
+}
+
+// TestSubscription_Unsubscribe tests that delivery stops and panics are counted
+func TestSubscription_Unsubscribe(t *testing.T) {
+	bus := NewEventBus()
+	calls := make(chan struct{}, 10)
+
+	sub := bus.SubscribeWithOptions(func(event Event) {
+		calls <- struct{}{}
+		panic("handler failure")
+	}, SubscriberOptions{Types: []EventType{EventTranslationError}})
+
+	bus.Publish(Event{Type: EventTranslationError})
+	<-calls
+	require.Eventually(t, func() bool { return sub.Panics() == 1 }, time.Second, time.Millisecond)
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	bus.Publish(Event{Type: EventTranslationError})
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Len(t, calls, 0)
+	assert.Empty(t, bus.handlers[EventTranslationError])
+}
@@ -0,0 +1,181 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// ErrNoEventLog is returned when events are queried from a bus without a log
+var ErrNoEventLog = errors.New("event log not configured")
+
+// EventLog is an append-only store of published events used for replay
+type EventLog interface {
+	// Append stores an event; events of a session arrive in sequence order
+	Append(event Event) error
+
+	// Since returns up to limit events of a session with a sequence greater
+	// than afterSequence in sequence order; limit <= 0 returns all of them
+	Since(sessionID string, afterSequence int64, limit int) ([]Event, error)
+
+	// LastSequence returns the highest stored sequence of a session, 0 if none
+	LastSequence(sessionID string) (int64, error)
+
+	// Close releases the log
+	Close() error
+}
+
+// NewEventLog opens an event log of the given type, "file" or "sqlite", at path
+func NewEventLog(logType, path string) (EventLog, error) {
+	switch logType {
+	case "file":
+		return NewFileEventLog(path)
+	case "sqlite":
+		return NewSQLiteEventLog(path)
+	default:
+		return nil, fmt.Errorf("unsupported event log type: %s", logType)
+	}
+}
+
+// fileLogEntry locates an event in a file log
+type fileLogEntry struct {
+	sequence int64
+	offset   int64
+}
+
+// FileEventLog stores events as JSON lines in a single append-only file and
+// keeps an index of each session's events in memory
+type FileEventLog struct {
+	file  *os.File
+	size  int64
+	index map[string][]fileLogEntry
+	mu    sync.RWMutex
+}
+
+// NewFileEventLog opens or creates the log at path and indexes its events. A
+// partially written last line, e.g. after a crash, is cut off.
+func NewFileEventLog(path string) (*FileEventLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	l := &FileEventLog{
+		file:  file,
+		index: make(map[string][]fileLogEntry),
+	}
+	if err := l.load(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// load builds the index and truncates a broken tail
+func (l *FileEventLog) load() error {
+	reader := bufio.NewReader(l.file)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+
+		var event Event
+		if json.Unmarshal(line, &event) != nil {
+			break
+		}
+		l.index[event.SessionID] = append(l.index[event.SessionID], fileLogEntry{sequence: event.Sequence, offset: offset})
+		offset += int64(len(line))
+	}
+
+	if err := l.file.Truncate(offset); err != nil {
+		return fmt.Errorf("failed to truncate event log: %w", err)
+	}
+	l.size = offset
+	return nil
+}
+
+// Append writes an event as a line at the end of the file
+func (l *FileEventLog) Append(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.WriteAt(data, l.size); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	l.index[event.SessionID] = append(l.index[event.SessionID], fileLogEntry{sequence: event.Sequence, offset: l.size})
+	l.size += int64(len(data))
+
+	return nil
+}
+
+// Since reads the events of a session after afterSequence
+func (l *FileEventLog) Since(sessionID string, afterSequence int64, limit int) ([]Event, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := l.index[sessionID]
+	start := sort.Search(len(entries), func(i int) bool { return entries[i].sequence > afterSequence })
+	entries = entries[start:]
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		reader := bufio.NewReader(io.NewSectionReader(l.file, entry.offset, l.size-entry.offset))
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// LastSequence returns the sequence of the last event of a session
+func (l *FileEventLog) LastSequence(sessionID string) (int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := l.index[sessionID]
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	return entries[len(entries)-1].sequence, nil
+}
+
+// Close syncs and closes the file
+func (l *FileEventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.file.Sync(); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
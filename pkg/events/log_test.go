@@ -0,0 +1,89 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLogs(t *testing.T) {
+	for _, logType := range []string{"file", "sqlite"} {
+		t.Run(logType, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "events."+logType)
+
+			eventLog, err := NewEventLog(logType, path)
+			require.NoError(t, err)
+
+			bus := NewEventBus()
+			bus.SetLog(eventLog)
+			for i := 0; i < 5; i++ {
+				bus.Publish(Event{Type: EventTranslationProgress, SessionID: "s1", Message: "step", Data: map[string]interface{}{"step": float64(i)}})
+			}
+			bus.Publish(Event{Type: EventTranslationStarted, SessionID: "s2"})
+
+			events, err := bus.EventsSince("s1", 2, 0)
+			require.NoError(t, err)
+			require.Len(t, events, 3)
+			assert.Equal(t, int64(3), events[0].Sequence)
+			assert.Equal(t, float64(2), events[0].Data["step"])
+			assert.Equal(t, EventTranslationProgress, events[0].Type)
+
+			events, err = bus.EventsSince("s1", 0, 2)
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+			require.NoError(t, eventLog.Close())
+
+			// Sequences continue after a restart
+			eventLog, err = NewEventLog(logType, path)
+			require.NoError(t, err)
+			defer eventLog.Close()
+
+			bus = NewEventBus()
+			bus.SetLog(eventLog)
+			bus.Publish(Event{Type: EventTranslationCompleted, SessionID: "s1"})
+
+			events, err = bus.EventsSince("s1", 5, 0)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, int64(6), events[0].Sequence)
+			assert.Equal(t, EventTranslationCompleted, events[0].Type)
+		})
+	}
+}
+
+func TestFileEventLog_TruncatesPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	eventLog, err := NewFileEventLog(path)
+	require.NoError(t, err)
+	require.NoError(t, eventLog.Append(Event{ID: "1", Type: EventTranslationStarted, SessionID: "s1", Sequence: 1}))
+	require.NoError(t, eventLog.Close())
+
+	// Simulate a crash in the middle of writing the next event
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"id":"2","type":"transl`)
+	require.NoError(t, err)
+	file.Close()
+
+	eventLog, err = NewFileEventLog(path)
+	require.NoError(t, err)
+	defer eventLog.Close()
+
+	last, err := eventLog.LastSequence("s1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), last)
+
+	require.NoError(t, eventLog.Append(Event{ID: "2", Type: EventTranslationProgress, SessionID: "s1", Sequence: 2}))
+	events, err := eventLog.Since("s1", 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestEventBus_EventsSinceWithoutLog(t *testing.T) {
+	_, err := NewEventBus().EventsSince("s1", 0, 0)
+	assert.ErrorIs(t, err, ErrNoEventLog)
+}
@@ -0,0 +1,115 @@
+package events
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+)
+
+// SQLiteEventLog stores events in an SQLite table
+type SQLiteEventLog struct {
+	db *sql.DB
+}
+
+// NewSQLiteEventLog opens or creates an event log in the SQLite database at path
+func NewSQLiteEventLog(path string) (*SQLiteEventLog, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS event_log (
+			session_id TEXT NOT NULL,
+			sequence INTEGER NOT NULL,
+			id TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			timestamp DATETIME NOT NULL,
+			message TEXT,
+			data TEXT,
+			PRIMARY KEY (session_id, sequence)
+		)
+	`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create event log table: %w", err)
+	}
+
+	return &SQLiteEventLog{db: db}, nil
+}
+
+// Append inserts an event
+func (l *SQLiteEventLog) Append(event Event) error {
+	var data []byte
+	if len(event.Data) > 0 {
+		var err error
+		if data, err = json.Marshal(event.Data); err != nil {
+			return fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+
+	_, err := l.db.Exec(`
+		INSERT INTO event_log (session_id, sequence, id, event_type, timestamp, message, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, event.SessionID, event.Sequence, event.ID, string(event.Type), event.Timestamp, event.Message, string(data))
+	return err
+}
+
+// Since queries the events of a session after afterSequence
+func (l *SQLiteEventLog) Since(sessionID string, afterSequence int64, limit int) ([]Event, error) {
+	if limit <= 0 {
+		limit = -1 // no limit in SQLite
+	}
+
+	rows, err := l.db.Query(`
+		SELECT session_id, sequence, id, event_type, timestamp, message, data
+		FROM event_log
+		WHERE session_id = ? AND sequence > ?
+		ORDER BY sequence
+		LIMIT ?
+	`, sessionID, afterSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]Event, 0)
+	for rows.Next() {
+		var event Event
+		var eventType string
+		var timestamp time.Time
+		var message, data sql.NullString
+
+		if err := rows.Scan(&event.SessionID, &event.Sequence, &event.ID, &eventType, &timestamp, &message, &data); err != nil {
+			return nil, err
+		}
+		event.Type = EventType(eventType)
+		event.Timestamp = timestamp
+		event.Message = message.String
+
+		if data.String != "" {
+			if err := json.Unmarshal([]byte(data.String), &event.Data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// LastSequence returns the highest sequence stored for a session
+func (l *SQLiteEventLog) LastSequence(sessionID string) (int64, error) {
+	var sequence sql.NullInt64
+	err := l.db.QueryRow(`SELECT MAX(sequence) FROM event_log WHERE session_id = ?`, sessionID).Scan(&sequence)
+	return sequence.Int64, err
+}
+
+// Close closes the database
+func (l *SQLiteEventLog) Close() error {
+	return l.db.Close()
+}
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSubscriberBuffer is the queue size of subscribers that do not set one
+const DefaultSubscriberBuffer = 1024
+
+// DropPolicy decides what happens when a subscriber's queue is full
+type DropPolicy int
+
+const (
+	// DropOldest discards the oldest queued event to make room
+	DropOldest DropPolicy = iota
+
+	// DropNewest discards the event being published
+	DropNewest
+
+	// Block makes Publish wait for room. Handlers of blocking subscriptions
+	// must not publish to the same bus.
+	Block
+)
+
+// SubscriberOptions configures a subscription
+type SubscriberOptions struct {
+	// Types limits delivery to these event types; empty means all events
+	Types []EventType
+
+	// SessionID limits delivery to events of one session; empty means all sessions
+	SessionID string
+
+	// BufferSize is the queue size, DefaultSubscriberBuffer when 0
+	BufferSize int
+
+	// Policy applies when the queue is full
+	Policy DropPolicy
+}
+
+// Subscription delivers events to a handler one at a time, in publish order
+type Subscription struct {
+	bus     *EventBus
+	handler EventHandler
+	options SubscriberOptions
+	queue   chan Event
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+	panics  atomic.Int64
+}
+
+// SubscribeWithOptions adds a handler that receives events from a bounded
+// queue. Unsubscribe stops delivery.
+func (eb *EventBus) SubscribeWithOptions(handler EventHandler, options SubscriberOptions) *Subscription {
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultSubscriberBuffer
+	}
+
+	subscription := &Subscription{
+		bus:     eb,
+		handler: handler,
+		options: options,
+		queue:   make(chan Event, options.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go subscription.run()
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if len(options.Types) == 0 {
+		eb.allEvents = append(eb.allEvents, subscription)
+	} else {
+		for _, eventType := range options.Types {
+			eb.handlers[eventType] = append(eb.handlers[eventType], subscription)
+		}
+	}
+
+	return subscription
+}
+
+// Unsubscribe stops delivery; queued events are discarded
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.remove(s)
+		close(s.done)
+	})
+}
+
+// Dropped returns the number of events discarded because the queue was full
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Panics returns the number of events whose handler panicked
+func (s *Subscription) Panics() int64 {
+	return s.panics.Load()
+}
+
+// enqueue queues an event according to the drop policy
+func (s *Subscription) enqueue(event Event) {
+	if s.options.SessionID != "" && event.SessionID != s.options.SessionID {
+		return
+	}
+
+	for {
+		select {
+		case s.queue <- event:
+			return
+		case <-s.done:
+			return
+		default:
+		}
+
+		switch s.options.Policy {
+		case DropNewest:
+			s.dropped.Add(1)
+			return
+		case Block:
+			select {
+			case s.queue <- event:
+			case <-s.done:
+			}
+			return
+		default:
+			select {
+			case <-s.queue:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	}
+}
+
+// run delivers queued events until the subscription ends
+func (s *Subscription) run() {
+	for {
+		select {
+		case event := <-s.queue:
+			s.deliver(event)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// deliver calls the handler, logging and counting panics
+func (s *Subscription) deliver(event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.panics.Add(1)
+			log.Printf("events: handler for %s event %s panicked: %v", event.Type, event.ID, r)
+		}
+	}()
+
+	s.handler(event)
+}
@@ -1,10 +1,12 @@
 package fb2
 
 import (
+	"encoding/base64"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
 // FB2 namespace constants
@@ -73,8 +75,10 @@ type Coverpage struct {
 
 // Image represents an image reference
 type Image struct {
-	Href string `xml:"http://www.w3.org/1999/xlink href,attr"`
-	Alt  string `xml:"alt,attr,omitempty"`
+	Href  string `xml:"http://www.w3.org/1999/xlink href,attr"`
+	Alt   string `xml:"alt,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+	ID    string `xml:"id,attr,omitempty"`
 }
 
 // Sequence represents a book series
@@ -127,6 +131,7 @@ type Section struct {
 	Subtitle  []string    `xml:"subtitle,omitempty"`
 	Cite      []Cite      `xml:"cite,omitempty"`
 	EmptyLine []struct{}  `xml:"empty-line,omitempty"`
+	Image     []Image     `xml:"image,omitempty"`
 }
 
 // Title represents a title
@@ -256,6 +261,22 @@ func (p *Parser) WriteToWriter(writer io.Writer, fb *FictionBook) error {
 	return nil
 }
 
+// GetBinary returns the binary an image links to, e.g. "#cover.jpg"
+func (fb *FictionBook) GetBinary(href string) (*Binary, bool) {
+	id := strings.TrimPrefix(href, "#")
+	for i := range fb.Binary {
+		if fb.Binary[i].ID == id {
+			return &fb.Binary[i], true
+		}
+	}
+	return nil, false
+}
+
+// Decode returns the data of the binary
+func (b *Binary) Decode() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(b.Data), ""))
+}
+
 // GetLanguage returns the document language
 func (fb *FictionBook) GetLanguage() string {
 	return fb.Description.TitleInfo.Lang
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -24,6 +25,14 @@ const (
 	FormatHTML    Format = "html"
 	FormatDOCX    Format = "docx"
 	FormatRTF     Format = "rtf"
+	FormatSRT     Format = "srt"
+	FormatVTT     Format = "vtt"
+	FormatASS     Format = "ass"
+	FormatPO      Format = "po"
+	FormatXLIFF   Format = "xliff"
+	FormatJSON    Format = "json"
+	FormatAndroid Format = "android"
+	FormatStrings Format = "strings"
 	FormatUnknown Format = "unknown"
 )
 
@@ -110,6 +119,20 @@ func (d *Detector) detectByExtension(ext string) Format {
 		return FormatDOCX
 	case "rtf":
 		return FormatRTF
+	case "srt":
+		return FormatSRT
+	case "vtt":
+		return FormatVTT
+	case "ass", "ssa":
+		return FormatASS
+	case "po", "pot":
+		return FormatPO
+	case "xlf", "xliff":
+		return FormatXLIFF
+	case "json":
+		return FormatJSON
+	case "strings":
+		return FormatStrings
 	default:
 		return FormatUnknown
 	}
@@ -243,6 +266,14 @@ func (d *Detector) detectByContent(data []byte) Format {
 		}
 	}
 
+	// Check for XML localization files
+	if strings.Contains(content, "<xliff") {
+		return FormatXLIFF
+	}
+	if strings.Contains(content, "<resources") {
+		return FormatAndroid
+	}
+
 	// Check for HTML
 	if strings.Contains(content, "<html") || strings.Contains(content, "<!DOCTYPE html") {
 		return FormatHTML
@@ -253,6 +284,11 @@ func (d *Detector) detectByContent(data []byte) Format {
 		return FormatRTF
 	}
 
+	// Check for subtitles
+	if subtitle := d.detectSubtitle(content); subtitle != FormatUnknown {
+		return subtitle
+	}
+
 	// Check for PDF content (if magic bytes were missed)
 	if strings.Contains(content, "%PDF") {
 		return FormatPDF
@@ -276,6 +312,30 @@ func (d *Detector) detectByContent(data []byte) Format {
 	return FormatUnknown
 }
 
+// srtTiming matches the timing line of an SRT cue
+var srtTiming = regexp.MustCompile(`^\d+:\d{2}:\d{2},\d{3} +--> +\d+:\d{2}:\d{2},\d{3}`)
+
+// detectSubtitle detects WebVTT, ASS/SSA and SRT subtitles by their header
+// or first cue
+func (d *Detector) detectSubtitle(content string) Format {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if strings.HasPrefix(content, "WEBVTT") {
+		return FormatVTT
+	}
+	if strings.HasPrefix(content, "[Script Info]") {
+		return FormatASS
+	}
+
+	// An SRT file starts with the index and timing of its first cue
+	lines := strings.SplitN(strings.TrimLeft(content, "\r\n"), "\n", 3)
+	if len(lines) >= 2 && strings.TrimSpace(lines[0]) != "" &&
+		strings.Trim(strings.TrimSpace(lines[0]), "0123456789") == "" &&
+		srtTiming.MatchString(strings.TrimSpace(lines[1])) {
+		return FormatSRT
+	}
+	return FormatUnknown
+}
+
 // isPlainText checks if data is mostly plain text
 func (d *Detector) isPlainText(data []byte) bool {
 	if len(data) == 0 {
@@ -297,6 +357,14 @@ func (d *Detector) IsSupported(format Format) bool {
 		FormatEPUB,
 		FormatTXT,
 		FormatHTML,
+		FormatSRT,
+		FormatVTT,
+		FormatASS,
+		FormatPO,
+		FormatXLIFF,
+		FormatJSON,
+		FormatAndroid,
+		FormatStrings,
 	}
 
 	for _, f := range supported {
@@ -314,9 +382,28 @@ func (d *Detector) GetSupportedFormats() []Format {
 		FormatEPUB,
 		FormatTXT,
 		FormatHTML,
+		FormatSRT,
+		FormatVTT,
+		FormatASS,
+		FormatPO,
+		FormatXLIFF,
+		FormatJSON,
+		FormatAndroid,
+		FormatStrings,
 	}
 }
 
+// IsSubtitle reports whether a format is a subtitle format
+func (f Format) IsSubtitle() bool {
+	return f == FormatSRT || f == FormatVTT || f == FormatASS
+}
+
+// IsLocalization reports whether a format is a software localization
+// format
+func (f Format) IsLocalization() bool {
+	return f == FormatPO || f == FormatXLIFF || f == FormatJSON || f == FormatAndroid || f == FormatStrings
+}
+
 // FormatToString converts Format to string
 func (f Format) String() string {
 	return string(f)
@@ -346,6 +433,22 @@ func ParseFormat(s string) Format {
 		return FormatDOCX
 	case "rtf":
 		return FormatRTF
+	case "srt":
+		return FormatSRT
+	case "vtt", "webvtt":
+		return FormatVTT
+	case "ass", "ssa":
+		return FormatASS
+	case "po", "pot":
+		return FormatPO
+	case "xliff", "xlf":
+		return FormatXLIFF
+	case "json":
+		return FormatJSON
+	case "android":
+		return FormatAndroid
+	case "strings":
+		return FormatStrings
 	default:
 		return FormatUnknown
 	}
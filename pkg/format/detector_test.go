@@ -162,6 +162,84 @@ func TestDetectFileRTF(t *testing.T) {
 	}
 }
 
+func TestDetectFileSubtitles(t *testing.T) {
+	detector := NewDetector()
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		expected Format
+	}{
+		{"SRT by extension", "movie.srt", "1\n00:00:01,000 --> 00:00:02,000\nHello\n", FormatSRT},
+		{"SRT by content", "movie", "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n", FormatSRT},
+		{"WebVTT by content", "movie", "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n", FormatVTT},
+		{"ASS by content", "movie", "[Script Info]\nTitle: Movie\n", FormatASS},
+		{"SSA by extension", "movie.ssa", "[Script Info]\n", FormatASS},
+		{"Numbered text", "notes", "1\nNot a subtitle\n", FormatTXT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(tempDir, tt.filename)
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			format, err := detector.DetectFile(filename)
+			if err != nil {
+				t.Fatalf("DetectFile() failed: %v", err)
+			}
+			if format != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, format)
+			}
+			if format.IsSubtitle() != (tt.expected != FormatTXT) {
+				t.Errorf("IsSubtitle() = %v for %s", format.IsSubtitle(), format)
+			}
+		})
+	}
+}
+
+func TestDetectFileLocalization(t *testing.T) {
+	detector := NewDetector()
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		expected Format
+	}{
+		{"PO template", "messages.pot", "msgid \"\"\nmsgstr \"\"\n", FormatPO},
+		{"XLIFF by extension", "app.xlf", "<?xml version=\"1.0\"?>\n<xliff version=\"1.2\"></xliff>\n", FormatXLIFF},
+		{"XLIFF by content", "app.xml", "<?xml version=\"1.0\"?>\n<xliff version=\"2.0\"></xliff>\n", FormatXLIFF},
+		{"Android resources", "strings.xml", "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n</resources>\n", FormatAndroid},
+		{"JSON", "en.json", "{\"hello\": \"Hello\"}\n", FormatJSON},
+		{"iOS strings", "Localizable.strings", "\"hello\" = \"Hello\";\n", FormatStrings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(tempDir, tt.filename)
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			format, err := detector.DetectFile(filename)
+			if err != nil {
+				t.Fatalf("DetectFile() failed: %v", err)
+			}
+			if format != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, format)
+			}
+			if !format.IsLocalization() || format.IsSubtitle() {
+				t.Errorf("IsLocalization() = %v for %s", format.IsLocalization(), format)
+			}
+		})
+	}
+}
+
 func TestDetectFileUnknown(t *testing.T) {
 	detector := NewDetector()
 	tempDir := t.TempDir()
@@ -334,6 +412,14 @@ func TestIsSupported(t *testing.T) {
 		FormatEPUB,
 		FormatTXT,
 		FormatHTML,
+		FormatSRT,
+		FormatVTT,
+		FormatASS,
+		FormatPO,
+		FormatXLIFF,
+		FormatJSON,
+		FormatAndroid,
+		FormatStrings,
 	}
 
 	unsupportedFormats := []Format{
@@ -363,7 +449,8 @@ func TestGetSupportedFormats(t *testing.T) {
 	detector := NewDetector()
 
 	supported := detector.GetSupportedFormats()
-	expected := []Format{FormatFB2, FormatEPUB, FormatTXT, FormatHTML}
+	expected := []Format{FormatFB2, FormatEPUB, FormatTXT, FormatHTML, FormatSRT, FormatVTT, FormatASS,
+		FormatPO, FormatXLIFF, FormatJSON, FormatAndroid, FormatStrings}
 
 	if len(supported) != len(expected) {
 		t.Errorf("GetSupportedFormats() returned %d formats, expected %d", len(supported), len(expected))
@@ -412,6 +499,18 @@ func TestParseFormat(t *testing.T) {
 		{"htm", FormatHTML},
 		{"docx", FormatDOCX},
 		{"rtf", FormatRTF},
+		{"srt", FormatSRT},
+		{"vtt", FormatVTT},
+		{"webvtt", FormatVTT},
+		{"ass", FormatASS},
+		{"ssa", FormatASS},
+		{"po", FormatPO},
+		{"pot", FormatPO},
+		{"xliff", FormatXLIFF},
+		{"xlf", FormatXLIFF},
+		{"json", FormatJSON},
+		{"android", FormatAndroid},
+		{"strings", FormatStrings},
 		{"FB2", FormatFB2}, // Case insensitive
 		{"EPUB", FormatEPUB},
 		{"unknown", FormatUnknown},
@@ -6,13 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"digital.vasic.translator/pkg/ebook"
 	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/format"
 	"digital.vasic.translator/pkg/grpc/proto"
 	"digital.vasic.translator/pkg/logger"
 	"digital.vasic.translator/pkg/markdown"
+	"digital.vasic.translator/pkg/metrics"
 	"digital.vasic.translator/pkg/sshworker"
 	"digital.vasic.translator/pkg/translator"
 	"digital.vasic.translator/pkg/translator/llm"
@@ -23,6 +26,7 @@ type CoreTranslatorImpl struct {
 	logger     logger.Logger
 	sessions   map[string]*TranslationJob
 	mutex      sync.RWMutex
+	metrics    *metrics.Metrics
 }
 
 // TranslationJob represents an active translation job
@@ -50,6 +54,20 @@ func NewCoreTranslator(logger logger.Logger) CoreTranslator {
 	}
 }
 
+// SetMetrics instruments the LLM translators created for jobs
+func (ct *CoreTranslatorImpl) SetMetrics(m *metrics.Metrics) {
+	ct.metrics = m
+}
+
+// instrument wraps an LLM translator created from config with the metrics, if any
+func (ct *CoreTranslatorImpl) instrument(trans translator.Translator, config translator.TranslationConfig) translator.Translator {
+	return ct.metrics.InstrumentTranslator(trans, metrics.Labels{
+		Provider:     config.Provider,
+		Model:        config.Model,
+		LanguagePair: config.SourceLang + "-" + config.TargetLang,
+	})
+}
+
 // Translate executes a translation job
 func (ct *CoreTranslatorImpl) Translate(ctx context.Context, req *proto.TranslationRequest, eventBus *events.EventBus) (*proto.TranslationStatusResponse, error) {
 	ct.logger.Info("Starting core translation", map[string]interface{}{
@@ -114,7 +132,7 @@ func (ct *CoreTranslatorImpl) executeTranslationPipeline(job *TranslationJob, ev
 	step := ct.createStep("parsing", "Parsing input ebook")
 	ct.updateJobStep(job, step)
 	
-	content, format, err := ct.parseInputFile(req.InputFile)
+	book, err := ct.parseInputFile(req.InputFile)
 	if err != nil {
 		ct.failStep(step, err)
 		return ct.createErrorResponse(job, step), err
@@ -125,7 +143,7 @@ func (ct *CoreTranslatorImpl) executeTranslationPipeline(job *TranslationJob, ev
 	step = ct.createStep("markdown_conversion", "Converting to markdown")
 	ct.updateJobStep(job, step)
 	
-	originalMarkdown, err := ct.convertToMarkdown(content, format)
+	originalMarkdown, err := ct.convertToMarkdown(book, req.InputFile)
 	if err != nil {
 		ct.failStep(step, err)
 		return ct.createErrorResponse(job, step), err
@@ -174,10 +192,9 @@ func (ct *CoreTranslatorImpl) executeTranslationPipeline(job *TranslationJob, ev
 	}
 	
 	// Verify EPUB
-	epubVerified := ct.verifyEPUB(outputPath)
+	epubVerified, verification := ct.verifyEPUB(outputPath)
 	epubSize := ct.getFileSize(outputPath)
-	ct.addGeneratedFile(job, outputPath, "epub", epubSize, epubVerified,
-		map[bool]string{true: "Valid EPUB format", false: "Invalid EPUB format"}[epubVerified])
+	ct.addGeneratedFile(job, outputPath, "epub", epubSize, epubVerified, verification)
 	ct.completeStep(step)
 	
 	// Generate session report
@@ -270,12 +287,12 @@ func (ct *CoreTranslatorImpl) executeSSHTranslation(job *TranslationJob, text st
 	ct.emitProgress(eventBus, job.ID, "translation_complete", "translation", 80, "Translation completed on remote worker")
 	
 	// Download result
-	translatedData, err := worker.DownloadData(ctx, remoteOutputPath)
+	translatedData, err := worker.ExecuteCommandWithOutput(ctx, fmt.Sprintf("cat '%s'", remoteOutputPath))
 	if err != nil {
 		return "", fmt.Errorf("failed to download translation result: %w", err)
 	}
 	
-	return string(translatedData), nil
+	return translatedData, nil
 }
 
 // executeLlamaCppTranslation uses local llama.cpp
@@ -312,7 +329,7 @@ func (ct *CoreTranslatorImpl) executeLlamaCppTranslation(job *TranslationJob, te
 	ct.emitProgress(eventBus, job.ID, "llm_ready", "translation", 10, "LLM translator initialized")
 	
 	// Translate
-	result, err := llmTranslator.TranslateWithProgress(job.Context, text, "Ebook content", eventBus, job.ID)
+	result, err := ct.instrument(llmTranslator, llmConfig).TranslateWithProgress(job.Context, text, "Ebook content", eventBus, job.ID)
 	if err != nil {
 		return "", fmt.Errorf("LLM translation failed: %w", err)
 	}
@@ -353,7 +370,7 @@ func (ct *CoreTranslatorImpl) executeAPITranslation(job *TranslationJob, text st
 	ct.emitProgress(eventBus, job.ID, "api_ready", "translation", 10, "API client initialized")
 	
 	// Translate
-	result, err := llmTranslator.TranslateWithProgress(job.Context, text, "Ebook content", eventBus, job.ID)
+	result, err := ct.instrument(llmTranslator, llmConfig).TranslateWithProgress(job.Context, text, "Ebook content", eventBus, job.ID)
 	if err != nil {
 		return "", fmt.Errorf("API translation failed: %w", err)
 	}
@@ -365,21 +382,53 @@ func (ct *CoreTranslatorImpl) executeAPITranslation(job *TranslationJob, text st
 
 // Helper methods
 
-func (ct *CoreTranslatorImpl) parseInputFile(filePath string) (string, string, error) {
-	parser := ebook.NewParser()
-	return parser.ParseFile(filePath)
+func (ct *CoreTranslatorImpl) parseInputFile(filePath string) (*ebook.Book, error) {
+	return ebook.NewUniversalParser().Parse(filePath)
 }
 
-func (ct *CoreTranslatorImpl) convertToMarkdown(content, format string) (string, error) {
-	switch format {
-	case "fb2":
-		parser := &ebook.FB2Parser{}
-		return parser.ToMarkdown(content)
-	case "epub":
-		converter := markdown.NewEPUBToMarkdownConverter()
-		return converter.Convert(content)
-	default:
-		return content, nil
+// convertToMarkdown renders the book as markdown; EPUBs go through the EPUB
+// converter, which keeps their structure and formatting
+func (ct *CoreTranslatorImpl) convertToMarkdown(book *ebook.Book, inputFile string) (string, error) {
+	if book.Format == format.FormatEPUB {
+		tempDir, err := os.MkdirTemp("", "grpc-translation-*")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tempDir)
+
+		mdPath := filepath.Join(tempDir, "book.md")
+		if err := markdown.NewEPUBToMarkdownConverter(false, "").ConvertEPUBToMarkdown(inputFile, mdPath); err != nil {
+			return "", err
+		}
+		content, err := os.ReadFile(mdPath)
+		return string(content), err
+	}
+
+	var sb strings.Builder
+	if book.Metadata.Title != "" {
+		sb.WriteString("# " + book.Metadata.Title + "\n\n")
+	}
+	for _, chapter := range book.Chapters {
+		if chapter.Title != "" {
+			sb.WriteString("## " + chapter.Title + "\n\n")
+		}
+		for _, section := range chapter.Sections {
+			writeMarkdownSection(&sb, section, 3)
+		}
+	}
+	return sb.String(), nil
+}
+
+// writeMarkdownSection writes a section and its subsections with headings of the given level
+func writeMarkdownSection(sb *strings.Builder, section ebook.Section, level int) {
+	if section.Title != "" {
+		sb.WriteString(strings.Repeat("#", min(level, 6)) + " " + section.Title + "\n\n")
+	}
+	if content := strings.TrimSpace(section.Content); content != "" {
+		sb.WriteString(content + "\n\n")
+	}
+	for _, sub := range section.Subsections {
+		writeMarkdownSection(sb, sub, level+1)
 	}
 }
 
@@ -403,25 +452,28 @@ func (ct *CoreTranslatorImpl) verifyTranslation(text, targetLang, script string)
 }
 
 func (ct *CoreTranslatorImpl) generateEPUB(content, outputPath, inputFile string) error {
-	generator := markdown.NewMarkdownToEPUBConverter()
-	return generator.GenerateEPUB(content, outputPath, inputFile)
+	mdPath := ct.generatePath(inputFile, "_epub_source.md")
+	if err := os.WriteFile(mdPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write EPUB source: %w", err)
+	}
+	defer os.Remove(mdPath)
+
+	return markdown.NewMarkdownToEPUBConverter().ConvertMarkdownToEPUB(mdPath, outputPath)
 }
 
-func (ct *CoreTranslatorImpl) verifyEPUB(path string) bool {
-	file, err := os.Open(path)
+// verifyEPUB validates the EPUB at path and describes the result
+func (ct *CoreTranslatorImpl) verifyEPUB(path string) (bool, string) {
+	report, err := ebook.NewEPUBValidator().Validate(path)
 	if err != nil {
-		return false
+		return false, fmt.Sprintf("Invalid EPUB format: %v", err)
 	}
-	defer file.Close()
-	
-	buffer := make([]byte, 1024)
-	n, err := file.Read(buffer)
-	if err != nil && err != nil {
-		return false
+	if err := report.Err(); err != nil {
+		return false, err.Error()
 	}
-	
-	content := string(buffer[:n])
-	return strings.Contains(content, "application/epub+zip") && string(buffer[:2]) == "PK"
+	if warnings := len(report.Warnings()); warnings > 0 {
+		return true, fmt.Sprintf("Valid EPUB format (%d warnings)", warnings)
+	}
+	return true, "Valid EPUB format"
 }
 
 func (ct *CoreTranslatorImpl) getFileSize(path string) int64 {
@@ -546,7 +598,7 @@ func (ct *CoreTranslatorImpl) emitProgress(eventBus *events.EventBus, sessionID,
 		return
 	}
 	
-	event := events.NewEvent(eventType, message, map[string]interface{}{
+	event := events.NewEvent(events.EventType(eventType), message, map[string]interface{}{
 		"session_id":   sessionID,
 		"step_name":    stepName,
 		"progress":     progress,
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/storage"
+)
+
+// SetProjectAccess enables project scoping: sessions carrying a project ID are
+// only visible to callers whose bearer token identifies a project member
+func (s *Server) SetProjectAccess(store storage.Storage, auth *security.AuthService) {
+	s.store = store
+	s.authService = auth
+}
+
+// callerUserID extracts the user ID from the bearer token in the request metadata
+func (s *Server) callerUserID(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing request metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	claims, err := s.authService.ValidateToken(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization token")
+	}
+
+	return claims.UserID, nil
+}
+
+// authorizeProject checks that the caller may access projectID; requireEdit
+// additionally demands the owner or editor role
+func (s *Server) authorizeProject(ctx context.Context, projectID string, requireEdit bool) error {
+	_, err := s.authorizedProject(ctx, projectID, requireEdit)
+	return err
+}
+
+// authorizedProject loads projectID after checking access like authorizeProject.
+// It returns nil when no project is given.
+func (s *Server) authorizedProject(ctx context.Context, projectID string, requireEdit bool) (*storage.Project, error) {
+	if projectID == "" {
+		return nil, nil
+	}
+
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "project storage not configured")
+	}
+
+	project, err := s.store.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			return nil, status.Errorf(codes.NotFound, "project not found: %s", projectID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to load project: %v", err)
+	}
+
+	// Without an auth service the server runs in single-tenant mode
+	if s.authService == nil {
+		return project, nil
+	}
+
+	userID, err := s.callerUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !project.IsMember(userID) {
+		// Do not reveal the existence of projects the caller cannot see
+		return nil, status.Errorf(codes.NotFound, "project not found: %s", projectID)
+	}
+	if requireEdit && !project.CanEdit(userID) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient project permissions")
+	}
+
+	return project, nil
+}
+
+// projectProvider returns the requested provider and model, falling back to the
+// provider settings of project when no provider is requested
+func projectProvider(project *storage.Project, provider, model string) (string, string) {
+	if project == nil || provider != "" {
+		return provider, model
+	}
+	if model == "" {
+		model = project.ProviderSettings.Model
+	}
+	return project.ProviderSettings.Provider, model
+}
@@ -0,0 +1,931 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: translator.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TranslationService_StartTranslation_FullMethodName          = "/translator.TranslationService/StartTranslation"
+	TranslationService_GetTranslationStatus_FullMethodName      = "/translator.TranslationService/GetTranslationStatus"
+	TranslationService_ListTranslations_FullMethodName          = "/translator.TranslationService/ListTranslations"
+	TranslationService_CancelTranslation_FullMethodName         = "/translator.TranslationService/CancelTranslation"
+	TranslationService_StreamTranslationProgress_FullMethodName = "/translator.TranslationService/StreamTranslationProgress"
+	TranslationService_GetProviders_FullMethodName              = "/translator.TranslationService/GetProviders"
+	TranslationService_SubscribeEvents_FullMethodName           = "/translator.TranslationService/SubscribeEvents"
+	TranslationService_TranslateText_FullMethodName             = "/translator.TranslationService/TranslateText"
+	TranslationService_TranslateSegments_FullMethodName         = "/translator.TranslationService/TranslateSegments"
+	TranslationService_ConvertScript_FullMethodName             = "/translator.TranslationService/ConvertScript"
+	TranslationService_DetectLanguage_FullMethodName            = "/translator.TranslationService/DetectLanguage"
+	TranslationService_AnalyzePreparation_FullMethodName        = "/translator.TranslationService/AnalyzePreparation"
+	TranslationService_GetPreparationResult_FullMethodName      = "/translator.TranslationService/GetPreparationResult"
+	TranslationService_GetDistributedStatus_FullMethodName      = "/translator.TranslationService/GetDistributedStatus"
+	TranslationService_DiscoverWorkers_FullMethodName           = "/translator.TranslationService/DiscoverWorkers"
+	TranslationService_PairWorker_FullMethodName                = "/translator.TranslationService/PairWorker"
+	TranslationService_UnpairWorker_FullMethodName              = "/translator.TranslationService/UnpairWorker"
+	TranslationService_GetVersionHealth_FullMethodName          = "/translator.TranslationService/GetVersionHealth"
+	TranslationService_ListVersionAlerts_FullMethodName         = "/translator.TranslationService/ListVersionAlerts"
+	TranslationService_CheckVersionDrift_FullMethodName         = "/translator.TranslationService/CheckVersionDrift"
+	TranslationService_AcknowledgeAlert_FullMethodName          = "/translator.TranslationService/AcknowledgeAlert"
+)
+
+// TranslationServiceClient is the client API for TranslationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Translation Service Definition
+type TranslationServiceClient interface {
+	// Start a new translation job
+	StartTranslation(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (*TranslationResponse, error)
+	// Get translation status
+	GetTranslationStatus(ctx context.Context, in *TranslationStatusRequest, opts ...grpc.CallOption) (*TranslationStatusResponse, error)
+	// List all translation sessions
+	ListTranslations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TranslationListResponse, error)
+	// Cancel a translation
+	CancelTranslation(ctx context.Context, in *CancelTranslationRequest, opts ...grpc.CallOption) (*CancelTranslationResponse, error)
+	// Stream translation progress
+	StreamTranslationProgress(ctx context.Context, in *TranslationStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslationProgressEvent], error)
+	// Get available providers and models
+	GetProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProvidersResponse, error)
+	// Subscribe to system events
+	SubscribeEvents(ctx context.Context, in *EventSubscriptionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SystemEvent], error)
+	// Translate a piece of text
+	TranslateText(ctx context.Context, in *TextTranslationRequest, opts ...grpc.CallOption) (*TextTranslationResponse, error)
+	// Translate a stream of segments; translations are returned as soon as they
+	// are ready, which may differ from the order the segments were sent in
+	TranslateSegments(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SegmentTranslationRequest, SegmentTranslationResponse], error)
+	// Convert Serbian text between Cyrillic and Latin script
+	ConvertScript(ctx context.Context, in *ScriptConversionRequest, opts ...grpc.CallOption) (*ScriptConversionResponse, error)
+	// Detect the language of a text
+	DetectLanguage(ctx context.Context, in *LanguageDetectionRequest, opts ...grpc.CallOption) (*LanguageDetectionResponse, error)
+	// Analyze content before translating it
+	AnalyzePreparation(ctx context.Context, in *PreparationRequest, opts ...grpc.CallOption) (*PreparationResponse, error)
+	// Get a stored preparation analysis
+	GetPreparationResult(ctx context.Context, in *PreparationResultRequest, opts ...grpc.CallOption) (*PreparationResponse, error)
+	// Get the state of distributed work
+	GetDistributedStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DistributedStatusResponse, error)
+	// Discover configured workers and pair with them
+	DiscoverWorkers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkerActionResponse, error)
+	// Pair with a worker
+	PairWorker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerActionResponse, error)
+	// Unpair from a worker
+	UnpairWorker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerActionResponse, error)
+	// Get worker version metrics and health
+	GetVersionHealth(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionHealthResponse, error)
+	// List current or past version drift alerts
+	ListVersionAlerts(ctx context.Context, in *VersionAlertsRequest, opts ...grpc.CallOption) (*VersionAlertsResponse, error)
+	// Check all workers for version drift
+	CheckVersionDrift(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionAlertsResponse, error)
+	// Acknowledge a version drift alert
+	AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error)
+}
+
+type translationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTranslationServiceClient(cc grpc.ClientConnInterface) TranslationServiceClient {
+	return &translationServiceClient{cc}
+}
+
+func (c *translationServiceClient) StartTranslation(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (*TranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationResponse)
+	err := c.cc.Invoke(ctx, TranslationService_StartTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) GetTranslationStatus(ctx context.Context, in *TranslationStatusRequest, opts ...grpc.CallOption) (*TranslationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationStatusResponse)
+	err := c.cc.Invoke(ctx, TranslationService_GetTranslationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) ListTranslations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TranslationListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationListResponse)
+	err := c.cc.Invoke(ctx, TranslationService_ListTranslations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) CancelTranslation(ctx context.Context, in *CancelTranslationRequest, opts ...grpc.CallOption) (*CancelTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTranslationResponse)
+	err := c.cc.Invoke(ctx, TranslationService_CancelTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) StreamTranslationProgress(ctx context.Context, in *TranslationStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslationProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TranslationService_ServiceDesc.Streams[0], TranslationService_StreamTranslationProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranslationStreamRequest, TranslationProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_StreamTranslationProgressClient = grpc.ServerStreamingClient[TranslationProgressEvent]
+
+func (c *translationServiceClient) GetProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProvidersResponse)
+	err := c.cc.Invoke(ctx, TranslationService_GetProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) SubscribeEvents(ctx context.Context, in *EventSubscriptionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SystemEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TranslationService_ServiceDesc.Streams[1], TranslationService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EventSubscriptionRequest, SystemEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_SubscribeEventsClient = grpc.ServerStreamingClient[SystemEvent]
+
+func (c *translationServiceClient) TranslateText(ctx context.Context, in *TextTranslationRequest, opts ...grpc.CallOption) (*TextTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TextTranslationResponse)
+	err := c.cc.Invoke(ctx, TranslationService_TranslateText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) TranslateSegments(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SegmentTranslationRequest, SegmentTranslationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TranslationService_ServiceDesc.Streams[2], TranslationService_TranslateSegments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SegmentTranslationRequest, SegmentTranslationResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_TranslateSegmentsClient = grpc.BidiStreamingClient[SegmentTranslationRequest, SegmentTranslationResponse]
+
+func (c *translationServiceClient) ConvertScript(ctx context.Context, in *ScriptConversionRequest, opts ...grpc.CallOption) (*ScriptConversionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptConversionResponse)
+	err := c.cc.Invoke(ctx, TranslationService_ConvertScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) DetectLanguage(ctx context.Context, in *LanguageDetectionRequest, opts ...grpc.CallOption) (*LanguageDetectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LanguageDetectionResponse)
+	err := c.cc.Invoke(ctx, TranslationService_DetectLanguage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) AnalyzePreparation(ctx context.Context, in *PreparationRequest, opts ...grpc.CallOption) (*PreparationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreparationResponse)
+	err := c.cc.Invoke(ctx, TranslationService_AnalyzePreparation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) GetPreparationResult(ctx context.Context, in *PreparationResultRequest, opts ...grpc.CallOption) (*PreparationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreparationResponse)
+	err := c.cc.Invoke(ctx, TranslationService_GetPreparationResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) GetDistributedStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DistributedStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DistributedStatusResponse)
+	err := c.cc.Invoke(ctx, TranslationService_GetDistributedStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) DiscoverWorkers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WorkerActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerActionResponse)
+	err := c.cc.Invoke(ctx, TranslationService_DiscoverWorkers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) PairWorker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerActionResponse)
+	err := c.cc.Invoke(ctx, TranslationService_PairWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) UnpairWorker(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerActionResponse)
+	err := c.cc.Invoke(ctx, TranslationService_UnpairWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) GetVersionHealth(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionHealthResponse)
+	err := c.cc.Invoke(ctx, TranslationService_GetVersionHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) ListVersionAlerts(ctx context.Context, in *VersionAlertsRequest, opts ...grpc.CallOption) (*VersionAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionAlertsResponse)
+	err := c.cc.Invoke(ctx, TranslationService_ListVersionAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) CheckVersionDrift(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionAlertsResponse)
+	err := c.cc.Invoke(ctx, TranslationService_CheckVersionDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationServiceClient) AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeAlertResponse)
+	err := c.cc.Invoke(ctx, TranslationService_AcknowledgeAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TranslationServiceServer is the server API for TranslationService service.
+// All implementations must embed UnimplementedTranslationServiceServer
+// for forward compatibility.
+//
+// Translation Service Definition
+type TranslationServiceServer interface {
+	// Start a new translation job
+	StartTranslation(context.Context, *TranslationRequest) (*TranslationResponse, error)
+	// Get translation status
+	GetTranslationStatus(context.Context, *TranslationStatusRequest) (*TranslationStatusResponse, error)
+	// List all translation sessions
+	ListTranslations(context.Context, *emptypb.Empty) (*TranslationListResponse, error)
+	// Cancel a translation
+	CancelTranslation(context.Context, *CancelTranslationRequest) (*CancelTranslationResponse, error)
+	// Stream translation progress
+	StreamTranslationProgress(*TranslationStreamRequest, grpc.ServerStreamingServer[TranslationProgressEvent]) error
+	// Get available providers and models
+	GetProviders(context.Context, *emptypb.Empty) (*ProvidersResponse, error)
+	// Subscribe to system events
+	SubscribeEvents(*EventSubscriptionRequest, grpc.ServerStreamingServer[SystemEvent]) error
+	// Translate a piece of text
+	TranslateText(context.Context, *TextTranslationRequest) (*TextTranslationResponse, error)
+	// Translate a stream of segments; translations are returned as soon as they
+	// are ready, which may differ from the order the segments were sent in
+	TranslateSegments(grpc.BidiStreamingServer[SegmentTranslationRequest, SegmentTranslationResponse]) error
+	// Convert Serbian text between Cyrillic and Latin script
+	ConvertScript(context.Context, *ScriptConversionRequest) (*ScriptConversionResponse, error)
+	// Detect the language of a text
+	DetectLanguage(context.Context, *LanguageDetectionRequest) (*LanguageDetectionResponse, error)
+	// Analyze content before translating it
+	AnalyzePreparation(context.Context, *PreparationRequest) (*PreparationResponse, error)
+	// Get a stored preparation analysis
+	GetPreparationResult(context.Context, *PreparationResultRequest) (*PreparationResponse, error)
+	// Get the state of distributed work
+	GetDistributedStatus(context.Context, *emptypb.Empty) (*DistributedStatusResponse, error)
+	// Discover configured workers and pair with them
+	DiscoverWorkers(context.Context, *emptypb.Empty) (*WorkerActionResponse, error)
+	// Pair with a worker
+	PairWorker(context.Context, *WorkerRequest) (*WorkerActionResponse, error)
+	// Unpair from a worker
+	UnpairWorker(context.Context, *WorkerRequest) (*WorkerActionResponse, error)
+	// Get worker version metrics and health
+	GetVersionHealth(context.Context, *emptypb.Empty) (*VersionHealthResponse, error)
+	// List current or past version drift alerts
+	ListVersionAlerts(context.Context, *VersionAlertsRequest) (*VersionAlertsResponse, error)
+	// Check all workers for version drift
+	CheckVersionDrift(context.Context, *emptypb.Empty) (*VersionAlertsResponse, error)
+	// Acknowledge a version drift alert
+	AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error)
+	mustEmbedUnimplementedTranslationServiceServer()
+}
+
+// UnimplementedTranslationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTranslationServiceServer struct{}
+
+func (UnimplementedTranslationServiceServer) StartTranslation(context.Context, *TranslationRequest) (*TranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTranslation not implemented")
+}
+func (UnimplementedTranslationServiceServer) GetTranslationStatus(context.Context, *TranslationStatusRequest) (*TranslationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTranslationStatus not implemented")
+}
+func (UnimplementedTranslationServiceServer) ListTranslations(context.Context, *emptypb.Empty) (*TranslationListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTranslations not implemented")
+}
+func (UnimplementedTranslationServiceServer) CancelTranslation(context.Context, *CancelTranslationRequest) (*CancelTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTranslation not implemented")
+}
+func (UnimplementedTranslationServiceServer) StreamTranslationProgress(*TranslationStreamRequest, grpc.ServerStreamingServer[TranslationProgressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTranslationProgress not implemented")
+}
+func (UnimplementedTranslationServiceServer) GetProviders(context.Context, *emptypb.Empty) (*ProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviders not implemented")
+}
+func (UnimplementedTranslationServiceServer) SubscribeEvents(*EventSubscriptionRequest, grpc.ServerStreamingServer[SystemEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedTranslationServiceServer) TranslateText(context.Context, *TextTranslationRequest) (*TextTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TranslateText not implemented")
+}
+func (UnimplementedTranslationServiceServer) TranslateSegments(grpc.BidiStreamingServer[SegmentTranslationRequest, SegmentTranslationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method TranslateSegments not implemented")
+}
+func (UnimplementedTranslationServiceServer) ConvertScript(context.Context, *ScriptConversionRequest) (*ScriptConversionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertScript not implemented")
+}
+func (UnimplementedTranslationServiceServer) DetectLanguage(context.Context, *LanguageDetectionRequest) (*LanguageDetectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectLanguage not implemented")
+}
+func (UnimplementedTranslationServiceServer) AnalyzePreparation(context.Context, *PreparationRequest) (*PreparationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzePreparation not implemented")
+}
+func (UnimplementedTranslationServiceServer) GetPreparationResult(context.Context, *PreparationResultRequest) (*PreparationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreparationResult not implemented")
+}
+func (UnimplementedTranslationServiceServer) GetDistributedStatus(context.Context, *emptypb.Empty) (*DistributedStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDistributedStatus not implemented")
+}
+func (UnimplementedTranslationServiceServer) DiscoverWorkers(context.Context, *emptypb.Empty) (*WorkerActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverWorkers not implemented")
+}
+func (UnimplementedTranslationServiceServer) PairWorker(context.Context, *WorkerRequest) (*WorkerActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PairWorker not implemented")
+}
+func (UnimplementedTranslationServiceServer) UnpairWorker(context.Context, *WorkerRequest) (*WorkerActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpairWorker not implemented")
+}
+func (UnimplementedTranslationServiceServer) GetVersionHealth(context.Context, *emptypb.Empty) (*VersionHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersionHealth not implemented")
+}
+func (UnimplementedTranslationServiceServer) ListVersionAlerts(context.Context, *VersionAlertsRequest) (*VersionAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersionAlerts not implemented")
+}
+func (UnimplementedTranslationServiceServer) CheckVersionDrift(context.Context, *emptypb.Empty) (*VersionAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckVersionDrift not implemented")
+}
+func (UnimplementedTranslationServiceServer) AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeAlert not implemented")
+}
+func (UnimplementedTranslationServiceServer) mustEmbedUnimplementedTranslationServiceServer() {}
+func (UnimplementedTranslationServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeTranslationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TranslationServiceServer will
+// result in compilation errors.
+type UnsafeTranslationServiceServer interface {
+	mustEmbedUnimplementedTranslationServiceServer()
+}
+
+func RegisterTranslationServiceServer(s grpc.ServiceRegistrar, srv TranslationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTranslationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TranslationService_ServiceDesc, srv)
+}
+
+func _TranslationService_StartTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).StartTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_StartTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).StartTranslation(ctx, req.(*TranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_GetTranslationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).GetTranslationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_GetTranslationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).GetTranslationStatus(ctx, req.(*TranslationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_ListTranslations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).ListTranslations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_ListTranslations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).ListTranslations(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_CancelTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).CancelTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_CancelTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).CancelTranslation(ctx, req.(*CancelTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_StreamTranslationProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TranslationStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TranslationServiceServer).StreamTranslationProgress(m, &grpc.GenericServerStream[TranslationStreamRequest, TranslationProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_StreamTranslationProgressServer = grpc.ServerStreamingServer[TranslationProgressEvent]
+
+func _TranslationService_GetProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).GetProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_GetProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).GetProviders(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventSubscriptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TranslationServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[EventSubscriptionRequest, SystemEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_SubscribeEventsServer = grpc.ServerStreamingServer[SystemEvent]
+
+func _TranslationService_TranslateText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TextTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).TranslateText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_TranslateText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).TranslateText(ctx, req.(*TextTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_TranslateSegments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TranslationServiceServer).TranslateSegments(&grpc.GenericServerStream[SegmentTranslationRequest, SegmentTranslationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranslationService_TranslateSegmentsServer = grpc.BidiStreamingServer[SegmentTranslationRequest, SegmentTranslationResponse]
+
+func _TranslationService_ConvertScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScriptConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).ConvertScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_ConvertScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).ConvertScript(ctx, req.(*ScriptConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_DetectLanguage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LanguageDetectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).DetectLanguage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_DetectLanguage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).DetectLanguage(ctx, req.(*LanguageDetectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_AnalyzePreparation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreparationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).AnalyzePreparation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_AnalyzePreparation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).AnalyzePreparation(ctx, req.(*PreparationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_GetPreparationResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreparationResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).GetPreparationResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_GetPreparationResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).GetPreparationResult(ctx, req.(*PreparationResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_GetDistributedStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).GetDistributedStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_GetDistributedStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).GetDistributedStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_DiscoverWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).DiscoverWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_DiscoverWorkers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).DiscoverWorkers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_PairWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).PairWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_PairWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).PairWorker(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_UnpairWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).UnpairWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_UnpairWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).UnpairWorker(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_GetVersionHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).GetVersionHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_GetVersionHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).GetVersionHealth(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_ListVersionAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).ListVersionAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_ListVersionAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).ListVersionAlerts(ctx, req.(*VersionAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_CheckVersionDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).CheckVersionDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_CheckVersionDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).CheckVersionDrift(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranslationService_AcknowledgeAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslationServiceServer).AcknowledgeAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranslationService_AcknowledgeAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslationServiceServer).AcknowledgeAlert(ctx, req.(*AcknowledgeAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TranslationService_ServiceDesc is the grpc.ServiceDesc for TranslationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TranslationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "translator.TranslationService",
+	HandlerType: (*TranslationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartTranslation",
+			Handler:    _TranslationService_StartTranslation_Handler,
+		},
+		{
+			MethodName: "GetTranslationStatus",
+			Handler:    _TranslationService_GetTranslationStatus_Handler,
+		},
+		{
+			MethodName: "ListTranslations",
+			Handler:    _TranslationService_ListTranslations_Handler,
+		},
+		{
+			MethodName: "CancelTranslation",
+			Handler:    _TranslationService_CancelTranslation_Handler,
+		},
+		{
+			MethodName: "GetProviders",
+			Handler:    _TranslationService_GetProviders_Handler,
+		},
+		{
+			MethodName: "TranslateText",
+			Handler:    _TranslationService_TranslateText_Handler,
+		},
+		{
+			MethodName: "ConvertScript",
+			Handler:    _TranslationService_ConvertScript_Handler,
+		},
+		{
+			MethodName: "DetectLanguage",
+			Handler:    _TranslationService_DetectLanguage_Handler,
+		},
+		{
+			MethodName: "AnalyzePreparation",
+			Handler:    _TranslationService_AnalyzePreparation_Handler,
+		},
+		{
+			MethodName: "GetPreparationResult",
+			Handler:    _TranslationService_GetPreparationResult_Handler,
+		},
+		{
+			MethodName: "GetDistributedStatus",
+			Handler:    _TranslationService_GetDistributedStatus_Handler,
+		},
+		{
+			MethodName: "DiscoverWorkers",
+			Handler:    _TranslationService_DiscoverWorkers_Handler,
+		},
+		{
+			MethodName: "PairWorker",
+			Handler:    _TranslationService_PairWorker_Handler,
+		},
+		{
+			MethodName: "UnpairWorker",
+			Handler:    _TranslationService_UnpairWorker_Handler,
+		},
+		{
+			MethodName: "GetVersionHealth",
+			Handler:    _TranslationService_GetVersionHealth_Handler,
+		},
+		{
+			MethodName: "ListVersionAlerts",
+			Handler:    _TranslationService_ListVersionAlerts_Handler,
+		},
+		{
+			MethodName: "CheckVersionDrift",
+			Handler:    _TranslationService_CheckVersionDrift_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlert",
+			Handler:    _TranslationService_AcknowledgeAlert_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTranslationProgress",
+			Handler:       _TranslationService_StreamTranslationProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _TranslationService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TranslateSegments",
+			Handler:       _TranslationService_TranslateSegments_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "translator.proto",
+}
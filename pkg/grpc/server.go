@@ -8,11 +8,18 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"digital.vasic.translator/pkg/events"
 	"digital.vasic.translator/pkg/grpc/proto"
 	"digital.vasic.translator/pkg/logger"
+	"digital.vasic.translator/pkg/metrics"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
 )
 
 // Server implements the gRPC TranslationService
@@ -30,14 +37,27 @@ type Server struct {
 	sessionsMutex sync.RWMutex
 	
 	// Event streaming
-	streams       map[string]chan *proto.TranslationProgressEvent
+	streams       map[string]*progressSubscriber
 	streamsMutex  sync.RWMutex
 	
+	// Session event log, kept in memory when no store is configured
+	eventLog      map[string][]*proto.TranslationProgressEvent
+	eventSeq      map[string]int64
+	eventLocks    map[string]*sync.Mutex // Serialize recording and fan-out per session
+	eventsMutex   sync.Mutex
+	
 	// Provider information
 	providers     *ProviderRegistry
 	
 	// Configuration
 	config        *ServerConfig
+	
+	// Persistence and project scoping
+	store         storage.Storage
+	authService   *security.AuthService
+	
+	// Operations shared with the REST API
+	service       *service.Service
 }
 
 // ServerConfig holds server configuration
@@ -45,12 +65,18 @@ type ServerConfig struct {
 	MaxConcurrentTranslations int
 	SessionTimeout          time.Duration
 	StreamBufferSize        int
-	EnableMetrics           bool
+}
+
+// progressSubscriber receives the live progress events of a session for one stream
+type progressSubscriber struct {
+	events chan *proto.TranslationProgressEvent
+	lagged chan struct{} // Closed when an event was dropped because events was full
 }
 
 // TranslationSession represents an active translation session
 type TranslationSession struct {
 	ID           string
+	ProjectID    string
 	Status       string
 	Request      *proto.TranslationRequest
 	Response     *proto.TranslationStatusResponse
@@ -63,6 +89,7 @@ type TranslationSession struct {
 	// Progress tracking
 	CurrentStep  string
 	Progress     float64
+	ErrorMessage string
 	Steps        []*proto.TranslationStep
 	Files        []*proto.GeneratedFile
 	
@@ -90,7 +117,6 @@ func NewServer(eventBus *events.EventBus, logger logger.Logger, translator CoreT
 			MaxConcurrentTranslations: 10,
 			SessionTimeout:          24 * time.Hour,
 			StreamBufferSize:        100,
-			EnableMetrics:           true,
 		}
 	}
 	
@@ -106,7 +132,10 @@ func NewServer(eventBus *events.EventBus, logger logger.Logger, translator CoreT
 		grpcServer: grpcServer,
 		translator: translator,
 		sessions:   make(map[string]*TranslationSession),
-		streams:    make(map[string]chan *proto.TranslationProgressEvent),
+		streams:    make(map[string]*progressSubscriber),
+		eventLog:   make(map[string][]*proto.TranslationProgressEvent),
+		eventSeq:   make(map[string]int64),
+		eventLocks: make(map[string]*sync.Mutex),
 		providers:  NewProviderRegistry(),
 		config:     config,
 	}
@@ -123,8 +152,13 @@ func (s *Server) StartTranslation(ctx context.Context, req *proto.TranslationReq
 		"session_id": req.SessionId,
 		"input_file": req.InputFile,
 		"provider":   req.ProviderConfig.Type,
+		"project_id": req.ProjectId,
 	})
 	
+	if err := s.authorizeProject(ctx, req.ProjectId, true); err != nil {
+		return nil, err
+	}
+	
 	// Check session limits
 	s.sessionsMutex.RLock()
 	activeCount := len(s.sessions)
@@ -144,15 +178,14 @@ func (s *Server) StartTranslation(ctx context.Context, req *proto.TranslationReq
 	// Create translation session
 	session := &TranslationSession{
 		ID:        req.SessionId,
+		ProjectID: req.ProjectId,
 		Status:    "pending",
 		Request:   req,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		CancelFunc: cancel,
 		EventBus:  events.NewEventBus(), // Private event bus for this session
-		Logger:    s.logger.With(map[string]interface{}{
-			"session_id": req.SessionId,
-		}),
+		Logger:    s.logger,
 		Ctx: sessionCtx,
 		Steps:    make([]*proto.TranslationStep, 0),
 		Files:    make([]*proto.GeneratedFile, 0),
@@ -163,6 +196,8 @@ func (s *Server) StartTranslation(ctx context.Context, req *proto.TranslationReq
 	s.sessions[req.SessionId] = session
 	s.sessionsMutex.Unlock()
 	
+	s.createStoredSession(session)
+	
 	// Start translation in goroutine
 	go s.runTranslation(session)
 	
@@ -178,17 +213,19 @@ func (s *Server) StartTranslation(ctx context.Context, req *proto.TranslationReq
 
 // GetTranslationStatus returns the current status of a translation
 func (s *Server) GetTranslationStatus(ctx context.Context, req *proto.TranslationStatusRequest) (*proto.TranslationStatusResponse, error) {
-	s.sessionsMutex.RLock()
-	session, exists := s.sessions[req.SessionId]
-	s.sessionsMutex.RUnlock()
-	
+	session, exists := s.lookupSession(ctx, req.SessionId)
 	if !exists {
 		return nil, fmt.Errorf("translation session not found: %s", req.SessionId)
 	}
 	
+	if err := s.authorizeProject(ctx, session.ProjectID, false); err != nil {
+		return nil, err
+	}
+	
 	// Update status response
 	session.Response = &proto.TranslationStatusResponse{
 		SessionId:          session.ID,
+		ProjectId:          session.ProjectID,
 		Status:             session.Status,
 		ProgressPercentage: session.Progress,
 		CurrentStep:        session.CurrentStep,
@@ -196,6 +233,7 @@ func (s *Server) GetTranslationStatus(ctx context.Context, req *proto.Translatio
 		UpdatedAt:          timeToProto(session.UpdatedAt),
 		Files:              session.Files,
 		Steps:              session.Steps,
+		ErrorMessage:       session.ErrorMessage,
 	}
 	
 	// Try to get status from core translator
@@ -212,13 +250,25 @@ func (s *Server) GetTranslationStatus(ctx context.Context, req *proto.Translatio
 }
 
 // ListTranslations returns all translation sessions
-func (s *Server) ListTranslations(ctx context.Context, _ *proto.Empty) (*proto.TranslationListResponse, error) {
+func (s *Server) ListTranslations(ctx context.Context, _ *emptypb.Empty) (*proto.TranslationListResponse, error) {
 	s.sessionsMutex.RLock()
-	defer s.sessionsMutex.RUnlock()
+	sessions := make([]*TranslationSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.sessionsMutex.RUnlock()
 	
-	translations := make([]*proto.TranslationStatusResponse, 0, len(s.sessions))
+	// Include the history persisted by earlier server processes
+	sessions = append(sessions, s.storedSessions(ctx)...)
 	
-	for _, session := range s.sessions {
+	translations := make([]*proto.TranslationStatusResponse, 0, len(sessions))
+	
+	for _, session := range sessions {
+		// Only list sessions of projects the caller belongs to
+		if s.authorizeProject(ctx, session.ProjectID, false) != nil {
+			continue
+		}
+		
 		status, err := s.GetTranslationStatus(ctx, &proto.TranslationStatusRequest{
 			SessionId: session.ID,
 		})
@@ -250,13 +300,24 @@ func (s *Server) CancelTranslation(ctx context.Context, req *proto.CancelTransla
 	s.sessionsMutex.RUnlock()
 	
 	if !exists {
+		message := "Translation session not found"
+		if stored, found := s.lookupSession(ctx, req.SessionId); found {
+			if err := s.authorizeProject(ctx, stored.ProjectID, true); err != nil {
+				return nil, err
+			}
+			message = "Translation session already finished"
+		}
 		return &proto.CancelTranslationResponse{
 			SessionId: req.SessionId,
 			Success:   false,
-			Message:   "Translation session not found",
+			Message:   message,
 		}, nil
 	}
 	
+	if err := s.authorizeProject(ctx, session.ProjectID, true); err != nil {
+		return nil, err
+	}
+	
 	// Cancel the session context
 	if session.CancelFunc != nil {
 		session.CancelFunc()
@@ -270,9 +331,13 @@ func (s *Server) CancelTranslation(ctx context.Context, req *proto.CancelTransla
 		})
 	}
 	
-	// Update session status
+	// Update session status; persistence happens after the lock is released
+	s.sessionsMutex.Lock()
 	session.Status = "cancelled"
 	session.UpdatedAt = time.Now()
+	record := sessionToStorage(session)
+	s.sessionsMutex.Unlock()
+	s.updateStoredSession(record)
 	
 	// Emit cancellation event
 	s.emitProgressEvent(session.ID, "cancelled", "", 0, "Translation cancelled: "+req.Reason, nil)
@@ -284,58 +349,106 @@ func (s *Server) CancelTranslation(ctx context.Context, req *proto.CancelTransla
 	}, nil
 }
 
-// StreamTranslationProgress streams translation progress events
+// StreamTranslationProgress streams translation progress events. Clients that
+// reconnect pass the sequence of the last event they received and get everything
+// they missed replayed from the session event log before live events resume.
+// A client that reads too slowly to keep up has its stream ended with Aborted
+// and resumes the same way.
 func (s *Server) StreamTranslationProgress(req *proto.TranslationStreamRequest, stream proto.TranslationService_StreamTranslationProgressServer) error {
 	s.logger.Info("Starting progress stream", map[string]interface{}{
-		"session_id": req.SessionId,
-		"client_id":  req.ClientId,
+		"session_id":    req.SessionId,
+		"client_id":     req.ClientId,
+		"last_sequence": req.LastSequence,
 	})
 	
+	session, exists := s.lookupSession(stream.Context(), req.SessionId)
+	if exists {
+		if err := s.authorizeProject(stream.Context(), session.ProjectID, false); err != nil {
+			return err
+		}
+	}
+	
 	// Create event channel for this stream
-	eventChan := make(chan *proto.TranslationProgressEvent, s.config.StreamBufferSize)
+	subscriber := &progressSubscriber{
+		events: make(chan *proto.TranslationProgressEvent, s.config.StreamBufferSize),
+		lagged: make(chan struct{}),
+	}
 	
 	// Store stream
 	streamKey := fmt.Sprintf("%s:%s", req.SessionId, req.ClientId)
 	s.streamsMutex.Lock()
-	s.streams[streamKey] = eventChan
+	s.streams[streamKey] = subscriber
 	s.streamsMutex.Unlock()
 	
-	// Clean up on exit
+	// Clean up on exit, unless a lagging or newer stream already replaced it
 	defer func() {
 		s.streamsMutex.Lock()
-		delete(s.streams, streamKey)
+		if s.streams[streamKey] == subscriber {
+			delete(s.streams, streamKey)
+		}
 		s.streamsMutex.Unlock()
-		close(eventChan)
 	}()
 	
-	// Send current status
-	if currentStatus, err := s.GetTranslationStatus(stream.Context(), &proto.TranslationStatusRequest{
-		SessionId: req.SessionId,
-	}); err == nil {
-		initialEvent := &proto.TranslationProgressEvent{
-			SessionId:          req.SessionId,
-			EventType:          "status_update",
-			ProgressPercentage: currentStatus.ProgressPercentage,
-			CurrentStep:        currentStatus.CurrentStep,
-			Message:            fmt.Sprintf("Current status: %s", currentStatus.Status),
-			Timestamp:          timeToProto(time.Now()),
+	// Send current status to new streams; resuming clients already know it
+	if req.LastSequence == 0 {
+		if currentStatus, err := s.GetTranslationStatus(stream.Context(), &proto.TranslationStatusRequest{
+			SessionId: req.SessionId,
+		}); err == nil {
+			initialEvent := &proto.TranslationProgressEvent{
+				SessionId:          req.SessionId,
+				EventType:          "status_update",
+				ProgressPercentage: currentStatus.ProgressPercentage,
+				StepName:           currentStatus.CurrentStep,
+				Message:            fmt.Sprintf("Current status: %s", currentStatus.Status),
+				Timestamp:          timeToProto(time.Now()),
+			}
+			if err := stream.Send(initialEvent); err != nil {
+				return err
+			}
 		}
-		if err := stream.Send(initialEvent); err != nil {
+	}
+	
+	// Replay the log; the stream is registered first so nothing emitted meanwhile is lost
+	lastSent := req.LastSequence
+	missed, err := s.eventsSince(stream.Context(), req.SessionId, lastSent)
+	if err != nil {
+		return fmt.Errorf("failed to load session events: %w", err)
+	}
+	for _, event := range missed {
+		if err := stream.Send(event); err != nil {
 			return err
 		}
+		lastSent = event.Sequence
+	}
+	
+	// A finished session will not emit anything else
+	if session, exists := s.lookupSession(stream.Context(), req.SessionId); exists && isTerminalStatus(session.Status) {
+		return nil
 	}
 	
 	// Stream events
 	for {
 		select {
-		case event, ok := <-eventChan:
-			if !ok {
-				return nil // Channel closed
+		case event := <-subscriber.events:
+			
+			// Events already delivered by the replay
+			if event.Sequence != 0 && event.Sequence <= lastSent {
+				continue
 			}
 			
 			if err := stream.Send(event); err != nil {
 				return err
 			}
+			if event.Sequence != 0 {
+				lastSent = event.Sequence
+			}
+			
+			if event.EventType == "completed" || event.EventType == "error" || event.EventType == "cancelled" {
+				return nil
+			}
+			
+		case <-subscriber.lagged:
+			return status.Errorf(codes.Aborted, "progress stream fell behind; reconnect with last_sequence %d to resume", lastSent)
 			
 		case <-stream.Context().Done():
 			return stream.Context().Err()
@@ -344,7 +457,7 @@ func (s *Server) StreamTranslationProgress(req *proto.TranslationStreamRequest,
 }
 
 // GetProviders returns available translation providers
-func (s *Server) GetProviders(ctx context.Context, _ *proto.Empty) (*proto.ProvidersResponse, error) {
+func (s *Server) GetProviders(ctx context.Context, _ *emptypb.Empty) (*proto.ProvidersResponse, error) {
 	providers := s.providers.GetAll()
 	return &proto.ProvidersResponse{
 		Providers: providers,
@@ -360,47 +473,34 @@ func (s *Server) SubscribeEvents(req *proto.EventSubscriptionRequest, stream pro
 	
 	// Create event channel
 	eventChan := make(chan *proto.SystemEvent, 100)
-	
-	// Subscribe to event bus
-	unsubscribe := s.eventBus.Subscribe(func(event *events.Event) {
-		// Filter by event types if specified
-		if len(req.EventTypes) > 0 {
-			found := false
-			for _, eventType := range req.EventTypes {
-				if event.Type == eventType {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return
-			}
-		}
-		
+
+	// Subscribe to event bus; the subscription keeps events in publish order
+	// and drops the oldest ones when this client falls too far behind
+	eventTypes := make([]events.EventType, 0, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		eventTypes = append(eventTypes, events.EventType(eventType))
+	}
+	subscription := s.eventBus.SubscribeWithOptions(func(event events.Event) {
 		// Convert to proto
 		protoEvent := &proto.SystemEvent{
-			EventType:  event.Type,
-			Source:     event.Source,
+			EventType:  string(event.Type),
+			Source:     "translator",
 			Timestamp:  timeToProto(event.Timestamp),
-			Data:       event.Data,
+			Data:       eventDataToProto(event.Data),
 			SessionId:  event.SessionID,
 			ClientId:   req.ClientId,
-			Severity:   proto.Severity(event.Severity),
+			Severity:   eventSeverity(event.Type),
 		}
-		
+
 		select {
 		case eventChan <- protoEvent:
-		default:
-			// Channel full, drop event
+		case <-stream.Context().Done():
 		}
-	})
-	
+	}, events.SubscriberOptions{Types: eventTypes, Policy: events.DropOldest})
+
 	// Clean up on exit
-	defer func() {
-		unsubscribe()
-		close(eventChan)
-	}()
-	
+	defer subscription.Unsubscribe()
+
 	// Stream events
 	for {
 		select {
@@ -426,9 +526,18 @@ func (s *Server) runTranslation(session *TranslationSession) {
 		"session_id": session.ID,
 	})
 	
-	// Update status
+	// Session state is changed under the lock and persisted after releasing it,
+	// so storage latency does not block other sessions
+	s.sessionsMutex.Lock()
+	if session.Status == "cancelled" {
+		s.sessionsMutex.Unlock()
+		return
+	}
 	session.Status = "running"
 	session.UpdatedAt = time.Now()
+	record := sessionToStorage(session)
+	s.sessionsMutex.Unlock()
+	s.updateStoredSession(record)
 	
 	// Emit start event
 	s.emitProgressEvent(session.ID, "started", "", 0, "Translation started", nil)
@@ -437,12 +546,27 @@ func (s *Server) runTranslation(session *TranslationSession) {
 	response, err := s.translator.Translate(session.Ctx, session.Request, session.EventBus)
 	
 	s.sessionsMutex.Lock()
-	defer s.sessionsMutex.Unlock()
+	// CancelTranslation already recorded the outcome
+	if session.Status == "cancelled" {
+		s.sessionsMutex.Unlock()
+		return
+	}
 	
 	if err != nil {
 		session.Status = "failed"
-		session.UpdatedAt = time.Now()
-		
+		session.ErrorMessage = err.Error()
+	} else {
+		session.Status = "completed"
+		session.Progress = 100.0
+		session.Files = response.Files
+		session.Steps = response.Steps
+	}
+	session.UpdatedAt = time.Now()
+	record = sessionToStorage(session)
+	s.sessionsMutex.Unlock()
+	s.updateStoredSession(record)
+	
+	if err != nil {
 		s.logger.Error("Translation failed", map[string]interface{}{
 			"session_id": session.ID,
 			"error": err.Error(),
@@ -456,13 +580,6 @@ func (s *Server) runTranslation(session *TranslationSession) {
 		return
 	}
 	
-	// Update session with results
-	session.Status = "completed"
-	session.UpdatedAt = time.Now()
-	session.Progress = 100.0
-	session.Files = response.Files
-	session.Steps = response.Steps
-	
 	s.logger.Info("Translation completed", map[string]interface{}{
 		"session_id": session.ID,
 		"files_generated": len(response.Files),
@@ -486,21 +603,76 @@ func (s *Server) emitProgressEvent(sessionID, eventType, stepName string, progre
 		Timestamp:          timeToProto(time.Now()),
 	}
 	
+	// Recording and fan-out happen under the session's event lock so streams see
+	// its events in sequence order; other sessions do not wait for the storage write
+	lock := s.sessionEventLock(sessionID)
+	lock.Lock()
+	s.recordEvent(event)
+	
 	// Send to all active streams for this session
+	lagging := make(map[string]*progressSubscriber)
 	s.streamsMutex.RLock()
-	for streamKey, eventChan := range s.streams {
+	for streamKey, subscriber := range s.streams {
 		if strings.HasPrefix(streamKey, sessionID+":") {
 			select {
-			case eventChan <- event:
+			case subscriber.events <- event:
 			default:
-				// Channel full, skip
+				lagging[streamKey] = subscriber
 			}
 		}
 	}
 	s.streamsMutex.RUnlock()
 	
+	// Streams that cannot keep up are ended rather than silently missing events;
+	// their clients resume from the event log
+	if len(lagging) > 0 {
+		s.streamsMutex.Lock()
+		for streamKey, subscriber := range lagging {
+			if s.streams[streamKey] == subscriber {
+				delete(s.streams, streamKey)
+				close(subscriber.lagged)
+			}
+		}
+		s.streamsMutex.Unlock()
+	}
+	lock.Unlock()
+	
 	// Also emit to main event bus
-	s.eventBus.Publish(events.NewEvent(eventType, message, metadata))
+	s.eventBus.Publish(events.NewEvent(events.EventType(eventType), message, metadata))
+}
+
+// sessionEventLock returns the lock that orders the events of a session
+func (s *Server) sessionEventLock(sessionID string) *sync.Mutex {
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+	
+	lock, ok := s.eventLocks[sessionID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.eventLocks[sessionID] = lock
+	}
+	return lock
+}
+
+// eventDataToProto flattens event data into the string map carried by SystemEvent
+func eventDataToProto(data map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		result[key] = fmt.Sprint(value)
+	}
+	return result
+}
+
+// eventSeverity derives the severity of a system event from its type
+func eventSeverity(eventType events.EventType) proto.Severity {
+	switch {
+	case strings.HasSuffix(string(eventType), "_error"):
+		return proto.Severity_ERROR
+	case strings.HasSuffix(string(eventType), "_warning"):
+		return proto.Severity_WARNING
+	default:
+		return proto.Severity_INFO
+	}
 }
 
 func (s *Server) cleanupRoutine() {
@@ -512,6 +684,8 @@ func (s *Server) cleanupRoutine() {
 	}
 }
 
+// cleanupOldSessions evicts finished sessions from memory; with a session store
+// their history stays available from storage
 func (s *Server) cleanupOldSessions() {
 	s.sessionsMutex.Lock()
 	defer s.sessionsMutex.Unlock()
@@ -519,10 +693,15 @@ func (s *Server) cleanupOldSessions() {
 	now := time.Now()
 	for sessionID, session := range s.sessions {
 		// Remove old completed/failed sessions
-		if (session.Status == "completed" || session.Status == "failed" || session.Status == "cancelled") &&
-			now.Sub(session.UpdatedAt) > s.config.SessionTimeout {
-			
+		if isTerminalStatus(session.Status) && now.Sub(session.UpdatedAt) > s.config.SessionTimeout {
 			delete(s.sessions, sessionID)
+			
+			s.eventsMutex.Lock()
+			delete(s.eventLog, sessionID)
+			delete(s.eventSeq, sessionID)
+			delete(s.eventLocks, sessionID)
+			s.eventsMutex.Unlock()
+			
 			s.logger.Info("Cleaned up old session", map[string]interface{}{
 				"session_id": sessionID,
 				"status":     session.Status,
@@ -645,6 +824,14 @@ func (pr *ProviderRegistry) Get(providerType string) (*proto.ProviderInfo, bool)
 	return provider, exists
 }
 
+// SetMetrics instruments the translators of the core translator when it
+// supports metrics, as the one created by NewCoreTranslator does
+func (s *Server) SetMetrics(m *metrics.Metrics) {
+	if instrumented, ok := s.translator.(interface{ SetMetrics(*metrics.Metrics) }); ok {
+		instrumented.SetMetrics(m)
+	}
+}
+
 // GetGRPCServer returns the underlying gRPC server instance
 func (s *Server) GetGRPCServer() *grpc.Server {
 	return s.grpcServer
@@ -652,7 +839,7 @@ func (s *Server) GetGRPCServer() *grpc.Server {
 
 // Shutdown gracefully shuts down the gRPC server
 func (s *Server) Shutdown() {
-	s.logger.Info("Shutting down gRPC server")
+	s.logger.Info("Shutting down gRPC server", nil)
 	
 	// Cancel all active sessions
 	s.sessionsMutex.Lock()
@@ -669,5 +856,5 @@ func (s *Server) Shutdown() {
 		s.grpcServer.GracefulStop()
 	}
 	
-	s.logger.Info("gRPC server shutdown complete")
+	s.logger.Info("gRPC server shutdown complete", nil)
 }
\ No newline at end of file
@@ -0,0 +1,411 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/grpc/proto"
+	"digital.vasic.translator/pkg/logger"
+	"digital.vasic.translator/pkg/metrics"
+	"digital.vasic.translator/pkg/security"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+)
+
+// fakeTranslator returns the input text with a prefix
+type fakeTranslator struct{}
+
+func (f *fakeTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	return "prevod " + text, nil
+}
+
+func (f *fakeTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return f.Translate(ctx, text, contextHint)
+}
+
+func (f *fakeTranslator) GetStats() translator.TranslationStats {
+	return translator.TranslationStats{Total: 1, Translated: 1}
+}
+
+func (f *fakeTranslator) GetName() string {
+	return "fake"
+}
+
+// fakeCoreTranslator completes every book translation with a single generated file
+type fakeCoreTranslator struct {
+	started chan string
+}
+
+func (f *fakeCoreTranslator) Translate(ctx context.Context, req *proto.TranslationRequest, eventBus *events.EventBus) (*proto.TranslationStatusResponse, error) {
+	f.started <- req.SessionId
+	return &proto.TranslationStatusResponse{
+		SessionId: req.SessionId,
+		Status:    "completed",
+		Files:     []*proto.GeneratedFile{{Path: req.OutputFile}},
+	}, nil
+}
+
+func (f *fakeCoreTranslator) Cancel(sessionID string) error {
+	return nil
+}
+
+func (f *fakeCoreTranslator) GetStatus(sessionID string) (*proto.TranslationStatusResponse, error) {
+	return &proto.TranslationStatusResponse{SessionId: sessionID, Status: "completed", ProgressPercentage: 100}, nil
+}
+
+// startTestServer serves a Server over an in-memory listener and returns a client for it
+func startTestServer(t *testing.T, withService bool) (proto.TranslationServiceClient, *fakeCoreTranslator) {
+	t.Helper()
+
+	core := &fakeCoreTranslator{started: make(chan string, 10)}
+	eventBus := events.NewEventBus()
+	server := NewServer(eventBus, logger.NewNoOpLogger(), core, nil)
+	if withService {
+		factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+			return &fakeTranslator{}, nil
+		}
+		server.SetService(service.New(config.DefaultConfig(), eventBus, factory, nil, nil))
+	}
+
+	return serveTestServer(t, server), core
+}
+
+// serveTestServer serves server over an in-memory listener and returns a client for it
+func serveTestServer(t *testing.T, server *Server) proto.TranslationServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	proto.RegisterTranslationServiceServer(server.GetGRPCServer(), server)
+	go server.GetGRPCServer().Serve(lis)
+	t.Cleanup(server.Shutdown)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return proto.NewTranslationServiceClient(conn)
+}
+
+func TestTranslateText(t *testing.T) {
+	client, _ := startTestServer(t, true)
+
+	resp, err := client.TranslateText(context.Background(), &proto.TextTranslationRequest{Text: "књига", Script: "latin"})
+	require.NoError(t, err)
+	assert.Equal(t, "књига", resp.Original)
+	assert.Equal(t, "prevod knjiga", resp.Translated)
+	assert.Equal(t, "fake", resp.Provider)
+	assert.NotEmpty(t, resp.SessionId)
+
+	_, err = client.TranslateText(context.Background(), &proto.TextTranslationRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServiceNotConfigured(t *testing.T) {
+	client, _ := startTestServer(t, false)
+
+	_, err := client.TranslateText(context.Background(), &proto.TextTranslationRequest{Text: "књига"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestTranslateSegments(t *testing.T) {
+	client, _ := startTestServer(t, true)
+
+	stream, err := client.TranslateSegments(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&proto.SegmentTranslationRequest{
+		Settings: &proto.SegmentStreamSettings{MaxInFlight: 2},
+	}))
+	for _, req := range []*proto.SegmentTranslationRequest{
+		{SegmentId: "1", Text: "први"},
+		{SegmentId: "2", Text: "други"},
+		{Text: "без идентификатора"},
+	} {
+		require.NoError(t, stream.Send(req))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var responses []*proto.SegmentTranslationResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		responses = append(responses, resp)
+	}
+
+	// Responses may arrive out of order
+	sort.Slice(responses, func(i, j int) bool { return responses[i].SegmentId < responses[j].SegmentId })
+	require.Len(t, responses, 3)
+	assert.Equal(t, "segment_id is required", responses[0].Error)
+	assert.Equal(t, "prevod први", responses[1].TranslatedText)
+	assert.Equal(t, "prevod други", responses[2].TranslatedText)
+}
+
+func TestTranslateSegmentsProjectAccess(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(&storage.Config{Database: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	require.NoError(t, store.CreateProject(ctx, &storage.Project{
+		ID:                    "p1",
+		Name:                  "Publisher",
+		OwnerID:               "owner",
+		DefaultTargetLanguage: "hr",
+		Members:               []storage.ProjectMember{{UserID: "viewer", Role: storage.ProjectRoleViewer}},
+	}))
+
+	eventBus := events.NewEventBus()
+	server := NewServer(eventBus, logger.NewNoOpLogger(), &fakeCoreTranslator{started: make(chan string, 10)}, nil)
+	factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		return &fakeTranslator{}, nil
+	}
+	server.SetService(service.New(config.DefaultConfig(), eventBus, factory, nil, store))
+	auth := security.NewAuthService("test-secret-key-16-chars", time.Hour)
+	server.SetProjectAccess(store, auth)
+	client := serveTestServer(t, server)
+
+	translate := func(userID string, settings *proto.SegmentStreamSettings) (*proto.SegmentTranslationResponse, error) {
+		token, err := auth.GenerateToken(userID, userID, nil)
+		require.NoError(t, err)
+		stream, err := client.TranslateSegments(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token))
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&proto.SegmentTranslationRequest{Settings: settings, SegmentId: "1", Text: "книга"}))
+		require.NoError(t, stream.CloseSend())
+		return stream.Recv()
+	}
+
+	resp, err := translate("owner", &proto.SegmentStreamSettings{ProjectId: "p1"})
+	require.NoError(t, err)
+	assert.Equal(t, "prevod книга", resp.TranslatedText)
+
+	_, err = translate("viewer", &proto.SegmentStreamSettings{ProjectId: "p1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = translate("stranger", &proto.SegmentStreamSettings{ProjectId: "p1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = translate("owner", &proto.SegmentStreamSettings{ProjectId: "p1", SourceLang: "klingon"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestConvertScript(t *testing.T) {
+	client, _ := startTestServer(t, true)
+
+	resp, err := client.ConvertScript(context.Background(), &proto.ScriptConversionRequest{Text: "књига", Target: "latin"})
+	require.NoError(t, err)
+	assert.Equal(t, "knjiga", resp.Converted)
+
+	_, err = client.ConvertScript(context.Background(), &proto.ScriptConversionRequest{Text: "књига", Target: "greek"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStartTranslation(t *testing.T) {
+	client, core := startTestServer(t, false)
+	ctx := context.Background()
+
+	resp, err := client.StartTranslation(ctx, &proto.TranslationRequest{
+		SessionId:      "session-1",
+		InputFile:      "book.fb2",
+		OutputFile:     "book_sr.epub",
+		ProviderConfig: &proto.ProviderConfig{Type: "fake"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "started", resp.Status)
+
+	select {
+	case sessionID := <-core.started:
+		assert.Equal(t, "session-1", sessionID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("translation was not started")
+	}
+
+	statusResp, err := client.GetTranslationStatus(ctx, &proto.TranslationStatusRequest{SessionId: "session-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", statusResp.Status)
+	assert.Equal(t, float64(100), statusResp.ProgressPercentage)
+
+	_, err = client.GetTranslationStatus(ctx, &proto.TranslationStatusRequest{SessionId: "missing"})
+	assert.Error(t, err)
+}
+
+// slowStore blocks persisting the outcome of the "slow" session until released
+type slowStore struct {
+	storage.Storage
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) UpdateSession(ctx context.Context, session *storage.TranslationSession) error {
+	if session.ID == "slow" && session.Status == "completed" {
+		s.blocked <- struct{}{}
+		<-s.release
+	}
+	return s.Storage.UpdateSession(ctx, session)
+}
+
+func (s *slowStore) AppendSessionEvent(ctx context.Context, event *storage.SessionEvent) error {
+	if event.SessionID == "slow" && event.EventType == "completed" {
+		s.blocked <- struct{}{}
+		<-s.release
+	}
+	return s.Storage.AppendSessionEvent(ctx, event)
+}
+
+func TestStoragePersistedOutsideLocks(t *testing.T) {
+	sqlite, err := storage.NewSQLiteStorage(&storage.Config{Database: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer sqlite.Close()
+	store := &slowStore{Storage: sqlite, blocked: make(chan struct{}), release: make(chan struct{})}
+
+	core := &fakeCoreTranslator{started: make(chan string, 10)}
+	server := NewServer(events.NewEventBus(), logger.NewNoOpLogger(), core, nil)
+	require.NoError(t, server.SetSessionStore(store))
+	client := serveTestServer(t, server)
+	ctx := context.Background()
+
+	start := func(sessionID string) {
+		_, err := client.StartTranslation(ctx, &proto.TranslationRequest{
+			SessionId:      sessionID,
+			ProviderConfig: &proto.ProviderConfig{Type: "fake"},
+		})
+		require.NoError(t, err)
+	}
+
+	// Hold the session update, then the event append, of the slow session
+	start("slow")
+	for i := 0; i < 2; i++ {
+		select {
+		case <-store.blocked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("slow session was not persisted")
+		}
+
+		// Other sessions keep working while a storage write is pending
+		listCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err = client.ListTranslations(listCtx, &emptypb.Empty{})
+		cancel()
+		require.NoError(t, err)
+
+		sessionID := fmt.Sprintf("fast-%d", i)
+		start(sessionID)
+		require.Eventually(t, func() bool {
+			events, err := sqlite.ListSessionEvents(ctx, sessionID, 0)
+			return err == nil && len(events) == 2 && events[1].EventType == "completed"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		store.release <- struct{}{}
+	}
+
+	require.Eventually(t, func() bool {
+		events, err := sqlite.ListSessionEvents(ctx, "slow", 0)
+		return err == nil && len(events) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStreamTranslationProgressResync(t *testing.T) {
+	config := &ServerConfig{MaxConcurrentTranslations: 1, SessionTimeout: time.Hour, StreamBufferSize: 1}
+	server := NewServer(events.NewEventBus(), logger.NewNoOpLogger(), &fakeCoreTranslator{started: make(chan string, 10)}, config)
+	client := serveTestServer(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamTranslationProgress(ctx, &proto.TranslationStreamRequest{SessionId: "s1", ClientId: "slow"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		server.streamsMutex.RLock()
+		defer server.streamsMutex.RUnlock()
+		return server.streams["s1:slow"] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The client reads nothing while the events arrive, so its stream falls behind
+	const total = 2000
+	message := strings.Repeat("x", 4096)
+	for i := 0; i < total; i++ {
+		server.emitProgressEvent("s1", "translation_progress", "translation", float64(i), message, nil)
+	}
+
+	var lastSequence int64
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.Aborted, status.Code(err))
+			break
+		}
+		require.Equal(t, lastSequence+1, event.Sequence)
+		lastSequence = event.Sequence
+	}
+	assert.Less(t, lastSequence, int64(total))
+
+	// Resuming replays everything the ended stream missed
+	stream, err = client.StreamTranslationProgress(ctx, &proto.TranslationStreamRequest{SessionId: "s1", ClientId: "slow", LastSequence: lastSequence})
+	require.NoError(t, err)
+	for lastSequence < total {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, lastSequence+1, event.Sequence)
+		lastSequence = event.Sequence
+	}
+}
+
+func TestGetProviders(t *testing.T) {
+	client, _ := startTestServer(t, false)
+
+	resp, err := client.GetProviders(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Providers)
+}
+
+func TestSetMetrics(t *testing.T) {
+	core := NewCoreTranslator(logger.NewNoOpLogger())
+	server := NewServer(events.NewEventBus(), logger.NewNoOpLogger(), core, nil)
+
+	m := metrics.New()
+	server.SetMetrics(m)
+
+	trans := core.(*CoreTranslatorImpl).instrument(&fakeTranslator{}, translator.TranslationConfig{Provider: "openai", Model: "gpt-4", SourceLang: "en", TargetLang: "de"})
+	_, err := trans.Translate(context.Background(), "hello", "")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, recorder.Body.String(), `translator_segments_translated_total{language_pair="en-de",model="gpt-4",provider="openai"} 1`)
+}
+
+func TestEventSeverity(t *testing.T) {
+	assert.Equal(t, proto.Severity_ERROR, eventSeverity(events.EventTranslationError))
+	assert.Equal(t, proto.Severity_WARNING, eventSeverity(events.EventVerificationWarning))
+	assert.Equal(t, proto.Severity_INFO, eventSeverity(events.EventTranslationStarted))
+
+	assert.Equal(t, map[string]string{"count": "3", "name": "book"}, eventDataToProto(map[string]interface{}{"count": 3, "name": "book"}))
+}
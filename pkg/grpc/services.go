@@ -0,0 +1,546 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"digital.vasic.translator/pkg/distributed"
+	"digital.vasic.translator/pkg/grpc/proto"
+	"digital.vasic.translator/pkg/service"
+	"digital.vasic.translator/pkg/storage"
+)
+
+// workerTimeout bounds worker discovery and version drift checks
+const workerTimeout = 5 * time.Minute
+
+// SetService enables the RPCs shared with the REST API: text and segment
+// translation, script conversion, language detection, preparation analysis,
+// worker management and version monitoring
+func (s *Server) SetService(svc *service.Service) {
+	s.service = svc
+}
+
+// requireService returns the shared service or an error when it is not configured
+func (s *Server) requireService() (*service.Service, error) {
+	if s.service == nil {
+		return nil, status.Error(codes.FailedPrecondition, "translation service not configured")
+	}
+	return s.service, nil
+}
+
+// toStatusError maps service errors to gRPC status errors
+func toStatusError(err error) error {
+	switch {
+	case service.IsInvalidArgument(err), errors.Is(err, service.ErrInvalidScript):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrDistributedUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, service.ErrStorageUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrPreparationResultNotFound):
+		return status.Error(codes.NotFound, "preparation result not found")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// TranslateText translates a piece of text
+func (s *Server) TranslateText(ctx context.Context, req *proto.TextTranslationRequest) (*proto.TextTranslationResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.authorizedProject(ctx, req.ProjectId, true)
+	if err != nil {
+		return nil, err
+	}
+	provider, model := projectProvider(project, req.Provider, req.Model)
+
+	result, err := svc.TranslateText(ctx, service.TextRequest{
+		Text:     req.Text,
+		Provider: provider,
+		Model:    model,
+		Context:  req.Context,
+		Script:   req.Script,
+		Project:  project,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.TextTranslationResponse{
+		Original:   result.Original,
+		Translated: result.Translated,
+		Provider:   result.Provider,
+		SessionId:  result.SessionID,
+		Stats: &proto.TranslationStats{
+			Total:      int32(result.Stats.Total),
+			Translated: int32(result.Stats.Translated),
+			Cached:     int32(result.Stats.Cached),
+			Errors:     int32(result.Stats.Errors),
+		},
+	}, nil
+}
+
+// TranslateSegments translates segments as the client sends them and returns each
+// translation as soon as it is ready, so responses may arrive out of order. At most
+// max_in_flight segments are translated at once; while that limit is reached the
+// server stops reading, so gRPC flow control holds back clients that send faster
+// than segments are translated. Failures of a single segment are reported on its
+// response and do not end the stream.
+func (s *Server) TranslateSegments(stream proto.TranslationService_TranslateSegmentsServer) error {
+	svc, err := s.requireService()
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	settings := first.GetSettings()
+	project, err := s.authorizedProject(stream.Context(), settings.GetProjectId(), true)
+	if err != nil {
+		return err
+	}
+	provider, model := projectProvider(project, settings.GetProvider(), settings.GetModel())
+
+	segments, err := svc.NewSegmentTranslator(service.SegmentSettings{
+		Provider:       provider,
+		Model:          model,
+		Script:         settings.GetScript(),
+		Concurrency:    int(settings.GetMaxInFlight()),
+		SourceLanguage: settings.GetSourceLang(),
+		TargetLanguage: settings.GetTargetLang(),
+		Project:        project,
+	})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		sendMutex sync.Mutex
+		sendErr   error
+	)
+	send := func(resp *proto.SegmentTranslationResponse) {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		if sendErr != nil {
+			return
+		}
+		if err := stream.Send(resp); err != nil {
+			sendErr = err
+			cancel()
+		}
+	}
+
+	slots := make(chan struct{}, segments.Concurrency())
+	var recvErr error
+
+recv:
+	for req := first; ; {
+		if req != first && req.Settings != nil {
+			recvErr = status.Error(codes.InvalidArgument, "stream settings are only accepted on the first message")
+			break
+		}
+
+		// A first message carrying only settings opens the stream without a segment
+		if req != first || req.SegmentId != "" || req.Text != "" {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				break recv
+			}
+
+			wg.Add(1)
+			go func(req *proto.SegmentTranslationRequest) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				send(translateSegment(ctx, segments, req))
+			}(req)
+		}
+
+		req, err = stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				recvErr = err
+			}
+			break
+		}
+	}
+
+	if recvErr != nil {
+		cancel()
+	}
+	wg.Wait()
+
+	switch {
+	case recvErr != nil:
+		return recvErr
+	case sendErr != nil:
+		return sendErr
+	case stream.Context().Err() != nil:
+		return toStatusError(stream.Context().Err())
+	}
+	return nil
+}
+
+// translateSegment translates a single streamed segment
+func translateSegment(ctx context.Context, segments *service.SegmentTranslator, req *proto.SegmentTranslationRequest) *proto.SegmentTranslationResponse {
+	resp := &proto.SegmentTranslationResponse{SegmentId: req.SegmentId}
+
+	// Responses are matched to segments by ID, since they may arrive out of order
+	if req.SegmentId == "" {
+		resp.Error = "segment_id is required"
+		return resp
+	}
+
+	result, err := segments.Translate(ctx, service.Segment{
+		ID:      req.SegmentId,
+		Text:    req.Text,
+		Context: req.Context,
+	})
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.TranslatedText = result.Translated
+	resp.Provider = result.Provider
+	resp.CacheHit = result.CacheHit
+	resp.QualityScore = result.QualityScore
+	return resp
+}
+
+// ConvertScript converts Serbian text between Cyrillic and Latin script
+func (s *Server) ConvertScript(ctx context.Context, req *proto.ScriptConversionRequest) (*proto.ScriptConversionResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := svc.ConvertScript(req.Text, req.Target)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.ScriptConversionResponse{
+		Original:  req.Text,
+		Converted: converted,
+		Target:    req.Target,
+	}, nil
+}
+
+// DetectLanguage detects the language of a text
+func (s *Server) DetectLanguage(ctx context.Context, req *proto.LanguageDetectionRequest) (*proto.LanguageDetectionResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	lang, err := svc.DetectLanguage(ctx, req.Text)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.LanguageDetectionResponse{
+		Code: lang.Code,
+		Name: lang.Name,
+	}, nil
+}
+
+// AnalyzePreparation analyzes content before translating it
+func (s *Server) AnalyzePreparation(ctx context.Context, req *proto.PreparationRequest) (*proto.PreparationResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeProject(ctx, req.ProjectId, true); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.AnalyzePreparation(ctx, service.PreparationRequest{
+		InputPath:      req.InputPath,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Format:         req.Format,
+		ProjectID:      req.ProjectId,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return preparationToProto(result)
+}
+
+// GetPreparationResult returns a stored preparation analysis
+func (s *Server) GetPreparationResult(ctx context.Context, req *proto.PreparationResultRequest) (*proto.PreparationResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeProject(ctx, req.ProjectId, false); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.GetPreparationResult(ctx, req.SessionId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	// Results of other projects are reported as missing so they cannot be probed
+	if result.ProjectID != req.ProjectId {
+		return nil, status.Error(codes.NotFound, "preparation result not found")
+	}
+
+	return preparationToProto(result)
+}
+
+// GetDistributedStatus returns the state of distributed work
+func (s *Server) GetDistributedStatus(ctx context.Context, _ *emptypb.Empty) (*proto.DistributedStatusResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	distributedStatus, err := svc.DistributedStatus()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := toStruct(distributedStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.DistributedStatusResponse{Status: result}, nil
+}
+
+// DiscoverWorkers discovers configured workers and pairs with them
+func (s *Server) DiscoverWorkers(ctx context.Context, _ *emptypb.Empty) (*proto.WorkerActionResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, workerTimeout)
+	defer cancel()
+
+	if err := svc.DiscoverWorkers(ctx); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.WorkerActionResponse{
+		Success: true,
+		Message: "Worker discovery completed",
+	}, nil
+}
+
+// PairWorker pairs with a worker
+func (s *Server) PairWorker(ctx context.Context, req *proto.WorkerRequest) (*proto.WorkerActionResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.PairWorker(req.WorkerId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.WorkerActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Successfully paired with worker %s", req.WorkerId),
+	}, nil
+}
+
+// UnpairWorker unpairs from a worker
+func (s *Server) UnpairWorker(ctx context.Context, req *proto.WorkerRequest) (*proto.WorkerActionResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.UnpairWorker(req.WorkerId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.WorkerActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Successfully unpaired from worker %s", req.WorkerId),
+	}, nil
+}
+
+// GetVersionHealth returns worker version metrics and health
+func (s *Server) GetVersionHealth(ctx context.Context, _ *emptypb.Empty) (*proto.VersionHealthResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	health, err := svc.VersionHealth()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	metrics, err := svc.VersionMetrics()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	healthStruct, err := toStruct(health)
+	if err != nil {
+		return nil, err
+	}
+	metricsStruct, err := toStruct(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.VersionHealthResponse{
+		Health:  healthStruct,
+		Metrics: metricsStruct,
+	}, nil
+}
+
+// ListVersionAlerts lists current or past version drift alerts
+func (s *Server) ListVersionAlerts(ctx context.Context, req *proto.VersionAlertsRequest) (*proto.VersionAlertsResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []*distributed.DriftAlert
+	if req.History {
+		alerts, err = svc.AlertHistory(int(req.Limit))
+	} else {
+		alerts, err = svc.VersionAlerts(req.Severity)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.VersionAlertsResponse{Alerts: alertsToProto(alerts)}, nil
+}
+
+// CheckVersionDrift checks all workers for version drift and returns the new alerts
+func (s *Server) CheckVersionDrift(ctx context.Context, _ *emptypb.Empty) (*proto.VersionAlertsResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, workerTimeout)
+	defer cancel()
+
+	alerts, err := svc.CheckVersionDrift(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.VersionAlertsResponse{Alerts: alertsToProto(alerts)}, nil
+}
+
+// AcknowledgeAlert acknowledges a version drift alert
+func (s *Server) AcknowledgeAlert(ctx context.Context, req *proto.AcknowledgeAlertRequest) (*proto.AcknowledgeAlertResponse, error) {
+	svc, err := s.requireService()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AcknowledgedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "acknowledged_by is required")
+	}
+
+	acknowledged, err := svc.AcknowledgeAlert(req.AlertId, req.AcknowledgedBy)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if !acknowledged {
+		return nil, status.Error(codes.NotFound, "Alert not found or already acknowledged")
+	}
+
+	return &proto.AcknowledgeAlertResponse{
+		Success: true,
+		Message: "Alert acknowledged successfully",
+	}, nil
+}
+
+// preparationToProto converts a preparation result into its response form
+func preparationToProto(result *storage.PreparationResult) (*proto.PreparationResponse, error) {
+	analysis, err := toStruct(result.Analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.PreparationResponse{
+		SessionId:   result.SessionID,
+		ProjectId:   result.ProjectID,
+		Status:      result.Status,
+		Analysis:    analysis,
+		CompletedAt: timestamppb.New(result.CreatedAt),
+	}, nil
+}
+
+// alertsToProto converts drift alerts into their response form
+func alertsToProto(alerts []*distributed.DriftAlert) []*proto.DriftAlert {
+	result := make([]*proto.DriftAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		converted := &proto.DriftAlert{
+			AlertId:         alert.AlertID,
+			WorkerId:        alert.WorkerID,
+			Severity:        alert.Severity,
+			Message:         alert.Message,
+			CurrentVersion:  alert.CurrentVersion.CodebaseVersion,
+			ExpectedVersion: alert.ExpectedVersion.CodebaseVersion,
+			DriftSeconds:    alert.DriftDuration.Seconds(),
+			Timestamp:       timestamppb.New(alert.Timestamp),
+			Acknowledged:    alert.Acknowledged,
+			AcknowledgedBy:  alert.AcknowledgedBy,
+		}
+		if alert.AcknowledgedAt != nil {
+			converted.AcknowledgedAt = timestamppb.New(*alert.AcknowledgedAt)
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+// toStruct converts a JSON-serializable value into a protobuf Struct, using the
+// same field names the REST API returns
+func toStruct(v interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+
+	result := &structpb.Struct{}
+	if err := result.UnmarshalJSON(data); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,272 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"digital.vasic.translator/pkg/grpc/proto"
+	"digital.vasic.translator/pkg/storage"
+)
+
+// storageTimeout bounds each persistence call made on behalf of a session
+const storageTimeout = 5 * time.Second
+
+// interruptedMessage is recorded for sessions that were running when the server stopped
+const interruptedMessage = "Translation interrupted by server restart"
+
+// SetSessionStore persists sessions and their event logs, so job history survives
+// restarts and progress streams can be resumed. Sessions that were still running
+// when the previous server process stopped are marked as failed.
+func (s *Server) SetSessionStore(store storage.Storage) error {
+	s.store = store
+	return s.markInterruptedSessions(context.Background())
+}
+
+// isTerminalStatus reports whether a session has finished
+func isTerminalStatus(status string) bool {
+	return status == "completed" || status == "failed" || status == "cancelled"
+}
+
+// lookupSession returns a live session, or rebuilds a finished one from storage.
+// Rebuilt sessions are not registered as live sessions.
+func (s *Server) lookupSession(ctx context.Context, sessionID string) (*TranslationSession, bool) {
+	s.sessionsMutex.RLock()
+	session, exists := s.sessions[sessionID]
+	s.sessionsMutex.RUnlock()
+	if exists || s.store == nil {
+		return session, exists
+	}
+
+	record, err := s.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrSessionNotFound) {
+			s.logger.Warn("Failed to load session from storage", map[string]interface{}{
+				"session_id": sessionID,
+				"error":      err.Error(),
+			})
+		}
+		return nil, false
+	}
+
+	return sessionFromStorage(record), true
+}
+
+// storedSessions returns persisted sessions that are not live in this process
+func (s *Server) storedSessions(ctx context.Context) []*TranslationSession {
+	if s.store == nil {
+		return nil
+	}
+
+	const pageSize = 100
+	var result []*TranslationSession
+	for offset := 0; ; offset += pageSize {
+		records, err := s.store.ListSessions(ctx, pageSize, offset)
+		if err != nil {
+			s.logger.Warn("Failed to list stored sessions", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return result
+		}
+
+		s.sessionsMutex.RLock()
+		for _, record := range records {
+			if _, live := s.sessions[record.ID]; !live {
+				result = append(result, sessionFromStorage(record))
+			}
+		}
+		s.sessionsMutex.RUnlock()
+
+		if len(records) < pageSize {
+			return result
+		}
+	}
+}
+
+// createStoredSession persists a newly started session
+func (s *Server) createStoredSession(session *TranslationSession) {
+	if s.store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+	defer cancel()
+
+	if err := s.store.CreateSession(ctx, sessionToStorage(session)); err != nil {
+		s.logger.Warn("Failed to persist session", map[string]interface{}{
+			"session_id": session.ID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// updateStoredSession persists a snapshot of a session taken with sessionToStorage.
+// It performs storage I/O, so callers must not hold sessionsMutex.
+func (s *Server) updateStoredSession(record *storage.TranslationSession) {
+	if s.store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+	defer cancel()
+
+	if err := s.store.UpdateSession(ctx, record); err != nil {
+		s.logger.Warn("Failed to update persisted session", map[string]interface{}{
+			"session_id": record.ID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// recordEvent appends an event to the session log and assigns its sequence number.
+// Callers must hold the session's event lock.
+func (s *Server) recordEvent(event *proto.TranslationProgressEvent) {
+	if s.store == nil {
+		s.eventsMutex.Lock()
+		defer s.eventsMutex.Unlock()
+		s.eventSeq[event.SessionId]++
+		event.Sequence = s.eventSeq[event.SessionId]
+		s.eventLog[event.SessionId] = append(s.eventLog[event.SessionId], event)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+	defer cancel()
+
+	record := &storage.SessionEvent{
+		SessionID: event.SessionId,
+		EventType: event.EventType,
+		StepName:  event.StepName,
+		Progress:  event.ProgressPercentage,
+		Message:   event.Message,
+		Metadata:  event.Metadata,
+		CreatedAt: event.Timestamp.AsTime(),
+	}
+	if err := s.store.AppendSessionEvent(ctx, record); err != nil {
+		// The event is still delivered live, it just cannot be replayed
+		s.logger.Warn("Failed to persist session event", map[string]interface{}{
+			"session_id": event.SessionId,
+			"event_type": event.EventType,
+			"error":      err.Error(),
+		})
+		return
+	}
+	event.Sequence = record.Sequence
+}
+
+// eventsSince returns the logged events of a session after the given sequence number
+func (s *Server) eventsSince(ctx context.Context, sessionID string, afterSequence int64) ([]*proto.TranslationProgressEvent, error) {
+	if s.store == nil {
+		s.eventsMutex.Lock()
+		defer s.eventsMutex.Unlock()
+
+		var result []*proto.TranslationProgressEvent
+		for _, event := range s.eventLog[sessionID] {
+			if event.Sequence > afterSequence {
+				result = append(result, event)
+			}
+		}
+		return result, nil
+	}
+
+	records, err := s.store.ListSessionEvents(ctx, sessionID, afterSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*proto.TranslationProgressEvent, 0, len(records))
+	for _, record := range records {
+		result = append(result, &proto.TranslationProgressEvent{
+			SessionId:          record.SessionID,
+			EventType:          record.EventType,
+			StepName:           record.StepName,
+			ProgressPercentage: record.Progress,
+			Message:            record.Message,
+			Metadata:           record.Metadata,
+			Timestamp:          timeToProto(record.CreatedAt),
+			Sequence:           record.Sequence,
+		})
+	}
+	return result, nil
+}
+
+// markInterruptedSessions fails sessions that a previous server process left unfinished
+func (s *Server) markInterruptedSessions(ctx context.Context) error {
+	for _, session := range s.storedSessions(ctx) {
+		if isTerminalStatus(session.Status) {
+			continue
+		}
+
+		session.Status = "failed"
+		session.ErrorMessage = interruptedMessage
+		session.UpdatedAt = time.Now()
+		s.updateStoredSession(sessionToStorage(session))
+
+		s.emitProgressEvent(session.ID, "error", "", session.Progress, interruptedMessage, map[string]interface{}{
+			"error": interruptedMessage,
+		})
+
+		s.logger.Info("Marked interrupted session as failed", map[string]interface{}{
+			"session_id": session.ID,
+		})
+	}
+
+	return nil
+}
+
+// sessionToStorage converts a session into its persisted form
+func sessionToStorage(session *TranslationSession) *storage.TranslationSession {
+	req := session.Request
+
+	record := &storage.TranslationSession{
+		ID:              session.ID,
+		ProjectID:       session.ProjectID,
+		BookTitle:       filepath.Base(req.GetInputFile()),
+		InputFile:       req.GetInputFile(),
+		OutputFile:      req.GetOutputFile(),
+		SourceLanguage:  req.GetSourceLang(),
+		TargetLanguage:  req.GetTargetLang(),
+		Provider:        req.GetProviderConfig().GetType(),
+		Model:           req.GetProviderConfig().GetModel(),
+		Status:          session.Status,
+		PercentComplete: session.Progress,
+		StartTime:       session.CreatedAt,
+		ErrorMessage:    session.ErrorMessage,
+		CreatedAt:       session.CreatedAt,
+		UpdatedAt:       session.UpdatedAt,
+	}
+	if isTerminalStatus(session.Status) {
+		endTime := session.UpdatedAt
+		record.EndTime = &endTime
+	}
+
+	return record
+}
+
+// sessionFromStorage rebuilds a session from its persisted form
+func sessionFromStorage(record *storage.TranslationSession) *TranslationSession {
+	return &TranslationSession{
+		ID:        record.ID,
+		ProjectID: record.ProjectID,
+		Status:    record.Status,
+		Request: &proto.TranslationRequest{
+			SessionId:  record.ID,
+			InputFile:  record.InputFile,
+			OutputFile: record.OutputFile,
+			SourceLang: record.SourceLanguage,
+			TargetLang: record.TargetLanguage,
+			ProviderConfig: &proto.ProviderConfig{
+				Type:  record.Provider,
+				Model: record.Model,
+			},
+			ProjectId: record.ProjectID,
+		},
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+		Progress:     record.PercentComplete,
+		ErrorMessage: record.ErrorMessage,
+		Steps:        make([]*proto.TranslationStep, 0),
+		Files:        make([]*proto.GeneratedFile, 0),
+	}
+}
@@ -117,18 +117,47 @@ func NewDetector(llmDetector LLMDetector) *Detector {
 
 // Detect detects the language of the given text
 func (d *Detector) Detect(ctx context.Context, text string) (Language, error) {
-	// Try LLM detection first if available
+	detection, err := d.DetectWithConfidence(ctx, text)
+	return detection.Language, err
+}
+
+// DetectWithConfidence detects the language of the given text statistically
+// and asks the LLM detector only when the statistical confidence is below
+// MinConfidence. Without an LLM detector, the statistical guess stands unless
+// the text is too short for trigram statistics, where character heuristics
+// decide.
+func (d *Detector) DetectWithConfidence(ctx context.Context, text string) (Detection, error) {
+	detection := DetectStatistical(text)
+	if detection.Confidence >= MinConfidence {
+		return detection, nil
+	}
+
+	// Try LLM detection for uncertain texts if available
 	if d.llmDetector != nil {
 		code, err := d.llmDetector.DetectLanguage(ctx, text)
 		if err == nil && code != "" {
 			if lang, ok := languageMap[strings.ToLower(code)]; ok {
-				return lang, nil
+				return d.overrule(detection, lang, SourceLLM), nil
 			}
 		}
 	}
 
+	if detection.Language.Code != "" && len(textTrigrams(text)) >= minStatisticalTrigrams {
+		return detection, nil
+	}
+
 	// Fallback to heuristic detection
-	return d.detectHeuristic(text), nil
+	return d.overrule(detection, d.detectHeuristic(text), SourceHeuristic), nil
+}
+
+// overrule replaces the language of an uncertain statistical detection
+func (d *Detector) overrule(detection Detection, lang Language, source string) Detection {
+	if lang != detection.Language {
+		detection.Confidence = 0
+	}
+	detection.Language = lang
+	detection.Source = source
+	return detection
 }
 
 // detectHeuristic uses character-based heuristics to detect language
@@ -444,11 +473,11 @@ func ParseLanguage(s string) (Language, error) {
 
 // GetSupportedLanguages returns list of supported languages
 func GetSupportedLanguages() []Language {
-	return []Language{
-		English, Russian, Serbian, German, French, Spanish,
-		Italian, Portuguese, Chinese, Japanese, Korean, Arabic,
-		Polish, Ukrainian, Czech, Slovak, Croatian, Bulgarian,
+	languages := make([]Language, len(supportedLanguages))
+	for i, entry := range supportedLanguages {
+		languages[i] = entry.lang
 	}
+	return languages
 }
 
 // isCyrillic checks if a rune is Cyrillic
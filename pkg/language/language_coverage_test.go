@@ -161,7 +161,7 @@ func TestLanguageDetector_DistinguishingSimilarLanguages(t *testing.T) {
 			expected Language
 		}{
 			{"¿Cómo está usted?", Spanish}, // Contains "ó" and "ú" which are detected as Spanish
-			{"Comment allez-vous?", English}, // Falls back to English 
+			{"Comment allez-vous?", French}, // Recognized from its trigrams
 			{"Come sta?", English}, // Falls back to English
 			{"Como vai você?", French}, // "você" contains "ê" which is detected as French
 		}
@@ -466,8 +466,9 @@ func BenchmarkParseLanguage(b *testing.B) {
 func TestGetSupportedLanguages(t *testing.T) {
 	languages := GetSupportedLanguages()
 	
-	// Should return exactly 18 supported languages
-	assert.Equal(t, 18, len(languages), "Should return 18 supported languages")
+	// Should return every language of the statistical detector
+	assert.Equal(t, len(supportedLanguages), len(languages), "Should return all supported languages")
+	assert.GreaterOrEqual(t, len(languages), 80, "Should support about 80 languages")
 	
 	// Check that major languages are included
 	expectedCodes := []string{"en", "ru", "de", "fr", "es", "it", "pt", "zh", "ja", "ko", "ar"}
@@ -0,0 +1,192 @@
+package language
+
+import "strings"
+
+// Languages recognized by the statistical detector in addition to the common ones
+var (
+	Afrikaans        = Language{Code: "af", Name: "Afrikaans", Native: "Afrikaans"}
+	Akan             = Language{Code: "ak", Name: "Akan", Native: "Akan"}
+	Amharic          = Language{Code: "am", Name: "Amharic", Native: "አማርኛ"}
+	Azerbaijani      = Language{Code: "az", Name: "Azerbaijani", Native: "Azərbaycan dili"}
+	Belarusian       = Language{Code: "be", Name: "Belarusian", Native: "Беларуская"}
+	Bengali          = Language{Code: "bn", Name: "Bengali", Native: "বাংলা"}
+	Bhojpuri         = Language{Code: "bho", Name: "Bhojpuri", Native: "भोजपुरी"}
+	Bosnian          = Language{Code: "bs", Name: "Bosnian", Native: "Bosanski"}
+	Burmese          = Language{Code: "my", Name: "Burmese", Native: "မြန်မာ"}
+	Cebuano          = Language{Code: "ceb", Name: "Cebuano", Native: "Cebuano"}
+	Chichewa         = Language{Code: "ny", Name: "Chichewa", Native: "Chichewa"}
+	Danish           = Language{Code: "da", Name: "Danish", Native: "Dansk"}
+	Dutch            = Language{Code: "nl", Name: "Dutch", Native: "Nederlands"}
+	Esperanto        = Language{Code: "eo", Name: "Esperanto", Native: "Esperanto"}
+	Estonian         = Language{Code: "et", Name: "Estonian", Native: "Eesti"}
+	Finnish          = Language{Code: "fi", Name: "Finnish", Native: "Suomi"}
+	Georgian         = Language{Code: "ka", Name: "Georgian", Native: "ქართული"}
+	Greek            = Language{Code: "el", Name: "Greek", Native: "Ελληνικά"}
+	Gujarati         = Language{Code: "gu", Name: "Gujarati", Native: "ગુજરાતી"}
+	HaitianCreole    = Language{Code: "ht", Name: "Haitian Creole", Native: "Kreyòl ayisyen"}
+	Hausa            = Language{Code: "ha", Name: "Hausa", Native: "Hausa"}
+	Hebrew           = Language{Code: "he", Name: "Hebrew", Native: "עברית"}
+	Hindi            = Language{Code: "hi", Name: "Hindi", Native: "हिन्दी"}
+	Hungarian        = Language{Code: "hu", Name: "Hungarian", Native: "Magyar"}
+	Igbo             = Language{Code: "ig", Name: "Igbo", Native: "Igbo"}
+	Ilocano          = Language{Code: "ilo", Name: "Ilocano", Native: "Ilokano"}
+	Indonesian       = Language{Code: "id", Name: "Indonesian", Native: "Bahasa Indonesia"}
+	Javanese         = Language{Code: "jv", Name: "Javanese", Native: "Basa Jawa"}
+	Kannada          = Language{Code: "kn", Name: "Kannada", Native: "ಕನ್ನಡ"}
+	Khmer            = Language{Code: "km", Name: "Khmer", Native: "ខ្មែរ"}
+	Kinyarwanda      = Language{Code: "rw", Name: "Kinyarwanda", Native: "Ikinyarwanda"}
+	Kirundi          = Language{Code: "rn", Name: "Kirundi", Native: "Ikirundi"}
+	Kurdish          = Language{Code: "ku", Name: "Kurdish", Native: "Kurdî"}
+	Latvian          = Language{Code: "lv", Name: "Latvian", Native: "Latviešu"}
+	Lithuanian       = Language{Code: "lt", Name: "Lithuanian", Native: "Lietuvių"}
+	Macedonian       = Language{Code: "mk", Name: "Macedonian", Native: "Македонски"}
+	Maithili         = Language{Code: "mai", Name: "Maithili", Native: "मैथिली"}
+	Malagasy         = Language{Code: "mg", Name: "Malagasy", Native: "Malagasy"}
+	Malayalam        = Language{Code: "ml", Name: "Malayalam", Native: "മലയാളം"}
+	Marathi          = Language{Code: "mr", Name: "Marathi", Native: "मराठी"}
+	Nepali           = Language{Code: "ne", Name: "Nepali", Native: "नेपाली"}
+	NorwegianBokmal  = Language{Code: "nb", Name: "Norwegian Bokmål", Native: "Norsk bokmål"}
+	NorwegianNynorsk = Language{Code: "nn", Name: "Norwegian Nynorsk", Native: "Norsk nynorsk"}
+	Odia             = Language{Code: "or", Name: "Odia", Native: "ଓଡ଼ିଆ"}
+	Oromo            = Language{Code: "om", Name: "Oromo", Native: "Afaan Oromoo"}
+	Persian          = Language{Code: "fa", Name: "Persian", Native: "فارسی"}
+	Punjabi          = Language{Code: "pa", Name: "Punjabi", Native: "ਪੰਜਾਬੀ"}
+	Romanian         = Language{Code: "ro", Name: "Romanian", Native: "Română"}
+	Saraiki          = Language{Code: "skr", Name: "Saraiki", Native: "سرائیکی"}
+	Shona            = Language{Code: "sn", Name: "Shona", Native: "chiShona"}
+	Sinhala          = Language{Code: "si", Name: "Sinhala", Native: "සිංහල"}
+	Slovenian        = Language{Code: "sl", Name: "Slovenian", Native: "Slovenščina"}
+	Somali           = Language{Code: "so", Name: "Somali", Native: "Soomaali"}
+	Swedish          = Language{Code: "sv", Name: "Swedish", Native: "Svenska"}
+	Tagalog          = Language{Code: "tl", Name: "Tagalog", Native: "Tagalog"}
+	Tamil            = Language{Code: "ta", Name: "Tamil", Native: "தமிழ்"}
+	Telugu           = Language{Code: "te", Name: "Telugu", Native: "తెలుగు"}
+	Thai             = Language{Code: "th", Name: "Thai", Native: "ไทย"}
+	Tigrinya         = Language{Code: "ti", Name: "Tigrinya", Native: "ትግርኛ"}
+	Turkish          = Language{Code: "tr", Name: "Turkish", Native: "Türkçe"}
+	Turkmen          = Language{Code: "tk", Name: "Turkmen", Native: "Türkmençe"}
+	Urdu             = Language{Code: "ur", Name: "Urdu", Native: "اردو"}
+	Uyghur           = Language{Code: "ug", Name: "Uyghur", Native: "ئۇيغۇرچە"}
+	Uzbek            = Language{Code: "uz", Name: "Uzbek", Native: "Oʻzbekcha"}
+	Vietnamese       = Language{Code: "vi", Name: "Vietnamese", Native: "Tiếng Việt"}
+	Yiddish          = Language{Code: "yi", Name: "Yiddish", Native: "ייִדיש"}
+	Yoruba           = Language{Code: "yo", Name: "Yoruba", Native: "Yorùbá"}
+	Zulu             = Language{Code: "zu", Name: "Zulu", Native: "isiZulu"}
+)
+
+// supportedLanguages lists every language the detector can return, together
+// with its ISO 639-2/639-3 codes. Languages without an ISO 639-1 code use
+// their ISO 639-3 code.
+var supportedLanguages = []struct {
+	lang    Language
+	aliases []string
+}{
+	{English, []string{"eng"}},
+	{Russian, []string{"rus"}},
+	{Serbian, []string{"srp"}},
+	{German, []string{"deu", "ger"}},
+	{French, []string{"fra", "fre"}},
+	{Spanish, []string{"spa"}},
+	{Italian, []string{"ita"}},
+	{Portuguese, []string{"por"}},
+	{Chinese, []string{"zho", "chi", "cmn"}},
+	{Japanese, []string{"jpn"}},
+	{Korean, []string{"kor"}},
+	{Arabic, []string{"ara", "arb"}},
+	{Polish, []string{"pol"}},
+	{Ukrainian, []string{"ukr"}},
+	{Czech, []string{"ces", "cze"}},
+	{Slovak, []string{"slk", "slo"}},
+	{Croatian, []string{"hrv"}},
+	{Bulgarian, []string{"bul"}},
+	{Afrikaans, []string{"afr"}},
+	{Akan, []string{"aka"}},
+	{Amharic, []string{"amh"}},
+	{Azerbaijani, []string{"aze", "azj"}},
+	{Belarusian, []string{"bel"}},
+	{Bengali, []string{"ben"}},
+	{Bhojpuri, nil},
+	{Bosnian, []string{"bos"}},
+	{Burmese, []string{"mya", "bur"}},
+	{Cebuano, nil},
+	{Chichewa, []string{"nya"}},
+	{Danish, []string{"dan"}},
+	{Dutch, []string{"nld", "dut"}},
+	{Esperanto, []string{"epo"}},
+	{Estonian, []string{"est"}},
+	{Finnish, []string{"fin"}},
+	{Georgian, []string{"kat", "geo"}},
+	{Greek, []string{"ell", "gre"}},
+	{Gujarati, []string{"guj"}},
+	{HaitianCreole, []string{"hat"}},
+	{Hausa, []string{"hau"}},
+	{Hebrew, []string{"heb"}},
+	{Hindi, []string{"hin"}},
+	{Hungarian, []string{"hun"}},
+	{Igbo, []string{"ibo"}},
+	{Ilocano, nil},
+	{Indonesian, []string{"ind"}},
+	{Javanese, []string{"jav"}},
+	{Kannada, []string{"kan"}},
+	{Khmer, []string{"khm"}},
+	{Kinyarwanda, []string{"kin"}},
+	{Kirundi, []string{"run"}},
+	{Kurdish, []string{"kur"}},
+	{Latvian, []string{"lav"}},
+	{Lithuanian, []string{"lit"}},
+	{Macedonian, []string{"mkd", "mac"}},
+	{Maithili, nil},
+	{Malagasy, []string{"mlg"}},
+	{Malayalam, []string{"mal"}},
+	{Marathi, []string{"mar"}},
+	{Nepali, []string{"nep"}},
+	{NorwegianBokmal, []string{"nob", "no", "nor", "norwegian"}},
+	{NorwegianNynorsk, []string{"nno"}},
+	{Odia, []string{"ori"}},
+	{Oromo, []string{"orm"}},
+	{Persian, []string{"fas", "per", "pes"}},
+	{Punjabi, []string{"pan"}},
+	{Romanian, []string{"ron", "rum"}},
+	{Saraiki, nil},
+	{Shona, []string{"sna"}},
+	{Sinhala, []string{"sin"}},
+	{Slovenian, []string{"slv"}},
+	{Somali, []string{"som"}},
+	{Swedish, []string{"swe"}},
+	{Tagalog, []string{"tgl"}},
+	{Tamil, []string{"tam"}},
+	{Telugu, []string{"tel"}},
+	{Thai, []string{"tha"}},
+	{Tigrinya, []string{"tir"}},
+	{Turkish, []string{"tur"}},
+	{Turkmen, []string{"tuk"}},
+	{Urdu, []string{"urd"}},
+	{Uyghur, []string{"uig"}},
+	{Uzbek, []string{"uzb"}},
+	{Vietnamese, []string{"vie"}},
+	{Yiddish, []string{"yid", "ydd"}},
+	{Yoruba, []string{"yor"}},
+	{Zulu, []string{"zul"}},
+}
+
+func init() {
+	// Add the codes and names of the languages missing from languageMap
+	for _, entry := range supportedLanguages {
+		keys := append([]string{entry.lang.Code, strings.ToLower(entry.lang.Name)}, entry.aliases...)
+		for _, key := range keys {
+			if _, exists := languageMap[key]; !exists {
+				languageMap[key] = entry.lang
+			}
+		}
+	}
+}
+
+// languageByCode returns the supported language with the given ISO 639-1 code
+func languageByCode(code string) (Language, bool) {
+	for _, entry := range supportedLanguages {
+		if entry.lang.Code == code {
+			return entry.lang, true
+		}
+	}
+	return Language{}, false
+}
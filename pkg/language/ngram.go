@@ -0,0 +1,348 @@
+package language
+
+import (
+	_ "embed"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MinConfidence is the statistical confidence below which Detect asks the LLM
+// detector, or falls back to character heuristics without one
+const MinConfidence = 0.5
+
+// Detection sources
+const (
+	SourceStatistical = "statistical"
+	SourceLLM         = "llm"
+	SourceHeuristic   = "heuristic"
+)
+
+const (
+	// minStatisticalTrigrams is the number of distinct trigrams below which an
+	// uncertain statistical guess is not trusted over character heuristics
+	minStatisticalTrigrams = 30
+
+	// profileSize is the number of trigrams kept per language profile
+	profileSize = 300
+
+	// maxTotalDistance is the distance of a text sharing no trigram with a profile
+	maxTotalDistance = profileSize * profileSize
+)
+
+// Detection is the language found for a text
+type Detection struct {
+	Language Language
+
+	// Confidence of the statistical detector in Language, from 0 to 1; 0 when
+	// the LLM or the heuristics chose a language it did not
+	Confidence float64
+
+	// Script is the dominant Unicode script of the text, e.g. "Cyrillic"
+	Script string
+
+	// Source is the method that chose the language
+	Source string
+}
+
+// Span is a run of paragraphs written in one language
+type Span struct {
+	Start      int // byte offset of the first paragraph
+	End        int // byte offset after the last paragraph
+	Language   Language
+	Confidence float64
+}
+
+//go:embed trigrams.txt
+var trigramData string
+
+type languageProfile struct {
+	lang     Language
+	trigrams []string // most frequent first
+}
+
+var (
+	profilesOnce sync.Once
+	profiles     map[string][]languageProfile // by script
+)
+
+// loadProfiles parses the embedded trigram profiles
+func loadProfiles() map[string][]languageProfile {
+	profilesOnce.Do(func() {
+		profiles = make(map[string][]languageProfile)
+		for _, line := range strings.Split(trigramData, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			lang, ok := languageByCode(fields[0])
+			if !ok {
+				continue
+			}
+			profiles[fields[1]] = append(profiles[fields[1]], languageProfile{
+				lang:     lang,
+				trigrams: strings.Split(fields[2], "|"),
+			})
+		}
+	})
+	return profiles
+}
+
+// scripts are checked in order; scriptLanguages maps those written in only one
+// of the supported languages
+var (
+	scripts = []struct {
+		name  string
+		table *unicode.RangeTable
+	}{
+		{"Latin", unicode.Latin},
+		{"Cyrillic", unicode.Cyrillic},
+		{"Arabic", unicode.Arabic},
+		{"Devanagari", unicode.Devanagari},
+		{"Hiragana", unicode.Hiragana},
+		{"Katakana", unicode.Katakana},
+		{"Han", unicode.Han},
+		{"Hangul", unicode.Hangul},
+		{"Greek", unicode.Greek},
+		{"Hebrew", unicode.Hebrew},
+		{"Ethiopic", unicode.Ethiopic},
+		{"Bengali", unicode.Bengali},
+		{"Georgian", unicode.Georgian},
+		{"Gujarati", unicode.Gujarati},
+		{"Gurmukhi", unicode.Gurmukhi},
+		{"Kannada", unicode.Kannada},
+		{"Khmer", unicode.Khmer},
+		{"Malayalam", unicode.Malayalam},
+		{"Myanmar", unicode.Myanmar},
+		{"Oriya", unicode.Oriya},
+		{"Sinhala", unicode.Sinhala},
+		{"Tamil", unicode.Tamil},
+		{"Telugu", unicode.Telugu},
+		{"Thai", unicode.Thai},
+	}
+
+	scriptLanguages = map[string]Language{
+		"Japanese":  Japanese,
+		"Han":       Chinese,
+		"Hangul":    Korean,
+		"Greek":     Greek,
+		"Bengali":   Bengali,
+		"Georgian":  Georgian,
+		"Gujarati":  Gujarati,
+		"Gurmukhi":  Punjabi,
+		"Kannada":   Kannada,
+		"Khmer":     Khmer,
+		"Malayalam": Malayalam,
+		"Myanmar":   Burmese,
+		"Oriya":     Odia,
+		"Sinhala":   Sinhala,
+		"Tamil":     Tamil,
+		"Telugu":    Telugu,
+		"Thai":      Thai,
+	}
+)
+
+// DetectStatistical detects the language of text by comparing its character
+// trigrams with the embedded language profiles. Text without letters of a
+// known script gets a zero Detection.
+func DetectStatistical(text string) Detection {
+	script := dominantScript(text)
+	if script == "" {
+		return Detection{}
+	}
+
+	if lang, ok := scriptLanguages[script]; ok {
+		return Detection{Language: lang, Confidence: 1, Script: script, Source: SourceStatistical}
+	}
+
+	candidates := loadProfiles()[script]
+	if len(candidates) == 0 {
+		return Detection{Script: script}
+	}
+
+	lang, confidence := closestProfile(textTrigrams(text), candidates)
+	return Detection{Language: lang, Confidence: confidence, Script: script, Source: SourceStatistical}
+}
+
+// dominantScript returns the script most letters of text are written in.
+// Han mixed with kana is reported as "Japanese".
+func dominantScript(text string) string {
+	counts := make(map[string]int)
+	for _, r := range text {
+		if isTrigramStop(r) {
+			continue
+		}
+		for _, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[s.name]++
+				break
+			}
+		}
+	}
+
+	best, max := "", 0
+	for _, s := range scripts {
+		if counts[s.name] > max {
+			best, max = s.name, counts[s.name]
+		}
+	}
+
+	kana := counts["Hiragana"] + counts["Katakana"]
+	if kana > 0 && (best == "Han" || best == "Hiragana" || best == "Katakana") {
+		return "Japanese"
+	}
+	return best
+}
+
+// isTrigramStop reports whether r separates words for trigram counting
+func isTrigramStop(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsDigit(r)
+}
+
+// textTrigrams returns the rank of every trigram of text, most frequent first
+func textTrigrams(text string) map[string]int {
+	runes := make([]rune, 0, len(text)+1)
+	for _, r := range text {
+		if isTrigramStop(r) {
+			r = ' '
+		}
+		runes = append(runes, unicode.ToLower(r))
+	}
+	runes = append(runes, ' ')
+
+	counts := make(map[string]int)
+	r1, r2 := ' ', runes[0]
+	for _, r3 := range runes[1:] {
+		// Skip trigrams made of a lone space between word boundaries
+		if !(r2 == ' ' && (r1 == ' ' || r3 == ' ')) {
+			counts[string([]rune{r1, r2, r3})]++
+		}
+		r1, r2 = r2, r3
+	}
+
+	trigrams := make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+
+	ranks := make(map[string]int, len(trigrams))
+	for rank, trigram := range trigrams {
+		ranks[trigram] = rank
+	}
+	return ranks
+}
+
+// closestProfile returns the language whose profile has the smallest
+// out-of-place distance to the trigram ranks of a text, and how clearly it
+// beats the runner-up
+func closestProfile(ranks map[string]int, candidates []languageProfile) (Language, float64) {
+	type scored struct {
+		lang  Language
+		score int
+	}
+
+	results := make([]scored, 0, len(candidates))
+	for _, p := range candidates {
+		distance := 0
+		for i, trigram := range p.trigrams {
+			if rank, ok := ranks[trigram]; ok {
+				distance += abs(rank - i)
+			} else {
+				distance += profileSize
+			}
+		}
+		results = append(results, scored{lang: p.lang, score: maxTotalDistance - distance})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+
+	best := results[0]
+	if len(results) == 1 {
+		return best.lang, 1
+	}
+	second := results[1]
+
+	switch {
+	case best.score <= 0:
+		return Language{}, 0
+	case second.score <= 0:
+		// Only one profile matched at all; trust it as far as the match goes
+		return best.lang, min(float64(best.score)/500, 1)
+	}
+
+	// The lead over the runner-up needed for full confidence shrinks as the
+	// text grows and its trigram ranks become reliable
+	rate := float64(best.score-second.score) / float64(second.score)
+	confidentRate := 12/float64(len(ranks)) + 0.05
+	return best.lang, min(rate/confidentRate, 1)
+}
+
+// paragraphBreak separates paragraphs for DetectSpans
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r]*\n`)
+
+// DetectSpans splits text into paragraphs, detects the language of each and
+// merges neighbouring paragraphs of the same language. Paragraphs too short
+// to detect confidently, such as headings, join the preceding span.
+func (d *Detector) DetectSpans(text string) []Span {
+	var spans []Span
+	start := 0
+	bounds := append(paragraphBreak.FindAllStringIndex(text, -1), []int{len(text), len(text)})
+	for _, bound := range bounds {
+		paragraph := text[start:bound[0]]
+		end := bound[0]
+		paragraphStart := start
+		start = bound[1]
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+
+		detection := DetectStatistical(paragraph)
+		last := len(spans) - 1
+		switch {
+		case last >= 0 && (detection.Confidence < MinConfidence || spans[last].Language == detection.Language):
+			spans[last] = mergeSpan(spans[last], end, detection)
+		default:
+			spans = append(spans, Span{
+				Start:      paragraphStart,
+				End:        end,
+				Language:   detection.Language,
+				Confidence: detection.Confidence,
+			})
+		}
+	}
+
+	// A leading uncertain paragraph belongs to the span after it
+	if len(spans) > 1 && spans[0].Confidence < MinConfidence {
+		spans[1].Start = spans[0].Start
+		spans = spans[1:]
+	}
+	return spans
+}
+
+// mergeSpan extends span to end, weighting confidence by length. Paragraphs
+// of another language only extend the span when they are uncertain.
+func mergeSpan(span Span, end int, detection Detection) Span {
+	if detection.Language == span.Language {
+		oldLength, newLength := float64(span.End-span.Start), float64(end-span.End)
+		span.Confidence = (span.Confidence*oldLength + detection.Confidence*newLength) / (oldLength + newLength)
+	}
+	span.End = end
+	return span
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
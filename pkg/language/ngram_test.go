@@ -0,0 +1,116 @@
+package language
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var statisticalSamples = []struct {
+	text     string
+	expected Language
+}{
+	{"Македонија е држава во југоисточна Европа. Главен град е Скопје, а најголемо езеро е Охридското езеро. Луѓето зборуваат македонски јазик и сакаат да одат на планина.", Macedonian},
+	{"Беларусь — дзяржава ва Усходняй Еўропе. Сталіца краіны — горад Мінск. Беларуская мова з'яўляецца дзяржаўнай мовай разам з рускай, і шмат людзей размаўляюць на ёй дома.", Belarusian},
+	{"Slovenija je država v srednji Evropi. Glavno mesto je Ljubljana, ki leži ob reki Ljubljanici. Ljudje govorijo slovenščino in radi hodijo v hribe, kjer je zrak svež.", Slovenian},
+	{"Sarajevo je glavni grad Bosne i Hercegovine. Historija grada je duga, a stari dio grada poznat je po Baščaršiji, gdje se pije kahva i jedu ćevapi. Hiljade turista dolazi svake sedmice.", Bosnian},
+	{"भारत दक्षिण एशिया में स्थित एक विशाल देश है। इसकी राजधानी नई दिल्ली है और यहाँ अनेक भाषाएँ बोली जाती हैं। हिंदी सबसे अधिक बोली जाने वाली भाषा है।", Hindi},
+	{"Türkiye, Avrupa ile Asya arasında yer alan bir ülkedir. Başkenti Ankara'dır, ancak en büyük şehri İstanbul'dur. İnsanlar çay içmeyi ve arkadaşlarıyla sohbet etmeyi çok sever.", Turkish},
+	{"Η Ελλάδα είναι χώρα της νοτιοανατολικής Ευρώπης. Πρωτεύουσα είναι η Αθήνα.", Greek},
+	{"ישראל היא מדינה במזרח התיכון. עיר הבירה שלה היא ירושלים, והעיר הגדולה ביותר היא תל אביב. השפה הרשמית היא עברית.", Hebrew},
+	{"Београд је главни град Србије. Историја града је дуга и богата, а Калемегдан је омиљено место за шетњу. Хиљаде туриста долази сваке недеље да види ушће Саве у Дунав.", Serbian},
+	{"Москва — столица России и крупнейший город страны. Здесь находится Кремль, Красная площадь и множество музеев, которые каждый год посещают миллионы туристов.", Russian},
+	{"Київ — столиця України, одне з найстаріших міст Східної Європи. Місто розташоване на річці Дніпро, і щороку його відвідують тисячі туристів з усього світу.", Ukrainian},
+	{"София е столицата на България и най-големият град в страната. Градът се намира в полите на планината Витоша и е известен с многото си църкви и паркове.", Bulgarian},
+	{"Bratislava je hlavné mesto Slovenska. Leží na rieke Dunaj a je známa svojím hradom, ktorý sa týči nad starým mestom. Ľudia tu radi chodia na prechádzky pozdĺž nábrežia.", Slovak},
+	{"Praha je hlavní město České republiky. Leží na řece Vltavě a je známá svým hradem, Karlovým mostem a starým městem, které každý rok navštíví miliony turistů.", Czech},
+	{"London is the capital of England and the United Kingdom. It stands on the River Thames and has been a major settlement for nearly two thousand years.", English},
+	{"Berlin ist die Hauptstadt Deutschlands und mit rund 3,7 Millionen Einwohnern die bevölkerungsreichste Stadt des Landes. Die Stadt liegt an der Spree.", German},
+	{"東京は日本の首都です。たくさんの人が住んでいます。", Japanese},
+	{"北京是中华人民共和国的首都，也是全国的政治和文化中心。", Chinese},
+}
+
+func TestDetectStatistical(t *testing.T) {
+	for _, tt := range statisticalSamples {
+		t.Run(tt.expected.Name, func(t *testing.T) {
+			detection := DetectStatistical(tt.text)
+
+			assert.Equal(t, tt.expected, detection.Language)
+			assert.Equal(t, SourceStatistical, detection.Source)
+			assert.Greater(t, detection.Confidence, 0.0)
+			assert.LessOrEqual(t, detection.Confidence, 1.0)
+		})
+	}
+}
+
+func TestDetectStatistical_Confidence(t *testing.T) {
+	long := DetectStatistical(statisticalSamples[0].text)
+	assert.GreaterOrEqual(t, long.Confidence, MinConfidence)
+	assert.Equal(t, "Cyrillic", long.Script)
+
+	// Serbian Latin and Croatian are too close to tell apart in one sentence
+	short := DetectStatistical("Grad je lep.")
+	assert.Less(t, short.Confidence, MinConfidence)
+
+	none := DetectStatistical("12345 !?")
+	assert.Equal(t, Detection{}, none)
+}
+
+func TestDetectStatistical_AllProfilesLoad(t *testing.T) {
+	count := 0
+	for script, candidates := range loadProfiles() {
+		for _, p := range candidates {
+			assert.Len(t, p.trigrams, profileSize, "%s profile of %s", script, p.lang.Code)
+			count++
+		}
+	}
+	assert.Equal(t, strings.Count(trigramData, "\n")-strings.Count(trigramData, "#"), count)
+}
+
+func TestDetector_DetectWithConfidence_LLMOnlyWhenUncertain(t *testing.T) {
+	calls := 0
+	detector := NewDetector(&MockLLMDetector{
+		detectFunc: func(ctx context.Context, text string) (string, error) {
+			calls++
+			return "hr", nil
+		},
+	})
+
+	detection, err := detector.DetectWithConfidence(context.Background(), statisticalSamples[0].text)
+	require.NoError(t, err)
+	assert.Equal(t, Macedonian, detection.Language)
+	assert.Equal(t, SourceStatistical, detection.Source)
+	assert.Equal(t, 0, calls)
+
+	detection, err = detector.DetectWithConfidence(context.Background(), "Grad je lep.")
+	require.NoError(t, err)
+	assert.Equal(t, Croatian, detection.Language)
+	assert.Equal(t, SourceLLM, detection.Source)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDetector_DetectSpans(t *testing.T) {
+	english := "London is the capital of England and the United Kingdom. It stands on the River Thames and has been a major settlement for nearly two thousand years."
+	german := "Berlin ist die Hauptstadt Deutschlands und mit rund 3,7 Millionen Einwohnern die bevölkerungsreichste Stadt des Landes. Die Stadt liegt an der Spree."
+	russian := "Москва — столица России и крупнейший город страны. Здесь находится Кремль, Красная площадь и множество музеев, которые каждый год посещают миллионы туристов."
+
+	text := "Chapter 1\n\n" + english + "\n\n" + english + "\n\n" + german + "\n \n" + russian
+	spans := NewDetector(nil).DetectSpans(text)
+
+	require.Len(t, spans, 3)
+	assert.Equal(t, English, spans[0].Language)
+	assert.Equal(t, 0, spans[0].Start, "the heading joins the first span")
+	assert.Equal(t, strings.Index(text, german)-2, spans[0].End)
+	assert.Equal(t, German, spans[1].Language)
+	assert.Equal(t, german, text[spans[1].Start:spans[1].End])
+	assert.Equal(t, Russian, spans[2].Language)
+	assert.Equal(t, len(text), spans[2].End)
+	for _, span := range spans {
+		assert.GreaterOrEqual(t, span.Confidence, MinConfidence)
+	}
+
+	assert.Empty(t, NewDetector(nil).DetectSpans("\n\n"))
+}
@@ -0,0 +1,229 @@
+package localization
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// androidFile is an Android strings.xml resource file. Strings, string
+// arrays and plurals are replaced by their translations in place, plurals
+// with the quantities of the target language. Strings marked
+// translatable="false" and references to other resources are left alone.
+type androidFile struct {
+	xmlText
+	data  []byte
+	units []*androidUnit
+}
+
+type androidUnit struct {
+	unit  *Unit
+	inner xmlRange // the content of the string, or of the plurals element
+	items string   // indentation of the items of plurals
+	close string   // indentation of the closing tag of plurals
+}
+
+func parseAndroid(c *Catalog, data []byte) error {
+	file := &androidFile{data: data}
+
+	var (
+		stack    []string
+		comment  string       // the comment before the resource
+		current  *androidUnit // string or plurals being read
+		array    string       // name of the string array being read
+		item     int          // index of the item in the array
+		start    int          // content start of the element being read
+		quantity string       // quantity of the plurals item being read
+		skip     bool
+	)
+
+	err := scanXML(data, func(t xmlToken) error {
+		switch tok := t.Token.(type) {
+		case xml.Comment:
+			if len(stack) == 1 {
+				comment = strings.TrimSpace(string(tok))
+			}
+		case xml.StartElement:
+			stack = append(stack, tok.Name.Local)
+			if len(stack) == 1 {
+				if tok.Name.Local != "resources" {
+					return fmt.Errorf("not an Android resource file")
+				}
+				return nil
+			}
+			name, _ := attr(tok, "name")
+			translatable, _ := attr(tok, "translatable")
+			switch {
+			case len(stack) == 2:
+				skip = translatable == "false"
+				switch tok.Name.Local {
+				case "string":
+					current = &androidUnit{unit: &Unit{ID: name, Note: comment, State: StateNew}}
+					start = t.end
+				case "string-array":
+					array, item = name, 0
+				case "plurals":
+					current = &androidUnit{unit: &Unit{ID: name, Note: comment, State: StateNew, SourcePlurals: map[string]string{}}}
+					current.inner.start = t.end
+				}
+				comment = ""
+			case len(stack) == 3 && tok.Name.Local == "item":
+				if array != "" {
+					current = &androidUnit{unit: &Unit{ID: array + "[" + strconv.Itoa(item) + "]", State: StateNew}}
+					start = t.end
+					item++
+				} else if current != nil && current.unit.IsPlural() {
+					quantity, _ = attr(tok, "quantity")
+					start = t.end
+					if len(current.unit.SourcePlurals) == 0 {
+						current.items = lineIndent(data, t.start)
+					}
+				}
+			}
+
+		case xml.EndElement:
+			switch {
+			case len(stack) == 2 && tok.Name.Local == "plurals" && current != nil:
+				current.inner.end = t.start
+				current.close = lineIndent(data, t.start)
+				file.add(c, current, skip)
+				current = nil
+			case len(stack) == 2:
+				if tok.Name.Local == "string" && current != nil {
+					current.inner = xmlRange{start, t.start}
+					current.unit.Source = string(data[start:t.start])
+					file.add(c, current, skip)
+				}
+				current, array = nil, ""
+			case len(stack) == 3 && tok.Name.Local == "item" && current != nil:
+				if current.unit.IsPlural() {
+					current.unit.SourcePlurals[quantity] = string(data[start:t.start])
+				} else {
+					current.inner = xmlRange{start, t.start}
+					current.unit.Source = string(data[start:t.start])
+					file.add(c, current, skip)
+					current = nil
+				}
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.file = file
+	return nil
+}
+
+// add adds a resource unless it is not to be translated
+func (f *androidFile) add(c *Catalog, u *androidUnit, skip bool) {
+	text := strings.TrimSpace(plainXML(u.unit.Source))
+	if u.unit.IsPlural() {
+		text = strings.TrimSpace(plainXML(pluralSource(u.unit.SourcePlurals, "other")))
+	}
+	if skip || text == "" || strings.HasPrefix(text, "@") || strings.HasPrefix(text, "?") {
+		return
+	}
+	f.units = append(f.units, u)
+	c.Units = append(c.Units, u.unit)
+}
+
+func (f *androidFile) split(text string) []piece {
+	pieces := f.xmlText.split(text)
+	for i := range pieces {
+		if !pieces[i].code {
+			pieces[i].text = unescapeAndroid(pieces[i].text)
+		}
+	}
+	return pieces
+}
+
+func (f *androidFile) join(pieces []piece) string {
+	escaped := make([]piece, len(pieces))
+	for i, p := range pieces {
+		escaped[i] = p
+		if !p.code {
+			escaped[i].text = escapeAndroid(p.text, i == 0)
+		}
+	}
+	return f.xmlText.join(escaped)
+}
+
+func (f *androidFile) render(c *Catalog) ([]byte, error) {
+	var edits []xmlEdit
+	for _, u := range f.units {
+		switch {
+		case !u.unit.IsPlural() && u.unit.Target != "":
+			edits = append(edits, xmlEdit{u.inner.start, u.inner.end, u.unit.Target})
+		case u.unit.IsPlural() && u.unit.TargetPlurals != nil:
+			newline := newlineOf(f.data)
+			var b strings.Builder
+			for _, category := range categoryNames {
+				if text, ok := u.unit.TargetPlurals[category]; ok {
+					fmt.Fprintf(&b, "%s%s<item quantity=\"%s\">%s</item>", newline, u.items, category, text)
+				}
+			}
+			b.WriteString(newline + u.close)
+			edits = append(edits, xmlEdit{u.inner.start, u.inner.end, b.String()})
+		}
+	}
+	return applyEdits(f.data, edits), nil
+}
+
+// unescapeAndroid resolves the backslash escapes of a string resource,
+// collapses its runs of spaces as Android does and drops the double quotes
+// that would keep them
+func unescapeAndroid(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			continue
+		}
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			for i+1 < len(s) && strings.IndexByte(" \t\n\r", s[i+1]) >= 0 {
+				i++
+			}
+			b.WriteByte(' ')
+			continue
+		}
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+5 <= len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteString(`\u`)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// escapeAndroid escapes text for a string resource; a leading @ or ? would
+// make it a reference
+func escapeAndroid(s string, first bool) string {
+	s = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(s)
+	if first && (strings.HasPrefix(s, "@") || strings.HasPrefix(s, "?")) {
+		s = `\` + s
+	}
+	return s
+}
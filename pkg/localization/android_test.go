@@ -0,0 +1,101 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testAndroid = `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <string name="app_name" translatable="false">Translator</string>
+    <!-- Shown on the start screen -->
+    <string name="greeting">Hello, %1$s! Don\'t <b>wait</b>.</string>
+    <string name="theme">@string/app_name</string>
+    <string-array name="sizes">
+        <item>Small</item>
+        <item>Large</item>
+    </string-array>
+    <plurals name="files">
+        <item quantity="one">%d file</item>
+        <item quantity="other">%d files</item>
+    </plurals>
+</resources>
+`
+
+func TestParseAndroid(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testAndroid), format.FormatAndroid)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	var ids []string
+	for _, u := range catalog.Units {
+		ids = append(ids, u.ID)
+	}
+	if got := strings.Join(ids, ","); got != "greeting,sizes[0],sizes[1],files" {
+		t.Fatalf("Unexpected units %s", got)
+	}
+	if u := catalog.Units[0]; u.Note != "Shown on the start screen" {
+		t.Errorf("Unexpected note %q", u.Note)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	if string(data) != testAndroid {
+		t.Errorf("Resources not kept byte for byte:\n%s", data)
+	}
+}
+
+func TestTranslateAndroid(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testAndroid), format.FormatAndroid)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	var seen []string
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		seen = append(seen, text)
+		if strings.HasPrefix(text, "Hello") {
+			return "Zdravo, <x1/>! Nemoj <x2/>čekati<x3/>.", nil
+		}
+		return "RU " + text, nil
+	}, "ru")
+	if _, err := translator.TranslateCatalog(context.Background(), catalog); err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if seen[0] != "Hello, <x1/>! Don't <x2/>wait<x3/>." {
+		t.Errorf("Unexpected text to translate %q", seen[0])
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`Hello, %1$s! Don\'t <b>wait</b>.`, `Zdravo, %1$s! Nemoj <b>čekati</b>.`,
+		`<item>Small</item>`, `<item>RU Small</item>`,
+		`<item>Large</item>`, `<item>RU Large</item>`,
+		`<item quantity="one">%d file</item>
+        <item quantity="other">%d files</item>`, `<item quantity="one">RU %d file</item>
+        <item quantity="few">RU %d files</item>
+        <item quantity="many">RU %d files</item>
+        <item quantity="other">RU %d files</item>`,
+	).Replace(testAndroid)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
+
+func TestEscapeAndroid(t *testing.T) {
+	if got := unescapeAndroid(`"Two  spaces"  and\nit\'s é`); got != "Two spaces and\nit's é" {
+		t.Errorf("Unexpected unescaped text %q", got)
+	}
+	if got := escapeAndroid("@home isn't \"here\"", true); got != `\@home isn\'t \"here\"` {
+		t.Errorf("Unexpected escaped text %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+// Package localization reads, translates and writes the string tables of
+// software: gettext PO/POT, XLIFF 1.2 and 2.0, JSON i18n files, Android
+// strings.xml and iOS .strings files. Files are written back byte for byte
+// except for the strings that were translated.
+package localization
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+// State is the translation state of a unit
+type State string
+
+const (
+	StateNew         State = "new"          // not translated yet
+	StateTranslated  State = "translated"   // translated
+	StateNeedsReview State = "needs-review" // translated, but to be checked: fuzzy in gettext
+)
+
+// Unit is a translatable string of a localization file. Source and Target
+// hold the string as the file writes it inside its element for XLIFF and
+// Android resources, with markup and escapes, and as plain text for the
+// other formats.
+type Unit struct {
+	ID      string // key, resource name, trans-unit id or msgid
+	Context string // gettext message context
+	Note    string // comments and descriptions for translators
+
+	Source string
+	Target string
+
+	// SourcePlurals and TargetPlurals hold the forms of plural strings by
+	// CLDR category: zero, one, two, few, many and other
+	SourcePlurals map[string]string
+	TargetPlurals map[string]string
+
+	State State
+}
+
+// IsPlural reports whether the unit has plural forms
+func (u *Unit) IsPlural() bool {
+	return u.SourcePlurals != nil
+}
+
+// Catalog is a parsed localization file
+type Catalog struct {
+	Format         format.Format
+	SourceLanguage string // as the file names it, if it does
+	TargetLanguage string
+	Units          []*Unit
+
+	file catalogFile
+}
+
+// catalogFile is the format-specific side of a catalog
+type catalogFile interface {
+	// split breaks the text of a unit into text and markup; join puts the
+	// pieces back together, escaping the text as the format needs
+	split(text string) []piece
+	join(pieces []piece) string
+
+	// pluralCategories returns the plural forms the format writes for a
+	// language
+	pluralCategories(rule PluralRule) []string
+
+	render(c *Catalog) ([]byte, error)
+}
+
+// piece is a span of the text of a unit: text or markup kept as it is
+type piece struct {
+	text string
+	code bool
+}
+
+// ReadCatalog reads and parses a localization file
+func ReadCatalog(filename string) (*Catalog, error) {
+	f, err := format.NewDetector().DetectFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read localization file: %w", err)
+	}
+	return ParseCatalog(data, f)
+}
+
+// ParseCatalog parses a localization file of the given format
+func ParseCatalog(data []byte, f format.Format) (*Catalog, error) {
+	c := &Catalog{Format: f}
+	var err error
+	switch f {
+	case format.FormatPO:
+		err = parsePO(c, data)
+	case format.FormatXLIFF:
+		err = parseXLIFF(c, data)
+	case format.FormatJSON:
+		err = parseJSON(c, data)
+	case format.FormatAndroid:
+		err = parseAndroid(c, data)
+	case format.FormatStrings:
+		err = parseStrings(c, data)
+	default:
+		return nil, fmt.Errorf("not a localization format: %s", f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s file: %w", f, err)
+	}
+	return c, nil
+}
+
+// Bytes renders the catalog in its format, with the translations in
+// place: in the targets of PO and XLIFF files, and instead of the source
+// strings in the others
+func (c *Catalog) Bytes() ([]byte, error) {
+	return c.file.render(c)
+}
+
+// WriteFile writes the catalog to a file
+func (c *Catalog) WriteFile(filename string) error {
+	data, err := c.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write localization file: %w", err)
+	}
+	return nil
+}
+
+// plainText is embedded by the formats whose strings hold no markup
+type plainText struct{}
+
+func (plainText) split(text string) []piece {
+	return []piece{{text: text}}
+}
+
+func (plainText) join(pieces []piece) string {
+	var b strings.Builder
+	for _, p := range pieces {
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+func (plainText) pluralCategories(rule PluralRule) []string {
+	return rule.Categories
+}
@@ -0,0 +1,300 @@
+package localization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonFile is a flat or nested JSON i18n file. String values are replaced
+// by their translations in place; i18next plural keys such as item_one and
+// item_other are rewritten with the categories of the target language.
+// Keys starting with @ hold metadata, as in ARB files: their descriptions
+// are passed as notes and they are not translated.
+type jsonFile struct {
+	plainText
+	data  []byte
+	units []*jsonUnit
+}
+
+type jsonUnit struct {
+	unit    *Unit
+	value   xmlRange // the string value
+	members []jsonMember
+}
+
+// jsonMember is a member of an object holding a string
+type jsonMember struct {
+	key      string
+	keyStart int
+	value    xmlRange
+	index    int // position in the object
+}
+
+func parseJSON(c *Catalog, data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON")
+	}
+	file := &jsonFile{data: data}
+	s := &jsonScanner{data: data}
+	s.skipBOM()
+	notes := map[string]string{}
+	if err := s.value("", func(path string, members []jsonMember) {
+		file.collect(c, path, members, notes)
+	}); err != nil {
+		return err
+	}
+	for _, u := range file.units {
+		u.unit.Note = notes[u.unit.ID]
+	}
+	c.file = file
+	return nil
+}
+
+// collect turns the string members of an object or array into units
+func (f *jsonFile) collect(c *Catalog, path string, object []jsonMember, notes map[string]string) {
+	parent, name := "", path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parent, name = path[:i], path[i+1:]
+	}
+	if strings.HasPrefix(name, "@") || strings.Contains(path, ".@") {
+		for _, m := range object {
+			if m.key == "description" && strings.HasPrefix(name, "@") {
+				notes[joinPath(parent, strings.TrimPrefix(name, "@"))] = f.stringAt(m.value)
+			}
+		}
+		return
+	}
+
+	// Plural keys are grouped by their base when they follow each other
+	groups := map[string][]jsonMember{}
+	for _, m := range object {
+		i := strings.LastIndex(m.key, "_")
+		if i > 0 && isCategory(m.key[i+1:]) {
+			base := m.key[:i]
+			groups[base] = append(groups[base], m)
+		}
+	}
+	plural := map[string]bool{}
+	for base, members := range groups {
+		contiguous := len(members) > 1 && members[len(members)-1].index-members[0].index == len(members)-1
+		if !contiguous {
+			continue
+		}
+		for _, m := range members {
+			plural[m.key] = true
+		}
+		u := &jsonUnit{unit: &Unit{ID: joinPath(path, base), SourcePlurals: map[string]string{}, State: StateNew}, members: members}
+		for _, m := range members {
+			u.unit.SourcePlurals[m.key[strings.LastIndex(m.key, "_")+1:]] = f.stringAt(m.value)
+		}
+		f.add(c, u)
+	}
+
+	for _, m := range object {
+		if plural[m.key] {
+			continue
+		}
+		if strings.HasPrefix(m.key, "@") {
+			continue
+		}
+		u := &jsonUnit{unit: &Unit{ID: joinPath(path, m.key), Source: f.stringAt(m.value), State: StateNew}, value: m.value}
+		if strings.TrimSpace(u.unit.Source) != "" {
+			f.add(c, u)
+		}
+	}
+}
+
+// add inserts a unit in file order
+func (f *jsonFile) add(c *Catalog, u *jsonUnit) {
+	start := f.unitStart(u)
+	i := len(f.units)
+	for i > 0 && f.unitStart(f.units[i-1]) > start {
+		i--
+	}
+	f.units = append(f.units[:i], append([]*jsonUnit{u}, f.units[i:]...)...)
+	c.Units = append(c.Units[:i], append([]*Unit{u.unit}, c.Units[i:]...)...)
+}
+
+func (f *jsonFile) unitStart(u *jsonUnit) int {
+	if u.members != nil {
+		return u.members[0].keyStart
+	}
+	return u.value.start
+}
+
+func (f *jsonFile) stringAt(r xmlRange) string {
+	var s string
+	json.Unmarshal(f.data[r.start:r.end], &s)
+	return s
+}
+
+func (f *jsonFile) render(c *Catalog) ([]byte, error) {
+	var edits []xmlEdit
+	for _, u := range f.units {
+		if u.members == nil {
+			if u.unit.Target != "" {
+				edits = append(edits, xmlEdit{u.value.start, u.value.end, quoteJSON(u.unit.Target)})
+			}
+			continue
+		}
+		if u.unit.TargetPlurals == nil {
+			continue
+		}
+
+		// The members are written again with the target categories, laid
+		// out as the first two were
+		first, last := u.members[0], u.members[len(u.members)-1]
+		colon := string(f.data[bytes.IndexByte(f.data[first.keyStart:], '"')+first.keyStart : first.value.start])
+		colon = colon[strings.LastIndex(colon, `"`)+1:]
+		separator := string(f.data[first.value.end:u.members[1].keyStart])
+		base := first.key[:strings.LastIndex(first.key, "_")]
+
+		var members []string
+		for _, category := range categoryNames {
+			if text, ok := u.unit.TargetPlurals[category]; ok {
+				members = append(members, quoteJSON(base+"_"+category)+colon+quoteJSON(text))
+			}
+		}
+		edits = append(edits, xmlEdit{first.keyStart, last.value.end, strings.Join(members, separator)})
+	}
+	return applyEdits(f.data, edits), nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" || strings.HasPrefix(key, "[") {
+		return path + key
+	}
+	return path + "." + key
+}
+
+func quoteJSON(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// jsonScanner walks a JSON document, reporting the string members of every
+// object with their byte ranges
+type jsonScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *jsonScanner) skipBOM() {
+	if bytes.HasPrefix(s.data, []byte("\xef\xbb\xbf")) {
+		s.pos = 3
+	}
+}
+
+func (s *jsonScanner) space() {
+	for s.pos < len(s.data) && strings.IndexByte(" \t\r\n", s.data[s.pos]) >= 0 {
+		s.pos++
+	}
+}
+
+// value reads a value; visit is called with the string members of every
+// object and array, the items of arrays keyed by their index in brackets
+func (s *jsonScanner) value(path string, visit func(path string, members []jsonMember)) error {
+	s.space()
+	if s.pos >= len(s.data) {
+		return fmt.Errorf("unexpected end of JSON")
+	}
+	switch s.data[s.pos] {
+	case '{':
+		s.pos++
+		var object []jsonMember
+		for index := 0; ; index++ {
+			s.space()
+			if s.data[s.pos] == '}' {
+				s.pos++
+				break
+			}
+			if s.data[s.pos] == ',' {
+				s.pos++
+				s.space()
+			}
+			keyStart := s.pos
+			keyEnd, err := s.skipString()
+			if err != nil {
+				return err
+			}
+			var key string
+			if err := json.Unmarshal(s.data[keyStart:keyEnd], &key); err != nil {
+				return err
+			}
+			s.space()
+			s.pos++ // the colon
+			s.space()
+			if s.data[s.pos] == '"' {
+				start := s.pos
+				end, err := s.skipString()
+				if err != nil {
+					return err
+				}
+				object = append(object, jsonMember{key: key, keyStart: keyStart, value: xmlRange{start, end}, index: index})
+				continue
+			}
+			if err := s.value(joinPath(path, key), visit); err != nil {
+				return err
+			}
+		}
+		visit(path, object)
+	case '[':
+		s.pos++
+		var items []jsonMember
+		for index := 0; ; index++ {
+			s.space()
+			if s.data[s.pos] == ']' {
+				s.pos++
+				break
+			}
+			if s.data[s.pos] == ',' {
+				s.pos++
+				s.space()
+			}
+			key := fmt.Sprintf("[%d]", index)
+			if s.data[s.pos] == '"' {
+				start := s.pos
+				end, err := s.skipString()
+				if err != nil {
+					return err
+				}
+				items = append(items, jsonMember{key: key, keyStart: start, value: xmlRange{start, end}, index: index})
+				continue
+			}
+			if err := s.value(path+key, visit); err != nil {
+				return err
+			}
+		}
+		visit(path, items)
+	case '"':
+		_, err := s.skipString()
+		return err
+	default:
+		for s.pos < len(s.data) && strings.IndexByte(",}] \t\r\n", s.data[s.pos]) < 0 {
+			s.pos++
+		}
+	}
+	return nil
+}
+
+// skipString moves past a string and returns its end
+func (s *jsonScanner) skipString() (int, error) {
+	if s.pos >= len(s.data) || s.data[s.pos] != '"' {
+		return 0, fmt.Errorf("expected a string at offset %d", s.pos)
+	}
+	for s.pos++; s.pos < len(s.data); s.pos++ {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+			return s.pos, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
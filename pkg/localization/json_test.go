@@ -0,0 +1,83 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testJSON = `{
+  "title": "Settings",
+  "menu": {
+    "open": "Open {{name}}",
+    "count": 3,
+    "items_one": "{{count}} item",
+    "items_other": "{{count}} items"
+  },
+  "tips": ["Save often", ""],
+  "welcome": "Hello, {name}!",
+  "@welcome": {
+    "description": "Greeting on the start screen"
+  }
+}
+`
+
+func TestParseJSON(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testJSON), format.FormatJSON)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	var ids []string
+	for _, u := range catalog.Units {
+		ids = append(ids, u.ID)
+	}
+	if got := strings.Join(ids, ","); got != "title,menu.open,menu.items,tips[0],welcome" {
+		t.Fatalf("Unexpected units %s", got)
+	}
+	if u := catalog.Units[2]; u.SourcePlurals["one"] != "{{count}} item" || u.SourcePlurals["other"] != "{{count}} items" {
+		t.Errorf("Unexpected plurals %v", u.SourcePlurals)
+	}
+	if u := catalog.Units[4]; u.Note != "Greeting on the start screen" {
+		t.Errorf("Unexpected note %q", u.Note)
+	}
+
+	if _, err := ParseCatalog([]byte(`{"a": `), format.FormatJSON); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestTranslateJSON(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testJSON), format.FormatJSON)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return "PL " + text, nil
+	}, "pl")
+	if _, err := translator.TranslateCatalog(context.Background(), catalog); err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`"Settings"`, `"PL Settings"`,
+		`"Open {{name}}"`, `"PL Open {{name}}"`,
+		`"items_one": "{{count}} item",
+    "items_other": "{{count}} items"`, `"items_one": "PL {{count}} item",
+    "items_few": "PL {{count}} items",
+    "items_many": "PL {{count}} items",
+    "items_other": "PL {{count}} items"`,
+		`"Save often"`, `"PL Save often"`,
+		`"Hello, {name}!"`, `"PL Hello, {name}!"`,
+	).Replace(testJSON)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
@@ -0,0 +1,361 @@
+package localization
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formatPlaceholder matches the placeholders of format strings, kept out of
+// translation: printf verbs such as %s, %1$d and %@, named placeholders such
+// as {name}, {{name}} and ${name}, markup tags and entities
+var formatPlaceholder = regexp.MustCompile(`%(?:\d+\$)?[-+0#']*(?:\d+|\*)?(?:\.(?:\d+|\*))?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcspn@%]|\$\{[^{}]*\}|\{\{[^{}]*\}\}|\{[^{}]*\}|<[^<>]+>|&(?:[a-zA-Z]+|#\d+|#x[0-9a-fA-F]+);`)
+
+// maskPlaceholder matches the placeholders of a translated string
+var maskPlaceholder = regexp.MustCompile(`(?i)<x(\d+) ?/>`)
+
+// masked is the text of a unit sent for translation, with its markup and
+// format placeholders replaced by <xN/> tags
+type masked struct {
+	text   string
+	tokens []piece // what each tag stands for
+	lead   string  // spaces around the text, kept as they are
+	trail  string
+}
+
+// mask replaces the markup and placeholders of the pieces of a unit by
+// numbered tags
+func mask(pieces []piece) *masked {
+	m := &masked{}
+	var b strings.Builder
+	token := func(p piece) {
+		m.tokens = append(m.tokens, p)
+		fmt.Fprintf(&b, "<x%d/>", len(m.tokens))
+	}
+	for _, p := range pieces {
+		if p.code {
+			token(p)
+			continue
+		}
+		pos := 0
+		for _, loc := range formatPlaceholder.FindAllStringIndex(p.text, -1) {
+			b.WriteString(p.text[pos:loc[0]])
+			token(piece{text: p.text[loc[0]:loc[1]]})
+			pos = loc[1]
+		}
+		b.WriteString(p.text[pos:])
+	}
+
+	text := b.String()
+	trimmed := strings.TrimSpace(text)
+	start := strings.Index(text, trimmed)
+	m.lead, m.text, m.trail = text[:start], trimmed, text[start+len(trimmed):]
+	return m
+}
+
+// translatable reports whether the masked text has words to translate
+func (m *masked) translatable() bool {
+	for _, r := range maskPlaceholder.ReplaceAllString(m.text, "") {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// pieces returns the pieces of the source, unchanged
+func (m *masked) pieces() []piece {
+	out, _ := m.unmask(m.text)
+	return out
+}
+
+// unmask puts the markup and placeholders back into a translation. Every
+// tag must come back once, in any order, as format strings may reorder
+// their arguments; tags that are lost go at the end, and the result is
+// reported as not kept.
+func (m *masked) unmask(translated string) ([]piece, bool) {
+	var out []piece
+	add := func(p piece) {
+		if !p.code && len(out) > 0 && !out[len(out)-1].code {
+			out[len(out)-1].text += p.text
+			return
+		}
+		if p.code || p.text != "" {
+			out = append(out, p)
+		}
+	}
+
+	add(piece{text: m.lead})
+	ok := true
+	used := make([]bool, len(m.tokens))
+	pos := 0
+	for _, loc := range maskPlaceholder.FindAllStringSubmatchIndex(translated, -1) {
+		add(piece{text: translated[pos:loc[0]]})
+		pos = loc[1]
+		id, _ := strconv.Atoi(translated[loc[2]:loc[3]])
+		if id < 1 || id > len(m.tokens) || used[id-1] {
+			ok = false
+			continue
+		}
+		used[id-1] = true
+		add(m.tokens[id-1])
+	}
+	add(piece{text: translated[pos:]})
+	for i, u := range used {
+		if !u {
+			ok = false
+			add(piece{text: " "})
+			add(m.tokens[i])
+		}
+	}
+	add(piece{text: m.trail})
+	return out, ok
+}
+
+// icuMessage is an ICU MessageFormat message: literal text, arguments kept
+// as they are, and plural and select arguments whose branches are messages
+type icuMessage []icuPart
+
+type icuPart struct {
+	text   string     // literal text
+	arg    string     // argument, quoted literal or # kept verbatim
+	choice *icuChoice // plural, selectordinal or select argument
+}
+
+type icuChoice struct {
+	name, kind string
+	offset     string // offset:N of plurals
+	branches   []icuBranch
+}
+
+type icuBranch struct {
+	key     string // category, =N or select value
+	message icuMessage
+}
+
+// hasChoice reports whether a message has plural or select arguments
+func (m icuMessage) hasChoice() bool {
+	for _, p := range m {
+		if p.choice != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// parseICU parses an ICU message, reporting whether it is one
+func parseICU(s string) (icuMessage, bool) {
+	p := &icuParser{s: s}
+	msg, ok := p.message(false)
+	return msg, ok && p.pos == len(s)
+}
+
+type icuParser struct {
+	s   string
+	pos int
+}
+
+// message parses text up to the end or the closing brace of a branch
+func (p *icuParser) message(inPlural bool) (icuMessage, bool) {
+	var msg icuMessage
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			msg = append(msg, icuPart{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '}':
+			flush()
+			return msg, true
+		case c == '{':
+			flush()
+			part, ok := p.argument()
+			if !ok {
+				return nil, false
+			}
+			msg = append(msg, part)
+		case c == '#' && inPlural:
+			flush()
+			msg = append(msg, icuPart{arg: "#"})
+			p.pos++
+		case c == '\'' && p.pos+1 < len(p.s) && p.s[p.pos+1] == '\'':
+			text.WriteByte('\'')
+			p.pos += 2
+		case c == '\'' && p.pos+1 < len(p.s) && strings.ContainsRune("{}#|", rune(p.s[p.pos+1])):
+			// A quoted literal runs to the next single apostrophe
+			flush()
+			start := p.pos
+			p.pos++
+			for p.pos < len(p.s) {
+				if p.s[p.pos] == '\'' {
+					if p.pos+1 < len(p.s) && p.s[p.pos+1] == '\'' {
+						p.pos += 2
+						continue
+					}
+					p.pos++
+					break
+				}
+				p.pos++
+			}
+			msg = append(msg, icuPart{arg: p.s[start:p.pos]})
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	flush()
+	return msg, true
+}
+
+// argument parses an argument from its opening brace
+func (p *icuParser) argument() (icuPart, bool) {
+	start := p.pos
+	p.pos++
+	name := p.word()
+	p.space()
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return icuPart{arg: p.s[start:p.pos]}, name != ""
+	}
+	if p.pos >= len(p.s) || p.s[p.pos] != ',' {
+		return icuPart{}, false
+	}
+	p.pos++
+	p.space()
+	kind := p.word()
+	p.space()
+
+	if kind != "plural" && kind != "selectordinal" && kind != "select" {
+		// Other arguments, such as numbers and dates, are kept as they are
+		for depth := 1; depth > 0; p.pos++ {
+			if p.pos >= len(p.s) {
+				return icuPart{}, false
+			}
+			switch p.s[p.pos] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		return icuPart{arg: p.s[start:p.pos]}, true
+	}
+
+	if p.pos >= len(p.s) || p.s[p.pos] != ',' {
+		return icuPart{}, false
+	}
+	p.pos++
+	choice := &icuChoice{name: name, kind: kind}
+	for {
+		p.space()
+		if p.pos >= len(p.s) {
+			return icuPart{}, false
+		}
+		if p.s[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		key := p.word()
+		if key == "" {
+			return icuPart{}, false
+		}
+		if strings.HasPrefix(key, "offset:") {
+			choice.offset = key
+			continue
+		}
+		p.space()
+		if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+			return icuPart{}, false
+		}
+		p.pos++
+		message, ok := p.message(kind != "select")
+		if !ok || p.pos >= len(p.s) {
+			return icuPart{}, false
+		}
+		p.pos++
+		choice.branches = append(choice.branches, icuBranch{key: key, message: message})
+	}
+	return icuPart{choice: choice}, len(choice.branches) > 0
+}
+
+func (p *icuParser) word() string {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune("{},} \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	word := p.s[start:p.pos]
+	// offset:N may have a space after the colon
+	if word == "offset:" {
+		p.space()
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		word = "offset:" + strings.TrimSpace(p.s[start+len(word):p.pos])
+	}
+	return word
+}
+
+func (p *icuParser) space() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// render writes a choice argument back with its translated branches
+func (c *icuChoice) render() string {
+	var b strings.Builder
+	b.WriteString("{" + c.name + ", " + c.kind + ",")
+	if c.offset != "" {
+		b.WriteString(" " + c.offset)
+	}
+	for _, branch := range c.branches {
+		b.WriteString(" " + branch.key + " {" + branch.message.render(c.kind != "select") + "}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// render writes a message back, quoting the apostrophes of its text that
+// would otherwise start a quoted literal
+func (m icuMessage) render(inPlural bool) string {
+	special := "{}|'"
+	if inPlural {
+		special += "#"
+	}
+	var b strings.Builder
+	for i, part := range m {
+		switch {
+		case part.choice != nil:
+			b.WriteString(part.choice.render())
+		case part.arg != "":
+			b.WriteString(part.arg)
+		default:
+			for j := 0; j < len(part.text); j++ {
+				b.WriteByte(part.text[j])
+				if part.text[j] != '\'' {
+					continue
+				}
+				next := byte(0)
+				if j+1 < len(part.text) {
+					next = part.text[j+1]
+				} else if i+1 < len(m) {
+					next = '{'
+					if m[i+1].arg != "" {
+						next = m[i+1].arg[0]
+					}
+				}
+				if next != 0 && strings.IndexByte(special, next) >= 0 {
+					b.WriteByte('\'')
+				}
+			}
+		}
+	}
+	return b.String()
+}
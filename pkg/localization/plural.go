@@ -0,0 +1,144 @@
+package localization
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// categoryNames are the CLDR plural categories, in their canonical order
+var categoryNames = []string{"zero", "one", "two", "few", "many", "other"}
+
+// categoryForms maps the categories to the forms of x/text
+var categoryForms = []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other}
+
+// PluralRule describes the plural forms of a language
+type PluralRule struct {
+	// Categories are the CLDR categories of the language, in canonical
+	// order: the forms of Android, i18next and ICU plurals
+	Categories []string
+
+	// Gettext are the categories of whole numbers, the msgstr forms of a
+	// PO file in order, and Expression picks one of them for n. Expression
+	// is empty when it is not known.
+	Gettext    []string
+	Expression string
+
+	// Examples lists a few counts of every category
+	Examples map[string]string
+}
+
+// gettextExpressions are the Plural-Forms expressions of the languages with
+// more than two forms, matching the CLDR rules in canonical order
+var gettextExpressions = map[string]string{
+	"ar": "(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5)",
+	"be": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"bs": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"cs": "(n==1 ? 0 : n>=2 && n<=4 ? 1 : 2)",
+	"cy": "(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n==3 ? 3 : n==6 ? 4 : 5)",
+	"ga": "(n==1 ? 0 : n==2 ? 1 : n>=3 && n<=6 ? 2 : n>=7 && n<=10 ? 3 : 4)",
+	"he": "(n==1 ? 0 : n==2 ? 1 : n>10 && n%10==0 ? 2 : 3)",
+	"hr": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"lt": "(n%10==1 && (n%100<11 || n%100>19) ? 0 : n%10>=2 && n%10<=9 && (n%100<11 || n%100>19) ? 1 : 2)",
+	"lv": "(n%10==0 || n%100>=11 && n%100<=19 ? 0 : n%10==1 && n%100!=11 ? 1 : 2)",
+	"mt": "(n==1 ? 0 : n==0 || n%100>=2 && n%100<=10 ? 1 : n%100>=11 && n%100<=19 ? 2 : 3)",
+	"pl": "(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"ro": "(n==1 ? 0 : n==0 || n%100>=1 && n%100<=19 ? 1 : 2)",
+	"ru": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"sk": "(n==1 ? 0 : n>=2 && n<=4 ? 1 : 2)",
+	"sl": "(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3)",
+	"sr": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+	"uk": "(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2)",
+}
+
+// PluralRuleFor returns the plural rule of a language code, English when
+// the code is empty
+func PluralRuleFor(code string) PluralRule {
+	if code == "" {
+		code = "en"
+	}
+	tag := language.Make(strings.ReplaceAll(code, "_", "-"))
+
+	integers := map[plural.Form][]string{}
+	for n := 0; n <= 1000; n++ {
+		form := plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)
+		if len(integers[form]) < 3 {
+			integers[form] = append(integers[form], strconv.Itoa(n))
+		}
+	}
+	decimals := map[plural.Form]string{}
+	for n := 0; n <= 10; n++ {
+		form := plural.Cardinal.MatchPlural(tag, n, 1, 1, 5, 5)
+		if _, ok := decimals[form]; !ok {
+			decimals[form] = strconv.Itoa(n) + ".5"
+		}
+	}
+
+	rule := PluralRule{Examples: map[string]string{}}
+	for i, form := range categoryForms {
+		name := categoryNames[i]
+		examples := integers[form]
+		if len(examples) > 0 {
+			rule.Gettext = append(rule.Gettext, name)
+		}
+		if decimal, ok := decimals[form]; ok {
+			examples = append(examples, decimal)
+		}
+		if len(examples) > 0 {
+			rule.Categories = append(rule.Categories, name)
+			rule.Examples[name] = strings.Join(examples, ", ")
+		}
+	}
+
+	base, _ := tag.Base()
+	rule.Expression = gettextExpressions[base.String()]
+	if rule.Expression == "" {
+		switch {
+		case len(rule.Gettext) == 1:
+			rule.Expression = "0"
+		case len(rule.Gettext) == 2 && strings.Join(integers[plural.One], ",") == "1":
+			rule.Expression = "(n != 1)"
+		case len(rule.Gettext) == 2 && strings.Join(integers[plural.One], ",") == "0,1":
+			rule.Expression = "(n > 1)"
+		}
+	}
+	return rule
+}
+
+// PluralForms returns the Plural-Forms header of a PO file, or "" when the
+// expression is not known
+func (r PluralRule) PluralForms() string {
+	if r.Expression == "" {
+		return ""
+	}
+	return "nplurals=" + strconv.Itoa(len(r.Gettext)) + "; plural=" + r.Expression + ";"
+}
+
+// pluralSource picks the source form a category is translated from: the
+// same category, or else other
+func pluralSource(forms map[string]string, category string) string {
+	if text, ok := forms[category]; ok {
+		return text
+	}
+	if text, ok := forms["other"]; ok {
+		return text
+	}
+	for i := len(categoryNames) - 1; i >= 0; i-- {
+		if text, ok := forms[categoryNames[i]]; ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// isCategory reports whether a name is a CLDR plural category
+func isCategory(name string) bool {
+	for _, c := range categoryNames {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+package localization
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// poFile is a gettext PO or POT file: its entries, each kept with its raw
+// lines, and the text between them
+type poFile struct {
+	plainText
+	parts   []poPart
+	header  *poEntry
+	newline string
+}
+
+// poPart is a block of lines: an entry, or lines kept verbatim
+type poPart struct {
+	entry *poEntry
+	raw   string
+}
+
+type poEntry struct {
+	raw      []string
+	comments []string // comment lines but the flags, in order
+	flagsAt  int      // index in comments the flags line goes before
+	flags    []string
+	keywords []string // msgctxt, msgid and msgid_plural lines, kept verbatim
+
+	msgctxt     *string
+	msgid       string
+	msgidPlural *string
+	msgstr      []string
+
+	unit   *Unit
+	parsed Unit // the unit as parsed, to tell whether it changed
+}
+
+// poKeyword matches the keyword and first string of a PO line
+var poKeyword = regexp.MustCompile(`^(msgctxt|msgid_plural|msgid|msgstr(?:\[(\d+)\])?)\s+(".*")\s*$`)
+
+func parsePO(c *Catalog, data []byte) error {
+	file := &poFile{newline: newlineOf(data)}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var verbatim []string
+	flush := func() {
+		if len(verbatim) > 0 {
+			file.parts = append(file.parts, poPart{raw: strings.Join(verbatim, file.newline)})
+			verbatim = nil
+		}
+	}
+	for i := 0; i < len(lines); {
+		if strings.TrimSpace(lines[i]) == "" {
+			verbatim = append(verbatim, lines[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) != "" {
+			j++
+		}
+		entry, err := parsePOEntry(lines[i:j])
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if entry == nil {
+			// Comments and obsolete entries are kept as they are
+			verbatim = append(verbatim, lines[i:j]...)
+		} else {
+			flush()
+			file.parts = append(file.parts, poPart{entry: entry})
+		}
+		i = j
+	}
+	flush()
+
+	// The header is the entry of the empty msgid
+	for _, part := range file.parts {
+		if e := part.entry; e != nil && e.msgid == "" && e.msgctxt == nil {
+			file.header = e
+			c.TargetLanguage = poHeaderField(strings.Join(e.msgstr, ""), "Language")
+			break
+		}
+	}
+
+	rule := PluralRuleFor(c.TargetLanguage)
+	for _, part := range file.parts {
+		e := part.entry
+		if e == nil || e == file.header {
+			continue
+		}
+		u := &Unit{ID: e.msgid, Source: e.msgid, State: StateNew}
+		if e.msgctxt != nil {
+			u.Context = *e.msgctxt
+		}
+		var notes []string
+		for _, comment := range e.comments {
+			if strings.HasPrefix(comment, "#.") || strings.HasPrefix(comment, "# ") || comment == "#" {
+				notes = append(notes, strings.TrimSpace(comment[min(2, len(comment)):]))
+			}
+		}
+		u.Note = strings.TrimSpace(strings.Join(notes, "\n"))
+
+		translated := false
+		if e.msgidPlural != nil {
+			u.SourcePlurals = map[string]string{"one": e.msgid, "other": *e.msgidPlural}
+			if len(e.msgstr) == len(rule.Gettext) && c.TargetLanguage != "" {
+				u.TargetPlurals = map[string]string{}
+				for i, category := range rule.Gettext {
+					u.TargetPlurals[category] = e.msgstr[i]
+					translated = translated || e.msgstr[i] != ""
+				}
+			}
+		} else if len(e.msgstr) > 0 {
+			u.Target = e.msgstr[0]
+			translated = u.Target != ""
+		}
+		if translated {
+			u.State = StateTranslated
+			for _, flag := range e.flags {
+				if flag == "fuzzy" {
+					u.State = StateNeedsReview
+				}
+			}
+		}
+
+		e.unit, e.parsed = u, *u
+		c.Units = append(c.Units, u)
+	}
+
+	c.file = file
+	return nil
+}
+
+// parsePOEntry parses the lines of an entry, or returns nil for a block
+// without a msgid
+func parsePOEntry(lines []string) (*poEntry, error) {
+	e := &poEntry{raw: lines, flagsAt: -1}
+	var current *string
+	var plural int
+	hasMsgid := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#~"):
+			return nil, nil
+		case strings.HasPrefix(trimmed, "#,"):
+			e.flagsAt = len(e.comments)
+			for _, flag := range strings.Split(trimmed[2:], ",") {
+				if flag = strings.TrimSpace(flag); flag != "" {
+					e.flags = append(e.flags, flag)
+				}
+			}
+		case strings.HasPrefix(trimmed, "#"):
+			e.comments = append(e.comments, trimmed)
+		case strings.HasPrefix(trimmed, `"`):
+			if current == nil {
+				return nil, fmt.Errorf("string without a keyword: %s", trimmed)
+			}
+			s, err := unquotePO(trimmed)
+			if err != nil {
+				return nil, err
+			}
+			*current += s
+			if plural < 0 {
+				e.keywords = append(e.keywords, line)
+			}
+		default:
+			m := poKeyword.FindStringSubmatch(trimmed)
+			if m == nil {
+				return nil, fmt.Errorf("unexpected line: %s", trimmed)
+			}
+			s, err := unquotePO(m[3])
+			if err != nil {
+				return nil, err
+			}
+			plural = -1
+			switch m[1] {
+			case "msgctxt":
+				e.msgctxt = &s
+				current = e.msgctxt
+			case "msgid":
+				e.msgid = s
+				current = &e.msgid
+				hasMsgid = true
+			case "msgid_plural":
+				e.msgidPlural = &s
+				current = e.msgidPlural
+			default:
+				index := 0
+				if m[2] != "" {
+					index, _ = strconv.Atoi(m[2])
+				}
+				for len(e.msgstr) <= index {
+					e.msgstr = append(e.msgstr, "")
+				}
+				e.msgstr[index] = s
+				current = &e.msgstr[index]
+				plural = index
+				continue
+			}
+			e.keywords = append(e.keywords, line)
+		}
+	}
+	if e.flagsAt < 0 {
+		// A new flags line goes before the previous-msgid comments
+		e.flagsAt = len(e.comments)
+		for i, comment := range e.comments {
+			if strings.HasPrefix(comment, "#|") {
+				e.flagsAt = i
+				break
+			}
+		}
+	}
+	if !hasMsgid {
+		return nil, nil
+	}
+	return e, nil
+}
+
+func (f *poFile) render(c *Catalog) ([]byte, error) {
+	rule := PluralRuleFor(c.TargetLanguage)
+
+	var parts []string
+	for _, part := range f.parts {
+		e := part.entry
+		switch {
+		case e == nil:
+			parts = append(parts, part.raw)
+		case e == f.header:
+			parts = append(parts, f.renderHeader(e, c, rule))
+		case e.unit.Target == e.parsed.Target && e.unit.State == e.parsed.State &&
+			fmt.Sprint(e.unit.TargetPlurals) == fmt.Sprint(e.parsed.TargetPlurals):
+			parts = append(parts, strings.Join(e.raw, f.newline))
+		default:
+			parts = append(parts, f.renderEntry(e, rule))
+		}
+	}
+	return []byte(strings.Join(parts, f.newline)), nil
+}
+
+// renderHeader sets the language and plural forms of the header
+func (f *poFile) renderHeader(e *poEntry, c *Catalog, rule PluralRule) string {
+	header := strings.Join(e.msgstr, "")
+	updated := header
+	if c.TargetLanguage != "" {
+		updated = setPOHeaderField(updated, "Language", c.TargetLanguage)
+		if forms := rule.PluralForms(); forms != "" {
+			updated = setPOHeaderField(updated, "Plural-Forms", forms)
+		}
+	}
+	if updated == header {
+		return strings.Join(e.raw, f.newline)
+	}
+	return f.renderLines(e, e.flags, poString("msgstr", updated))
+}
+
+func (f *poFile) renderEntry(e *poEntry, rule PluralRule) string {
+	var msgstr []string
+	if e.msgidPlural == nil {
+		msgstr = poString("msgstr", e.unit.Target)
+	} else {
+		for i, category := range rule.Gettext {
+			msgstr = append(msgstr, poString(fmt.Sprintf("msgstr[%d]", i), e.unit.TargetPlurals[category])...)
+		}
+	}
+
+	var flags []string
+	for _, flag := range e.flags {
+		if flag != "fuzzy" {
+			flags = append(flags, flag)
+		}
+	}
+	if e.unit.State == StateNeedsReview {
+		flags = append([]string{"fuzzy"}, flags...)
+	}
+	return f.renderLines(e, flags, msgstr)
+}
+
+// renderLines writes an entry with its comments and keywords as they
+// were, and the given flags and msgstr lines
+func (f *poFile) renderLines(e *poEntry, flags, msgstr []string) string {
+	var lines []string
+	for i := 0; i <= len(e.comments); i++ {
+		if i == e.flagsAt && len(flags) > 0 {
+			lines = append(lines, "#, "+strings.Join(flags, ", "))
+		}
+		if i < len(e.comments) {
+			lines = append(lines, e.comments[i])
+		}
+	}
+	lines = append(lines, e.keywords...)
+	lines = append(lines, msgstr...)
+	return strings.Join(lines, f.newline)
+}
+
+// poString writes a keyword and its string, over several lines after an
+// empty one when the string has line breaks inside, as gettext does
+func poString(keyword, s string) []string {
+	if i := strings.Index(s, "\n"); i < 0 || i == len(s)-1 {
+		return []string{keyword + " " + quotePO(s)}
+	}
+	lines := []string{keyword + ` ""`}
+	for s != "" {
+		i := strings.Index(s, "\n")
+		if i < 0 {
+			i = len(s) - 1
+		}
+		lines = append(lines, quotePO(s[:i+1]))
+		s = s[i+1:]
+	}
+	return lines
+}
+
+func quotePO(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("malformed string: %s", s)
+	}
+	var b strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i+1 == len(body) {
+			b.WriteByte(body[i])
+			continue
+		}
+		i++
+		switch c := body[i]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		case 'x':
+			j := i + 1
+			for j < len(body) && j < i+3 && strings.IndexByte("0123456789abcdefABCDEF", body[j]) >= 0 {
+				j++
+			}
+			v, _ := strconv.ParseUint(body[i+1:j], 16, 8)
+			b.WriteByte(byte(v))
+			i = j - 1
+		default:
+			if c >= '0' && c <= '7' {
+				j := i
+				for j < len(body) && j < i+3 && body[j] >= '0' && body[j] <= '7' {
+					j++
+				}
+				v, _ := strconv.ParseUint(body[i:j], 8, 8)
+				b.WriteByte(byte(v))
+				i = j - 1
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// poHeaderField returns a field of a PO header
+func poHeaderField(header, name string) string {
+	for _, line := range strings.Split(header, "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// setPOHeaderField sets a field of a PO header, adding it when missing
+func setPOHeaderField(header, name, value string) string {
+	lines := strings.Split(header, "\n")
+	for i, line := range lines {
+		if key, _, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			lines[i] = name + ": " + value
+			return strings.Join(lines, "\n")
+		}
+	}
+	if header != "" && !strings.HasSuffix(header, "\n") {
+		header += "\n"
+	}
+	return header + name + ": " + value + "\n"
+}
@@ -0,0 +1,122 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testPOT = `# Translations of the app
+msgid ""
+msgstr ""
+"Project-Id-Version: app 1.0\n"
+"Language: \n"
+"Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;\n"
+
+#. Shown on the start screen
+#: src/main.c:10
+#, c-format
+msgid "Hello, %s!"
+msgstr ""
+
+#: src/main.c:20
+msgctxt "menu"
+msgid "Open"
+msgstr "Otvori"
+
+#, c-format
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] ""
+msgstr[1] ""
+
+msgid ""
+"A long text\n"
+"over two lines"
+msgstr ""
+
+#~ msgid "Old"
+#~ msgstr "Staro"
+`
+
+func TestParsePO(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testPOT), format.FormatPO)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	if len(catalog.Units) != 4 {
+		t.Fatalf("Expected 4 units, got %d", len(catalog.Units))
+	}
+	hello := catalog.Units[0]
+	if hello.Source != "Hello, %s!" || hello.Note != "Shown on the start screen" || hello.State != StateNew {
+		t.Errorf("Unexpected unit %+v", hello)
+	}
+	open := catalog.Units[1]
+	if open.Context != "menu" || open.Target != "Otvori" || open.State != StateTranslated {
+		t.Errorf("Unexpected unit %+v", open)
+	}
+	files := catalog.Units[2]
+	if files.SourcePlurals["one"] != "One file" || files.SourcePlurals["other"] != "%d files" {
+		t.Errorf("Unexpected plurals %v", files.SourcePlurals)
+	}
+	if catalog.Units[3].Source != "A long text\nover two lines" {
+		t.Errorf("Unexpected source %q", catalog.Units[3].Source)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	if string(data) != testPOT {
+		t.Errorf("PO not kept byte for byte:\n%s", data)
+	}
+}
+
+func TestTranslatePO(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testPOT), format.FormatPO)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		if text == "Hello, <x1/>!" {
+			return "Zdravo!", nil
+		}
+		return "SR " + text, nil
+	}, "sr")
+	stats, err := translator.TranslateCatalog(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if stats.Units != 3 || stats.Skipped != 1 || stats.NeedsReview != 1 || stats.Review[0] != "Hello, %s!" {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`"Language: \n"`, `"Language: sr\n"`,
+		"nplurals=INTEGER; plural=EXPRESSION;", "nplurals=3; plural="+PluralRuleFor("sr").Expression+";",
+		"#, c-format\nmsgid \"Hello, %s!\"\nmsgstr \"\"", "#, fuzzy, c-format\nmsgid \"Hello, %s!\"\nmsgstr \"Zdravo! %s\"",
+		"msgstr[0] \"\"\nmsgstr[1] \"\"", "msgstr[0] \"SR One file\"\nmsgstr[1] \"SR %d files\"\nmsgstr[2] \"SR %d files\"",
+		"\"over two lines\"\nmsgstr \"\"", "\"over two lines\"\nmsgstr \"\"\n\"SR A long text\\n\"\n\"over two lines\"",
+	).Replace(testPOT)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+
+	// The translated file reads back with its states
+	translated, err := ParseCatalog(data, format.FormatPO)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+	if translated.TargetLanguage != "sr" || translated.Units[0].State != StateNeedsReview ||
+		translated.Units[2].TargetPlurals["few"] != "SR %d files" || translated.Units[2].State != StateTranslated {
+		t.Errorf("Unexpected catalog %s %+v %+v", translated.TargetLanguage, translated.Units[0], translated.Units[2])
+	}
+}
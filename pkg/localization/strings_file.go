@@ -0,0 +1,172 @@
+package localization
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// stringsFile is an iOS or macOS .strings file of "key" = "value"; pairs.
+// Values are replaced by their translations in place, and files in UTF-16
+// are written back in UTF-16.
+type stringsFile struct {
+	plainText
+	data     []byte // the file decoded to UTF-8
+	encoding encoding.Encoding
+	units    []*stringsUnit
+}
+
+type stringsUnit struct {
+	unit  *Unit
+	value xmlRange // the quoted value
+}
+
+func parseStrings(c *Catalog, data []byte) error {
+	file := &stringsFile{}
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xfe}):
+		file.encoding = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		file.encoding = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	}
+	if file.encoding != nil {
+		decoded, err := file.encoding.NewDecoder().Bytes(data)
+		if err != nil {
+			return fmt.Errorf("failed to decode UTF-16: %w", err)
+		}
+		data = decoded
+	}
+	file.data = data
+
+	pos := 0
+	if bytes.HasPrefix(data, []byte("\xef\xbb\xbf")) {
+		pos = 3
+	}
+	var comment string
+	var tokens []xmlRange // key, =, value and ; of the pair being read
+	for pos < len(data) {
+		switch {
+		case data[pos] == ' ' || data[pos] == '\t' || data[pos] == '\n' || data[pos] == '\r':
+			pos++
+		case bytes.HasPrefix(data[pos:], []byte("/*")):
+			end := bytes.Index(data[pos+2:], []byte("*/"))
+			if end < 0 {
+				return fmt.Errorf("unterminated comment")
+			}
+			comment = strings.TrimSpace(string(data[pos+2 : pos+2+end]))
+			pos += end + 4
+		case bytes.HasPrefix(data[pos:], []byte("//")):
+			end := bytes.IndexByte(data[pos:], '\n')
+			if end < 0 {
+				end = len(data) - pos
+			}
+			comment = strings.TrimSpace(string(data[pos+2 : pos+end]))
+			pos += end
+		case data[pos] == '"':
+			end := pos + 1
+			for end < len(data) && data[end] != '"' {
+				if data[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(data) {
+				return fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, xmlRange{pos, end + 1})
+			pos = end + 1
+		case data[pos] == '=' || data[pos] == ';':
+			tokens = append(tokens, xmlRange{pos, pos + 1})
+			pos++
+			if data[pos-1] == ';' {
+				if len(tokens) != 4 || data[tokens[1].start] != '=' {
+					return fmt.Errorf("malformed entry at offset %d", tokens[0].start)
+				}
+				key, value := file.token(tokens[0]), tokens[2]
+				u := &stringsUnit{unit: &Unit{ID: key, Note: comment, Source: file.token(value), State: StateNew}, value: value}
+				if strings.TrimSpace(u.unit.Source) != "" {
+					file.units = append(file.units, u)
+					c.Units = append(c.Units, u.unit)
+				}
+				tokens, comment = nil, ""
+			}
+		default:
+			// Keys may be written without quotes
+			end := pos
+			for end < len(data) && strings.IndexByte(" \t\r\n=;\"", data[end]) < 0 {
+				end++
+			}
+			tokens = append(tokens, xmlRange{pos, end})
+			pos = end
+		}
+	}
+	if len(tokens) > 0 {
+		return fmt.Errorf("unterminated entry at offset %d", tokens[0].start)
+	}
+
+	c.file = file
+	return nil
+}
+
+// token returns the text of a key or value
+func (f *stringsFile) token(r xmlRange) string {
+	s := string(f.data[r.start:r.end])
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	return unescapeStrings(s[1 : len(s)-1])
+}
+
+func (f *stringsFile) render(c *Catalog) ([]byte, error) {
+	var edits []xmlEdit
+	for _, u := range f.units {
+		if u.unit.Target != "" {
+			edits = append(edits, xmlEdit{u.value.start, u.value.end, `"` + escapeStrings(u.unit.Target) + `"`})
+		}
+	}
+	data := applyEdits(f.data, edits)
+	if f.encoding != nil {
+		return f.encoding.NewEncoder().Bytes(data)
+	}
+	return data, nil
+}
+
+func unescapeStrings(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'U', 'u':
+			if i+5 <= len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func escapeStrings(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`).Replace(s)
+}
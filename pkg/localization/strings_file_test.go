@@ -0,0 +1,85 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testStrings = `/* Title of the settings screen */
+"settings.title" = "Settings";
+
+// Greeting with the user's name
+"greeting" = "Hello, %@! You have %d \"new\" messages.";
+plain_key = "Done";
+`
+
+func TestTranslateStrings(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testStrings), format.FormatStrings)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+	if len(catalog.Units) != 3 {
+		t.Fatalf("Expected 3 units, got %d", len(catalog.Units))
+	}
+	greeting := catalog.Units[1]
+	if greeting.ID != "greeting" || greeting.Note != "Greeting with the user's name" ||
+		greeting.Source != `Hello, %@! You have %d "new" messages.` {
+		t.Errorf("Unexpected unit %+v", greeting)
+	}
+	if catalog.Units[2].ID != "plain_key" {
+		t.Errorf("Unexpected key %q", catalog.Units[2].ID)
+	}
+
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return "FR " + text, nil
+	}, "fr")
+	if _, err := translator.TranslateCatalog(context.Background(), catalog); err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`"Settings"`, `"FR Settings"`,
+		`"Hello, %@!`, `"FR Hello, %@!`,
+		`"Done"`, `"FR Done"`,
+	).Replace(testStrings)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
+
+func TestTranslateStringsUTF16(t *testing.T) {
+	encoding := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	data, err := encoding.NewEncoder().Bytes([]byte(`"ok" = "OK";` + "\n"))
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+
+	catalog, err := ParseCatalog(data, format.FormatStrings)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+	catalog.Units[0].Target = "D'accord"
+	out, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	decoded, err := encoding.NewDecoder().Bytes(out)
+	if err != nil || string(decoded) != `"ok" = "D'accord";`+"\n" {
+		t.Errorf("Unexpected output %q (%v)", decoded, err)
+	}
+	if out[0] != 0xff || out[1] != 0xfe {
+		t.Errorf("Expected a UTF-16 byte order mark")
+	}
+
+	if _, err := ParseCatalog([]byte(`"a" = "b"`), format.FormatStrings); err == nil {
+		t.Error("Expected an error for an unterminated entry")
+	}
+}
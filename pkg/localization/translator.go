@@ -0,0 +1,263 @@
+package localization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"digital.vasic.translator/pkg/ebook"
+)
+
+// LocalizationContext is passed for the strings of localization files,
+// followed by their key and notes
+const LocalizationContext = "User interface string of an app: keep every <xN/> tag, which stands for a placeholder or markup, and translate only the text"
+
+// Stats reports the work of a localization translation
+type Stats struct {
+	Units       int      // units translated
+	Skipped     int      // units left alone, as they were translated already
+	NeedsReview int      // translated units marked for review
+	Review      []string // IDs of the units marked for review
+}
+
+// Translator translates localization files string by string. Placeholders
+// and markup are masked as <xN/> tags, plural strings get the forms of the
+// target language, and units whose placeholders did not come back intact
+// are marked for review, fuzzy in gettext terms.
+type Translator struct {
+	translate ebook.TranslateFunc
+
+	// TargetLanguage is the code of the language translated to, which
+	// decides the plural forms
+	TargetLanguage string
+
+	// Overwrite translates units that have a translation already
+	Overwrite bool
+
+	// MarkForReview marks every translated unit for review
+	MarkForReview bool
+
+	// OnProgress, when set, is called after each unit
+	OnProgress func(done, total int)
+}
+
+// NewTranslator creates a translator into the given language
+func NewTranslator(translate ebook.TranslateFunc, targetLanguage string) *Translator {
+	return &Translator{
+		translate:      translate,
+		TargetLanguage: targetLanguage,
+	}
+}
+
+// Translate translates the localization file at inputPath and writes it to
+// outputPath in the same format
+func (t *Translator) Translate(ctx context.Context, inputPath, outputPath string) (*Stats, error) {
+	catalog, err := ReadCatalog(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := t.TranslateCatalog(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := catalog.WriteFile(outputPath); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// TranslateCatalog translates the units of a catalog
+func (t *Translator) TranslateCatalog(ctx context.Context, c *Catalog) (*Stats, error) {
+	if t.TargetLanguage != "" {
+		c.TargetLanguage = t.TargetLanguage
+	}
+	rule := PluralRuleFor(c.TargetLanguage)
+
+	stats := &Stats{}
+	for i, u := range c.Units {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if u.State != StateNew && !t.Overwrite {
+			stats.Skipped++
+		} else {
+			kept, err := t.translateUnit(ctx, c, u, rule)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate %q: %w", u.ID, err)
+			}
+			u.State = StateTranslated
+			if !kept || t.MarkForReview {
+				u.State = StateNeedsReview
+				stats.NeedsReview++
+				stats.Review = append(stats.Review, u.ID)
+			}
+			stats.Units++
+		}
+		if t.OnProgress != nil {
+			t.OnProgress(i+1, len(c.Units))
+		}
+	}
+	return stats, nil
+}
+
+// translateUnit translates the text or the plural forms of a unit,
+// reporting whether its placeholders were kept
+func (t *Translator) translateUnit(ctx context.Context, c *Catalog, u *Unit, rule PluralRule) (bool, error) {
+	context := unitContext(u)
+	if !u.IsPlural() {
+		target, kept, err := t.translateText(ctx, c, u.Source, context, rule)
+		u.Target = target
+		return kept, err
+	}
+
+	kept := true
+	u.TargetPlurals = map[string]string{}
+	for _, category := range c.file.pluralCategories(rule) {
+		target, ok, err := t.translateText(ctx, c, pluralSource(u.SourcePlurals, category), pluralContext(context, category, rule), rule)
+		if err != nil {
+			return false, err
+		}
+		u.TargetPlurals[category] = target
+		kept = kept && ok
+	}
+	return kept, nil
+}
+
+// translateText translates a string of a unit, as an ICU message when it
+// has plural or select arguments
+func (t *Translator) translateText(ctx context.Context, c *Catalog, text, context string, rule PluralRule) (string, bool, error) {
+	pieces := c.file.split(text)
+	plain := true
+	for _, p := range pieces {
+		plain = plain && !p.code
+	}
+	if plain {
+		if msg, ok := parseICU(text); ok && msg.hasChoice() {
+			translated, kept, err := t.translateMessage(ctx, msg, context, rule)
+			if err != nil {
+				return "", false, err
+			}
+			return c.file.join([]piece{{text: translated.render(false)}}), kept, nil
+		}
+	}
+
+	out, kept, err := t.translatePieces(ctx, pieces, context)
+	if err != nil {
+		return "", false, err
+	}
+	return c.file.join(out), kept, nil
+}
+
+// translatePieces translates text with its markup and placeholders masked
+func (t *Translator) translatePieces(ctx context.Context, pieces []piece, context string) ([]piece, bool, error) {
+	m := mask(pieces)
+	if !m.translatable() {
+		return m.pieces(), true, nil
+	}
+	translated, err := t.translate(ctx, m.text, context)
+	if err != nil {
+		return nil, false, err
+	}
+	out, kept := m.unmask(translated)
+	return out, kept, nil
+}
+
+// translateMessage translates an ICU message: its text with the arguments
+// masked, and the branches of its plural and select arguments one by one.
+// Plurals get the categories of the target language, each translated from
+// the same category of the source or else from other.
+func (t *Translator) translateMessage(ctx context.Context, msg icuMessage, context string, rule PluralRule) (icuMessage, bool, error) {
+	kept := true
+	var pieces []piece
+	for _, part := range msg {
+		switch {
+		case part.choice != nil:
+			choice, ok, err := t.translateChoice(ctx, part.choice, context, rule)
+			if err != nil {
+				return nil, false, err
+			}
+			kept = kept && ok
+			pieces = append(pieces, piece{text: choice.render(), code: true})
+		case part.arg != "":
+			pieces = append(pieces, piece{text: part.arg, code: true})
+		default:
+			pieces = append(pieces, piece{text: part.text})
+		}
+	}
+
+	out, ok, err := t.translatePieces(ctx, pieces, context)
+	if err != nil {
+		return nil, false, err
+	}
+	translated := make(icuMessage, len(out))
+	for i, p := range out {
+		if p.code {
+			translated[i] = icuPart{arg: p.text}
+		} else {
+			translated[i] = icuPart{text: p.text}
+		}
+	}
+	return translated, kept && ok, nil
+}
+
+func (t *Translator) translateChoice(ctx context.Context, choice *icuChoice, context string, rule PluralRule) (*icuChoice, bool, error) {
+	branches := choice.branches
+	if choice.kind == "plural" {
+		branches = nil
+		forms := map[string]icuMessage{}
+		for _, branch := range choice.branches {
+			if strings.HasPrefix(branch.key, "=") {
+				branches = append(branches, branch)
+			} else if isCategory(branch.key) {
+				forms[branch.key] = branch.message
+			}
+		}
+		for _, category := range rule.Categories {
+			message, ok := forms[category]
+			if !ok {
+				message = forms["other"]
+			}
+			branches = append(branches, icuBranch{key: category, message: message})
+		}
+	}
+
+	kept := true
+	translated := &icuChoice{name: choice.name, kind: choice.kind, offset: choice.offset}
+	for _, branch := range branches {
+		branchContext := fmt.Sprintf("%s\nBranch %q of the %s argument %q", context, branch.key, choice.kind, choice.name)
+		if choice.kind == "plural" && isCategory(branch.key) {
+			branchContext = pluralContext(branchContext, branch.key, rule)
+		}
+		message, ok, err := t.translateMessage(ctx, branch.message, branchContext, rule)
+		if err != nil {
+			return nil, false, err
+		}
+		kept = kept && ok
+		translated.branches = append(translated.branches, icuBranch{key: branch.key, message: message})
+	}
+	return translated, kept, nil
+}
+
+// unitContext describes a unit with its key and notes
+func unitContext(u *Unit) string {
+	var b strings.Builder
+	b.WriteString(LocalizationContext)
+	if u.ID != "" && u.ID != u.Source {
+		b.WriteString("\nKey: " + u.ID)
+	}
+	if u.Context != "" {
+		b.WriteString("\nContext: " + u.Context)
+	}
+	if u.Note != "" {
+		b.WriteString("\nNote: " + u.Note)
+	}
+	return b.String()
+}
+
+// pluralContext asks for a plural form of the target language
+func pluralContext(context, category string, rule PluralRule) string {
+	return fmt.Sprintf("%s\nTranslate as the plural form %q of the target language, used for counts such as %s",
+		context, category, rule.Examples[category])
+}
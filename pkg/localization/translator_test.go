@@ -0,0 +1,180 @@
+package localization
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+func TestPluralRuleFor(t *testing.T) {
+	tests := []struct {
+		code        string
+		categories  string
+		pluralForms string
+	}{
+		{"", "one,other", "nplurals=2; plural=(n != 1);"},
+		{"de", "one,other", "nplurals=2; plural=(n != 1);"},
+		{"fr", "one,other", "nplurals=2; plural=(n > 1);"},
+		{"ja", "other", "nplurals=1; plural=0;"},
+		{"ru", "one,few,many,other", "nplurals=3; plural=" + gettextExpressions["ru"] + ";"},
+		{"pt_BR", "one,other", "nplurals=2; plural=(n > 1);"},
+		{"ar", "zero,one,two,few,many,other", "nplurals=6; plural=" + gettextExpressions["ar"] + ";"},
+	}
+
+	for _, tt := range tests {
+		rule := PluralRuleFor(tt.code)
+		if got := strings.Join(rule.Categories, ","); got != tt.categories {
+			t.Errorf("PluralRuleFor(%q) categories = %s, want %s", tt.code, got, tt.categories)
+		}
+		if got := rule.PluralForms(); got != tt.pluralForms {
+			t.Errorf("PluralRuleFor(%q) Plural-Forms = %s, want %s", tt.code, got, tt.pluralForms)
+		}
+	}
+}
+
+func TestMaskPlaceholders(t *testing.T) {
+	m := mask([]piece{{text: "  Hi %1$s, you have {count} <b>new</b> ${kind} {{x}} 100% &amp; more\n"}})
+	if m.text != "Hi <x1/>, you have <x2/> <x3/>new<x4/> <x5/> <x6/> 100% <x7/> more" {
+		t.Fatalf("Unexpected masked text %q", m.text)
+	}
+
+	// Tags may come back reordered and with another spelling
+	out, kept := m.unmask("<X2 /> <x1/> <x3/>nouveaux<x4/> <x5/> <x6/> 100% <x7/> plus")
+	if !kept || plainPieces(out) != "  {count} %1$s <b>nouveaux</b> ${kind} {{x}} 100% &amp; plus\n" {
+		t.Errorf("Unexpected unmasked text %q (kept %v)", plainPieces(out), kept)
+	}
+
+	// Lost and repeated tags leave the translation for review
+	out, kept = m.unmask("<x1/> <x1/> salut")
+	if kept || !strings.Contains(plainPieces(out), "{count}") {
+		t.Errorf("Expected lost placeholders to be added back, got %q (kept %v)", plainPieces(out), kept)
+	}
+
+	if mask([]piece{{text: "%d / %s"}}).translatable() {
+		t.Error("Expected placeholders alone not to be translatable")
+	}
+}
+
+func plainPieces(pieces []piece) string {
+	var b strings.Builder
+	for _, p := range pieces {
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+func TestTranslateICUPlural(t *testing.T) {
+	data := `{"files": "{count, plural, =0 {No files} one {# file in {folder}} other {# files in {folder}}} for '{'you'}'"}`
+	catalog, err := ParseCatalog([]byte(data), format.FormatJSON)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+
+	var contexts []string
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		contexts = append(contexts, context)
+		return strings.NewReplacer("No files", "Нет файлов", "files in", "файлов в", "file in", "файл в", "for", "для").Replace(text), nil
+	}, "ru")
+	stats, err := translator.TranslateCatalog(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if stats.NeedsReview != 0 {
+		t.Errorf("Unexpected review %v", stats.Review)
+	}
+
+	want := "{count, plural, =0 {Нет файлов} one {# файл в {folder}} few {# файлов в {folder}} many {# файлов в {folder}} other {# файлов в {folder}}} для '{'you'}'"
+	if got := catalog.Units[0].Target; got != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, got)
+	}
+	if !strings.Contains(strings.Join(contexts, "\n"), `plural form "few"`) {
+		t.Errorf("Expected the plural forms to be described, got %q", contexts)
+	}
+}
+
+func TestTranslatorStates(t *testing.T) {
+	po := `msgid "New"
+msgstr ""
+
+#, fuzzy
+msgid "Fuzzy"
+msgstr "Unscharf"
+
+msgid "Done"
+msgstr "Fertig"
+`
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return "DE " + text, nil
+	}
+
+	catalog, _ := ParseCatalog([]byte(po), format.FormatPO)
+	translator := NewTranslator(translate, "de")
+	translator.MarkForReview = true
+	var progress []int
+	translator.OnProgress = func(done, total int) {
+		progress = append(progress, done)
+	}
+	stats, err := translator.TranslateCatalog(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if stats.Units != 1 || stats.Skipped != 2 || stats.NeedsReview != 1 || len(progress) != 3 {
+		t.Errorf("Unexpected stats %+v and progress %v", stats, progress)
+	}
+	data, _ := catalog.Bytes()
+	if !strings.HasPrefix(string(data), "#, fuzzy\nmsgid \"New\"\nmsgstr \"DE New\"\n") {
+		t.Errorf("Expected the new unit to be fuzzy:\n%s", data)
+	}
+
+	catalog, _ = ParseCatalog([]byte(po), format.FormatPO)
+	translator = NewTranslator(translate, "de")
+	translator.Overwrite = true
+	if stats, _ := translator.TranslateCatalog(context.Background(), catalog); stats.Units != 3 {
+		t.Errorf("Expected every unit to be translated, got %+v", stats)
+	}
+	data, _ = catalog.Bytes()
+	if strings.Contains(string(data), "fuzzy") || !strings.Contains(string(data), `msgstr "DE Fuzzy"`) {
+		t.Errorf("Expected the fuzzy flag to be removed:\n%s", data)
+	}
+
+	failing := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return "", errors.New("provider down")
+	}, "de")
+	catalog, _ = ParseCatalog([]byte(po), format.FormatPO)
+	if _, err := failing.TranslateCatalog(context.Background(), catalog); err == nil || !strings.Contains(err.Error(), "provider down") {
+		t.Errorf("Expected the provider error, got %v", err)
+	}
+}
+
+func TestTranslateFile(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "en.json")
+	output := filepath.Join(dir, "de.json")
+	if err := os.WriteFile(input, []byte(`{"ok": "OK", "cancel": "Cancel"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return strings.ToUpper(text) + "!", nil
+	}, "de")
+	stats, err := translator.Translate(context.Background(), input, output)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if stats.Units != 2 {
+		t.Errorf("Expected 2 units, got %+v", stats)
+	}
+	data, _ := os.ReadFile(output)
+	if string(data) != `{"ok": "OK!", "cancel": "CANCEL!"}` {
+		t.Errorf("Unexpected output %s", data)
+	}
+
+	if _, err := translator.Translate(context.Background(), filepath.Join(dir, "missing.po"), output); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,228 @@
+package localization
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// xliffFile is an XLIFF 1.2 or 2.0 file. Targets are written into the
+// units, or added after their sources; the rest of the file is kept byte
+// for byte.
+type xliffFile struct {
+	xmlText
+	data  []byte
+	v2    bool
+	units []*xliffUnit
+	langs []xmlRange // start tags naming the target language: <file> in 1.2, <xliff> in 2.0
+}
+
+type xmlRange struct{ start, end int }
+
+type xliffUnit struct {
+	unit   *Unit
+	parsed Unit
+
+	source    xmlRange // the whole source element
+	inner     xmlRange // its content
+	target    xmlRange // the whole target element, if there is one
+	hasTarget bool
+	targetTag xmlRange // the start tag of the target
+	stateTag  xmlRange // the start tag of the segment in 2.0
+}
+
+func parseXLIFF(c *Catalog, data []byte) error {
+	file := &xliffFile{data: data}
+
+	var (
+		stack     []string
+		unitID    string       // id of the trans-unit or 2.0 unit
+		notes     []string     // notes of the unit
+		skip      bool         // translate="no"
+		segments  []*xliffUnit // segments of the unit
+		current   *xliffUnit   // trans-unit or 2.0 segment
+		noteStart = -1
+	)
+
+	parent := func() string {
+		if len(stack) < 2 {
+			return ""
+		}
+		return stack[len(stack)-2]
+	}
+
+	err := scanXML(data, func(t xmlToken) error {
+		switch tok := t.Token.(type) {
+		case xml.StartElement:
+			stack = append(stack, tok.Name.Local)
+			switch tok.Name.Local {
+			case "xliff":
+				version, _ := attr(tok, "version")
+				file.v2 = strings.HasPrefix(version, "2")
+				if file.v2 {
+					c.SourceLanguage, _ = attr(tok, "srcLang")
+					c.TargetLanguage, _ = attr(tok, "trgLang")
+					file.langs = append(file.langs, xmlRange{t.start, t.end})
+				}
+			case "file":
+				if !file.v2 {
+					if lang, ok := attr(tok, "source-language"); ok && c.SourceLanguage == "" {
+						c.SourceLanguage = lang
+					}
+					if lang, ok := attr(tok, "target-language"); ok && c.TargetLanguage == "" {
+						c.TargetLanguage = lang
+					}
+					file.langs = append(file.langs, xmlRange{t.start, t.end})
+				}
+			case "trans-unit", "unit":
+				unitID, _ = attr(tok, "id")
+				translate, _ := attr(tok, "translate")
+				skip = translate == "no"
+				notes, segments = nil, nil
+				if !file.v2 {
+					current = &xliffUnit{unit: &Unit{ID: unitID, State: StateNew}}
+				}
+			case "segment":
+				current = &xliffUnit{unit: &Unit{ID: unitID, State: StateNew}, stateTag: xmlRange{t.start, t.end}}
+				state, _ := attr(tok, "state")
+				subState, _ := attr(tok, "subState")
+				current.unit.State = xliffState(state, subState)
+			case "source":
+				if current != nil && (parent() == "trans-unit" || parent() == "segment") {
+					current.source.start = t.start
+					current.inner.start = t.end
+				}
+			case "target":
+				if current != nil && (parent() == "trans-unit" || parent() == "segment") {
+					current.hasTarget = true
+					current.target.start = t.start
+					current.targetTag = xmlRange{t.start, t.end}
+					if !file.v2 {
+						state, _ := attr(tok, "state")
+						current.unit.State = xliffState(state, "")
+					}
+				}
+			case "note":
+				noteStart = t.end
+			}
+
+		case xml.EndElement:
+			switch tok.Name.Local {
+			case "source":
+				if current != nil && (parent() == "trans-unit" || parent() == "segment") {
+					current.inner.end = t.start
+					current.source.end = t.end
+					current.unit.Source = string(data[current.inner.start:current.inner.end])
+				}
+			case "target":
+				if current != nil && current.hasTarget && current.target.end == 0 && (parent() == "trans-unit" || parent() == "segment") {
+					current.target.end = t.end
+					current.unit.Target = string(data[current.targetTag.end:t.start])
+				}
+			case "note":
+				if noteStart >= 0 {
+					notes = append(notes, strings.TrimSpace(plainXML(string(data[noteStart:t.start]))))
+					noteStart = -1
+				}
+			case "segment":
+				if current != nil {
+					segments = append(segments, current)
+					current = nil
+				}
+			case "trans-unit", "unit":
+				if !file.v2 && current != nil {
+					segments = append(segments, current)
+				}
+				for i, u := range segments {
+					if skip || strings.TrimSpace(u.unit.Source) == "" {
+						continue
+					}
+					if len(segments) > 1 {
+						u.unit.ID = fmt.Sprintf("%s#%d", unitID, i+1)
+					}
+					u.unit.Note = strings.Join(notes, "\n")
+					if u.unit.Target == "" {
+						u.unit.State = StateNew
+					} else if u.unit.State == StateNew {
+						u.unit.State = StateTranslated
+					}
+					u.parsed = *u.unit
+					file.units = append(file.units, u)
+					c.Units = append(c.Units, u.unit)
+				}
+				current, segments = nil, nil
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.file = file
+	return nil
+}
+
+// xliffState maps the states of XLIFF 1.2 targets and 2.0 segments
+func xliffState(state, subState string) State {
+	switch {
+	case strings.HasPrefix(state, "needs-review") || strings.Contains(subState, "needs-review"):
+		return StateNeedsReview
+	case state == "translated" || state == "reviewed" || state == "final" || state == "signed-off":
+		return StateTranslated
+	}
+	return StateNew
+}
+
+func (f *xliffFile) render(c *Catalog) ([]byte, error) {
+	var edits []xmlEdit
+	if c.TargetLanguage != "" {
+		name := "target-language"
+		if f.v2 {
+			name = "trgLang"
+		}
+		for _, r := range f.langs {
+			edits = append(edits, xmlEdit{r.start, r.end, setAttribute(string(f.data[r.start:r.end]), name, c.TargetLanguage)})
+		}
+	}
+
+	for _, u := range f.units {
+		if u.unit.Target == u.parsed.Target && u.unit.State == u.parsed.State {
+			continue
+		}
+
+		targetTag := "<target>"
+		if u.hasTarget {
+			targetTag = string(f.data[u.targetTag.start:u.targetTag.end])
+			targetTag = strings.TrimSuffix(strings.TrimSuffix(targetTag, ">"), "/") + ">"
+		}
+		if !f.v2 {
+			state := "translated"
+			if u.unit.State == StateNeedsReview {
+				state = "needs-review-translation"
+			}
+			targetTag = setAttribute(targetTag, "state", state)
+		} else {
+			tag := setAttribute(string(f.data[u.stateTag.start:u.stateTag.end]), "state", "translated")
+			if u.unit.State == StateNeedsReview {
+				tag = setAttribute(tag, "subState", "translator:needs-review")
+			}
+			edits = append(edits, xmlEdit{u.stateTag.start, u.stateTag.end, tag})
+		}
+		target := targetTag + u.unit.Target + "</target>"
+
+		if u.hasTarget {
+			edits = append(edits, xmlEdit{u.target.start, u.target.end, target})
+		} else {
+			separator := ""
+			if indent := lineIndent(f.data, u.source.start); indent != "" {
+				separator = newlineOf(f.data) + indent
+			}
+			edits = append(edits, xmlEdit{u.source.end, u.source.end, separator + target})
+		}
+	}
+	return applyEdits(f.data, edits), nil
+}
@@ -0,0 +1,119 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"digital.vasic.translator/pkg/format"
+)
+
+const testXLIFF12 = `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file source-language="en" datatype="plaintext" original="app">
+    <body>
+      <trans-unit id="greeting">
+        <source>Hello, <g id="1">%1$s</g> &amp; friends</source>
+        <note>Shown on the start screen</note>
+      </trans-unit>
+      <trans-unit id="open">
+        <source>Open</source>
+        <target state="final">Otvori</target>
+      </trans-unit>
+      <trans-unit id="brand" translate="no">
+        <source>Translator</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+
+const testXLIFF20 = `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="2.0" xmlns="urn:oasis:names:tc:xliff:document:2.0" srcLang="en">
+  <file id="f1">
+    <unit id="welcome">
+      <segment>
+        <source>Welcome to {app}.</source>
+      </segment>
+      <segment state="translated">
+        <source>Enjoy!</source>
+        <target>Uživajte!</target>
+      </segment>
+    </unit>
+  </file>
+</xliff>
+`
+
+func TestTranslateXLIFF12(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testXLIFF12), format.FormatXLIFF)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+	if catalog.SourceLanguage != "en" || len(catalog.Units) != 2 {
+		t.Fatalf("Unexpected catalog %s with %d units", catalog.SourceLanguage, len(catalog.Units))
+	}
+	if u := catalog.Units[0]; u.ID != "greeting" || u.Note != "Shown on the start screen" {
+		t.Errorf("Unexpected unit %+v", u)
+	}
+	if u := catalog.Units[1]; u.Target != "Otvori" || u.State != StateTranslated {
+		t.Errorf("Unexpected unit %+v", u)
+	}
+
+	var seen string
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		seen = text
+		return "Zdravo, <x1/><x2/><x3/> i prijatelji", nil
+	}, "sr")
+	if _, err := translator.TranslateCatalog(context.Background(), catalog); err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if seen != "Hello, <x1/><x2/><x3/> & friends" {
+		t.Errorf("Unexpected text to translate %q", seen)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`original="app"`, `original="app" target-language="sr"`,
+		"friends</source>\n", "friends</source>\n        <target state=\"translated\">Zdravo, <g id=\"1\">%1$s</g> i prijatelji</target>\n",
+	).Replace(testXLIFF12)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
+
+func TestTranslateXLIFF20(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testXLIFF20), format.FormatXLIFF)
+	if err != nil {
+		t.Fatalf("ParseCatalog failed: %v", err)
+	}
+	if len(catalog.Units) != 2 || catalog.Units[0].ID != "welcome#1" || catalog.Units[1].State != StateTranslated {
+		t.Fatalf("Unexpected units %+v", catalog.Units)
+	}
+
+	translator := NewTranslator(func(ctx context.Context, text, context string) (string, error) {
+		return "Dobrodošli.", nil
+	}, "sr")
+	stats, err := translator.TranslateCatalog(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("TranslateCatalog failed: %v", err)
+	}
+	if stats.NeedsReview != 1 {
+		t.Errorf("Expected the unit that lost {app} to need review, got %+v", stats)
+	}
+
+	data, err := catalog.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	want := strings.NewReplacer(
+		`srcLang="en"`, `srcLang="en" trgLang="sr"`,
+		"<segment>\n        <source>Welcome to {app}.</source>\n",
+		"<segment state=\"translated\" subState=\"translator:needs-review\">\n        <source>Welcome to {app}.</source>\n        <target>Dobrodošli. {app}</target>\n",
+	).Replace(testXLIFF20)
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
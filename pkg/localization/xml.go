@@ -0,0 +1,186 @@
+package localization
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// xmlToken is a token of an XML file with its byte range
+type xmlToken struct {
+	xml.Token
+	start, end int
+}
+
+// scanXML walks the tokens of an XML file. Self-closing elements end with
+// an empty range right after their start tag.
+func scanXML(data []byte, visit func(t xmlToken) error) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Entity = xml.HTMLEntity
+	for {
+		start := int(d.InputOffset())
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := visit(xmlToken{Token: tok, start: start, end: int(d.InputOffset())}); err != nil {
+			return err
+		}
+	}
+}
+
+// attr returns an attribute of an element by its local name
+func attr(e xml.StartElement, name string) (string, bool) {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// xmlEdit replaces a byte range of a file
+type xmlEdit struct {
+	start, end int
+	text       string
+}
+
+// applyEdits applies edits that do not overlap
+func applyEdits(data []byte, edits []xmlEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out bytes.Buffer
+	pos := 0
+	for _, e := range edits {
+		out.Write(data[pos:e.start])
+		out.WriteString(e.text)
+		pos = e.end
+	}
+	out.Write(data[pos:])
+	return out.Bytes()
+}
+
+// setAttribute sets an attribute of a start tag, adding it when missing
+func setAttribute(tag, name, value string) string {
+	value = `"` + strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;").Replace(value) + `"`
+	existing := regexp.MustCompile(`(\s` + regexp.QuoteMeta(name) + `\s*=\s*)("[^"]*"|'[^']*')`)
+	if loc := existing.FindStringSubmatchIndex(tag); loc != nil {
+		return tag[:loc[4]] + value + tag[loc[5]:]
+	}
+	end := len(tag) - 1
+	if strings.HasSuffix(tag, "/>") {
+		end--
+	}
+	for end > 0 && (tag[end-1] == ' ' || tag[end-1] == '\t' || tag[end-1] == '\n' || tag[end-1] == '\r') {
+		end--
+	}
+	return tag[:end] + " " + name + "=" + value + tag[end:]
+}
+
+// lineIndent returns the spaces a position is indented by on its line
+func lineIndent(data []byte, pos int) string {
+	start := bytes.LastIndexByte(data[:pos], '\n') + 1
+	if indent := string(data[start:pos]); strings.TrimSpace(indent) == "" {
+		return indent
+	}
+	return ""
+}
+
+// newlineOf returns the line break a file uses
+func newlineOf(data []byte) string {
+	if bytes.Contains(data, []byte("\r\n")) {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// xmlText is embedded by the XML formats, whose strings hold markup and
+// entities
+type xmlText struct{}
+
+func (xmlText) split(text string) []piece {
+	var pieces []piece
+	for text != "" {
+		switch {
+		case strings.HasPrefix(text, "<![CDATA["):
+			// The content of a CDATA section is text, escapes and all
+			end := strings.Index(text, "]]>")
+			if end < 0 {
+				end = len(text)
+			}
+			pieces = append(pieces, piece{text: "<![CDATA[", code: true})
+			if end > 9 {
+				pieces = append(pieces, piece{text: text[9:end]})
+			}
+			if end == len(text) {
+				return pieces
+			}
+			pieces = append(pieces, piece{text: "]]>", code: true})
+			text = text[end+3:]
+		case strings.HasPrefix(text, "<"):
+			end := strings.Index(text, ">")
+			if close := strings.Index(text, "-->"); strings.HasPrefix(text, "<!--") && close >= 0 {
+				end = close + 2
+			}
+			if end < 0 {
+				end = len(text) - 1
+			}
+			pieces = append(pieces, piece{text: text[:end+1], code: true})
+			text = text[end+1:]
+		default:
+			end := strings.IndexByte(text, '<')
+			if end < 0 {
+				end = len(text)
+			}
+			pieces = append(pieces, piece{text: html.UnescapeString(text[:end])})
+			text = text[end:]
+		}
+	}
+	return pieces
+}
+
+func (xmlText) join(pieces []piece) string {
+	var b strings.Builder
+	cdata := false
+	for _, p := range pieces {
+		switch {
+		case p.code:
+			b.WriteString(p.text)
+			if p.text == "<![CDATA[" {
+				cdata = true
+			} else if p.text == "]]>" {
+				cdata = false
+			}
+		case cdata:
+			b.WriteString(p.text)
+		default:
+			b.WriteString(escapeXMLText(p.text))
+		}
+	}
+	return b.String()
+}
+
+func (xmlText) pluralCategories(rule PluralRule) []string {
+	return rule.Categories
+}
+
+func escapeXMLText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// plainXML returns the text of XML content without its markup
+func plainXML(s string) string {
+	var b strings.Builder
+	for _, p := range (xmlText{}).split(s) {
+		if !p.code {
+			b.WriteString(p.text)
+		}
+	}
+	return b.String()
+}
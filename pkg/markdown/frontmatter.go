@@ -0,0 +1,241 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// splitFrontmatter splits the YAML frontmatter off the start of a document.
+// The frontmatter runs from a "---" line at the very start through the next
+// "---" or "..." line; a "---" anywhere else is a thematic break.
+func splitFrontmatter(content string) (frontmatter, body string) {
+	first, _, ok := strings.Cut(content, "\n")
+	if !ok || strings.TrimRight(first, " \t\r") != "---" {
+		return "", content
+	}
+
+	pos := len(first) + 1
+	for pos < len(content) {
+		line, _, _ := strings.Cut(content[pos:], "\n")
+		next := pos + len(line)
+		if next < len(content) {
+			next++
+		}
+		if closing := strings.TrimRight(line, " \t\r"); closing == "---" || closing == "..." {
+			return content[:next], content[next:]
+		}
+		pos = next
+	}
+	return "", content
+}
+
+// frontmatterKey matches a top-level "key: value" line of the frontmatter
+var frontmatterKey = regexp.MustCompile(`^([A-Za-z0-9_-]+):(?:([ \t]+)(.*?))?(\r?)$`)
+
+// translateFrontmatter translates the values of the selected frontmatter
+// keys: scalars keep their quoting style, block scalars and sequences of
+// scalars are translated line by line. All other lines are kept as they are.
+func (mt *MarkdownTranslator) translateFrontmatter(frontmatter string) (string, error) {
+	keys := make(map[string]bool, len(mt.FrontmatterKeys))
+	for _, key := range mt.FrontmatterKeys {
+		keys[key] = true
+	}
+
+	lines := strings.SplitAfter(frontmatter, "\n")
+	var out strings.Builder
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		m := frontmatterKey.FindStringSubmatch(strings.TrimSuffix(line, "\n"))
+		if m == nil || !keys[m[1]] {
+			out.WriteString(line)
+			continue
+		}
+
+		// The indented lines below the key belong to its value
+		end := i + 1
+		for end < len(lines) && isNestedLine(lines[end]) {
+			end++
+		}
+		nested := lines[i+1 : end]
+
+		value := m[3]
+		switch {
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			out.WriteString(line)
+			translated, err := mt.translateBlockScalar(nested)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(translated)
+			i = end - 1
+		case value == "" || strings.HasPrefix(value, "#"):
+			out.WriteString(line)
+			translated, err := mt.translateSequence(nested)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(translated)
+			i = end - 1
+		default:
+			translated, err := mt.translateScalar(value)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(m[1] + ":" + m[2] + translated + m[4] + line[len(strings.TrimSuffix(line, "\n")):])
+		}
+	}
+	return out.String(), nil
+}
+
+// isNestedLine reports whether a frontmatter line is blank or indented
+func isNestedLine(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return line != ""
+	}
+	return line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(line, "- ")
+}
+
+// translateScalar translates a scalar value, keeping its quotes and any
+// comment after it
+func (mt *MarkdownTranslator) translateScalar(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := closingQuote(value)
+		if end < 0 {
+			return value, nil
+		}
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return value, nil
+		}
+		translated, err := mt.translateText(unquoted)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Quote(translated) + value[end+1:], nil
+
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(strings.ReplaceAll(value[1:], "''", "\x00\x00"), "'")
+		if end < 0 {
+			return value, nil
+		}
+		end++
+		translated, err := mt.translateText(strings.ReplaceAll(value[1:end], "''", "'"))
+		if err != nil {
+			return "", err
+		}
+		return "'" + strings.ReplaceAll(translated, "'", "''") + "'" + value[end+1:], nil
+
+	case strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") ||
+		strings.HasPrefix(value, "&") || strings.HasPrefix(value, "*") || strings.HasPrefix(value, "!"):
+		// Flow collections, anchors, aliases and tags are kept
+		return value, nil
+	}
+
+	plain, comment := value, ""
+	if i := strings.Index(value, " #"); i >= 0 {
+		plain, comment = value[:i], value[i:]
+	}
+	plain = strings.TrimRight(plain, " \t")
+	comment = value[len(plain):len(value)-len(comment)] + comment
+	translated, err := mt.translateText(plain)
+	if err != nil {
+		return "", err
+	}
+	return plainScalar(translated) + comment, nil
+}
+
+// closingQuote returns the index of the quote closing a double-quoted value
+func closingQuote(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// plainScalar returns s as a plain scalar, or double-quoted when it would
+// not read back as the same string
+func plainScalar(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.ContainsAny(s, "\n\r\t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// translateBlockScalar translates the body of a literal or folded block
+// scalar and indents the translation like the original
+func (mt *MarkdownTranslator) translateBlockScalar(lines []string) (string, error) {
+	var body []string
+	indent := ""
+	for _, line := range lines {
+		content := strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(content) == "" {
+			body = append(body, "")
+			continue
+		}
+		if indent == "" {
+			indent = content[:len(content)-len(strings.TrimLeft(content, " \t"))]
+		}
+		body = append(body, strings.TrimPrefix(content, indent))
+	}
+	text := strings.TrimSpace(strings.Join(body, "\n"))
+	if text == "" {
+		return strings.Join(lines, ""), nil
+	}
+
+	translated, err := mt.translateText(text)
+	if err != nil {
+		return "", err
+	}
+
+	// Blank lines that followed the body are kept after the translation
+	trailing := 0
+	for trailing < len(lines) && strings.TrimSpace(lines[len(lines)-1-trailing]) == "" {
+		trailing++
+	}
+	newline := "\n"
+	if strings.HasSuffix(lines[0], "\r\n") {
+		newline = "\r\n"
+	}
+	var out strings.Builder
+	for _, line := range strings.Split(translated, "\n") {
+		if strings.TrimSpace(line) != "" {
+			out.WriteString(indent + strings.TrimRight(line, "\r"))
+		}
+		out.WriteString(newline)
+	}
+	out.WriteString(strings.Join(lines[len(lines)-trailing:], ""))
+	return out.String(), nil
+}
+
+// translateSequence translates the scalar items of a block sequence
+func (mt *MarkdownTranslator) translateSequence(lines []string) (string, error) {
+	var out strings.Builder
+	for _, line := range lines {
+		content := strings.TrimSuffix(line, "\n")
+		cr := ""
+		if strings.HasSuffix(content, "\r") {
+			content, cr = content[:len(content)-1], "\r"
+		}
+		item := strings.TrimLeft(content, " \t")
+		if !strings.HasPrefix(item, "- ") || frontmatterKey.MatchString(item[2:]) {
+			out.WriteString(line)
+			continue
+		}
+		prefix := content[:len(content)-len(item)] + "- "
+		translated, err := mt.translateScalar(strings.TrimLeft(item[2:], " "))
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(prefix + translated + cr + line[len(strings.TrimSuffix(line, "\n")):])
+	}
+	return out.String(), nil
+}
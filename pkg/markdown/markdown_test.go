@@ -26,12 +26,12 @@ func TestMarkdownTranslation(t *testing.T) {
 		{
 			name:     "Bold text",
 			input:    "This is **bold** text.",
-			expected: "TRANSLATED: This is**TRANSLATED: bold**TRANSLATED: text.",
+			expected: "TRANSLATED: This is **bold** text.",
 		},
 		{
 			name:     "Italic text",
 			input:    "This is *italic* text.",
-			expected: "TRANSLATED: This is*TRANSLATED: italic*TRANSLATED: text.",
+			expected: "TRANSLATED: This is *italic* text.",
 		},
 		{
 			name:     "Header",
@@ -85,12 +85,22 @@ func TestInlineFormatting(t *testing.T) {
 		{
 			name:     "Mixed formatting",
 			input:    "Text with **bold** and *italic* and `code`.",
-			contains: []string{"**TR[bold]**", "*TR[italic]*", "`code`"},
+			contains: []string{"TR[Text with **bold** and *italic* and `code`.]"},
 		},
 		{
 			name:     "Link",
 			input:    "Check [this link](http://example.com).",
-			contains: []string{"[TR[this link]](http://example.com)"},
+			contains: []string{"TR[Check [this link](http://example.com).]"},
+		},
+		{
+			name:     "Image alt text and title",
+			input:    `See ![A red_map](Images/red_map.png "The map") here.`,
+			contains: []string{`TR[See ![TR[A red_map\]](Images/red_map.png "TR[The map]") here.]`},
+		},
+		{
+			name:     "Image without title",
+			input:    "![Cover](Images/cover.jpg)",
+			contains: []string{`![TR[Cover\]](Images/cover.jpg)`},
 		},
 	}
 
@@ -131,9 +141,9 @@ This is content.`
 		t.Fatalf("Translation failed: %v", err)
 	}
 
-	// Frontmatter should be preserved exactly
-	if !strings.Contains(result, "title: Test Book") {
-		t.Error("Frontmatter 'title' was not preserved")
+	// The title is translated, the other keys are preserved exactly
+	if !strings.Contains(result, `title: "TRANSLATED: Test Book"`) {
+		t.Error("Frontmatter 'title' was not translated")
 	}
 
 	if !strings.Contains(result, "authors: Author Name") {
@@ -333,15 +343,16 @@ Final paragraph.`
 		description string
 		shouldHave  string
 	}{
-		{"Frontmatter", "title: Complex Document"},
+		{"Frontmatter", "title: TR:Complex Document"},
+		{"Frontmatter language", "language: en"},
 		{"Main header", "# TR:Main Title"},
 		{"Chapter header", "## TR:Chapter 1: Introduction"},
 		{"Subsection", "### TR:Section 1.1"},
-		{"Bold formatting", "**TR:bold**"},
-		{"Italic formatting", "*TR:italic*"},
-		{"List structure", "- TR:Item 1 with**TR:bold**"},
+		{"Bold formatting", "TR:This is a paragraph with **bold text** and *italic text*."},
+		{"List structure", "- TR:Item 1 with **bold**"},
 		{"Code block untranslated", "int main() { return 0; }"},
-		{"Link structure", "[TR:link](http://example.com)"},
+		{"Link structure", "- TR:Item 3 with [link](http://example.com)"},
+		{"Blockquote", "> TR:This is a blockquote."},
 	}
 
 	for _, check := range checks {
@@ -387,7 +398,7 @@ func BenchmarkInlineFormatting(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		translator.translateInlineFormatting(input)
+		translator.TranslateMarkdown(input)
 	}
 }
 
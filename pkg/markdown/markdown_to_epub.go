@@ -49,12 +49,22 @@ func (c *MarkdownToEPUBConverter) ConvertMarkdownToEPUB(mdPath, epubPath string)
 
 	c.metadata = metadata
 
+	// The spine of an EPUB must not be empty
+	if len(chapters) == 0 {
+		chapters = []ebook.Chapter{{Title: metadata.Title}}
+	}
+
 	// Create EPUB
 	if err := c.createEPUB(chapters, epubPath); err != nil {
 		return fmt.Errorf("failed to create EPUB: %w", err)
 	}
 
-	return nil
+	// Validate EPUB
+	report, err := ebook.NewEPUBValidator().Validate(epubPath)
+	if err != nil {
+		return fmt.Errorf("failed to validate EPUB: %w", err)
+	}
+	return report.Err()
 }
 
 // parseMarkdown parses markdown content into chapters
@@ -337,7 +347,11 @@ func (c *MarkdownToEPUBConverter) writeContentOPF(zw *zip.Writer, chapters []ebo
 	if c.metadata.Publisher != "" {
 		opf.WriteString(fmt.Sprintf("    <dc:publisher>%s</dc:publisher>\n", c.escapeXML(c.metadata.Publisher)))
 	}
-	opf.WriteString(fmt.Sprintf("    <dc:language>%s</dc:language>\n", c.metadata.Language))
+	language := c.metadata.Language
+	if language == "" {
+		language = "en"
+	}
+	opf.WriteString(fmt.Sprintf("    <dc:language>%s</dc:language>\n", c.escapeXML(language)))
 	
 	// Use ISBN as identifier if available, otherwise generate UUID
 	if c.metadata.ISBN != "" {
@@ -1,22 +1,45 @@
 package markdown
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
 )
 
-// MarkdownTranslator translates markdown content while preserving formatting
+// DefaultFrontmatterKeys are the frontmatter keys translated by default
+var DefaultFrontmatterKeys = []string{"title", "subtitle", "description", "summary"}
+
+// MarkdownTranslator translates markdown content while preserving formatting.
+//
+// The document is parsed as CommonMark with the GFM extensions and
+// footnotes. Every paragraph, heading and table cell is translated as a
+// whole, with its inline markup (emphasis delimiters, code spans, link
+// destinations, footnote references, inline HTML) sent as <xN/>
+// placeholders. Only the text of those blocks and the alt text of images
+// change; every other byte of the document is kept as it was.
 type MarkdownTranslator struct {
 	translateFunc func(text string) (string, error)
+
+	// FrontmatterKeys are the top-level keys of the YAML frontmatter whose
+	// values are translated
+	FrontmatterKeys []string
 }
 
 // NewMarkdownTranslator creates a new markdown translator
 func NewMarkdownTranslator(translateFunc func(string) (string, error)) *MarkdownTranslator {
 	return &MarkdownTranslator{
-		translateFunc: translateFunc,
+		translateFunc:   translateFunc,
+		FrontmatterKeys: DefaultFrontmatterKeys,
 	}
 }
 
@@ -44,305 +67,424 @@ func (mt *MarkdownTranslator) TranslateMarkdownFile(inputPath, outputPath string
 
 // TranslateMarkdown translates markdown content while preserving formatting
 func (mt *MarkdownTranslator) TranslateMarkdown(content string) (string, error) {
-	var result strings.Builder
-	scanner := bufio.NewScanner(strings.NewReader(content))
-
-	inFrontmatter := false
-	inCodeBlock := false
-	frontmatterCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Handle frontmatter (YAML between ---)
-		if line == "---" {
-			frontmatterCount++
-			result.WriteString(line + "\n")
-			if frontmatterCount == 1 {
-				inFrontmatter = true
-			} else if frontmatterCount == 2 {
-				inFrontmatter = false
-			}
-			continue
-		}
-
-		// Handle code blocks
-		if strings.HasPrefix(line, "```") {
-			inCodeBlock = !inCodeBlock
-			result.WriteString(line + "\n")
-			continue
-		}
-
-		// Don't translate frontmatter or code blocks
-		if inFrontmatter || inCodeBlock {
-			result.WriteString(line + "\n")
-			continue
-		}
-
-		// Translate the line while preserving markdown syntax
-		translated, err := mt.translateLine(line)
+	frontmatter, body := splitFrontmatter(content)
+	if frontmatter != "" {
+		translated, err := mt.translateFrontmatter(frontmatter)
 		if err != nil {
-			return "", fmt.Errorf("failed to translate line: %w", err)
+			return "", fmt.Errorf("failed to translate frontmatter: %w", err)
 		}
-
-		result.WriteString(translated + "\n")
+		frontmatter = translated
 	}
 
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("error reading content: %w", err)
+	translated, err := mt.translateBody([]byte(body))
+	if err != nil {
+		return "", err
 	}
-
-	return result.String(), nil
+	return frontmatter + translated, nil
 }
 
-// translateLine translates a single line while preserving markdown formatting
-func (mt *MarkdownTranslator) translateLine(line string) (string, error) {
-	// Empty lines
-	if strings.TrimSpace(line) == "" {
-		return line, nil
-	}
+// markdownParser parses CommonMark with tables, strikethrough, task lists,
+// autolinks and footnotes
+var markdownParser = goldmark.New(goldmark.WithExtensions(extension.GFM, extension.Footnote)).Parser()
 
-	// Headers
-	if strings.HasPrefix(line, "#") {
-		return mt.translateHeader(line)
-	}
+// edit replaces a byte range of the source
+type edit struct {
+	start, stop int
+	text        string
+}
 
-	// Horizontal rules
-	if matched, _ := regexp.MatchString(`^[-*_]{3,}$`, strings.TrimSpace(line)); matched {
-		return line, nil
+// translateBody translates the markdown of a document without frontmatter
+func (mt *MarkdownTranslator) translateBody(source []byte) (string, error) {
+	doc := markdownParser.Parse(text.NewReader(source))
+
+	// Alt texts and titles of images are translated on their own and end
+	// up either in the markup of a translated block or as edits of their own
+	var alts []edit
+	var blocks []*textBlock
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if img, ok := n.(*ast.Image); ok {
+			alts = append(alts, imageTexts(img, source)...)
+			return ast.WalkSkipChildren, nil
+		}
+		switch n.Kind() {
+		case ast.KindParagraph, ast.KindTextBlock, ast.KindHeading, east.KindTableCell:
+			block := newTextBlock(n, source)
+			if len(block.leaves) > 0 {
+				blocks = append(blocks, block)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", err
 	}
 
-	// Lists
-	if matched, _ := regexp.MatchString(`^\s*[-*+]\s`, line); matched {
-		return mt.translateList(line)
+	for i := range alts {
+		translated, err := mt.translateText(alts[i].text)
+		if err != nil {
+			return "", err
+		}
+		// Escape the delimiter closing the alt text or title
+		closing := string(source[alts[i].stop])
+		alts[i].text = strings.ReplaceAll(translated, closing, `\`+closing)
 	}
 
-	// Numbered lists
-	if matched, _ := regexp.MatchString(`^\s*\d+\.\s`, line); matched {
-		return mt.translateNumberedList(line)
+	var edits []edit
+	used := make([]bool, len(alts))
+	for _, block := range blocks {
+		e, err := block.translate(mt, alts, used)
+		if err != nil {
+			return "", err
+		}
+		edits = append(edits, e)
 	}
-
-	// Blockquotes
-	if strings.HasPrefix(strings.TrimSpace(line), ">") {
-		return mt.translateBlockquote(line)
+	for i, alt := range alts {
+		if !used[i] {
+			edits = append(edits, alt)
+		}
 	}
 
-	// Image references (don't translate alt text for now, keep original)
-	if matched, _ := regexp.MatchString(`!\[.*?\]\(.*?\)`, line); matched {
-		return line, nil
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out strings.Builder
+	pos := 0
+	for _, e := range edits {
+		out.Write(source[pos:e.start])
+		out.WriteString(e.text)
+		pos = e.stop
 	}
-
-	// Regular paragraph with inline formatting
-	return mt.translateInlineFormatting(line)
+	out.Write(source[pos:])
+	return out.String(), nil
 }
 
-// translateHeader translates a header line
-func (mt *MarkdownTranslator) translateHeader(line string) (string, error) {
-	// Extract header level and text
-	match := regexp.MustCompile(`^(#{1,6})\s+(.+)$`).FindStringSubmatch(line)
-	if len(match) != 3 {
-		return line, nil
+// imageTexts returns the source ranges and texts of the alt text and title
+// of an image. Images referring to a definition by their alt text are left
+// alone.
+func imageTexts(img *ast.Image, source []byte) []edit {
+	var leaves []text.Segment
+	collectLeaves(img, source, &leaves)
+	if len(leaves) == 0 {
+		return nil
 	}
-
-	headerLevel := match[1]
-	headerText := match[2]
-
-	// Translate header text (preserve inline formatting)
-	translated, err := mt.translateInlineFormatting(headerText)
-	if err != nil {
-		return "", err
+	start := leaves[0].Start
+	stop := labelEnd(source, leaves[len(leaves)-1].Stop)
+	if stop < 0 || isShortcutReference(source, stop) {
+		return nil
 	}
 
-	return headerLevel + " " + translated, nil
-}
-
-// translateList translates a list item
-func (mt *MarkdownTranslator) translateList(line string) (string, error) {
-	// Extract indentation, bullet, and text
-	match := regexp.MustCompile(`^(\s*)([-*+])\s+(.+)$`).FindStringSubmatch(line)
-	if len(match) != 4 {
-		return line, nil
+	var texts []edit
+	if alt := string(source[start:stop]); strings.TrimSpace(alt) != "" {
+		texts = append(texts, edit{start: start, stop: stop, text: alt})
 	}
-
-	indent := match[1]
-	bullet := match[2]
-	text := match[3]
-
-	// Translate text
-	translated, err := mt.translateInlineFormatting(text)
-	if err != nil {
-		return "", err
+	if start, stop, ok := imageTitle(source, stop+1); ok {
+		texts = append(texts, edit{start: start, stop: stop, text: string(source[start:stop])})
 	}
-
-	return indent + bullet + " " + translated, nil
+	return texts
 }
 
-// translateNumberedList translates a numbered list item
-func (mt *MarkdownTranslator) translateNumberedList(line string) (string, error) {
-	// Extract indentation, number, and text
-	match := regexp.MustCompile(`^(\s*)(\d+)\.\s+(.+)$`).FindStringSubmatch(line)
-	if len(match) != 4 {
-		return line, nil
+// imageTitle returns the range of the title of the inline link destination
+// starting at pos, between its quotes or parentheses
+func imageTitle(source []byte, pos int) (start, stop int, ok bool) {
+	if pos >= len(source) || source[pos] != '(' {
+		return 0, 0, false
 	}
+	i := skipSpace(source, pos+1)
 
-	indent := match[1]
-	number := match[2]
-	text := match[3]
-
-	// Translate text
-	translated, err := mt.translateInlineFormatting(text)
-	if err != nil {
-		return "", err
+	// The destination is either in angle brackets or runs to whitespace
+	// outside balanced parentheses
+	if i < len(source) && source[i] == '<' {
+		for i < len(source) && source[i] != '>' {
+			if source[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		i++
+	} else {
+		depth := 0
+	destination:
+		for ; i < len(source); i++ {
+			switch c := source[i]; {
+			case c == '\\':
+				i++
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					break destination
+				}
+				depth--
+			case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+				break destination
+			}
+		}
 	}
 
-	return indent + number + ". " + translated, nil
+	i = skipSpace(source, i)
+	if i >= len(source) {
+		return 0, 0, false
+	}
+	closing := map[byte]byte{'"': '"', '\'': '\'', '(': ')'}[source[i]]
+	if closing == 0 {
+		return 0, 0, false
+	}
+	start = i + 1
+	for i = start; i < len(source) && source[i] != closing; i++ {
+		if source[i] == '\\' {
+			i++
+		}
+	}
+	if i >= len(source) || strings.TrimSpace(string(source[start:i])) == "" {
+		return 0, 0, false
+	}
+	return start, i, true
 }
 
-// translateBlockquote translates a blockquote
-func (mt *MarkdownTranslator) translateBlockquote(line string) (string, error) {
-	// Extract quote marker and text
-	match := regexp.MustCompile(`^(\s*>+)\s*(.*)$`).FindStringSubmatch(line)
-	if len(match) != 3 {
-		return line, nil
+// skipSpace returns the position of the first non-whitespace byte at or
+// after pos
+func skipSpace(source []byte, pos int) int {
+	for pos < len(source) && (source[pos] == ' ' || source[pos] == '\t' || source[pos] == '\n' || source[pos] == '\r') {
+		pos++
 	}
+	return pos
+}
 
-	quoteMarker := match[1]
-	text := match[2]
-
-	if text == "" {
-		return line, nil
+// collectLeaves collects the text segments of inline nodes that are
+// translated: text outside code spans, images, autolinks, inline HTML and
+// links whose label is their reference
+func collectLeaves(n ast.Node, source []byte, leaves *[]text.Segment) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch node := c.(type) {
+		case *ast.Text:
+			if node.Segment.Len() > 0 {
+				*leaves = append(*leaves, node.Segment)
+			}
+		case *ast.CodeSpan, *ast.Image, *ast.AutoLink, *ast.RawHTML:
+			// Kept as markup
+		case *ast.Link:
+			var label []text.Segment
+			collectLeaves(node, source, &label)
+			if len(label) > 0 {
+				if end := labelEnd(source, label[len(label)-1].Stop); end >= 0 && !isShortcutReference(source, end) {
+					*leaves = append(*leaves, label...)
+				}
+			}
+		default:
+			collectLeaves(c, source, leaves)
+		}
 	}
+}
 
-	// Translate text
-	translated, err := mt.translateInlineFormatting(text)
-	if err != nil {
-		return "", err
+// labelEnd returns the position of the ] closing a link label at or after
+// pos, or -1
+func labelEnd(source []byte, pos int) int {
+	for i := pos; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+		case ']':
+			return i
+		}
 	}
+	return -1
+}
 
-	return quoteMarker + " " + translated, nil
+// isShortcutReference reports whether the label closed at end is also the
+// reference of its link, as in [label] and [label][]
+func isShortcutReference(source []byte, end int) bool {
+	rest := source[end+1:]
+	return !bytes.HasPrefix(rest, []byte("(")) && (!bytes.HasPrefix(rest, []byte("[")) || bytes.HasPrefix(rest, []byte("[]")))
 }
 
-// translateInlineFormatting translates text while preserving inline markdown formatting
-func (mt *MarkdownTranslator) translateInlineFormatting(text string) (string, error) {
-	// Pattern to match markdown inline formatting
-	// Matches: **bold**, *italic*, `code`, [link](url), etc.
-	pattern := regexp.MustCompile(`(\*\*.*?\*\*|\*.*?\*|__.*?__|_.*?_|` + "`" + `.*?` + "`" + `|\[.*?\]\(.*?\))`)
+// textBlock is a paragraph, heading or table cell whose text is translated
+type textBlock struct {
+	source     []byte
+	leaves     []text.Segment
+	separators [][2]int // line ends through the start of the next line
+	hardBreaks []int    // positions of hard line breaks
+	tableCell  bool
+}
 
-	// Find all formatted segments
-	segments := pattern.FindAllStringIndex(text, -1)
+func newTextBlock(n ast.Node, source []byte) *textBlock {
+	b := &textBlock{source: source, tableCell: n.Kind() == east.KindTableCell}
+	collectLeaves(n, source, &b.leaves)
 
-	if len(segments) == 0 {
-		// No formatting, translate entire text
-		return mt.translateText(text)
+	lines := n.Lines()
+	for i := 0; i+1 < lines.Len(); i++ {
+		end := lines.At(i).Stop
+		for end > lines.At(i).Start && (source[end-1] == '\n' || source[end-1] == '\r') {
+			end--
+		}
+		b.separators = append(b.separators, [2]int{end, lines.At(i + 1).Start})
 	}
 
-	// Build result preserving formatting
-	var result strings.Builder
-	lastEnd := 0
-
-	for _, seg := range segments {
-		start, end := seg[0], seg[1]
+	ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if t, ok := c.(*ast.Text); ok && entering && t.HardLineBreak() {
+			b.hardBreaks = append(b.hardBreaks, t.Segment.Stop)
+		}
+		return ast.WalkContinue, nil
+	})
+	return b
+}
 
-		// Translate text before formatted segment
-		if start > lastEnd {
-			plainText := text[lastEnd:start]
-			if strings.TrimSpace(plainText) != "" {
-				translated, err := mt.translateText(plainText)
-				if err != nil {
-					return "", err
-				}
-				result.WriteString(translated)
+// blockPlaceholder matches the placeholders of a translated block
+var blockPlaceholder = regexp.MustCompile(`<x(\d+)/>`)
+
+// translate translates the block and returns the edit replacing its text.
+// The block is translated as a whole with its markup as placeholders; when
+// the translation loses them, the text between markup is translated piece
+// by piece.
+func (b *textBlock) translate(mt *MarkdownTranslator, alts []edit, used []bool) (edit, error) {
+	start, stop := b.leaves[0].Start, b.leaves[len(b.leaves)-1].Stop
+
+	// The block alternates text runs and markup; runs[i] precedes gaps[i]
+	var runs []string
+	var gaps []string
+	var run strings.Builder
+	for i, leaf := range b.leaves {
+		if i > 0 {
+			lead, markup, trail := b.gap(b.leaves[i-1].Stop, leaf.Start, alts, used)
+			run.WriteString(lead)
+			if markup != "" {
+				runs = append(runs, run.String())
+				gaps = append(gaps, markup)
+				run.Reset()
 			}
+			run.WriteString(trail)
 		}
+		run.Write(leaf.Value(b.source))
+	}
+	runs = append(runs, run.String())
 
-		// Handle formatted segment
-		formatted := text[start:end]
-		translatedFormatted, err := mt.translateFormattedSegment(formatted)
-		if err != nil {
-			return "", err
+	var whole strings.Builder
+	for i, r := range runs {
+		whole.WriteString(r)
+		if i < len(gaps) {
+			fmt.Fprintf(&whole, "<x%d/>", i+1)
 		}
-		result.WriteString(translatedFormatted)
+	}
 
-		lastEnd = end
+	translated, err := mt.translateText(whole.String())
+	if err != nil {
+		return edit{}, err
+	}
+	if result, ok := b.rebuild(translated, gaps); ok {
+		return edit{start: start, stop: stop, text: result}, nil
 	}
 
-	// Translate remaining text
-	if lastEnd < len(text) {
-		plainText := text[lastEnd:]
-		if strings.TrimSpace(plainText) != "" {
-			translated, err := mt.translateText(plainText)
+	// The placeholders were lost: translate the runs one by one
+	var out strings.Builder
+	for i, r := range runs {
+		lead, core, trail := splitWhitespace(r)
+		out.WriteString(lead)
+		if core != "" {
+			translated, err := mt.translateText(core)
 			if err != nil {
-				return "", err
+				return edit{}, err
 			}
-			result.WriteString(translated)
+			out.WriteString(b.clean(translated))
+		}
+		out.WriteString(trail)
+		if i < len(gaps) {
+			out.WriteString(gaps[i])
 		}
 	}
-
-	return result.String(), nil
+	return edit{start: start, stop: stop, text: out.String()}, nil
 }
 
-// translateFormattedSegment translates a formatted markdown segment
-func (mt *MarkdownTranslator) translateFormattedSegment(segment string) (string, error) {
-	// Bold: **text** or __text__
-	if strings.HasPrefix(segment, "**") && strings.HasSuffix(segment, "**") {
-		inner := segment[2 : len(segment)-2]
-		translated, err := mt.translateText(inner)
-		if err != nil {
-			return "", err
+// gap returns the source between two text segments of the block: the markup
+// with the whitespace around it. Soft line breaks become spaces; markup
+// holding a hard line break is kept with its whitespace.
+func (b *textBlock) gap(start, stop int, alts []edit, used []bool) (lead, markup, trail string) {
+	hard := false
+	for _, pos := range b.hardBreaks {
+		if pos >= start && pos <= stop {
+			hard = true
 		}
-		return "**" + translated + "**", nil
 	}
 
-	if strings.HasPrefix(segment, "__") && strings.HasSuffix(segment, "__") {
-		inner := segment[2 : len(segment)-2]
-		translated, err := mt.translateText(inner)
-		if err != nil {
-			return "", err
+	var sb strings.Builder
+	pos := start
+	for pos < stop {
+		next := stop
+		var replacement string
+		// The nearest separator or alt text inside the gap
+		for _, sep := range b.separators {
+			if sep[0] >= pos && sep[1] <= stop && sep[0] < next {
+				next, replacement = sep[0], " "
+				if hard {
+					replacement = string(b.source[sep[0]:sep[1]])
+				}
+			}
 		}
-		return "__" + translated + "__", nil
-	}
-
-	// Italic: *text* or _text_
-	if strings.HasPrefix(segment, "*") && strings.HasSuffix(segment, "*") && !strings.HasPrefix(segment, "**") {
-		inner := segment[1 : len(segment)-1]
-		translated, err := mt.translateText(inner)
-		if err != nil {
-			return "", err
+		alt := -1
+		for i, a := range alts {
+			if a.start >= pos && a.stop <= stop && a.start < next {
+				next, alt = a.start, i
+			}
 		}
-		return "*" + translated + "*", nil
-	}
-
-	if strings.HasPrefix(segment, "_") && strings.HasSuffix(segment, "_") && !strings.HasPrefix(segment, "__") {
-		inner := segment[1 : len(segment)-1]
-		translated, err := mt.translateText(inner)
-		if err != nil {
-			return "", err
+		sb.Write(b.source[pos:next])
+		if next == stop {
+			break
+		}
+		if alt >= 0 {
+			used[alt] = true
+			sb.WriteString(alts[alt].text)
+			pos = alts[alt].stop
+			continue
+		}
+		sb.WriteString(replacement)
+		for _, sep := range b.separators {
+			if sep[0] == next {
+				pos = sep[1]
+			}
 		}
-		return "_" + translated + "_", nil
 	}
 
-	// Code: `text`
-	if strings.HasPrefix(segment, "`") && strings.HasSuffix(segment, "`") {
-		// Don't translate code
-		return segment, nil
+	gap := sb.String()
+	if hard {
+		return "", gap, ""
 	}
+	lead, markup, trail = splitWhitespace(gap)
+	if markup == "" {
+		return collapse(lead + trail), "", ""
+	}
+	return collapse(lead), markup, collapse(trail)
+}
 
-	// Links: [text](url)
-	linkPattern := regexp.MustCompile(`^\[(.*?)\]\((.*?)\)$`)
-	if match := linkPattern.FindStringSubmatch(segment); len(match) == 3 {
-		linkText := match[1]
-		linkURL := match[2]
-
-		// Translate link text only
-		translated, err := mt.translateText(linkText)
-		if err != nil {
-			return "", err
+// rebuild puts the markup back into a translated block. It fails unless
+// every placeholder comes back once and in order.
+func (b *textBlock) rebuild(translated string, gaps []string) (string, bool) {
+	var out strings.Builder
+	pos := 0
+	next := 1
+	for _, m := range blockPlaceholder.FindAllStringSubmatchIndex(translated, -1) {
+		id, _ := strconv.Atoi(translated[m[2]:m[3]])
+		if id != next {
+			return "", false
 		}
-		return "[" + translated + "](" + linkURL + ")", nil
+		out.WriteString(b.clean(translated[pos:m[0]]))
+		out.WriteString(gaps[id-1])
+		pos = m[1]
+		next++
+	}
+	if next != len(gaps)+1 {
+		return "", false
 	}
+	out.WriteString(b.clean(translated[pos:]))
+	return out.String(), true
+}
 
-	// Unknown format, translate as-is
-	return mt.translateText(segment)
+// clean keeps translated text inside its block: line breaks become spaces,
+// and pipes are escaped in table cells
+func (b *textBlock) clean(s string) string {
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+	if b.tableCell {
+		s = regexp.MustCompile(`(^|[^\\])\|`).ReplaceAllString(s, `$1\|`)
+	}
+	return s
 }
 
 // translateText translates plain text using the provided translation function
@@ -357,5 +499,21 @@ func (mt *MarkdownTranslator) translateText(text string) (string, error) {
 		return "", fmt.Errorf("translation error: %w", err)
 	}
 
-	return translated, nil
+	return strings.TrimSpace(translated), nil
+}
+
+// splitWhitespace splits leading and trailing whitespace off s
+func splitWhitespace(s string) (lead, core, trail string) {
+	core = strings.TrimLeft(s, " \t\r\n")
+	lead = s[:len(s)-len(core)]
+	trimmed := strings.TrimRight(core, " \t\r\n")
+	return lead, trimmed, core[len(trimmed):]
+}
+
+// collapse collapses whitespace to a single space
+func collapse(s string) string {
+	if s == "" {
+		return ""
+	}
+	return " "
 }
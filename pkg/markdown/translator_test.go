@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// recordingTranslator prefixes every text with TR: and records the texts
+func recordingTranslator() (*MarkdownTranslator, *[]string) {
+	var texts []string
+	return NewMarkdownTranslator(func(text string) (string, error) {
+		texts = append(texts, text)
+		return "TR:" + text, nil
+	}), &texts
+}
+
+func TestTranslateMarkdownBatchesParagraphs(t *testing.T) {
+	translator, texts := recordingTranslator()
+
+	input := "First line of a paragraph\nwith **bold** and `code`\ncontinued here.\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	if len(*texts) != 1 {
+		t.Fatalf("Expected one call for the paragraph, got %d: %q", len(*texts), *texts)
+	}
+	want := "First line of a paragraph with <x1/>bold<x2/> and <x3/> continued here."
+	if (*texts)[0] != want {
+		t.Errorf("Sent %q, want %q", (*texts)[0], want)
+	}
+	if result != "TR:First line of a paragraph with **bold** and `code` continued here.\n" {
+		t.Errorf("Unexpected result %q", result)
+	}
+}
+
+func TestTranslateMarkdownLineBreaks(t *testing.T) {
+	translator, texts := recordingTranslator()
+
+	input := "> Quoted first line\n> quoted second line  \n> after a hard break\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	want := "Quoted first line quoted second line<x1/>after a hard break"
+	if len(*texts) != 1 || (*texts)[0] != want {
+		t.Errorf("Sent %q, want %q", *texts, want)
+	}
+	if result != "> TR:Quoted first line quoted second line  \n> after a hard break\n" {
+		t.Errorf("Unexpected result %q", result)
+	}
+}
+
+func TestTranslateMarkdownLinksAndFootnotes(t *testing.T) {
+	translator, _ := recordingTranslator()
+
+	input := "See [the guide][guide], [shortcut] and <https://example.com>.[^1]\n\n" +
+		"[guide]: https://example.com/guide \"Guide\"\n" +
+		"[shortcut]: https://example.com/shortcut\n\n" +
+		"[^1]: A footnote.\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	want := "TR:See [the guide][guide], [shortcut] and <https://example.com>.[^1]\n\n" +
+		"[guide]: https://example.com/guide \"Guide\"\n" +
+		"[shortcut]: https://example.com/shortcut\n\n" +
+		"[^1]: TR:A footnote.\n"
+	if result != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, result)
+	}
+}
+
+func TestTranslateMarkdownTablesAndHTML(t *testing.T) {
+	translator := NewMarkdownTranslator(func(text string) (string, error) {
+		return "a|" + text, nil
+	})
+
+	input := "| Name | Value |\n|------|------:|\n| First | `1` |\n\n<div class=\"note\">\nKept as it is.\n</div>\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	want := "| a\\|Name | a\\|Value |\n|------|------:|\n| a\\|First | `1` |\n\n<div class=\"note\">\nKept as it is.\n</div>\n"
+	if result != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, result)
+	}
+}
+
+func TestTranslateMarkdownThematicBreak(t *testing.T) {
+	translator, _ := recordingTranslator()
+
+	input := "First part.\n\n---\n\nSecond part.\n\n---\n\nThird part.\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	want := "TR:First part.\n\n---\n\nTR:Second part.\n\n---\n\nTR:Third part.\n"
+	if result != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, result)
+	}
+}
+
+func TestTranslateMarkdownKeepsUntouchedBytes(t *testing.T) {
+	translator := NewMarkdownTranslator(func(text string) (string, error) {
+		return text, nil
+	})
+
+	input := "Setext *title*\n==============\n\n" +
+		"1)  Odd   spacing\n   * nested __item__\n\n" +
+		"~~~go\nfunc main() {}\n~~~\n\n" +
+		"    indented code\n\n" +
+		"- [x] done \\*escaped\\* &amp; entity\n"
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+	if result != input {
+		t.Errorf("Identity translation changed the document:\n%s", result)
+	}
+}
+
+func TestTranslateMarkdownLostPlaceholders(t *testing.T) {
+	placeholder := regexp.MustCompile(`<x\d+/>`)
+	translator := NewMarkdownTranslator(func(text string) (string, error) {
+		return "TR:" + placeholder.ReplaceAllString(text, ""), nil
+	})
+
+	result, err := translator.TranslateMarkdown("Some **bold** words here.\n")
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+	if result != "TR:Some **TR:bold** TR:words here.\n" {
+		t.Errorf("Unexpected result %q", result)
+	}
+}
+
+func TestTranslateMarkdownFrontmatter(t *testing.T) {
+	translator, _ := recordingTranslator()
+	translator.FrontmatterKeys = []string{"title", "description", "tags", "summary", "subtitle"}
+
+	input := `---
+title: "A \"quoted\" title"
+subtitle: 'It''s here' # kept comment
+author: Someone
+description: |
+  First line.
+  Second line.
+tags:
+  - one
+  - two
+summary: Plain
+---
+Body.
+`
+	result, err := translator.TranslateMarkdown(input)
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+
+	want := `---
+title: "TR:A \"quoted\" title"
+subtitle: 'TR:It''s here' # kept comment
+author: Someone
+description: |
+  TR:First line.
+  Second line.
+tags:
+  - TR:one
+  - TR:two
+summary: TR:Plain
+---
+TR:Body.
+`
+	if result != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, result)
+	}
+}
+
+func TestTranslateMarkdownFrontmatterQuoting(t *testing.T) {
+	translator := NewMarkdownTranslator(func(text string) (string, error) {
+		return "Title: " + text, nil
+	})
+
+	result, err := translator.TranslateMarkdown("---\ntitle: Book\n...\n")
+	if err != nil {
+		t.Fatalf("Translation failed: %v", err)
+	}
+	if !strings.Contains(result, `title: "Title: Book"`) {
+		t.Errorf("Plain value needing quotes was not quoted: %q", result)
+	}
+}
@@ -0,0 +1,159 @@
+// Package metrics exports translation, cache, queue and worker metrics in the
+// Prometheus and OpenMetrics text formats.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/pkg/security"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every metric name
+const Namespace = "translator"
+
+// Cache names used by CacheLookup
+const (
+	// CacheTranslation is the shared translation cache of the server
+	CacheTranslation = "translation"
+
+	// CacheMemory is the translation memory kept by each translator
+	CacheMemory = "memory"
+)
+
+// Labels identify the translator a measurement belongs to
+type Labels struct {
+	Provider     string
+	Model        string
+	LanguagePair string // e.g. "ru-sr"
+}
+
+// values returns the label values in the order of translatorLabels
+func (l Labels) values() []string {
+	return []string{l.Provider, l.Model, l.LanguagePair}
+}
+
+var translatorLabels = []string{"provider", "model", "language_pair"}
+
+// Metrics holds the collectors of a server in their own registry
+type Metrics struct {
+	registry *prometheus.Registry
+
+	segments     *prometheus.CounterVec
+	requests     *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	tokens       *prometheus.CounterVec
+	cacheLookups *prometheus.CounterVec
+}
+
+// New creates the metrics along with Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		segments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "segments_translated_total",
+			Help:      "Segments translated successfully, including cache hits.",
+		}, translatorLabels),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "provider_requests_total",
+			Help:      "Translation requests sent to providers by result (success or error).",
+		}, append(append([]string(nil), translatorLabels...), "result")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "provider_request_duration_seconds",
+			Help:      "Duration of translation requests sent to providers.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		}, translatorLabels),
+		tokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "tokens_total",
+			Help:      "Tokens consumed by providers by type (prompt or completion).",
+		}, append(append([]string(nil), translatorLabels...), "type")),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Lookups in the translation cache and translation memory by result (hit or miss).",
+		}, []string{"cache", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.segments, m.requests, m.latency, m.tokens, m.cacheLookups,
+	)
+
+	return m
+}
+
+// Handler serves the metrics, in OpenMetrics format when the scraper asks for it
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+// Register adds a collector, e.g. one reporting the state of another component
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	return m.registry.Register(collector)
+}
+
+// RegisterCache reports the number of entries in the shared translation cache
+func (m *Metrics) RegisterCache(c *cache.Cache) error {
+	return m.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "cache_entries",
+		Help:      "Entries in the translation cache, including expired ones not yet removed.",
+	}, func() float64 {
+		return float64(c.Size())
+	}))
+}
+
+// RegisterRateLimiter reports the number of clients tracked by the rate limiter
+func (m *Metrics) RegisterRateLimiter(rl *security.RateLimiter) error {
+	return m.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "rate_limiter_clients",
+		Help:      "Clients currently tracked by the request rate limiter.",
+	}, func() float64 {
+		count, _ := rl.GetStats()["total_limiters"].(int)
+		return float64(count)
+	}))
+}
+
+// CacheLookup counts a lookup in one of the caches
+func (m *Metrics) CacheLookup(cacheName string, hit bool) {
+	if m == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(cacheName, result).Inc()
+}
+
+// observeRequest records a provider request
+func (m *Metrics) observeRequest(labels Labels, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.requests.WithLabelValues(append(labels.values(), result)...).Inc()
+	m.latency.WithLabelValues(labels.values()...).Observe(duration.Seconds())
+}
+
+// addTokens records the token usage of a provider request
+func (m *Metrics) addTokens(labels Labels, promptTokens, completionTokens int) {
+	if promptTokens > 0 {
+		m.tokens.WithLabelValues(append(labels.values(), "prompt")...).Add(float64(promptTokens))
+	}
+	if completionTokens > 0 {
+		m.tokens.WithLabelValues(append(labels.values(), "completion")...).Add(float64(completionTokens))
+	}
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/translator"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTranslator answers repeated texts from its memory and reports token
+// usage. Texts it has to translate wait for release, if set, after signalling
+// started.
+type fakeTranslator struct {
+	memory  map[string]string
+	stats   translator.TranslationStats
+	err     error
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *fakeTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	if translated, ok := f.memory[text]; ok {
+		f.stats.Cached++
+		translator.RecordCacheHit(ctx)
+		return translated, nil
+	}
+	if f.err != nil {
+		return "", f.err
+	}
+	if f.release != nil {
+		f.started <- struct{}{}
+		<-f.release
+	}
+
+	translator.RecordUsage(ctx, 10, 4)
+	f.memory[text] = "sr:" + text
+	return f.memory[text], nil
+}
+
+func (f *fakeTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return f.Translate(ctx, text, contextHint)
+}
+
+func (f *fakeTranslator) GetStats() translator.TranslationStats { return f.stats }
+
+func (f *fakeTranslator) GetName() string { return "fake" }
+
+func TestInstrumentTranslator(t *testing.T) {
+	m := New()
+	labels := Labels{Provider: "openai", Model: "gpt-4", LanguagePair: "ru-sr"}
+	inner := &fakeTranslator{memory: map[string]string{}}
+	trans := m.InstrumentTranslator(inner, labels)
+
+	ctx := context.Background()
+	_, err := trans.Translate(ctx, "привет", "")
+	require.NoError(t, err)
+	_, err = trans.TranslateWithProgress(ctx, "привет", "", nil, "s1")
+	require.NoError(t, err)
+
+	inner.err = errors.New("provider down")
+	_, err = trans.Translate(ctx, "мир", "")
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.segments.WithLabelValues("openai", "gpt-4", "ru-sr")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("openai", "gpt-4", "ru-sr", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("openai", "gpt-4", "ru-sr", "error")))
+	assert.Equal(t, 10.0, testutil.ToFloat64(m.tokens.WithLabelValues("openai", "gpt-4", "ru-sr", "prompt")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(m.tokens.WithLabelValues("openai", "gpt-4", "ru-sr", "completion")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues(CacheMemory, "hit")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues(CacheMemory, "miss")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.latency))
+}
+
+func TestInstrumentTranslatorConcurrentCalls(t *testing.T) {
+	m := New()
+	inner := &fakeTranslator{
+		memory:  map[string]string{"привет": "zdravo"},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	trans := m.InstrumentTranslator(inner, Labels{Provider: "openai"})
+
+	// A memory hit completing while another call waits for the provider does
+	// not turn that call into a hit
+	done := make(chan error)
+	go func() {
+		_, err := trans.Translate(context.Background(), "мир", "")
+		done <- err
+	}()
+	<-inner.started
+
+	_, err := trans.Translate(context.Background(), "привет", "")
+	require.NoError(t, err)
+	close(inner.release)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues(CacheMemory, "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues(CacheMemory, "miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("openai", "", "", "success")))
+}
+
+func TestInstrumentTranslatorWithoutMetrics(t *testing.T) {
+	var m *Metrics
+	inner := &fakeTranslator{memory: map[string]string{}}
+
+	assert.Same(t, translator.Translator(inner), m.InstrumentTranslator(inner, Labels{}))
+	m.CacheLookup(CacheTranslation, true)
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	c := cache.NewCache(time.Minute, true)
+	c.Set("key", "value")
+	require.NoError(t, m.RegisterCache(c))
+	m.CacheLookup(CacheTranslation, true)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "translator_cache_entries 1")
+	assert.Contains(t, w.Body.String(), `translator_cache_lookups_total{cache="translation",result="hit"} 1`)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+
+	// OpenMetrics is negotiated by the Accept header
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	w = httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, req)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, w.Body.String(), "# EOF")
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/translator"
+)
+
+// instrumentedTranslator measures the calls of a translator
+type instrumentedTranslator struct {
+	translator.Translator
+	metrics *Metrics
+	labels  Labels
+}
+
+// InstrumentTranslator wraps a translator to count its segments, provider
+// requests, latency, token usage and translation memory hits. It returns trans
+// unchanged when m is nil.
+func (m *Metrics) InstrumentTranslator(trans translator.Translator, labels Labels) translator.Translator {
+	if m == nil {
+		return trans
+	}
+	return &instrumentedTranslator{Translator: trans, metrics: m, labels: labels}
+}
+
+// Translate translates text and records the call
+func (t *instrumentedTranslator) Translate(ctx context.Context, text string, contextHint string) (string, error) {
+	return t.observe(ctx, func(ctx context.Context) (string, error) {
+		return t.Translator.Translate(ctx, text, contextHint)
+	})
+}
+
+// TranslateWithProgress translates text with progress events and records the call
+func (t *instrumentedTranslator) TranslateWithProgress(ctx context.Context, text string, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return t.observe(ctx, func(ctx context.Context) (string, error) {
+		return t.Translator.TranslateWithProgress(ctx, text, contextHint, eventBus, sessionID)
+	})
+}
+
+// observe runs a translation call. Answers from the translator's memory, which
+// the translator reports through the call's context, are counted as cache hits
+// rather than provider requests.
+func (t *instrumentedTranslator) observe(ctx context.Context, call func(ctx context.Context) (string, error)) (string, error) {
+	ctx = translator.WithUsageRecorder(ctx, func(promptTokens, completionTokens int) {
+		t.metrics.addTokens(t.labels, promptTokens, completionTokens)
+	})
+	var cached atomic.Bool
+	ctx = translator.WithCacheHitRecorder(ctx, func() {
+		cached.Store(true)
+	})
+
+	start := time.Now()
+	result, err := call(ctx)
+	duration := time.Since(start)
+
+	hit := err == nil && cached.Load()
+	t.metrics.CacheLookup(CacheMemory, hit)
+	if !hit {
+		t.metrics.observeRequest(t.labels, duration, err)
+	}
+	if err == nil {
+		t.metrics.segments.WithLabelValues(t.labels.values()...).Inc()
+	}
+
+	return result, err
+}
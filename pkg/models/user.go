@@ -15,12 +15,17 @@ type User struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	IsActive  bool      `json:"is_active"`
+
+	// OIDCIssuer and OIDCSubject identify the single sign-on identity linked to this account
+	OIDCIssuer  string `json:"oidc_issuer,omitempty"`
+	OIDCSubject string `json:"oidc_subject,omitempty"`
 }
 
 // UserRepository defines user storage interface
 type UserRepository interface {
 	FindByUsername(username string) (*User, error)
 	FindByEmail(email string) (*User, error)
+	FindByOIDCSubject(issuer, subject string) (*User, error)
 	Create(user *User) error
 	Update(user *User) error
 	Delete(id string) error
@@ -57,6 +62,19 @@ func (r *InMemoryUserRepository) FindByEmail(email string) (*User, error) {
 	return nil, ErrUserNotFound
 }
 
+// FindByOIDCSubject finds the user linked to an identity provider subject
+func (r *InMemoryUserRepository) FindByOIDCSubject(issuer, subject string) (*User, error) {
+	if issuer == "" || subject == "" {
+		return nil, ErrUserNotFound
+	}
+	for _, user := range r.users {
+		if user.OIDCIssuer == issuer && user.OIDCSubject == subject {
+			return user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
 // Create creates a new user
 func (r *InMemoryUserRepository) Create(user *User) error {
 	// Hash password before storing
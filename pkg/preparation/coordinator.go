@@ -3,6 +3,7 @@ package preparation
 import (
 	"context"
 	"digital.vasic.translator/pkg/ebook"
+	"digital.vasic.translator/pkg/tracing"
 	"digital.vasic.translator/pkg/translator"
 	"digital.vasic.translator/pkg/translator/llm"
 	"encoding/json"
@@ -11,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // PreparationCoordinator orchestrates multi-pass content analysis
@@ -60,6 +63,13 @@ func NewPreparationCoordinator(config PreparationConfig) (*PreparationCoordinato
 func (pc *PreparationCoordinator) PrepareBook(ctx context.Context, book *ebook.Book) (*PreparationResult, error) {
 	startTime := time.Now()
 
+	ctx, span := tracing.Start(ctx, "preparation.PrepareBook",
+		attribute.Int("preparation.pass_count", pc.config.PassCount),
+		attribute.String("translation.source_language", pc.config.SourceLanguage),
+		attribute.String("translation.target_language", pc.config.TargetLanguage),
+	)
+	defer span.End()
+
 	log.Printf("🔍 Starting preparation phase: %d passes with %d providers", pc.config.PassCount, len(pc.providers))
 	log.Printf("   Analysis scope: content_type=%t, characters=%t, terminology=%t, culture=%t, chapters=%t",
 		pc.config.AnalyzeContentType, pc.config.AnalyzeCharacters, pc.config.AnalyzeTerminology,
@@ -87,7 +97,12 @@ func (pc *PreparationCoordinator) PrepareBook(ctx context.Context, book *ebook.B
 			provider.GetName())
 
 		// Perform analysis pass
-		pass, err := pc.performPass(ctx, passNum, provider, bookContent, previousAnalysis)
+		passCtx, passSpan := tracing.Start(ctx, "preparation.pass",
+			attribute.Int("preparation.pass", passNum),
+			attribute.String("translator.name", provider.GetName()),
+		)
+		pass, err := pc.performPass(passCtx, passNum, provider, bookContent, previousAnalysis)
+		tracing.End(passSpan, err)
 		if err != nil {
 			log.Printf("  ❌ Pass %d failed: %v", passNum, err)
 			continue
@@ -132,6 +147,10 @@ func (pc *PreparationCoordinator) PrepareBook(ctx context.Context, book *ebook.B
 
 	result.CompletedAt = time.Now()
 	result.TotalDuration = result.CompletedAt.Sub(startTime)
+	span.SetAttributes(
+		attribute.Int("preparation.passes_completed", len(result.Passes)),
+		attribute.Int("preparation.total_tokens", result.TotalTokens),
+	)
 
 	log.Printf("✅ Preparation complete: %d passes in %.2fs", len(result.Passes), result.TotalDuration.Seconds())
 	log.Printf("   Final analysis: %s (%s) - %d untranslatable terms, %d footnotes, %d characters, %d cultural refs",
@@ -0,0 +1,333 @@
+package script
+
+// schemes holds the registered transliteration schemes by language and standard
+var schemes = make(map[string]*Scheme)
+
+func schemeKey(language string, standard Standard) string {
+	return language + "/" + string(standard)
+}
+
+func register(s *Scheme) {
+	schemes[schemeKey(s.Language, s.Standard)] = s
+}
+
+// alias registers the rules of an existing scheme under another standard that
+// adopted them
+func alias(language string, from, to Standard, name string) {
+	s := *schemes[schemeKey(language, from)]
+	s.Standard = to
+	s.Name = name
+	register(&s)
+}
+
+// letters builds context-free rules from pairs of source and replacement
+func letters(pairs ...string) []rule {
+	rules := make([]rule, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		rules = append(rules, rule{from: pairs[i], to: pairs[i+1]})
+	}
+	return rules
+}
+
+// concat joins rule tables; earlier tables take precedence for equal lengths
+func concat(tables ...[]rule) []rule {
+	var rules []rule
+	for _, table := range tables {
+		rules = append(rules, table...)
+	}
+	return rules
+}
+
+// cyrillicCommon are the letters most Cyrillic alphabets share and most
+// standards transliterate alike
+var cyrillicCommon = letters(
+	"а", "a", "б", "b", "в", "v", "д", "d", "з", "z", "к", "k", "л", "l", "м", "m",
+	"н", "n", "о", "o", "п", "p", "р", "r", "с", "s", "т", "t", "у", "u", "ф", "f",
+)
+
+// iso9Cyrillic is the ISO 9:1995 table for every Cyrillic letter of the
+// registered languages; each letter has its own Latin counterpart
+var iso9Cyrillic = letters(
+	"г", "g", "ґ", "g̀", "ѓ", "ǵ", "ђ", "đ", "е", "e", "ё", "ë", "є", "ê", "ж", "ž",
+	"ѕ", "ẑ", "и", "i", "і", "ì", "ї", "ï", "й", "j", "ј", "ǰ", "љ", "l̂", "њ", "n̂",
+	"ћ", "ć", "ќ", "ḱ", "ө", "ô", "ў", "ŭ", "ү", "ù", "х", "h", "ц", "c", "ч", "č",
+	"џ", "d̂", "ш", "š", "щ", "ŝ", "ъ", "ʺ", "ы", "y", "ь", "ʹ", "э", "è", "ю", "û",
+	"я", "â",
+)
+
+func init() {
+	for _, lang := range []string{"ru", "uk", "be", "bg", "mk", "sr", "mn"} {
+		register(&Scheme{
+			Language:   lang,
+			Standard:   ISO9,
+			Name:       "ISO 9:1995",
+			Script:     "Cyrillic",
+			Reversible: true,
+			rules:      concat(cyrillicCommon, iso9Cyrillic),
+		})
+	}
+
+	registerRussian()
+	registerUkrainian()
+	registerBelarusian()
+	registerBulgarian()
+	registerMacedonian()
+	registerSerbian()
+	registerMongolian()
+	registerGreek()
+}
+
+func registerRussian() {
+	// е and ё are iotated at the start of a word and after vowels and signs
+	const iotated = "^аеёиоуыэюяйъь"
+
+	register(&Scheme{
+		Language: "ru",
+		Standard: BGNPCGN,
+		Name:     "BGN/PCGN 1947",
+		Script:   "Cyrillic",
+		rules: concat([]rule{
+			{from: "е", to: "ye", after: iotated},
+			{from: "ё", to: "yë", after: iotated},
+		}, cyrillicCommon, letters(
+			"г", "g", "е", "e", "ё", "ë", "ж", "zh", "и", "i", "й", "y", "х", "kh",
+			"ц", "ts", "ч", "ch", "ш", "sh", "щ", "shch", "ъ", "”", "ы", "y", "ь", "’",
+			"э", "e", "ю", "yu", "я", "ya",
+		)),
+	})
+
+	register(&Scheme{
+		Language: "ru",
+		Standard: National,
+		Name:     "ICAO Doc 9303 (Russian passports)",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "е", "e", "ё", "e", "ж", "zh", "и", "i", "й", "i", "х", "kh",
+			"ц", "ts", "ч", "ch", "ш", "sh", "щ", "shch", "ъ", "ie", "ы", "y", "ь", "",
+			"э", "e", "ю", "iu", "я", "ia",
+		)),
+	})
+}
+
+func registerUkrainian() {
+	register(&Scheme{
+		Language: "uk",
+		Standard: National,
+		Name:     "KMU Resolution 55 (2010)",
+		Script:   "Cyrillic",
+		rules: concat([]rule{
+			// Є, Ї, Й, Ю and Я are iotated only at the start of a word
+			{from: "є", to: "ye", after: "^"},
+			{from: "ї", to: "yi", after: "^"},
+			{from: "й", to: "y", after: "^"},
+			{from: "ю", to: "yu", after: "^"},
+			{from: "я", to: "ya", after: "^"},
+			// зг is written zgh to tell it apart from zh for ж
+			{from: "зг", to: "zgh"},
+		}, cyrillicCommon, letters(
+			"г", "h", "ґ", "g", "е", "e", "є", "ie", "ж", "zh", "и", "y", "і", "i",
+			"ї", "i", "й", "i", "х", "kh", "ц", "ts", "ч", "ch", "ш", "sh", "щ", "shch",
+			"ь", "", "ю", "iu", "я", "ia", "'", "", "’", "", "ʼ", "",
+		)),
+	})
+	alias("uk", National, BGNPCGN, "BGN/PCGN 2019 (KMU 2010)")
+}
+
+func registerBelarusian() {
+	// е, ё, ю and я are iotated at the start of a word, after vowels, ў,
+	// the soft sign and the apostrophe
+	const iotated = "^аеёіоуыэюяўь'’ʼ"
+
+	register(&Scheme{
+		Language: "be",
+		Standard: National,
+		Name:     "Belarusian geographical names instruction (2007)",
+		Script:   "Cyrillic",
+		rules: concat([]rule{
+			{from: "е", to: "je", after: iotated},
+			{from: "ё", to: "jo", after: iotated},
+			{from: "ю", to: "ju", after: iotated},
+			{from: "я", to: "ja", after: iotated},
+			// Soft consonants take an acute instead of the soft sign
+			{from: "дзь", to: "dź"},
+			{from: "зь", to: "ź"},
+			{from: "ль", to: "ĺ"},
+			{from: "нь", to: "ń"},
+			{from: "сь", to: "ś"},
+			{from: "ць", to: "ć"},
+			{from: "дж", to: "dž"},
+			{from: "дз", to: "dz"},
+		}, cyrillicCommon, letters(
+			"г", "h", "ґ", "g", "е", "ie", "ё", "io", "ж", "ž", "і", "i", "й", "j",
+			"ў", "ŭ", "х", "ch", "ц", "c", "ч", "č", "ш", "š", "ы", "y", "ь", "",
+			"э", "e", "ю", "iu", "я", "ia", "'", "", "’", "", "ʼ", "",
+		)),
+	})
+
+	register(&Scheme{
+		Language: "be",
+		Standard: BGNPCGN,
+		Name:     "BGN/PCGN 1979",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "h", "ґ", "g", "е", "ye", "ё", "yo", "ж", "zh", "і", "i", "й", "y",
+			"ў", "w", "х", "kh", "ц", "ts", "ч", "ch", "ш", "sh", "ы", "y", "ь", "’",
+			"э", "e", "ю", "yu", "я", "ya", "'", "”", "’", "”", "ʼ", "”",
+		)),
+	})
+}
+
+func registerBulgarian() {
+	register(&Scheme{
+		Language: "bg",
+		Standard: National,
+		Name:     "Streamlined System (Transliteration Act 2009)",
+		Script:   "Cyrillic",
+		rules: concat([]rule{
+			// Word-final ия is ia, as in София → Sofia
+			{from: "ия", to: "ia", before: "$"},
+		}, cyrillicCommon, letters(
+			"г", "g", "е", "e", "ж", "zh", "и", "i", "й", "y", "х", "h", "ц", "ts",
+			"ч", "ch", "ш", "sh", "щ", "sht", "ъ", "a", "ь", "y", "ю", "yu", "я", "ya",
+		)),
+	})
+	alias("bg", National, BGNPCGN, "BGN/PCGN 2013 (Streamlined System)")
+}
+
+func registerMacedonian() {
+	register(&Scheme{
+		Language: "mk",
+		Standard: National,
+		Name:     "Official Macedonian romanization",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "ѓ", "gj", "е", "e", "ж", "zh", "ѕ", "dz", "и", "i", "ј", "j",
+			"љ", "lj", "њ", "nj", "ќ", "kj", "х", "h", "ц", "c", "ч", "ch", "џ", "dzh",
+			"ш", "sh",
+		)),
+	})
+
+	register(&Scheme{
+		Language: "mk",
+		Standard: BGNPCGN,
+		Name:     "BGN/PCGN 1981",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "ѓ", "gj", "е", "e", "ж", "zh", "ѕ", "dz", "и", "i", "ј", "j",
+			"љ", "lj", "њ", "nj", "ќ", "kj", "х", "kh", "ц", "ts", "ч", "ch", "џ", "dzh",
+			"ш", "sh",
+		)),
+	})
+}
+
+func registerSerbian() {
+	// Gaj's Latin alphabet maps letter for letter, so it reverses like Converter
+	register(&Scheme{
+		Language:   "sr",
+		Standard:   National,
+		Name:       "Serbian Latin alphabet",
+		Script:     "Cyrillic",
+		Reversible: true,
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "ђ", "đ", "е", "e", "ж", "ž", "и", "i", "ј", "j", "љ", "lj",
+			"њ", "nj", "ћ", "ć", "х", "h", "ц", "c", "ч", "č", "џ", "dž", "ш", "š",
+		)),
+	})
+	alias("sr", National, BGNPCGN, "BGN/PCGN 1962 (Serbian Latin alphabet)")
+}
+
+func registerMongolian() {
+	register(&Scheme{
+		Language: "mn",
+		Standard: National,
+		Name:     "MNS 5217:2012",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "е", "ye", "ё", "yo", "ж", "j", "и", "i", "й", "i", "ө", "ö",
+			"ү", "ü", "х", "kh", "ц", "ts", "ч", "ch", "ш", "sh", "щ", "sh", "ъ", "i",
+			"ы", "y", "ь", "i", "э", "e", "ю", "yu", "я", "ya",
+		)),
+	})
+
+	register(&Scheme{
+		Language: "mn",
+		Standard: BGNPCGN,
+		Name:     "BGN/PCGN 1964",
+		Script:   "Cyrillic",
+		rules: concat(cyrillicCommon, letters(
+			"г", "g", "е", "ye", "ё", "yo", "ж", "j", "и", "i", "й", "i", "ө", "ö",
+			"ү", "ü", "х", "kh", "ц", "ts", "ч", "ch", "ш", "sh", "щ", "shch", "ъ", "”",
+			"ы", "y", "ь", "’", "э", "e", "ю", "yu", "я", "ya",
+		)),
+	})
+}
+
+// greekCommon are the Greek letters ISO 843 and ELOT 743 transliterate alike
+var greekCommon = letters(
+	"α", "a", "β", "v", "γ", "g", "δ", "d", "ε", "e", "ζ", "z", "θ", "th", "ι", "i",
+	"κ", "k", "λ", "l", "μ", "m", "ν", "n", "ξ", "x", "ο", "o", "π", "p", "ρ", "r",
+	"σ", "s", "ς", "s", "τ", "t", "φ", "f", "χ", "ch", "ψ", "ps",
+	"ά", "á", "έ", "é", "ί", "í", "ό", "ó", "ύ", "ý",
+)
+
+func registerGreek() {
+	register(&Scheme{
+		Language:   "el",
+		Standard:   ISO843,
+		Name:       "ISO 843:1997 type 1",
+		Script:     "Greek",
+		Reversible: true,
+		rules: concat([]rule{
+			// γ is nasal before velars
+			{from: "γ", to: "n", before: "γκξχ"},
+			{from: "αυ", to: "au"},
+			{from: "ευ", to: "eu"},
+			{from: "ηυ", to: "īu"},
+			{from: "ου", to: "ou"},
+		}, greekCommon, letters(
+			"η", "ī", "υ", "y", "ω", "ō", "ή", "ī́", "ώ", "ṓ",
+			"ϊ", "ï", "ϋ", "ÿ", "ΐ", "ḯ", "ΰ", "ÿ́",
+		)),
+		reverse: concat([]rule{
+			{from: "s", to: "ς", before: "$"},
+		}, letters("ng", "γγ", "nk", "γκ", "nx", "γξ", "nch", "γχ")),
+	})
+
+	// Voiceless consonants turn the υ of αυ, ευ and ηυ into f
+	const voiceless = "θκξπσςτφχψ$"
+
+	register(&Scheme{
+		Language: "el",
+		Standard: National,
+		Name:     "ELOT 743:2001",
+		Script:   "Greek",
+		rules: concat([]rule{
+			{from: "αυ", to: "af", before: voiceless},
+			{from: "ευ", to: "ef", before: voiceless},
+			{from: "ηυ", to: "if", before: voiceless},
+			{from: "αύ", to: "áf", before: voiceless},
+			{from: "εύ", to: "éf", before: voiceless},
+			{from: "ηύ", to: "íf", before: voiceless},
+			{from: "αυ", to: "av"},
+			{from: "ευ", to: "ev"},
+			{from: "ηυ", to: "iv"},
+			{from: "αύ", to: "áv"},
+			{from: "εύ", to: "év"},
+			{from: "ηύ", to: "ív"},
+			{from: "ου", to: "ou"},
+			{from: "ού", to: "oú"},
+			// μπ, ντ and γκ are b, d and g at the start of a word
+			{from: "μπ", to: "b", after: "^"},
+			{from: "ντ", to: "d", after: "^"},
+			{from: "γκ", to: "g", after: "^"},
+			{from: "γγ", to: "ng"},
+			{from: "γκ", to: "gk"},
+			{from: "γξ", to: "nx"},
+			{from: "γχ", to: "nch"},
+		}, greekCommon, letters(
+			"η", "i", "υ", "y", "ω", "o", "ή", "í", "ώ", "ó",
+			"ϊ", "i", "ϋ", "y", "ΐ", "í", "ΰ", "ý",
+		)),
+	})
+	alias("el", National, BGNPCGN, "BGN/PCGN 1996 (ELOT 743)")
+}
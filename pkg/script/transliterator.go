@@ -0,0 +1,325 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Standard names a transliteration standard
+type Standard string
+
+const (
+	// ISO9 is ISO 9:1995, a reversible one letter for one letter system for Cyrillic
+	ISO9 Standard = "iso9"
+	// ISO843 is ISO 843:1997 type 1, the reversible system for Greek
+	ISO843 Standard = "iso843"
+	// BGNPCGN is the romanization of the US and UK geographic names boards
+	BGNPCGN Standard = "bgn-pcgn"
+	// National is the official romanization of the country of the language
+	National Standard = "national"
+)
+
+var (
+	// ErrUnsupportedScheme is returned for language and standard pairs without a scheme
+	ErrUnsupportedScheme = errors.New("unsupported transliteration scheme")
+
+	// ErrNotReversible is returned when reversing a scheme that loses information
+	ErrNotReversible = errors.New("transliteration scheme is not reversible")
+)
+
+// rule replaces from with to where the neighbouring runes allow it. Rules are
+// written in lowercase; the case of the source is carried over.
+type rule struct {
+	from, to string
+
+	// after lists the runes the match must follow; '^' stands for a word start
+	after string
+
+	// before lists the runes the match must precede; '$' stands for a word end
+	before string
+}
+
+// Scheme describes a registered transliteration scheme
+type Scheme struct {
+	Language   string // ISO 639-1 code, e.g. "uk"
+	Standard   Standard
+	Name       string // e.g. "ISO 9:1995"
+	Script     string // native script, e.g. "Cyrillic"
+	Reversible bool
+
+	rules   []rule
+	reverse []rule // extra context rules for reversing, tried first
+}
+
+// Transliterator converts text of one language between its native script and
+// Latin script following a single standard
+type Transliterator struct {
+	scheme     *Scheme
+	forward    []rule
+	backward   []rule
+	exceptions map[bool][]exception // by direction, forward is true
+}
+
+type exception struct {
+	word        []rune // lowercase
+	replacement string
+}
+
+// romanNumeral matches a non-empty Roman numeral
+var romanNumeral = regexp.MustCompile(`^M{0,3}(CM|CD|D?C{0,3})(XC|XL|L?X{0,3})(IX|IV|V?I{0,3})$`)
+
+// cyrillicNumerals maps the Cyrillic letters used in place of Latin ones in
+// Roman numerals
+var cyrillicNumerals = map[rune]rune{'І': 'I', 'Х': 'X', 'С': 'C', 'М': 'M'}
+
+// NewTransliterator creates a transliterator for the given language and standard
+func NewTransliterator(language string, standard Standard) (*Transliterator, error) {
+	scheme, ok := schemes[schemeKey(strings.ToLower(language), standard)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnsupportedScheme, language, standard)
+	}
+
+	t := &Transliterator{
+		scheme:     scheme,
+		forward:    sortRules(scheme.rules),
+		exceptions: make(map[bool][]exception),
+	}
+
+	if scheme.Reversible {
+		var backward []rule
+		seen := make(map[string]bool)
+		for _, r := range scheme.rules {
+			if r.after != "" || r.before != "" || r.to == "" || seen[r.to] {
+				continue
+			}
+			seen[r.to] = true
+			backward = append(backward, rule{from: r.to, to: r.from})
+		}
+		t.backward = append(sortRules(scheme.reverse), sortRules(backward)...)
+	}
+
+	return t, nil
+}
+
+// Schemes returns all registered transliteration schemes
+func Schemes() []Scheme {
+	list := make([]Scheme, 0, len(schemes))
+	for _, s := range schemes {
+		list = append(list, *s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Language != list[j].Language {
+			return list[i].Language < list[j].Language
+		}
+		return list[i].Standard < list[j].Standard
+	})
+	return list
+}
+
+// Scheme returns the scheme the transliterator follows
+func (t *Transliterator) Scheme() Scheme {
+	return *t.scheme
+}
+
+// Reversible reports whether Reverse restores the native script
+func (t *Transliterator) Reversible() bool {
+	return t.scheme.Reversible
+}
+
+// AddException makes Transliterate write replacement for word, e.g. a
+// foreign name with an established Latin spelling. Matching ignores case.
+func (t *Transliterator) AddException(word, replacement string) {
+	t.addException(true, word, replacement)
+}
+
+// AddReverseException makes Reverse write replacement for word
+func (t *Transliterator) AddReverseException(word, replacement string) {
+	t.addException(false, word, replacement)
+}
+
+// KeepWords leaves the given words unchanged in both directions, e.g. brand
+// names written in Latin script inside native text
+func (t *Transliterator) KeepWords(words ...string) {
+	for _, word := range words {
+		t.addException(true, word, word)
+		t.addException(false, word, word)
+	}
+}
+
+func (t *Transliterator) addException(forward bool, word, replacement string) {
+	if word == "" {
+		return
+	}
+	list := append(t.exceptions[forward], exception{word: []rune(strings.ToLower(word)), replacement: replacement})
+	// Longer exceptions win over the ones they start with
+	sort.SliceStable(list, func(i, j int) bool { return len(list[i].word) > len(list[j].word) })
+	t.exceptions[forward] = list
+}
+
+// Transliterate converts native script text to Latin script. Roman numerals
+// typed with Cyrillic look-alike letters become Latin numerals.
+func (t *Transliterator) Transliterate(text string) string {
+	return t.apply(text, t.forward, true)
+}
+
+// Reverse converts Latin script text back to the native script. Roman
+// numerals are kept.
+func (t *Transliterator) Reverse(text string) (string, error) {
+	if !t.scheme.Reversible {
+		return "", fmt.Errorf("%w: %s", ErrNotReversible, t.scheme.Name)
+	}
+	return t.apply(text, t.backward, false), nil
+}
+
+func (t *Transliterator) apply(text string, rules []rule, forward bool) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the rune count; fall back to per-rune lowering
+		lower = make([]rune, len(runes))
+		for i, r := range runes {
+			lower[i] = unicode.ToLower(r)
+		}
+	}
+
+	var result strings.Builder
+	result.Grow(len(text))
+
+	for i := 0; i < len(runes); {
+		if i == 0 || !isWordRune(runes[i-1]) {
+			if n, replacement, ok := t.matchWord(runes, lower, i, forward); ok {
+				result.WriteString(replacement)
+				i += n
+				continue
+			}
+		}
+
+		if r, n, ok := matchRule(rules, runes, lower, i); ok {
+			result.WriteString(matchCase(r.to, runes, i, n))
+			i += n
+			continue
+		}
+
+		result.WriteRune(runes[i])
+		i++
+	}
+
+	return result.String()
+}
+
+// matchWord matches an exception or a Roman numeral at the word starting at i
+func (t *Transliterator) matchWord(runes, lower []rune, i int, forward bool) (int, string, bool) {
+	for _, e := range t.exceptions[forward] {
+		end := i + len(e.word)
+		if end <= len(lower) && string(lower[i:end]) == string(e.word) &&
+			(end == len(runes) || !isWordRune(runes[end])) {
+			return len(e.word), e.replacement, true
+		}
+	}
+
+	end := i
+	for end < len(runes) && isWordRune(runes[end]) {
+		end++
+	}
+	word := runes[i:end]
+	if len(word) == 0 {
+		return 0, "", false
+	}
+
+	numeral := make([]rune, len(word))
+	lookalikes := 0
+	for j, r := range word {
+		if latin, ok := cyrillicNumerals[r]; ok {
+			r = latin
+			lookalikes++
+		}
+		numeral[j] = r
+	}
+	if !romanNumeral.MatchString(string(numeral)) {
+		return 0, "", false
+	}
+
+	if forward {
+		// Only rewrite numerals that are not also plain words, like the
+		// Russian "С" or the Ukrainian and Belarusian "І"
+		if lookalikes == 0 || (len(word) == 1 && (word[0] == 'С' || word[0] == 'І')) {
+			return 0, "", false
+		}
+		return len(word), string(numeral), true
+	}
+	// Single letters are more often words, like the Serbian "I"
+	if lookalikes > 0 || len(word) == 1 {
+		return 0, "", false
+	}
+	return len(word), string(word), true
+}
+
+// matchRule finds the first rule matching at i
+func matchRule(rules []rule, runes, lower []rune, i int) (rule, int, bool) {
+	for _, r := range rules {
+		n := utf8.RuneCountInString(r.from)
+		if i+n > len(lower) || string(lower[i:i+n]) != r.from {
+			continue
+		}
+		if r.after != "" && !contextAllows(r.after, runes, i-1, '^') {
+			continue
+		}
+		if r.before != "" && !contextAllows(r.before, runes, i+n, '$') {
+			continue
+		}
+		return r, n, true
+	}
+	return rule{}, 0, false
+}
+
+// contextAllows reports whether the rune at i is in set; boundary stands for
+// the text or a word ending there
+func contextAllows(set string, runes []rune, i int, boundary rune) bool {
+	if i < 0 || i >= len(runes) || !isWordRune(runes[i]) {
+		return strings.ContainsRune(set, boundary)
+	}
+	return strings.ContainsRune(set, unicode.ToLower(runes[i]))
+}
+
+// matchCase gives the replacement for runes[i:i+n] the case of the source
+func matchCase(to string, runes []rune, i, n int) string {
+	if to == "" || !unicode.IsUpper(runes[i]) {
+		return to
+	}
+
+	upper := true
+	if n > 1 {
+		upper = unicode.IsUpper(runes[i+1])
+	} else {
+		// A single capital is part of an uppercase word when a neighbour is uppercase too
+		upper = (i+1 < len(runes) && unicode.IsUpper(runes[i+1])) ||
+			(i > 0 && unicode.IsUpper(runes[i-1]) && (i+1 == len(runes) || !unicode.IsLower(runes[i+1])))
+	}
+	if upper {
+		return strings.ToUpper(to)
+	}
+
+	first, size := utf8.DecodeRuneInString(to)
+	return string(unicode.ToUpper(first)) + to[size:]
+}
+
+// isWordRune reports whether r is part of a word; apostrophes are, as they
+// separate letters inside Ukrainian and Belarusian words
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// sortRules orders rules longest match first, keeping the table order of
+// rules with the same length so that context rules precede their fallback
+func sortRules(rules []rule) []rule {
+	sorted := append([]rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return utf8.RuneCountInString(sorted[i].from) > utf8.RuneCountInString(sorted[j].from)
+	})
+	return sorted
+}
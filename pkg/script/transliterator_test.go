@@ -0,0 +1,214 @@
+package script
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		language string
+		standard Standard
+		native   string
+		latin    string
+	}{
+		// Russian
+		{"ru", National, "Щукин Юрий Васильевич", "Shchukin Iurii Vasilevich"},
+		{"ru", BGNPCGN, "Ельцин, Фёдоров и подъезд", "Yel’tsin, Fëdorov i pod”yezd"},
+		{"ru", ISO9, "Щука и ёж", "Ŝuka i ëž"},
+		{"ru", National, "МОСКВА", "MOSKVA"},
+
+		// Ukrainian word-initial and medial iotated vowels, зг and the apostrophe
+		{"uk", National, "Єнакієве", "Yenakiieve"},
+		{"uk", National, "Згурівка і Розгон", "Zghurivka i Rozghon"},
+		{"uk", National, "Їжакевич, Юрій", "Yizhakevych, Yurii"},
+		{"uk", National, "Знам'янка і Короп'є", "Znamianka i Koropie"},
+		{"uk", National, "ЄВРОПА", "YEVROPA"},
+		{"uk", ISO9, "Ґанок і їжак", "G̀anok ì ïžak"},
+
+		// Belarusian
+		{"be", National, "Магілёў і Віцебск", "Mahilioŭ i Viciebsk"},
+		{"be", National, "Ельск", "Jeĺsk"},
+		{"be", BGNPCGN, "Гомель і Магілёў", "Homyel’ i Mahilyow"},
+
+		// Bulgarian word-final ия
+		{"bg", National, "Търново, София и Мизия", "Tarnovo, Sofia i Mizia"},
+		{"bg", National, "Щастие и Благоевград", "Shtastie i Blagoevgrad"},
+
+		// Macedonian
+		{"mk", National, "Ѓорче Петров и Охрид", "Gjorche Petrov i Ohrid"},
+		{"mk", BGNPCGN, "Кичево", "Kichevo"},
+
+		// Mongolian
+		{"mn", National, "Улаанбаатар, Өлгий", "Ulaanbaatar, Ölgii"},
+
+		// Greek diphthongs before voiced and voiceless sounds and initial μπ
+		{"el", National, "Αθήνα και Ευρώπη", "Athína kai Evrópi"},
+		{"el", National, "Παύλος, αυτός, Μπαμπάς", "Pávlos, aftós, Bampás"},
+		{"el", National, "Άγγελος", "Ángelos"},
+		{"el", ISO843, "Αθήνα", "Athī́na"},
+	}
+
+	for _, test := range tests {
+		tr, err := NewTransliterator(test.language, test.standard)
+		if err != nil {
+			t.Fatalf("NewTransliterator(%q, %q) failed: %v", test.language, test.standard, err)
+		}
+		if result := tr.Transliterate(test.native); result != test.latin {
+			t.Errorf("%s/%s Transliterate(%q) = %q, expected %q", test.language, test.standard, test.native, result, test.latin)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		language string
+		standard Standard
+		text     string
+	}{
+		{"ru", ISO9, "Съешь же ещё этих мягких французских булок, да выпей чаю"},
+		{"uk", ISO9, "Ґанок, їжак, Євген і м'ята"},
+		{"uk", ISO9, "І він пішов"},
+		{"be", ISO9, "І ён пайшоў"},
+		{"be", ISO9, "Беларусь, Магілёў і Віцебск"},
+		{"bg", ISO9, "Щастието на България"},
+		{"mk", ISO9, "Ѓорѓи, ѕвезда, Љубљана, Њујорк, Ќерка, Џамија"},
+		{"sr", ISO9, "Ђорђе, Ћирило и Џонатан"},
+		{"mn", ISO9, "Өлгий, Үнэн"},
+		{"sr", National, "Љубав је лепа ствар"},
+		{"el", ISO843, "Άγγελος από την Ελλάδα και η Ευρώπη"},
+	}
+
+	for _, test := range tests {
+		tr, err := NewTransliterator(test.language, test.standard)
+		if err != nil {
+			t.Fatalf("NewTransliterator(%q, %q) failed: %v", test.language, test.standard, err)
+		}
+		if !tr.Reversible() {
+			t.Fatalf("%s/%s should be reversible", test.language, test.standard)
+		}
+
+		latin := tr.Transliterate(test.text)
+		back, err := tr.Reverse(latin)
+		if err != nil {
+			t.Fatalf("Reverse(%q) failed: %v", latin, err)
+		}
+		if back != test.text {
+			t.Errorf("%s/%s round trip failed:\nOriginal: %q\nLatin: %q\nBack: %q", test.language, test.standard, test.text, latin, back)
+		}
+	}
+}
+
+func TestReverse_NotReversible(t *testing.T) {
+	tr, err := NewTransliterator("uk", National)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Reversible() {
+		t.Error("Ukrainian national scheme should not be reversible")
+	}
+	if _, err := tr.Reverse("Kyiv"); !errors.Is(err, ErrNotReversible) {
+		t.Errorf("expected ErrNotReversible, got %v", err)
+	}
+}
+
+func TestNewTransliterator_Unsupported(t *testing.T) {
+	if _, err := NewTransliterator("xx", National); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Errorf("expected ErrUnsupportedScheme, got %v", err)
+	}
+	if _, err := NewTransliterator("ru", ISO843); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Errorf("expected ErrUnsupportedScheme, got %v", err)
+	}
+	if _, err := NewTransliterator("RU", National); err != nil {
+		t.Errorf("language codes should be case-insensitive: %v", err)
+	}
+}
+
+func TestRomanNumerals(t *testing.T) {
+	ru, _ := NewTransliterator("ru", National)
+	tests := []struct {
+		native string
+		latin  string
+	}{
+		{"Людовик XIV", "Liudovik XIV"},          // Latin numerals are kept
+		{"Глава ХІV", "Glava XIV"},               // Cyrillic look-alikes become Latin
+		{"XX век и ХХІ век", "XX vek i XXI vek"}, // Mixed
+		{"С утра", "S utra"},                     // A lone С is a word
+	}
+	for _, test := range tests {
+		if result := ru.Transliterate(test.native); result != test.latin {
+			t.Errorf("Transliterate(%q) = %q, expected %q", test.native, result, test.latin)
+		}
+	}
+
+	uk, _ := NewTransliterator("uk", ISO9)
+	if result := uk.Transliterate("І він пішов"); result != "Ì vìn pìšov" {
+		t.Errorf("a lone І is a word, got %q", result)
+	}
+
+	sr, _ := NewTransliterator("sr", National)
+	back, err := sr.Reverse("Luj XIV i Petar I")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Луј XIV и Петар И"; back != expected {
+		t.Errorf("Reverse kept %q, expected %q", back, expected)
+	}
+}
+
+func TestExceptions(t *testing.T) {
+	ru, _ := NewTransliterator("ru", National)
+	ru.AddException("Чайковский", "Tchaikovsky")
+	ru.AddException("Нью-Йорк", "New York")
+
+	// Exceptions apply to whole words in any case
+	result := ru.Transliterate("Чайковский в Нью-Йорке, ЧАЙКОВСКИЙ в Нью-Йорк")
+	if expected := "Tchaikovsky v Niu-Iorke, Tchaikovsky v New York"; result != expected {
+		t.Errorf("Transliterate with exceptions = %q, expected %q", result, expected)
+	}
+
+	sr, _ := NewTransliterator("sr", National)
+	sr.KeepWords("iPhone", "Microsoft")
+	back, err := sr.Reverse("Imam iPhone i radim za Microsoft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Имам iPhone и радим за Microsoft"; back != expected {
+		t.Errorf("Reverse with kept words = %q, expected %q", back, expected)
+	}
+}
+
+func TestSerbianNationalMatchesConverter(t *testing.T) {
+	tr, _ := NewTransliterator("sr", National)
+	converter := NewConverter()
+
+	for _, text := range []string{
+		"Пример текста на српском ћириличном писму",
+		"Љубав је лепа ствар која траје вечно",
+		"Ђорђе је добар човек",
+	} {
+		if result, expected := tr.Transliterate(text), converter.ToLatin(text); result != expected {
+			t.Errorf("Transliterate(%q) = %q, Converter gives %q", text, result, expected)
+		}
+	}
+
+	// Digraphs follow the case of the word
+	if result := tr.Transliterate("ЏЕМ и ЊЕГОШ"); result != "DŽEM i NJEGOŠ" {
+		t.Errorf("Transliterate of uppercase digraphs = %q", result)
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, s := range Schemes() {
+		seen[s.Language] = true
+		if s.Name == "" || s.Script == "" {
+			t.Errorf("scheme %s/%s lacks a name or script", s.Language, s.Standard)
+		}
+	}
+	for _, lang := range []string{"ru", "uk", "bg", "mk", "be", "el", "mn", "sr"} {
+		if !seen[lang] {
+			t.Errorf("no scheme registered for %s", lang)
+		}
+	}
+}
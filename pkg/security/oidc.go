@@ -0,0 +1,491 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrOIDCStateInvalid is returned when a callback carries an unknown or expired state
+	ErrOIDCStateInvalid = errors.New("invalid or expired OIDC state")
+
+	// ErrOIDCTokenInvalid is returned when the ID token fails validation
+	ErrOIDCTokenInvalid = errors.New("invalid OIDC ID token")
+)
+
+// OIDCConfig holds the OpenID Connect relying party configuration
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// RoleClaim names the ID token claim holding the user's groups (e.g. "groups", "roles")
+	RoleClaim string
+	// RoleMapping maps identity provider groups to local roles
+	RoleMapping map[string]string
+	// DefaultRoles are assigned when no group maps to a local role
+	DefaultRoles []string
+
+	// StateTTL bounds how long a login attempt may take
+	StateTTL time.Duration
+	// PostLoginRedirect is where the browser is sent once the session cookie is set
+	PostLoginRedirect string
+	// HTTPClient is used for discovery, JWKS and token requests
+	HTTPClient *http.Client
+}
+
+// OIDCDiscovery is the subset of the discovery document the relying party needs
+type OIDCDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint,omitempty"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCIdentity represents a validated identity from the ID token
+type OIDCIdentity struct {
+	Issuer        string   `json:"issuer"`
+	Subject       string   `json:"subject"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Username      string   `json:"username"`
+	Name          string   `json:"name"`
+	Groups        []string `json:"groups"`
+	Roles         []string `json:"roles"`
+
+	// LinkByEmail is set when the user asked, at login, to link an existing
+	// local account with the same verified email address
+	LinkByEmail bool `json:"link_by_email"`
+}
+
+// OIDCClaims represents the ID token claims used for login
+type OIDCClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Nonce             string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// oidcPendingLogin tracks an authorization request until its callback arrives
+type oidcPendingLogin struct {
+	nonce        string
+	codeVerifier string
+	linkByEmail  bool
+	expiresAt    time.Time
+}
+
+// OIDCProvider implements the authorization-code flow against an OpenID Connect issuer
+type OIDCProvider struct {
+	config    OIDCConfig
+	discovery *OIDCDiscovery
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	pending map[string]oidcPendingLogin
+}
+
+// NewOIDCProvider fetches the issuer's discovery document and signing keys
+func NewOIDCProvider(ctx context.Context, config OIDCConfig) (*OIDCProvider, error) {
+	if config.IssuerURL == "" || config.ClientID == "" || config.RedirectURL == "" {
+		return nil, errors.New("OIDC issuer URL, client ID and redirect URL are required")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if config.StateTTL <= 0 {
+		config.StateTTL = 10 * time.Minute
+	}
+	if config.PostLoginRedirect == "" {
+		config.PostLoginRedirect = "/"
+	}
+
+	p := &OIDCProvider{
+		config:  config,
+		keys:    make(map[string]*rsa.PublicKey),
+		pending: make(map[string]oidcPendingLogin),
+	}
+
+	discoveryURL := strings.TrimSuffix(config.IssuerURL, "/") + "/.well-known/openid-configuration"
+	var discovery OIDCDiscovery
+	if err := p.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(config.IssuerURL, "/") {
+		return nil, fmt.Errorf("OIDC issuer mismatch: expected %s, got %s", config.IssuerURL, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing required endpoints")
+	}
+	p.discovery = &discovery
+
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Discovery returns the issuer's discovery document
+func (p *OIDCProvider) Discovery() OIDCDiscovery {
+	return *p.discovery
+}
+
+// StateTTL returns how long a login attempt may take
+func (p *OIDCProvider) StateTTL() time.Duration {
+	return p.config.StateTTL
+}
+
+// PostLoginRedirect returns where the browser is sent after a successful login
+func (p *OIDCProvider) PostLoginRedirect() string {
+	return p.config.PostLoginRedirect
+}
+
+// AuthCodeURL starts a login and returns the state and the URL to redirect the user to.
+// linkByEmail records that the user asked to link an existing account by verified email
+func (p *OIDCProvider) AuthCodeURL(linkByEmail bool) (string, string, error) {
+	state, err := randomURLString(24)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomURLString(24)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	p.mu.Lock()
+	p.removeExpiredLocked()
+	p.pending[state] = oidcPendingLogin{
+		nonce:        nonce,
+		codeVerifier: verifier,
+		linkByEmail:  linkByEmail,
+		expiresAt:    time.Now().Add(p.config.StateTTL),
+	}
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(verifier))
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return state, p.discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange completes a login: it redeems the authorization code and validates the ID token
+func (p *OIDCProvider) Exchange(ctx context.Context, state, code string) (*OIDCIdentity, error) {
+	p.mu.Lock()
+	login, ok := p.pending[state]
+	delete(p.pending, state)
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(login.expiresAt) {
+		return nil, ErrOIDCStateInvalid
+	}
+	if code == "" {
+		return nil, errors.New("authorization code is required")
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {login.codeVerifier},
+	}
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tokenResponse struct {
+		IDToken     string `json:"id_token"`
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, errors.New("token response did not include an ID token")
+	}
+
+	identity, err := p.VerifyIDToken(ctx, tokenResponse.IDToken, login.nonce)
+	if err != nil {
+		return nil, err
+	}
+	identity.LinkByEmail = login.linkByEmail
+
+	return identity, nil
+}
+
+// VerifyIDToken validates the signature and claims of an ID token and maps it to an identity
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, rawToken, expectedNonce string) (*OIDCIdentity, error) {
+	claims := &OIDCClaims{}
+	token, err := jwt.ParseWithClaims(rawToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.keyFor(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(p.discovery.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+
+	if expectedNonce != "" && claims.Nonce != expectedNonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCTokenInvalid)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrOIDCTokenInvalid)
+	}
+
+	// Decode the raw claims a second time to read the configurable group claim
+	var rawClaims map[string]interface{}
+	if mapClaims, ok := parseUnverifiedClaims(rawToken); ok {
+		rawClaims = mapClaims
+	}
+
+	identity := &OIDCIdentity{
+		Issuer:        p.discovery.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Username:      claims.PreferredUsername,
+		Name:          claims.Name,
+		Groups:        claimStrings(rawClaims, p.config.RoleClaim),
+	}
+	if identity.Username == "" {
+		identity.Username = claims.Email
+	}
+	if identity.Username == "" {
+		identity.Username = claims.Subject
+	}
+	identity.Roles = p.MapRoles(identity.Groups)
+
+	return identity, nil
+}
+
+// MapRoles converts identity provider groups into local roles
+func (p *OIDCProvider) MapRoles(groups []string) []string {
+	seen := make(map[string]bool)
+	var roles []string
+	for _, group := range groups {
+		role, ok := p.config.RoleMapping[group]
+		if !ok || seen[role] {
+			continue
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+
+	if len(roles) == 0 {
+		roles = append(roles, p.config.DefaultRoles...)
+	}
+	if len(roles) == 0 {
+		roles = []string{"user"}
+	}
+
+	return roles
+}
+
+// keyFor returns the signing key with the given ID, refreshing the JWKS once on a miss
+// so that issuer key rotation is picked up
+func (p *OIDCProvider) keyFor(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key: %q", kid)
+}
+
+// lookupKey finds a cached key; an empty kid matches only if the issuer publishes a single key
+func (p *OIDCProvider) lookupKey(kid string) *rsa.PublicKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+// refreshKeys downloads the issuer's JSON Web Key Set
+func (p *OIDCProvider) refreshKeys(ctx context.Context) error {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.discovery.JWKSURI, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no usable RSA signing keys")
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	return nil
+}
+
+// removeExpiredLocked drops abandoned login attempts; p.mu must be held
+func (p *OIDCProvider) removeExpiredLocked() {
+	now := time.Now()
+	for state, login := range p.pending {
+		if now.After(login.expiresAt) {
+			delete(p.pending, state)
+		}
+	}
+}
+
+// getJSON performs a GET request and decodes the JSON response
+func (p *OIDCProvider) getJSON(ctx context.Context, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// parseUnverifiedClaims decodes the claims of an already verified token into a map
+func parseUnverifiedClaims(rawToken string) (map[string]interface{}, bool) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, claims); err != nil {
+		return nil, false
+	}
+	return claims, true
+}
+
+// claimStrings reads a string or string-array claim
+func claimStrings(claims map[string]interface{}, name string) []string {
+	if name == "" || claims == nil {
+		return nil
+	}
+
+	switch value := claims[name].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// randomURLString returns a URL-safe random string built from n random bytes
+func randomURLString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,361 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer is a minimal stand-in OpenID Connect issuer
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	claims jwt.MapClaims
+
+	mu    sync.Mutex
+	codes map[string]testAuthorization
+}
+
+type testAuthorization struct {
+	nonce     string
+	challenge string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &testIssuer{
+		key:   key,
+		kid:   "key-1",
+		codes: make(map[string]testAuthorization),
+		claims: jwt.MapClaims{
+			"sub":                "sso-user-1",
+			"email":              "ana@example.com",
+			"preferred_username": "ana",
+			"name":               "Ana Petrović",
+			"groups":             []string{"translators", "unrelated"},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OIDCDiscovery{
+			Issuer:                issuer.server.URL,
+			AuthorizationEndpoint: issuer.server.URL + "/authorize",
+			TokenEndpoint:         issuer.server.URL + "/token",
+			JWKSURI:               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": issuer.kid,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(issuer.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(issuer.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		issuer.mu.Lock()
+		auth, ok := issuer.codes[r.FormValue("code")]
+		delete(issuer.codes, r.FormValue("code"))
+		issuer.mu.Unlock()
+
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     issuer.signIDToken(t, auth.nonce, nil),
+		})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// authorize simulates the user approving the login and returns the authorization code
+func (i *testIssuer) authorize(t *testing.T, authURL string) string {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+
+	code := "code-" + query.Get("state")
+	i.mu.Lock()
+	i.codes[code] = testAuthorization{
+		nonce:     query.Get("nonce"),
+		challenge: query.Get("code_challenge"),
+	}
+	i.mu.Unlock()
+
+	return code
+}
+
+func (i *testIssuer) signIDToken(t *testing.T, nonce string, overrides jwt.MapClaims) string {
+	claims := jwt.MapClaims{
+		"iss":   i.server.URL,
+		"aud":   "translator",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": nonce,
+	}
+	for k, v := range i.claims {
+		claims[k] = v
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = i.kid
+	signed, err := token.SignedString(i.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newTestOIDCProvider(t *testing.T, issuer *testIssuer) *OIDCProvider {
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		IssuerURL:    issuer.server.URL,
+		ClientID:     "translator",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/api/v1/auth/oidc/callback",
+		RoleClaim:    "groups",
+		RoleMapping:  map[string]string{"translators": "translator", "admins": "admin"},
+		DefaultRoles: []string{"viewer"},
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := newTestOIDCProvider(t, issuer)
+
+	state, authURL, err := provider.AuthCodeURL(true)
+	require.NoError(t, err)
+	assert.Contains(t, authURL, issuer.server.URL+"/authorize?")
+	assert.Contains(t, authURL, "code_challenge_method=S256")
+
+	code := issuer.authorize(t, authURL)
+	identity, err := provider.Exchange(context.Background(), state, code)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sso-user-1", identity.Subject)
+	assert.Equal(t, "ana", identity.Username)
+	assert.Equal(t, "ana@example.com", identity.Email)
+	assert.Equal(t, issuer.server.URL, identity.Issuer)
+	assert.False(t, identity.EmailVerified)
+	assert.True(t, identity.LinkByEmail)
+	assert.Equal(t, []string{"translators", "unrelated"}, identity.Groups)
+	assert.Equal(t, []string{"translator"}, identity.Roles)
+
+	// States are single use
+	_, err = provider.Exchange(context.Background(), state, code)
+	assert.ErrorIs(t, err, ErrOIDCStateInvalid)
+}
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := newTestOIDCProvider(t, issuer)
+	ctx := context.Background()
+
+	_, err := provider.VerifyIDToken(ctx, issuer.signIDToken(t, "n1", nil), "n1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		overrides jwt.MapClaims
+		nonce     string
+	}{
+		{"wrong nonce", nil, "other"},
+		{"wrong audience", jwt.MapClaims{"aud": "someone-else"}, "n1"},
+		{"wrong issuer", jwt.MapClaims{"iss": "https://evil.example.com"}, "n1"},
+		{"expired", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}, "n1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.VerifyIDToken(ctx, issuer.signIDToken(t, "n1", tt.overrides), tt.nonce)
+			assert.ErrorIs(t, err, ErrOIDCTokenInvalid)
+		})
+	}
+
+	t.Run("foreign signing key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss": issuer.server.URL, "aud": "translator", "sub": "x",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = issuer.kid
+		signed, err := token.SignedString(otherKey)
+		require.NoError(t, err)
+
+		_, err = provider.VerifyIDToken(ctx, signed, "")
+		assert.ErrorIs(t, err, ErrOIDCTokenInvalid)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		issuer.key, issuer.kid = newKey, "key-2"
+
+		_, err = provider.VerifyIDToken(ctx, issuer.signIDToken(t, "n1", nil), "n1")
+		assert.NoError(t, err)
+	})
+}
+
+func TestOIDCProvider_MapRoles(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := newTestOIDCProvider(t, issuer)
+
+	assert.Equal(t, []string{"admin", "translator"}, provider.MapRoles([]string{"admins", "translators", "admins"}))
+	assert.Equal(t, []string{"viewer"}, provider.MapRoles([]string{"unknown"}))
+	assert.Equal(t, []string{"viewer"}, provider.MapRoles(nil))
+}
+
+func TestNewOIDCProvider_InvalidIssuer(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	_, err := NewOIDCProvider(context.Background(), OIDCConfig{ClientID: "translator"})
+	assert.Error(t, err)
+
+	_, err = NewOIDCProvider(context.Background(), OIDCConfig{
+		IssuerURL:   issuer.server.URL + "/other",
+		ClientID:    "translator",
+		RedirectURL: "http://localhost/callback",
+	})
+	assert.Error(t, err)
+}
+
+func TestUserAuthService_AuthenticateOIDC(t *testing.T) {
+	repo := models.NewInMemoryUserRepository()
+	uas := NewUserAuthService("test-secret-key-16-chars", time.Hour, repo)
+
+	identity := &OIDCIdentity{
+		Issuer:        "https://idp.example.com",
+		Subject:       "sub-1",
+		Username:      "ana",
+		Email:         "ana@example.com",
+		EmailVerified: true,
+		Roles:         []string{"translator"},
+	}
+
+	// First login provisions the account
+	response, err := uas.AuthenticateOIDC(identity)
+	require.NoError(t, err)
+	assert.Equal(t, "ana", response.Username)
+	assert.Equal(t, []string{"translator"}, response.Roles)
+
+	claims, err := uas.ValidateToken(response.Token)
+	require.NoError(t, err)
+	assert.Equal(t, response.UserID, claims.UserID)
+
+	// Later logins find the account by subject and sync roles
+	identity.Roles = []string{"admin"}
+	identity.Username = "renamed"
+	again, err := uas.AuthenticateOIDC(identity)
+	require.NoError(t, err)
+	assert.Equal(t, response.UserID, again.UserID)
+	assert.Equal(t, []string{"admin"}, again.Roles)
+
+	users, err := repo.List()
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// Inactive accounts stay locked out
+	users[0].IsActive = false
+	_, err = uas.AuthenticateOIDC(identity)
+	assert.ErrorIs(t, err, models.ErrUserInactive)
+
+	_, err = uas.AuthenticateOIDC(&OIDCIdentity{})
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
+
+func TestUserAuthService_AuthenticateOIDCNeverClaimsLocalAccounts(t *testing.T) {
+	repo := models.NewInMemoryUserRepository()
+	uas := NewUserAuthService("test-secret-key-16-chars", time.Hour, repo)
+
+	admin, err := uas.CreateUser(CreateUserRequest{
+		Username: "admin",
+		Email:    "admin@example.com",
+		Password: "local-password",
+		Roles:    []string{"admin"},
+	})
+	require.NoError(t, err)
+
+	// A matching username or unverified email never links
+	attacker := &OIDCIdentity{
+		Issuer:      "https://idp.example.com",
+		Subject:     "attacker",
+		Username:    "admin",
+		Email:       "admin@example.com",
+		LinkByEmail: true,
+		Roles:       []string{"user"},
+	}
+	response, err := uas.AuthenticateOIDC(attacker)
+	require.NoError(t, err)
+	assert.NotEqual(t, admin.ID, response.UserID)
+	assert.NotEqual(t, "admin", response.Username)
+
+	stored, err := repo.FindByUsername("admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, stored.Roles)
+	assert.Empty(t, stored.OIDCSubject)
+
+	// A verified email links only when the user asks for it
+	owner := &OIDCIdentity{
+		Issuer:        "https://idp.example.com",
+		Subject:       "owner",
+		Username:      "someone",
+		Email:         "admin@example.com",
+		EmailVerified: true,
+		Roles:         []string{"admin"},
+	}
+	response, err = uas.AuthenticateOIDC(owner)
+	require.NoError(t, err)
+	assert.NotEqual(t, admin.ID, response.UserID)
+
+	owner.Subject = "owner-2"
+	owner.LinkByEmail = true
+	response, err = uas.AuthenticateOIDC(owner)
+	require.NoError(t, err)
+	assert.Equal(t, admin.ID, response.UserID)
+
+	linked, err := repo.FindByOIDCSubject("https://idp.example.com", "owner-2")
+	require.NoError(t, err)
+	assert.Equal(t, admin.ID, linked.ID)
+
+	// A second identity cannot take over an account that is already linked
+	owner.Subject = "owner-3"
+	_, err = uas.AuthenticateOIDC(owner)
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
@@ -424,6 +424,19 @@ func (m *MockUserRepository) FindByUsername(username string) (*models.User, erro
 	return user, nil
 }
 
+func (m *MockUserRepository) FindByOIDCSubject(issuer, subject string) (*models.User, error) {
+	if m.forceError {
+		return nil, fmt.Errorf("forced repository error")
+	}
+
+	for _, user := range m.users {
+		if user.OIDCIssuer == issuer && user.OIDCSubject == subject {
+			return user, nil
+		}
+	}
+	return nil, models.ErrUserNotFound
+}
+
 func (m *MockUserRepository) FindByEmail(email string) (*models.User, error) {
 	if m.forceError {
 		return nil, fmt.Errorf("forced repository error")
@@ -2,6 +2,7 @@ package security
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -75,6 +76,104 @@ func (uas *UserAuthService) AuthenticateUser(req LoginRequest) (*LoginResponse,
 	}, nil
 }
 
+// AuthenticateOIDC signs in a user verified by an OIDC provider. Accounts are linked by
+// issuer and subject only; an existing local account is linked by email just when the
+// provider verified the address and the user asked for it. Any other first login
+// provisions a new account, so an identity provider can never claim a local user by name
+func (uas *UserAuthService) AuthenticateOIDC(identity *OIDCIdentity) (*LoginResponse, error) {
+	if identity == nil || identity.Issuer == "" || identity.Subject == "" {
+		return nil, models.ErrInvalidCredentials
+	}
+
+	user, err := uas.userRepo.FindByOIDCSubject(identity.Issuer, identity.Subject)
+	if errors.Is(err, models.ErrUserNotFound) && identity.LinkByEmail && identity.EmailVerified && identity.Email != "" {
+		user, err = uas.userRepo.FindByEmail(identity.Email)
+		if err == nil && user.OIDCSubject != "" {
+			// Already linked to another identity
+			return nil, models.ErrInvalidCredentials
+		}
+	}
+
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		user, err = uas.provisionOIDCUser(identity)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	default:
+		if !user.IsActive {
+			return nil, models.ErrUserInactive
+		}
+		user.OIDCIssuer = identity.Issuer
+		user.OIDCSubject = identity.Subject
+		user.Roles = identity.Roles
+		if err := uas.userRepo.Update(user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	token, err := uas.GenerateToken(user.ID, user.Username, user.Roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &LoginResponse{
+		Token:    token,
+		UserID:   user.ID,
+		Username: user.Username,
+		Roles:    user.Roles,
+		TokenTTL: uas.tokenTTL,
+	}, nil
+}
+
+// provisionOIDCUser creates the local account for a first SSO login, picking a
+// username that does not collide with an existing account
+func (uas *UserAuthService) provisionOIDCUser(identity *OIDCIdentity) (*models.User, error) {
+	username := identity.Username
+	if username == "" {
+		username = identity.Subject
+	}
+	if _, err := uas.userRepo.FindByUsername(username); err == nil {
+		digest := sha256.Sum256([]byte(identity.Issuer + "\x00" + identity.Subject))
+		username = fmt.Sprintf("%s-%s", username, hex.EncodeToString(digest[:4]))
+		if _, err := uas.userRepo.FindByUsername(username); err == nil {
+			return nil, models.ErrUserAlreadyExists
+		}
+	}
+
+	// Only keep addresses the provider vouches for that no other account uses
+	email := ""
+	if identity.EmailVerified && identity.Email != "" {
+		if _, err := uas.userRepo.FindByEmail(identity.Email); errors.Is(err, models.ErrUserNotFound) {
+			email = identity.Email
+		}
+	}
+
+	// SSO users never log in with a password, so give them an unguessable one
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	user := &models.User{
+		ID:          generateUserID(),
+		Username:    username,
+		Email:       email,
+		Password:    hex.EncodeToString(password),
+		Roles:       identity.Roles,
+		IsActive:    true,
+		OIDCIssuer:  identity.Issuer,
+		OIDCSubject: identity.Subject,
+	}
+	if err := uas.userRepo.Create(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
 // ValidateUser validates a user's existence and status
 func (uas *UserAuthService) ValidateUser(userID string) (*models.User, error) {
 	// Find user by ID
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/pkg/language"
+	"digital.vasic.translator/pkg/metrics"
+	"digital.vasic.translator/pkg/script"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+	"digital.vasic.translator/pkg/verification"
+)
+
+const (
+	// DefaultSegmentConcurrency is the number of segments translated at once when the caller sets no limit
+	DefaultSegmentConcurrency = 4
+
+	// MaxSegmentConcurrency caps the number of segments translated at once
+	MaxSegmentConcurrency = 32
+)
+
+// SetCache enables the shared translation cache used for segment translation
+func (s *Service) SetCache(c *cache.Cache) {
+	s.cache = c
+}
+
+// SetMetrics enables counting of translation cache lookups
+func (s *Service) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
+// SegmentSettings configures a segment translator
+type SegmentSettings struct {
+	Provider       string
+	Model          string
+	Script         string // "latin" converts translations to Latin script
+	Concurrency    int
+	SourceLanguage string           // Defaults to the project's source language, else Russian
+	TargetLanguage string           // Defaults to the project's target language, else Serbian
+	Project        *storage.Project // Applies the project's glossary and style guide and scopes the cache to it
+}
+
+// Segment is a unit of text translated on its own
+type Segment struct {
+	ID      string
+	Text    string
+	Context string
+}
+
+// SegmentResult is the translation of a segment
+type SegmentResult struct {
+	ID           string
+	Translated   string
+	Provider     string
+	CacheHit     bool
+	QualityScore float64
+}
+
+// SegmentTranslator translates segments concurrently with fixed provider settings
+type SegmentTranslator struct {
+	settings SegmentSettings
+	cache    *cache.Cache
+	metrics  *metrics.Metrics
+	verifier *verification.Verifier
+	source   language.Language
+	target   language.Language
+	idle     chan translator.Translator
+}
+
+// NewSegmentTranslator creates a segment translator. Translators keep per-instance
+// state, so one is created for each segment that may be in flight.
+func (s *Service) NewSegmentTranslator(settings SegmentSettings) (*SegmentTranslator, error) {
+	if settings.Concurrency <= 0 {
+		settings.Concurrency = DefaultSegmentConcurrency
+	}
+	if settings.Concurrency > MaxSegmentConcurrency {
+		settings.Concurrency = MaxSegmentConcurrency
+	}
+	if settings.Script != "" && settings.Script != "latin" && settings.Script != "cyrillic" {
+		return nil, ErrInvalidScript
+	}
+
+	var projectSource, projectTarget string
+	if settings.Project != nil {
+		projectSource = settings.Project.DefaultSourceLanguage
+		projectTarget = settings.Project.DefaultTargetLanguage
+	}
+	source, err := segmentLanguage("source", settings.SourceLanguage, projectSource, sourceLanguage)
+	if err != nil {
+		return nil, err
+	}
+	target, err := segmentLanguage("target", settings.TargetLanguage, projectTarget, targetLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &SegmentTranslator{
+		settings: settings,
+		cache:    s.cache,
+		metrics:  s.metrics,
+		verifier: verification.NewVerifierWithConfig(source, target, nil, "", verification.VerificationConfig{}),
+		source:   source,
+		target:   target,
+		idle:     make(chan translator.Translator, settings.Concurrency),
+	}
+
+	for i := 0; i < settings.Concurrency; i++ {
+		trans, err := s.translators(settings.Provider, settings.Model, source.Code, target.Code)
+		if err != nil {
+			return nil, &InvalidArgumentError{Message: err.Error()}
+		}
+		st.idle <- trans
+	}
+
+	return st, nil
+}
+
+// segmentLanguage resolves the language requested for one side of a translation,
+// falling back to the project default and then to the translator's language
+func segmentLanguage(side, requested, projectDefault, fallback string) (language.Language, error) {
+	code := requested
+	if code == "" {
+		code = projectDefault
+	}
+	if code == "" {
+		code = fallback
+	}
+
+	lang, err := language.ParseLanguage(code)
+	if err != nil {
+		return language.Language{}, invalidArgument("invalid %s language: %s", side, code)
+	}
+	return lang, nil
+}
+
+// Concurrency returns the number of segments that can be translated at once
+func (st *SegmentTranslator) Concurrency() int {
+	return st.settings.Concurrency
+}
+
+// Translate translates a segment, blocking while all translators are busy.
+// It is safe to call from multiple goroutines.
+func (st *SegmentTranslator) Translate(ctx context.Context, segment Segment) (*SegmentResult, error) {
+	if strings.TrimSpace(segment.Text) == "" {
+		return nil, invalidArgument("segment text is required")
+	}
+
+	var trans translator.Translator
+	select {
+	case trans = <-st.idle:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { st.idle <- trans }()
+
+	result := &SegmentResult{ID: segment.ID, Provider: trans.GetName()}
+
+	key := st.cacheKey(trans, segment)
+	if translated, ok := st.cached(key); ok {
+		result.Translated = translated
+		result.CacheHit = true
+	} else {
+		cachedBefore := trans.GetStats().Cached
+		translated, err := trans.Translate(ctx, segment.Text, ProjectContext(st.settings.Project, segment.Text, segment.Context))
+		if err != nil {
+			return nil, err
+		}
+		// The translator may also answer from its own translation memory
+		result.Translated = translated
+		result.CacheHit = trans.GetStats().Cached > cachedBefore
+		if st.cache != nil {
+			st.cache.Set(key, translated)
+		}
+	}
+
+	switch st.settings.Script {
+	case "latin":
+		result.Translated = script.NewConverter().ToLatin(result.Translated)
+	case "cyrillic":
+		result.Translated = script.NewConverter().ToCyrillic(result.Translated)
+	}
+
+	verified, err := st.verifier.VerifyTranslation(ctx, verification.VerificationRequest{
+		Original:   segment.Text,
+		Translated: result.Translated,
+		SourceLang: st.source.Code,
+		TargetLang: st.target.Code,
+		Context:    segment.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.QualityScore = verified.QualityScore
+
+	return result, nil
+}
+
+// cached looks a segment translation up in the shared cache
+func (st *SegmentTranslator) cached(key string) (string, bool) {
+	if st.cache == nil {
+		return "", false
+	}
+
+	translated, ok := st.cache.Get(key)
+	st.metrics.CacheLookup(metrics.CacheTranslation, ok)
+	return translated, ok
+}
+
+// cacheKey identifies a segment translation in the shared cache. Translations
+// of different projects are kept apart, since glossaries and style guides differ,
+// and so are translations between different languages.
+func (st *SegmentTranslator) cacheKey(trans translator.Translator, segment Segment) string {
+	projectID := ""
+	if st.settings.Project != nil {
+		projectID = st.settings.Project.ID
+	}
+	return fmt.Sprintf("segment|%s|%s-%s|%s|%s|%s|%s", projectID, st.source.Code, st.target.Code, trans.GetName(), st.settings.Model, segment.Context, segment.Text)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTranslator tracks how many translations run at the same time
+type countingTranslator struct {
+	fakeTranslator
+	active  *int32
+	maxSeen *int32
+	calls   *int32
+}
+
+func (c *countingTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	atomic.AddInt32(c.calls, 1)
+	n := atomic.AddInt32(c.active, 1)
+	defer atomic.AddInt32(c.active, -1)
+	for {
+		seen := atomic.LoadInt32(c.maxSeen)
+		if n <= seen || atomic.CompareAndSwapInt32(c.maxSeen, seen, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return c.fakeTranslator.Translate(ctx, text, contextHint)
+}
+
+func TestSegmentTranslator(t *testing.T) {
+	var active, maxSeen, calls int32
+	var providers []string
+	factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		if provider == "broken" {
+			return nil, errors.New("unsupported provider: broken")
+		}
+		providers = append(providers, provider)
+		return &countingTranslator{
+			fakeTranslator: fakeTranslator{name: provider},
+			active:         &active,
+			maxSeen:        &maxSeen,
+			calls:          &calls,
+		}, nil
+	}
+
+	svc := New(config.DefaultConfig(), events.NewEventBus(), factory, nil, nil)
+	svc.SetCache(cache.NewCache(time.Minute, true))
+
+	segments, err := svc.NewSegmentTranslator(SegmentSettings{Provider: "deepseek", Script: "latin", Concurrency: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, segments.Concurrency())
+	assert.Equal(t, []string{"deepseek", "deepseek"}, providers)
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	results := make([]*SegmentResult, 6)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := segments.Translate(ctx, Segment{ID: string(rune('a' + i)), Text: "књига број " + string(rune('1'+i))})
+			require.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxSeen), int32(2))
+	for i, result := range results {
+		assert.Equal(t, string(rune('a'+i)), result.ID)
+		assert.Equal(t, "deepseek", result.Provider)
+		assert.Equal(t, "prevod knjiga broj "+string(rune('1'+i)), result.Translated)
+		assert.False(t, result.CacheHit)
+		assert.Greater(t, result.QualityScore, 0.0)
+	}
+
+	// Repeated segments are served from the cache
+	result, err := segments.Translate(ctx, Segment{ID: "again", Text: "књига број 1"})
+	require.NoError(t, err)
+	assert.True(t, result.CacheHit)
+	assert.Equal(t, "prevod knjiga broj 1", result.Translated)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+
+	_, err = segments.Translate(ctx, Segment{ID: "empty"})
+	assert.True(t, IsInvalidArgument(err))
+
+	_, err = svc.NewSegmentTranslator(SegmentSettings{Provider: "broken"})
+	assert.True(t, IsInvalidArgument(err))
+
+	_, err = svc.NewSegmentTranslator(SegmentSettings{Script: "greek"})
+	assert.ErrorIs(t, err, ErrInvalidScript)
+
+	providers = nil
+	segments, err = svc.NewSegmentTranslator(SegmentSettings{Concurrency: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, MaxSegmentConcurrency, segments.Concurrency())
+}
+
+func TestSegmentCacheScopedToProject(t *testing.T) {
+	var active, maxSeen, calls int32
+	factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		return &countingTranslator{
+			fakeTranslator: fakeTranslator{name: "fake"},
+			active:         &active,
+			maxSeen:        &maxSeen,
+			calls:          &calls,
+		}, nil
+	}
+
+	svc := New(config.DefaultConfig(), events.NewEventBus(), factory, nil, nil)
+	svc.SetCache(cache.NewCache(time.Minute, true))
+	ctx := context.Background()
+
+	for _, project := range []*storage.Project{{ID: "p1"}, {ID: "p2"}, {ID: "p1"}} {
+		segments, err := svc.NewSegmentTranslator(SegmentSettings{Concurrency: 1, Project: project})
+		require.NoError(t, err)
+		_, err = segments.Translate(ctx, Segment{ID: "1", Text: "книга"})
+		require.NoError(t, err)
+	}
+
+	// The repeated project is answered from the cache, the other project is not
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestSegmentTranslatorLanguages(t *testing.T) {
+	svc := newTestService(t, nil)
+	var pairs []string
+	inner := svc.translators
+	svc.translators = func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		pairs = append(pairs, sourceLang+"-"+targetLang)
+		return inner(provider, model, sourceLang, targetLang)
+	}
+
+	segments, err := svc.NewSegmentTranslator(SegmentSettings{})
+	require.NoError(t, err)
+	assert.Equal(t, "ru", segments.source.Code)
+	assert.Equal(t, "sr", segments.target.Code)
+
+	// The project's defaults apply unless the request names a language
+	project := &storage.Project{ID: "p1", DefaultSourceLanguage: "en", DefaultTargetLanguage: "hr"}
+	pairs = nil
+	segments, err = svc.NewSegmentTranslator(SegmentSettings{Project: project, TargetLanguage: "de", Concurrency: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "en", segments.source.Code)
+	assert.Equal(t, "de", segments.target.Code)
+
+	// The translators translate between the languages the stream is verified in
+	assert.Equal(t, []string{"en-de", "en-de"}, pairs)
+
+	_, err = svc.NewSegmentTranslator(SegmentSettings{SourceLanguage: "klingon"})
+	assert.True(t, IsInvalidArgument(err))
+	assert.Equal(t, "invalid source language: klingon", err.Error())
+}
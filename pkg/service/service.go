@@ -0,0 +1,500 @@
+// Package service holds the translation operations shared by the REST and gRPC APIs.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"digital.vasic.translator/internal/cache"
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/distributed"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/language"
+	"digital.vasic.translator/pkg/metrics"
+	"digital.vasic.translator/pkg/script"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+	"digital.vasic.translator/pkg/translator/llm"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDistributedUnavailable is returned when distributed work is not configured
+	ErrDistributedUnavailable = errors.New("distributed work not available")
+
+	// ErrQueueUnavailable is returned when the job queue for pull workers is not enabled
+	ErrQueueUnavailable = errors.New("job queue not enabled")
+
+	// ErrInvalidScript is returned for unknown script conversion targets
+	ErrInvalidScript = errors.New("invalid target script")
+
+	// ErrStorageUnavailable is returned when an operation needs persistence that is not configured
+	ErrStorageUnavailable = errors.New("storage not configured")
+)
+
+// InvalidArgumentError reports a request that cannot be served as given
+type InvalidArgumentError struct {
+	Message string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.Message
+}
+
+func invalidArgument(format string, args ...interface{}) error {
+	return &InvalidArgumentError{Message: fmt.Sprintf(format, args...)}
+}
+
+// IsInvalidArgument reports whether err was caused by bad input
+func IsInvalidArgument(err error) bool {
+	var target *InvalidArgumentError
+	return errors.As(err, &target)
+}
+
+const (
+	// sourceLanguage and targetLanguage are the languages translated between
+	// unless a request, project or job names others
+	sourceLanguage = "ru"
+	targetLanguage = "sr"
+)
+
+// TranslatorFactory creates a translator for a provider and model that
+// translates from sourceLang to targetLang
+type TranslatorFactory func(provider, model, sourceLang, targetLang string) (translator.Translator, error)
+
+// Service implements the operations exposed by both the REST and the gRPC API
+type Service struct {
+	config      *config.Config
+	eventBus    *events.EventBus
+	translators TranslatorFactory
+	distributed *distributed.DistributedManager
+	storage     storage.Storage
+	cache       *cache.Cache
+	metrics     *metrics.Metrics
+}
+
+// New creates a service. translators may be nil to build LLM translators from
+// cfg; dm and store may be nil when distributed work or persistence are disabled.
+func New(cfg *config.Config, eventBus *events.EventBus, translators TranslatorFactory, dm *distributed.DistributedManager, store storage.Storage) *Service {
+	if translators == nil {
+		translators = func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+			return NewLLMTranslator(cfg, provider, model, sourceLang, targetLang)
+		}
+	}
+
+	return &Service{
+		config:      cfg,
+		eventBus:    eventBus,
+		translators: translators,
+		distributed: dm,
+		storage:     store,
+	}
+}
+
+// NewLLMTranslator creates an LLM translator using the provider settings from
+// cfg; empty languages select Russian to Serbian
+func NewLLMTranslator(cfg *config.Config, providerName, model, sourceLang, targetLang string) (translator.Translator, error) {
+	if providerName == "" {
+		providerName = cfg.Translation.DefaultProvider
+	}
+	sourceLang, targetLang = languagePair(sourceLang, targetLang)
+
+	translationConfig := translator.TranslationConfig{
+		SourceLang: sourceLang,
+		TargetLang: targetLang,
+		Provider:   providerName,
+		Model:      model,
+		Options:    make(map[string]interface{}),
+	}
+
+	// Load provider config
+	if providerCfg, ok := cfg.Translation.Providers[providerName]; ok {
+		translationConfig.APIKey = providerCfg.APIKey
+		translationConfig.BaseURL = providerCfg.BaseURL
+		if model == "" {
+			translationConfig.Model = providerCfg.Model
+		}
+		translationConfig.Options = providerCfg.Options
+	}
+
+	return llm.NewLLMTranslator(translationConfig)
+}
+
+// TranslatorLabels returns the metric labels of the translator NewLLMTranslator
+// creates for a provider, model and languages
+func TranslatorLabels(cfg *config.Config, providerName, model, sourceLang, targetLang string) metrics.Labels {
+	if providerName == "" {
+		providerName = cfg.Translation.DefaultProvider
+	}
+	if providerCfg, ok := cfg.Translation.Providers[providerName]; ok && model == "" {
+		model = providerCfg.Model
+	}
+	sourceLang, targetLang = languagePair(sourceLang, targetLang)
+
+	return metrics.Labels{
+		Provider:     providerName,
+		Model:        model,
+		LanguagePair: sourceLang + "-" + targetLang,
+	}
+}
+
+// languagePair fills in the default languages of a translation
+func languagePair(sourceLang, targetLang string) (string, string) {
+	if sourceLang == "" {
+		sourceLang = sourceLanguage
+	}
+	if targetLang == "" {
+		targetLang = targetLanguage
+	}
+	return sourceLang, targetLang
+}
+
+// TextRequest represents a text translation request
+type TextRequest struct {
+	Text     string
+	Provider string
+	Model    string
+	Context  string
+	Script   string
+	Project  *storage.Project // Applies the project's glossary, style guide and translation cache
+}
+
+// TextResult represents a translated text
+type TextResult struct {
+	Original   string
+	Translated string
+	Provider   string
+	SessionID  string
+	Stats      translator.TranslationStats
+}
+
+// TranslateText translates a piece of text, preferring distributed workers when available
+func (s *Service) TranslateText(ctx context.Context, req TextRequest) (*TextResult, error) {
+	if req.Text == "" {
+		return nil, invalidArgument("text is required")
+	}
+
+	trans, err := s.translators(req.Provider, req.Model, sourceLanguage, targetLanguage)
+	if err != nil {
+		return nil, &InvalidArgumentError{Message: err.Error()}
+	}
+
+	sessionID := uuid.New().String()
+
+	translated, cached, err := s.projectCached(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		contextHint := ProjectContext(req.Project, req.Text, req.Context)
+		translated, err = s.translate(ctx, trans, req.Text, contextHint, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.cacheProjectTranslation(ctx, req, translated); err != nil {
+			return nil, err
+		}
+	}
+
+	// Convert script if requested
+	if req.Script == "latin" {
+		translated = script.NewConverter().ToLatin(translated)
+	}
+
+	return &TextResult{
+		Original:   req.Text,
+		Translated: translated,
+		Provider:   trans.GetName(),
+		SessionID:  sessionID,
+		Stats:      trans.GetStats(),
+	}, nil
+}
+
+// translate translates text, preferring distributed workers when available
+func (s *Service) translate(ctx context.Context, trans translator.Translator, text, contextHint, sessionID string) (string, error) {
+	if s.distributed == nil {
+		return trans.TranslateWithProgress(ctx, text, contextHint, s.eventBus, sessionID)
+	}
+
+	translated, err := s.distributed.TranslateDistributed(ctx, text, contextHint)
+	if err != nil {
+		// Fallback to local translation
+		s.eventBus.Publish(events.Event{
+			Type:      "distributed_fallback",
+			SessionID: sessionID,
+			Message:   "Distributed translation failed, using local translator",
+			Data: map[string]interface{}{
+				"error": err.Error(),
+			},
+		})
+		return trans.TranslateWithProgress(ctx, text, contextHint, s.eventBus, sessionID)
+	}
+	return translated, nil
+}
+
+// ProjectContext builds the context hint for text translated within a project
+// from the project's style guide, the caller's context and the glossary terms
+// that occur in the text. Without a project the caller's context is returned.
+func ProjectContext(project *storage.Project, text, contextHint string) string {
+	if project == nil {
+		return contextHint
+	}
+
+	var parts []string
+	if project.StyleGuide != "" {
+		parts = append(parts, "Style guide: "+project.StyleGuide)
+	}
+	if contextHint != "" {
+		parts = append(parts, contextHint)
+	}
+
+	var terms []string
+	lower := strings.ToLower(text)
+	for _, entry := range project.Glossary {
+		if entry.Source != "" && strings.Contains(lower, strings.ToLower(entry.Source)) {
+			terms = append(terms, fmt.Sprintf("%s = %s", entry.Source, entry.Target))
+		}
+	}
+	if len(terms) > 0 {
+		parts = append(parts, "Glossary (use these translations): "+strings.Join(terms, "; "))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// projectProvider returns the provider and model a project translation is cached under
+func (s *Service) projectProvider(req TextRequest) (string, string) {
+	provider := req.Provider
+	if provider == "" {
+		provider = s.config.Translation.DefaultProvider
+	}
+	return provider, req.Model
+}
+
+// projectCached looks a text up in the translation cache of its project. The
+// context is part of the lookup, since the same text may translate differently.
+func (s *Service) projectCached(ctx context.Context, req TextRequest) (string, bool, error) {
+	if req.Project == nil || s.storage == nil {
+		return "", false, nil
+	}
+
+	provider, model := s.projectProvider(req)
+	cached, err := s.storage.GetProjectCachedTranslation(ctx, req.Project.ID, projectCacheText(req), sourceLanguage, targetLanguage, provider, model)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read project cache: %w", err)
+	}
+	if cached == nil {
+		return "", false, nil
+	}
+	return cached.TargetText, true, nil
+}
+
+// cacheProjectTranslation stores a translation in the cache of its project
+func (s *Service) cacheProjectTranslation(ctx context.Context, req TextRequest, translated string) error {
+	if req.Project == nil || s.storage == nil {
+		return nil
+	}
+
+	provider, model := s.projectProvider(req)
+	now := time.Now()
+	err := s.storage.CacheTranslation(ctx, &storage.TranslationCache{
+		ID:             uuid.New().String(),
+		ProjectID:      req.Project.ID,
+		SourceText:     projectCacheText(req),
+		TargetText:     translated,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		Provider:       provider,
+		Model:          model,
+		CreatedAt:      now,
+		LastAccessedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update project cache: %w", err)
+	}
+	return nil
+}
+
+// projectCacheText is the source text a translation is cached under: the text
+// and, when given, the caller's context
+func projectCacheText(req TextRequest) string {
+	if req.Context == "" {
+		return req.Text
+	}
+	return req.Context + "\n\n" + req.Text
+}
+
+// ConvertScript converts Serbian text to the "latin" or "cyrillic" script
+func (s *Service) ConvertScript(text, target string) (string, error) {
+	converter := script.NewConverter()
+
+	switch target {
+	case "latin":
+		return converter.ToLatin(text), nil
+	case "cyrillic":
+		return converter.ToCyrillic(text), nil
+	default:
+		return "", ErrInvalidScript
+	}
+}
+
+// Transliterate converts text of the given language to "latin" script, or
+// back to its native script for any other target, following the standard
+// ("national" when empty)
+func (s *Service) Transliterate(text, lang, standard, target string) (string, error) {
+	if standard == "" {
+		standard = string(script.National)
+	}
+
+	tr, err := script.NewTransliterator(lang, script.Standard(standard))
+	if err != nil {
+		return "", invalidArgument("%v", err)
+	}
+
+	if target == "latin" {
+		return tr.Transliterate(text), nil
+	}
+	converted, err := tr.Reverse(text)
+	if err != nil {
+		return "", invalidArgument("%v", err)
+	}
+	return converted, nil
+}
+
+// DetectLanguage detects the language of a text
+func (s *Service) DetectLanguage(ctx context.Context, text string) (language.Language, error) {
+	if text == "" {
+		return language.Language{}, invalidArgument("text is required")
+	}
+
+	return language.NewDetector(nil).Detect(ctx, text)
+}
+
+// PreparationRequest represents a content preparation analysis request
+type PreparationRequest struct {
+	InputPath      string
+	SourceLanguage string
+	TargetLanguage string
+	Format         string
+	ProjectID      string
+}
+
+// AnalyzePreparation inspects the input of a future translation and stores the result
+func (s *Service) AnalyzePreparation(ctx context.Context, req PreparationRequest) (*storage.PreparationResult, error) {
+	targetLang, err := language.ParseLanguage(req.TargetLanguage)
+	if err != nil {
+		return nil, invalidArgument("invalid target language: %v", err)
+	}
+
+	if _, err := os.Stat(req.InputPath); os.IsNotExist(err) {
+		return nil, invalidArgument("input path does not exist")
+	}
+
+	sessionID := uuid.New().String()
+
+	analysis := map[string]interface{}{
+		"input_path":      req.InputPath,
+		"target_language": targetLang.Code,
+		"format":          req.Format,
+		"status":          "analyzing",
+		"session_id":      sessionID,
+	}
+
+	// Emit analysis started event
+	s.eventBus.Publish(events.Event{
+		Type:      events.EventTranslationStarted,
+		SessionID: sessionID,
+		Message:   "Content preparation analysis started",
+		Data:      copyData(analysis),
+	})
+
+	// Perform basic analysis
+	fileInfo, err := os.Stat(req.InputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze input: %w", err)
+	}
+
+	analysis["file_size"] = fileInfo.Size()
+	analysis["file_modified"] = fileInfo.ModTime()
+	analysis["is_directory"] = fileInfo.IsDir()
+
+	if fileInfo.IsDir() {
+		// Count files in directory
+		fileCount := 0
+		filepath.Walk(req.InputPath, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				fileCount++
+			}
+			return nil
+		})
+		analysis["file_count"] = fileCount
+	}
+
+	analysis["status"] = "completed"
+
+	result := &storage.PreparationResult{
+		SessionID: sessionID,
+		ProjectID: req.ProjectID,
+		Status:    "completed",
+		Analysis:  analysis,
+		CreatedAt: time.Now(),
+	}
+
+	if s.storage != nil {
+		if err := s.storage.SavePreparationResult(ctx, result); err != nil {
+			return nil, fmt.Errorf("failed to store analysis: %w", err)
+		}
+	}
+
+	// Emit completion event
+	s.eventBus.Publish(events.Event{
+		Type:      events.EventTranslationCompleted,
+		SessionID: sessionID,
+		Message:   "Content preparation analysis completed",
+		Data:      copyData(analysis),
+	})
+
+	return result, nil
+}
+
+// GetPreparationResult loads a stored preparation result
+func (s *Service) GetPreparationResult(ctx context.Context, sessionID string) (*storage.PreparationResult, error) {
+	if sessionID == "" {
+		return nil, invalidArgument("session_id is required")
+	}
+	if s.storage == nil {
+		return nil, ErrStorageUnavailable
+	}
+
+	return s.storage.GetPreparationResult(ctx, sessionID)
+}
+
+// maxEventsPerQuery bounds the events returned by one EventsSince call
+const maxEventsPerQuery = 1000
+
+// EventsSince returns up to limit logged events of a session with a sequence
+// greater than afterSequence, so clients can catch up on missed events
+func (s *Service) EventsSince(sessionID string, afterSequence int64, limit int) ([]events.Event, error) {
+	if afterSequence < 0 {
+		return nil, invalidArgument("after sequence cannot be negative")
+	}
+	if limit <= 0 || limit > maxEventsPerQuery {
+		limit = maxEventsPerQuery
+	}
+	return s.eventBus.EventsSince(sessionID, afterSequence, limit)
+}
+
+// copyData returns a shallow copy of event data so later changes do not leak into published events
+func copyData(data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"digital.vasic.translator/internal/config"
+	"digital.vasic.translator/pkg/distributed"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+	"digital.vasic.translator/pkg/translator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTranslator returns the input text with a prefix
+type fakeTranslator struct {
+	name string
+}
+
+func (f *fakeTranslator) Translate(ctx context.Context, text, contextHint string) (string, error) {
+	return "prevod " + text, nil
+}
+
+func (f *fakeTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	return f.Translate(ctx, text, contextHint)
+}
+
+func (f *fakeTranslator) GetStats() translator.TranslationStats {
+	return translator.TranslationStats{Total: 1, Translated: 1}
+}
+
+func (f *fakeTranslator) GetName() string {
+	return f.name
+}
+
+func newTestService(t *testing.T, store storage.Storage) *Service {
+	t.Helper()
+
+	factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		if provider == "broken" {
+			return nil, errors.New("unsupported provider: broken")
+		}
+		return &fakeTranslator{name: "fake"}, nil
+	}
+
+	return New(config.DefaultConfig(), events.NewEventBus(), factory, nil, store)
+}
+
+func TestTranslateText(t *testing.T) {
+	svc := newTestService(t, nil)
+	ctx := context.Background()
+
+	result, err := svc.TranslateText(ctx, TextRequest{Text: "књига", Script: "latin"})
+	require.NoError(t, err)
+	assert.Equal(t, "књига", result.Original)
+	assert.Equal(t, "prevod knjiga", result.Translated)
+	assert.Equal(t, "fake", result.Provider)
+	assert.NotEmpty(t, result.SessionID)
+	assert.Equal(t, 1, result.Stats.Translated)
+
+	_, err = svc.TranslateText(ctx, TextRequest{})
+	assert.True(t, IsInvalidArgument(err))
+
+	_, err = svc.TranslateText(ctx, TextRequest{Text: "x", Provider: "broken"})
+	assert.True(t, IsInvalidArgument(err))
+	assert.Contains(t, err.Error(), "unsupported provider")
+}
+
+func TestConvertScript(t *testing.T) {
+	svc := newTestService(t, nil)
+
+	latin, err := svc.ConvertScript("Београд", "latin")
+	require.NoError(t, err)
+	assert.Equal(t, "Beograd", latin)
+
+	cyrillic, err := svc.ConvertScript("Beograd", "cyrillic")
+	require.NoError(t, err)
+	assert.Equal(t, "Београд", cyrillic)
+
+	_, err = svc.ConvertScript("Beograd", "greek")
+	assert.ErrorIs(t, err, ErrInvalidScript)
+}
+
+func TestTransliterate(t *testing.T) {
+	svc := newTestService(t, nil)
+
+	latin, err := svc.Transliterate("Київ", "uk", "", "latin")
+	require.NoError(t, err)
+	assert.Equal(t, "Kyiv", latin)
+
+	cyrillic, err := svc.Transliterate("Ŝuka", "ru", "iso9", "cyrillic")
+	require.NoError(t, err)
+	assert.Equal(t, "Щука", cyrillic)
+
+	_, err = svc.Transliterate("Kyiv", "uk", "national", "cyrillic")
+	assert.True(t, IsInvalidArgument(err))
+
+	_, err = svc.Transliterate("text", "xx", "", "latin")
+	assert.True(t, IsInvalidArgument(err))
+}
+
+// hintTranslator records the context hints it is asked to translate with
+type hintTranslator struct {
+	fakeTranslator
+	hints []string
+}
+
+func (h *hintTranslator) TranslateWithProgress(ctx context.Context, text, contextHint string, eventBus *events.EventBus, sessionID string) (string, error) {
+	h.hints = append(h.hints, contextHint)
+	return h.Translate(ctx, text, contextHint)
+}
+
+func TestProjectContext(t *testing.T) {
+	project := &storage.Project{
+		ID:         "p1",
+		StyleGuide: "Formal register",
+		Glossary: []storage.GlossaryEntry{
+			{Source: "Книга", Target: "Књига"},
+			{Source: "сад", Target: "врт"},
+		},
+	}
+
+	assert.Equal(t, "Style guide: Formal register\nchapter 1\nGlossary (use these translations): Книга = Књига",
+		ProjectContext(project, "Это книга", "chapter 1"))
+	assert.Equal(t, "Style guide: Formal register", ProjectContext(project, "Это дом", ""))
+	assert.Equal(t, "chapter 1", ProjectContext(nil, "Это книга", "chapter 1"))
+}
+
+func TestTranslateTextProjectCache(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(&storage.Config{Database: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer store.Close()
+
+	trans := &hintTranslator{fakeTranslator: fakeTranslator{name: "fake"}}
+	factory := func(provider, model, sourceLang, targetLang string) (translator.Translator, error) {
+		return trans, nil
+	}
+	svc := New(config.DefaultConfig(), events.NewEventBus(), factory, nil, store)
+	ctx := context.Background()
+
+	project := &storage.Project{ID: "p1", Glossary: []storage.GlossaryEntry{{Source: "книга", Target: "књига"}}}
+	result, err := svc.TranslateText(ctx, TextRequest{Text: "книга", Project: project})
+	require.NoError(t, err)
+	assert.Equal(t, "prevod книга", result.Translated)
+	assert.Equal(t, []string{"Glossary (use these translations): книга = књига"}, trans.hints)
+
+	// The second request is answered from the project's cache
+	result, err = svc.TranslateText(ctx, TextRequest{Text: "книга", Project: project, Script: "latin"})
+	require.NoError(t, err)
+	assert.Equal(t, "prevod kniga", result.Translated)
+	assert.Len(t, trans.hints, 1)
+
+	// Other projects and requests without a project do not share it
+	_, err = svc.TranslateText(ctx, TextRequest{Text: "книга", Project: &storage.Project{ID: "p2"}})
+	require.NoError(t, err)
+	_, err = svc.TranslateText(ctx, TextRequest{Text: "книга"})
+	require.NoError(t, err)
+	assert.Len(t, trans.hints, 3)
+
+	cached, err := store.GetProjectCachedTranslation(ctx, "p1", "книга", "ru", "sr", "openai", "")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, "prevod книга", cached.TargetText)
+}
+
+func TestAnalyzePreparation(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(&storage.Config{Database: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer store.Close()
+
+	svc := newTestService(t, store)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0644))
+
+	result, err := svc.AnalyzePreparation(ctx, PreparationRequest{
+		InputPath:      dir,
+		TargetLanguage: "sr",
+		ProjectID:      "p1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Status)
+	assert.Equal(t, 2, result.Analysis["file_count"])
+
+	stored, err := svc.GetPreparationResult(ctx, result.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", stored.ProjectID)
+
+	_, err = svc.AnalyzePreparation(ctx, PreparationRequest{InputPath: filepath.Join(dir, "missing"), TargetLanguage: "sr"})
+	assert.True(t, IsInvalidArgument(err))
+	assert.Equal(t, "input path does not exist", err.Error())
+
+	_, err = svc.AnalyzePreparation(ctx, PreparationRequest{InputPath: dir, TargetLanguage: "xx-invalid"})
+	assert.True(t, IsInvalidArgument(err))
+	assert.Contains(t, err.Error(), "invalid target language")
+
+	_, err = svc.GetPreparationResult(ctx, "missing")
+	assert.ErrorIs(t, err, storage.ErrPreparationResultNotFound)
+}
+
+func TestWorkerOperationsWithoutDistributedManager(t *testing.T) {
+	svc := newTestService(t, nil)
+	ctx := context.Background()
+
+	_, err := svc.DistributedStatus()
+	assert.ErrorIs(t, err, ErrDistributedUnavailable)
+	assert.ErrorIs(t, svc.DiscoverWorkers(ctx), ErrDistributedUnavailable)
+	assert.ErrorIs(t, svc.PairWorker("w1"), ErrDistributedUnavailable)
+	assert.True(t, IsInvalidArgument(svc.UnpairWorker("")))
+	_, err = svc.VersionAlerts("critical")
+	assert.ErrorIs(t, err, ErrDistributedUnavailable)
+	_, err = svc.AcknowledgeAlert("a1", "ops")
+	assert.ErrorIs(t, err, ErrDistributedUnavailable)
+	_, err = svc.GetPreparationResult(ctx, "s1")
+	assert.ErrorIs(t, err, ErrStorageUnavailable)
+}
+
+func TestRunJob(t *testing.T) {
+	svc := newTestService(t, nil)
+	ctx := context.Background()
+
+	job := &distributed.TranslationJob{
+		ID:       "j1",
+		LeaseID:  "l1",
+		Segments: []distributed.JobSegment{{ID: "s1", Text: "књига"}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, svc.RunJob(ctx, job, &out, nil))
+	assert.Contains(t, out.String(), `"translated":"prevod књига"`)
+	assert.Contains(t, out.String(), `"type":"done"`)
+
+	job.Provider = "broken"
+	assert.True(t, IsInvalidArgument(svc.RunJob(ctx, job, &out, nil)))
+	assert.True(t, IsInvalidArgument(svc.RunJob(ctx, &distributed.TranslationJob{ID: "j2", LeaseID: "l2"}, &out, nil)))
+
+	_, err := svc.TranslateJob(ctx, job, nil)
+	assert.ErrorIs(t, err, ErrDistributedUnavailable)
+}
+
+func TestEventsSince(t *testing.T) {
+	svc := newTestService(t, nil)
+
+	_, err := svc.EventsSince("s1", 0, 10)
+	assert.ErrorIs(t, err, events.ErrNoEventLog)
+
+	eventLog, err := events.NewFileEventLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	require.NoError(t, err)
+	defer eventLog.Close()
+	svc.eventBus.SetLog(eventLog)
+
+	for i := 0; i < 3; i++ {
+		svc.eventBus.Publish(events.Event{Type: events.EventTranslationProgress, SessionID: "s1"})
+	}
+
+	list, err := svc.EventsSince("s1", 1, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, int64(2), list[0].Sequence)
+
+	_, err = svc.EventsSince("s1", -1, 0)
+	assert.True(t, IsInvalidArgument(err))
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"digital.vasic.translator/pkg/distributed"
+	"digital.vasic.translator/pkg/ebook"
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+)
+
+// manager returns the distributed manager or ErrDistributedUnavailable
+func (s *Service) manager() (*distributed.DistributedManager, error) {
+	if s.distributed == nil {
+		return nil, ErrDistributedUnavailable
+	}
+	return s.distributed, nil
+}
+
+// DistributedStatus returns the state of distributed work
+func (s *Service) DistributedStatus() (map[string]interface{}, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	return dm.GetStatus(), nil
+}
+
+// DiscoverWorkers discovers configured workers and pairs with them
+func (s *Service) DiscoverWorkers(ctx context.Context) error {
+	dm, err := s.manager()
+	if err != nil {
+		return err
+	}
+	return dm.DiscoverAndPairWorkers(ctx)
+}
+
+// PairWorker pairs with a configured worker
+func (s *Service) PairWorker(workerID string) error {
+	if workerID == "" {
+		return invalidArgument("Worker ID is required")
+	}
+	dm, err := s.manager()
+	if err != nil {
+		return err
+	}
+	return dm.PairWorker(workerID)
+}
+
+// UnpairWorker unpairs from a worker
+func (s *Service) UnpairWorker(workerID string) error {
+	if workerID == "" {
+		return invalidArgument("Worker ID is required")
+	}
+	dm, err := s.manager()
+	if err != nil {
+		return err
+	}
+	return dm.UnpairWorker(workerID)
+}
+
+// TranslateDistributed translates text on the paired workers only
+func (s *Service) TranslateDistributed(ctx context.Context, text, contextHint string) (string, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return "", err
+	}
+	return dm.TranslateDistributed(ctx, text, contextHint)
+}
+
+// TranslateJob ships a batch of segments to the paired workers and returns
+// the translations by segment ID. Only workers with all of tags are used.
+func (s *Service) TranslateJob(ctx context.Context, job *distributed.TranslationJob, tags []string) (map[string]string, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	return dm.TranslateJob(ctx, job, tags)
+}
+
+// TranslateBook translates a book in place on the paired workers, shipping the
+// title and then each chapter with its section titles and contents as one job.
+// Jobs of a book translated within project carry the project's languages,
+// style guide and glossary; project may be nil.
+func (s *Service) TranslateBook(ctx context.Context, book *ebook.Book, project *storage.Project, sessionID string) error {
+	dm, err := s.manager()
+	if err != nil {
+		return err
+	}
+
+	if book.Metadata.Title != "" {
+		job := bookJob(project)
+		job.Segments = []distributed.JobSegment{{ID: "title", Text: book.Metadata.Title, Context: "Book title"}}
+		results, err := dm.TranslateJob(ctx, job, nil)
+		if err != nil {
+			return fmt.Errorf("failed to translate book title: %w", err)
+		}
+		book.Metadata.Title = results["title"]
+	}
+
+	for i := range book.Chapters {
+		job := bookJob(project)
+		var targets []*string
+		var kinds []string
+		add := func(text *string, kind string) {
+			if *text == "" {
+				return
+			}
+			job.Segments = append(job.Segments, distributed.JobSegment{
+				ID:   fmt.Sprintf("%d", len(targets)),
+				Text: *text,
+			})
+			targets = append(targets, text)
+			kinds = append(kinds, kind)
+		}
+
+		chapter := &book.Chapters[i]
+		add(&chapter.Title, "Chapter title")
+		for j := range chapter.Sections {
+			addSection(&chapter.Sections[j], add)
+		}
+		if len(job.Segments) == 0 {
+			continue
+		}
+		for j := range job.Segments {
+			job.Segments[j].Context = segmentContext(job.Segments, kinds, j)
+		}
+
+		results, err := dm.TranslateJob(ctx, job, nil)
+		if err != nil {
+			return fmt.Errorf("failed to translate chapter %d: %w", i+1, err)
+		}
+		for j, segment := range job.Segments {
+			*targets[j] = results[segment.ID]
+		}
+
+		s.eventBus.Publish(events.Event{
+			Type:      events.EventTranslationProgress,
+			SessionID: sessionID,
+			Message:   fmt.Sprintf("Translated chapter %d of %d", i+1, len(book.Chapters)),
+			Data: map[string]interface{}{
+				"job_id":   job.ID,
+				"chapter":  i + 1,
+				"chapters": len(book.Chapters),
+				"segments": len(job.Segments),
+			},
+		})
+	}
+
+	return nil
+}
+
+// bookJob creates an empty job for a book translated within project, which may be nil
+func bookJob(project *storage.Project) *distributed.TranslationJob {
+	job := &distributed.TranslationJob{}
+	var projectSource, projectTarget string
+	if project != nil {
+		projectSource = project.DefaultSourceLanguage
+		projectTarget = project.DefaultTargetLanguage
+		if project.StyleGuide != "" {
+			job.Context = "Style guide: " + project.StyleGuide
+		}
+		for _, entry := range project.Glossary {
+			job.Glossary = append(job.Glossary, distributed.GlossaryTerm{Source: entry.Source, Target: entry.Target})
+		}
+	}
+	job.SourceLanguage, job.TargetLanguage = languagePair(projectSource, projectTarget)
+	return job
+}
+
+// addSection adds the title and content of a section and its subsections to a chapter job
+func addSection(section *ebook.Section, add func(text *string, kind string)) {
+	add(&section.Title, "Section title")
+	add(&section.Content, "")
+	for i := range section.Subsections {
+		addSection(&section.Subsections[i], add)
+	}
+}
+
+// segmentContextChars bounds the text taken from each neighbouring segment
+const segmentContextChars = 500
+
+// segmentContext describes segment i of a chapter job by its kind, if it is a
+// title, and the end of the segment before it and the start of the one after it
+func segmentContext(segments []distributed.JobSegment, kinds []string, i int) string {
+	var parts []string
+	if kinds[i] != "" {
+		parts = append(parts, kinds[i])
+	}
+	if i > 0 {
+		text := []rune(segments[i-1].Text)
+		if len(text) > segmentContextChars {
+			text = text[len(text)-segmentContextChars:]
+		}
+		parts = append(parts, "Previous paragraph:\n"+string(text))
+	}
+	if i+1 < len(segments) {
+		text := []rune(segments[i+1].Text)
+		if len(text) > segmentContextChars {
+			text = text[:segmentContextChars]
+		}
+		parts = append(parts, "Next paragraph:\n"+string(text))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// RunJob translates a job shipped by a coordinator on this worker and streams
+// the results to w; flush is called after every message and may be nil
+func (s *Service) RunJob(ctx context.Context, job *distributed.TranslationJob, w io.Writer, flush func()) error {
+	if err := job.Validate(); err != nil {
+		return &InvalidArgumentError{Message: err.Error()}
+	}
+
+	trans, err := s.translators(job.Provider, job.Model, job.SourceLanguage, job.TargetLanguage)
+	if err != nil {
+		return &InvalidArgumentError{Message: err.Error()}
+	}
+
+	return distributed.RunJob(ctx, job, trans, w, flush)
+}
+
+// jobQueue returns the job queue for pull workers or ErrQueueUnavailable
+func (s *Service) jobQueue() (*distributed.JobQueue, error) {
+	if s.distributed == nil || s.distributed.Queue() == nil {
+		return nil, ErrQueueUnavailable
+	}
+	return s.distributed.Queue(), nil
+}
+
+// RegisterQueueWorker registers a pull worker with the job queue
+func (s *Service) RegisterQueueWorker(ctx context.Context, reg distributed.WorkerRegistration) error {
+	if reg.WorkerID == "" {
+		return invalidArgument("Worker ID is required")
+	}
+	queue, err := s.jobQueue()
+	if err != nil {
+		return err
+	}
+	return queue.Register(ctx, reg)
+}
+
+// PullQueueJob waits up to wait for a job for a pull worker; it returns nil
+// when none arrived in time
+func (s *Service) PullQueueJob(ctx context.Context, workerID string, wait time.Duration) (*distributed.TranslationJob, error) {
+	queue, err := s.jobQueue()
+	if err != nil {
+		return nil, err
+	}
+	return queue.Pull(ctx, workerID, wait)
+}
+
+// RenewQueueLease extends a lease held by a pull worker
+func (s *Service) RenewQueueLease(ctx context.Context, workerID, leaseID string) error {
+	queue, err := s.jobQueue()
+	if err != nil {
+		return err
+	}
+	return queue.RenewLease(ctx, workerID, leaseID)
+}
+
+// CompleteQueueLease records the results a pull worker reports for a lease
+func (s *Service) CompleteQueueLease(ctx context.Context, workerID, leaseID string, messages []distributed.JobMessage) error {
+	queue, err := s.jobQueue()
+	if err != nil {
+		return err
+	}
+	return queue.Complete(ctx, workerID, leaseID, messages)
+}
+
+// VersionMetrics returns version management metrics
+func (s *Service) VersionMetrics() (*distributed.VersionMetrics, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	return dm.GetVersionMetrics(), nil
+}
+
+// VersionAlerts returns current version drift alerts, optionally filtered by severity
+func (s *Service) VersionAlerts(severity string) ([]*distributed.DriftAlert, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := dm.GetVersionAlerts()
+	if severity == "" {
+		return alerts, nil
+	}
+
+	filtered := make([]*distributed.DriftAlert, 0)
+	for _, alert := range alerts {
+		if alert.Severity == severity {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered, nil
+}
+
+// VersionHealth returns overall version management health
+func (s *Service) VersionHealth() (map[string]interface{}, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	return dm.GetVersionHealth(), nil
+}
+
+// CheckVersionDrift checks all workers for version drift and returns the new alerts
+func (s *Service) CheckVersionDrift(ctx context.Context) ([]*distributed.DriftAlert, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	return dm.CheckVersionDrift(ctx), nil
+}
+
+// AlertHistory returns up to limit past drift alerts
+func (s *Service) AlertHistory(limit int) ([]*distributed.DriftAlert, error) {
+	dm, err := s.manager()
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	return dm.GetAlertHistory(limit), nil
+}
+
+// AcknowledgeAlert marks an alert as acknowledged; it reports false for unknown
+// or already acknowledged alerts
+func (s *Service) AcknowledgeAlert(alertID, acknowledgedBy string) (bool, error) {
+	if alertID == "" {
+		return false, invalidArgument("Alert ID is required")
+	}
+	dm, err := s.manager()
+	if err != nil {
+		return false, err
+	}
+	return dm.AcknowledgeAlert(alertID, acknowledgedBy), nil
+}
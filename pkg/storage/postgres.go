@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -90,6 +91,56 @@ func (s *PostgreSQLStorage) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_cache_lookup ON translation_cache(source_text, source_language, target_language, provider, model);
 	CREATE INDEX IF NOT EXISTS idx_cache_last_accessed ON translation_cache(last_accessed_at);
+
+	ALTER TABLE translation_sessions ADD COLUMN IF NOT EXISTS project_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE translation_cache ADD COLUMN IF NOT EXISTS project_id TEXT NOT NULL DEFAULT '';
+
+	CREATE INDEX IF NOT EXISTS idx_sessions_project ON translation_sessions(project_id);
+	CREATE INDEX IF NOT EXISTS idx_cache_project_lookup ON translation_cache(project_id, source_language, target_language, provider, model);
+
+	CREATE TABLE IF NOT EXISTS projects (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT,
+		owner_id TEXT NOT NULL,
+		default_source_language TEXT,
+		default_target_language TEXT,
+		provider_settings TEXT,
+		glossary TEXT,
+		style_guide TEXT,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS project_members (
+		project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+		user_id TEXT NOT NULL,
+		role TEXT NOT NULL,
+		added_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (project_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_project_members_user ON project_members(user_id);
+
+	CREATE TABLE IF NOT EXISTS preparation_results (
+		session_id TEXT PRIMARY KEY,
+		project_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		analysis TEXT,
+		created_at TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS session_events (
+		session_id TEXT NOT NULL,
+		sequence BIGINT NOT NULL,
+		event_type TEXT NOT NULL,
+		step_name TEXT,
+		progress DOUBLE PRECISION DEFAULT 0,
+		message TEXT,
+		metadata TEXT,
+		created_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (session_id, sequence)
+	);
 	`
 
 	_, err := s.db.Exec(schema)
@@ -100,14 +151,14 @@ func (s *PostgreSQLStorage) initSchema() error {
 func (s *PostgreSQLStorage) CreateSession(ctx context.Context, session *TranslationSession) error {
 	query := `
 		INSERT INTO translation_sessions (
-			id, book_title, input_file, output_file, source_language, target_language,
+			id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
-		session.ID, session.BookTitle, session.InputFile, session.OutputFile,
+		session.ID, session.ProjectID, session.BookTitle, session.InputFile, session.OutputFile,
 		session.SourceLanguage, session.TargetLanguage, session.Provider, session.Model,
 		session.Status, session.PercentComplete, session.CurrentChapter, session.TotalChapters,
 		session.ItemsCompleted, session.ItemsFailed, session.ItemsTotal,
@@ -120,7 +171,7 @@ func (s *PostgreSQLStorage) CreateSession(ctx context.Context, session *Translat
 // GetSession retrieves a session by ID
 func (s *PostgreSQLStorage) GetSession(ctx context.Context, sessionID string) (*TranslationSession, error) {
 	query := `
-		SELECT id, book_title, input_file, output_file, source_language, target_language,
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, end_time, error_message,
 			created_at, updated_at
@@ -133,7 +184,7 @@ func (s *PostgreSQLStorage) GetSession(ctx context.Context, sessionID string) (*
 	var errorMessage sql.NullString
 
 	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
-		&session.ID, &session.BookTitle, &session.InputFile, &session.OutputFile,
+		&session.ID, &session.ProjectID, &session.BookTitle, &session.InputFile, &session.OutputFile,
 		&session.SourceLanguage, &session.TargetLanguage, &session.Provider, &session.Model,
 		&session.Status, &session.PercentComplete, &session.CurrentChapter, &session.TotalChapters,
 		&session.ItemsCompleted, &session.ItemsFailed, &session.ItemsTotal,
@@ -141,7 +192,7 @@ func (s *PostgreSQLStorage) GetSession(ctx context.Context, sessionID string) (*
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("session not found: %s", sessionID)
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
 	}
 	if err != nil {
 		return nil, err
@@ -179,7 +230,7 @@ func (s *PostgreSQLStorage) UpdateSession(ctx context.Context, session *Translat
 // ListSessions lists translation sessions with pagination
 func (s *PostgreSQLStorage) ListSessions(ctx context.Context, limit, offset int) ([]*TranslationSession, error) {
 	query := `
-		SELECT id, book_title, input_file, output_file, source_language, target_language,
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, end_time, error_message,
 			created_at, updated_at
@@ -194,54 +245,91 @@ func (s *PostgreSQLStorage) ListSessions(ctx context.Context, limit, offset int)
 	}
 	defer rows.Close()
 
-	var sessions []*TranslationSession
-	for rows.Next() {
-		session := &TranslationSession{}
-		var endTime sql.NullTime
-		var errorMessage sql.NullString
-
-		err := rows.Scan(
-			&session.ID, &session.BookTitle, &session.InputFile, &session.OutputFile,
-			&session.SourceLanguage, &session.TargetLanguage, &session.Provider, &session.Model,
-			&session.Status, &session.PercentComplete, &session.CurrentChapter, &session.TotalChapters,
-			&session.ItemsCompleted, &session.ItemsFailed, &session.ItemsTotal,
-			&session.StartTime, &endTime, &errorMessage, &session.CreatedAt, &session.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return scanSessionRows(rows)
+}
 
-		if endTime.Valid {
-			session.EndTime = &endTime.Time
-		}
-		if errorMessage.Valid {
-			session.ErrorMessage = errorMessage.String
-		}
+// ListProjectSessions lists the translation sessions of a project with pagination
+func (s *PostgreSQLStorage) ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error) {
+	query := `
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
+			provider, model, status, percent_complete, current_chapter, total_chapters,
+			items_completed, items_failed, items_total, start_time, end_time, error_message,
+			created_at, updated_at
+		FROM translation_sessions
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
 
-		sessions = append(sessions, session)
+	rows, err := s.db.QueryContext(ctx, query, projectID, limit, offset)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return sessions, rows.Err()
+	return scanSessionRows(rows)
 }
 
-// DeleteSession deletes a session
+// DeleteSession deletes a session and its event log
 func (s *PostgreSQLStorage) DeleteSession(ctx context.Context, sessionID string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM session_events WHERE session_id = $1", sessionID); err != nil {
+		return err
+	}
 	_, err := s.db.ExecContext(ctx, "DELETE FROM translation_sessions WHERE id = $1", sessionID)
 	return err
 }
 
-// GetCachedTranslation retrieves a cached translation
+// AppendSessionEvent appends an event to a session's log and assigns its sequence number
+func (s *PostgreSQLStorage) AppendSessionEvent(ctx context.Context, event *SessionEvent) error {
+	metadata, err := marshalEventMetadata(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO session_events (session_id, sequence, event_type, step_name, progress, message, metadata, created_at)
+		SELECT $1, COALESCE(MAX(sequence), 0) + 1, $2, $3, $4, $5, $6, $7
+		FROM session_events WHERE session_id = $1
+		RETURNING sequence
+	`, event.SessionID, event.EventType, event.StepName, event.Progress, event.Message, metadata, event.CreatedAt,
+	).Scan(&event.Sequence)
+
+	return err
+}
+
+// ListSessionEvents returns the events of a session with a sequence greater than afterSequence
+func (s *PostgreSQLStorage) ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT session_id, sequence, event_type, step_name, progress, message, metadata, created_at
+		FROM session_events
+		WHERE session_id = $1 AND sequence > $2
+		ORDER BY sequence
+	`, sessionID, afterSequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSessionEventRows(rows)
+}
+
+// GetCachedTranslation retrieves a cached translation that is not scoped to a project
 func (s *PostgreSQLStorage) GetCachedTranslation(ctx context.Context, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
+	return s.GetProjectCachedTranslation(ctx, "", sourceText, sourceLanguage, targetLanguage, provider, model)
+}
+
+// GetProjectCachedTranslation retrieves a cached translation belonging to a project
+func (s *PostgreSQLStorage) GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
 	query := `
-		SELECT id, source_text, target_text, source_language, target_language, provider, model,
+		SELECT id, project_id, source_text, target_text, source_language, target_language, provider, model,
 			created_at, access_count, last_accessed_at
 		FROM translation_cache
-		WHERE source_text = $1 AND source_language = $2 AND target_language = $3 AND provider = $4 AND model = $5
+		WHERE project_id = $1 AND source_text = $2 AND source_language = $3 AND target_language = $4 AND provider = $5 AND model = $6
 	`
 
 	cache := &TranslationCache{}
-	err := s.db.QueryRowContext(ctx, query, sourceText, sourceLanguage, targetLanguage, provider, model).Scan(
-		&cache.ID, &cache.SourceText, &cache.TargetText, &cache.SourceLanguage, &cache.TargetLanguage,
+	err := s.db.QueryRowContext(ctx, query, projectID, sourceText, sourceLanguage, targetLanguage, provider, model).Scan(
+		&cache.ID, &cache.ProjectID, &cache.SourceText, &cache.TargetText, &cache.SourceLanguage, &cache.TargetLanguage,
 		&cache.Provider, &cache.Model, &cache.CreatedAt, &cache.AccessCount, &cache.LastAccessedAt,
 	)
 
@@ -265,16 +353,16 @@ func (s *PostgreSQLStorage) GetCachedTranslation(ctx context.Context, sourceText
 func (s *PostgreSQLStorage) CacheTranslation(ctx context.Context, cache *TranslationCache) error {
 	query := `
 		INSERT INTO translation_cache (
-			id, source_text, target_text, source_language, target_language, provider, model,
+			id, project_id, source_text, target_text, source_language, target_language, provider, model,
 			created_at, access_count, last_accessed_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (id) DO UPDATE SET
 			target_text = EXCLUDED.target_text,
 			last_accessed_at = EXCLUDED.last_accessed_at
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
-		cache.ID, cache.SourceText, cache.TargetText, cache.SourceLanguage, cache.TargetLanguage,
+		cache.ID, cache.ProjectID, cache.SourceText, cache.TargetText, cache.SourceLanguage, cache.TargetLanguage,
 		cache.Provider, cache.Model, cache.CreatedAt, cache.AccessCount, cache.LastAccessedAt,
 	)
 
@@ -346,6 +434,239 @@ func (s *PostgreSQLStorage) GetStatistics(ctx context.Context) (*Statistics, err
 	return stats, nil
 }
 
+// CreateProject creates a new project together with its members
+func (s *PostgreSQLStorage) CreateProject(ctx context.Context, project *Project) error {
+	providerSettings, glossary, err := marshalProjectData(project)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO projects (
+			id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		project.ID, project.Name, project.Description, project.OwnerID,
+		project.DefaultSourceLanguage, project.DefaultTargetLanguage,
+		providerSettings, glossary, project.StyleGuide, project.CreatedAt, project.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := s.replaceProjectMembers(ctx, tx, project); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetProject retrieves a project by ID
+func (s *PostgreSQLStorage) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	query := `
+		SELECT id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		FROM projects
+		WHERE id = $1
+	`
+
+	project, err := scanProject(s.db.QueryRowContext(ctx, query, projectID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadProjectMembers(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// UpdateProject updates an existing project and replaces its members
+func (s *PostgreSQLStorage) UpdateProject(ctx context.Context, project *Project) error {
+	providerSettings, glossary, err := marshalProjectData(project)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	project.UpdatedAt = time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE projects
+		SET name = $1, description = $2, owner_id = $3, default_source_language = $4,
+			default_target_language = $5, provider_settings = $6, glossary = $7, style_guide = $8,
+			updated_at = $9
+		WHERE id = $10
+	`,
+		project.Name, project.Description, project.OwnerID, project.DefaultSourceLanguage,
+		project.DefaultTargetLanguage, providerSettings, glossary, project.StyleGuide,
+		project.UpdatedAt, project.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, project.ID)
+	}
+
+	if err := s.replaceProjectMembers(ctx, tx, project); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListProjects lists the projects a user owns or is a member of; an empty userID lists all projects
+func (s *PostgreSQLStorage) ListProjects(ctx context.Context, userID string) ([]*Project, error) {
+	query := `
+		SELECT id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		FROM projects
+	`
+	var args []interface{}
+	if userID != "" {
+		query += ` WHERE owner_id = $1 OR id IN (SELECT project_id FROM project_members WHERE user_id = $1)`
+		args = append(args, userID)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if err := s.loadProjectMembers(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	return projects, nil
+}
+
+// DeleteProject deletes a project; memberships are removed by the foreign key cascade
+func (s *PostgreSQLStorage) DeleteProject(ctx context.Context, projectID string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM projects WHERE id = $1", projectID)
+	return err
+}
+
+// replaceProjectMembers rewrites the member list of a project inside a transaction
+func (s *PostgreSQLStorage) replaceProjectMembers(ctx context.Context, tx *sql.Tx, project *Project) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM project_members WHERE project_id = $1", project.ID); err != nil {
+		return err
+	}
+
+	for _, member := range project.Members {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO project_members (project_id, user_id, role, added_at) VALUES ($1, $2, $3, $4)",
+			project.ID, member.UserID, string(member.Role), member.AddedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadProjectMembers reads the members of a project
+func (s *PostgreSQLStorage) loadProjectMembers(ctx context.Context, project *Project) error {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT user_id, role, added_at FROM project_members WHERE project_id = $1 ORDER BY added_at",
+		project.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	project.Members = []ProjectMember{}
+	for rows.Next() {
+		var member ProjectMember
+		var role string
+		if err := rows.Scan(&member.UserID, &role, &member.AddedAt); err != nil {
+			return err
+		}
+		member.Role = ProjectRole(role)
+		project.Members = append(project.Members, member)
+	}
+
+	return rows.Err()
+}
+
+// SavePreparationResult stores or replaces a preparation result
+func (s *PostgreSQLStorage) SavePreparationResult(ctx context.Context, result *PreparationResult) error {
+	analysis, err := json.Marshal(result.Analysis)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO preparation_results (session_id, project_id, status, analysis, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (session_id) DO UPDATE SET
+			project_id = EXCLUDED.project_id,
+			status = EXCLUDED.status,
+			analysis = EXCLUDED.analysis
+	`, result.SessionID, result.ProjectID, result.Status, string(analysis), result.CreatedAt)
+
+	return err
+}
+
+// GetPreparationResult retrieves a preparation result by session ID
+func (s *PostgreSQLStorage) GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error) {
+	result := &PreparationResult{}
+	var analysis sql.NullString
+
+	err := s.db.QueryRowContext(ctx,
+		"SELECT session_id, project_id, status, analysis, created_at FROM preparation_results WHERE session_id = $1",
+		sessionID,
+	).Scan(&result.SessionID, &result.ProjectID, &result.Status, &analysis, &result.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrPreparationResultNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if analysis.Valid && analysis.String != "" {
+		if err := json.Unmarshal([]byte(analysis.String), &result.Analysis); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
 // Ping checks the database connection
 func (s *PostgreSQLStorage) Ping(ctx context.Context) error {
 	return s.db.PingContext(ctx)
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrProjectNotFound is returned when a project does not exist
+	ErrProjectNotFound = errors.New("project not found")
+
+	// ErrPreparationResultNotFound is returned when a preparation result does not exist
+	ErrPreparationResultNotFound = errors.New("preparation result not found")
+)
+
+// ProjectRole represents the role a member has within a project
+type ProjectRole string
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+// ProjectMember represents a user that belongs to a project
+type ProjectMember struct {
+	UserID  string      `json:"user_id"`
+	Role    ProjectRole `json:"role"`
+	AddedAt time.Time   `json:"added_at"`
+}
+
+// ProjectProviderSettings holds the default provider settings of a project
+type ProjectProviderSettings struct {
+	Provider    string            `json:"provider,omitempty"`
+	Model       string            `json:"model,omitempty"`
+	Temperature float64           `json:"temperature,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// GlossaryEntry represents a fixed term translation within a project
+type GlossaryEntry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Project represents a workspace that scopes sessions, cached translations
+// and preparation results to a publisher or client
+type Project struct {
+	ID                    string                  `json:"id"`
+	Name                  string                  `json:"name"`
+	Description           string                  `json:"description,omitempty"`
+	OwnerID               string                  `json:"owner_id"`
+	Members               []ProjectMember         `json:"members"`
+	DefaultSourceLanguage string                  `json:"default_source_language,omitempty"`
+	DefaultTargetLanguage string                  `json:"default_target_language,omitempty"`
+	ProviderSettings      ProjectProviderSettings `json:"provider_settings"`
+	Glossary              []GlossaryEntry         `json:"glossary,omitempty"`
+	StyleGuide            string                  `json:"style_guide,omitempty"`
+	CreatedAt             time.Time               `json:"created_at"`
+	UpdatedAt             time.Time               `json:"updated_at"`
+}
+
+// PreparationResult represents a stored preparation analysis
+type PreparationResult struct {
+	SessionID string                 `json:"session_id"`
+	ProjectID string                 `json:"project_id,omitempty"`
+	Status    string                 `json:"status"`
+	Analysis  map[string]interface{} `json:"analysis"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// RoleOf returns the role of a user within the project
+func (p *Project) RoleOf(userID string) (ProjectRole, bool) {
+	if userID == "" {
+		return "", false
+	}
+	if p.OwnerID == userID {
+		return ProjectRoleOwner, true
+	}
+	for _, member := range p.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+// IsMember reports whether a user belongs to the project
+func (p *Project) IsMember(userID string) bool {
+	_, ok := p.RoleOf(userID)
+	return ok
+}
+
+// CanEdit reports whether a user may start translations and change project data
+func (p *Project) CanEdit(userID string) bool {
+	role, ok := p.RoleOf(userID)
+	return ok && (role == ProjectRoleOwner || role == ProjectRoleEditor)
+}
+
+// SetMember adds a member or updates the role of an existing member
+func (p *Project) SetMember(userID string, role ProjectRole) {
+	for i := range p.Members {
+		if p.Members[i].UserID == userID {
+			p.Members[i].Role = role
+			return
+		}
+	}
+	p.Members = append(p.Members, ProjectMember{
+		UserID:  userID,
+		Role:    role,
+		AddedAt: time.Now(),
+	})
+}
+
+// RemoveMember removes a member from the project and reports whether it was present
+func (p *Project) RemoveMember(userID string) bool {
+	for i := range p.Members {
+		if p.Members[i].UserID == userID {
+			p.Members = append(p.Members[:i], p.Members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ValidProjectRole reports whether role is a known project role
+func ValidProjectRole(role ProjectRole) bool {
+	switch role {
+	case ProjectRoleOwner, ProjectRoleEditor, ProjectRoleViewer:
+		return true
+	}
+	return false
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// marshalProjectData encodes the JSON columns of a project
+func marshalProjectData(project *Project) (string, string, error) {
+	providerSettings, err := json.Marshal(project.ProviderSettings)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal provider settings: %w", err)
+	}
+
+	glossary, err := json.Marshal(project.Glossary)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal glossary: %w", err)
+	}
+
+	return string(providerSettings), string(glossary), nil
+}
+
+// scanProject reads a project row written by the SQL storage backends
+func scanProject(row rowScanner) (*Project, error) {
+	project := &Project{}
+	var description, sourceLanguage, targetLanguage, providerSettings, glossary, styleGuide sql.NullString
+
+	err := row.Scan(
+		&project.ID, &project.Name, &description, &project.OwnerID,
+		&sourceLanguage, &targetLanguage, &providerSettings, &glossary, &styleGuide,
+		&project.CreatedAt, &project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Description = description.String
+	project.DefaultSourceLanguage = sourceLanguage.String
+	project.DefaultTargetLanguage = targetLanguage.String
+	project.StyleGuide = styleGuide.String
+
+	if providerSettings.String != "" {
+		if err := json.Unmarshal([]byte(providerSettings.String), &project.ProviderSettings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal provider settings: %w", err)
+		}
+	}
+	if glossary.String != "" && glossary.String != "null" {
+		if err := json.Unmarshal([]byte(glossary.String), &project.Glossary); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal glossary: %w", err)
+		}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProject(id, ownerID string) *Project {
+	now := time.Now()
+	return &Project{
+		ID:                    id,
+		Name:                  "Publisher " + id,
+		OwnerID:               ownerID,
+		DefaultSourceLanguage: "ru",
+		DefaultTargetLanguage: "sr",
+		ProviderSettings: ProjectProviderSettings{
+			Provider:    "deepseek",
+			Model:       "deepseek-chat",
+			Temperature: 0.3,
+		},
+		Glossary: []GlossaryEntry{
+			{Source: "Москва", Target: "Москва", Note: "keep Cyrillic"},
+		},
+		StyleGuide: "Use ekavica.",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// TestProject_Membership tests role resolution and member management
+func TestProject_Membership(t *testing.T) {
+	project := newTestProject("p1", "owner")
+
+	role, ok := project.RoleOf("owner")
+	assert.True(t, ok)
+	assert.Equal(t, ProjectRoleOwner, role)
+	assert.True(t, project.CanEdit("owner"))
+
+	assert.False(t, project.IsMember("alice"))
+	assert.False(t, project.IsMember(""))
+
+	project.SetMember("alice", ProjectRoleViewer)
+	assert.True(t, project.IsMember("alice"))
+	assert.False(t, project.CanEdit("alice"))
+
+	project.SetMember("alice", ProjectRoleEditor)
+	assert.Len(t, project.Members, 1)
+	assert.True(t, project.CanEdit("alice"))
+
+	assert.True(t, project.RemoveMember("alice"))
+	assert.False(t, project.RemoveMember("alice"))
+	assert.False(t, project.IsMember("alice"))
+
+	assert.True(t, ValidProjectRole(ProjectRoleViewer))
+	assert.False(t, ValidProjectRole("admin"))
+}
+
+// TestSQLiteStorage_Projects tests project CRUD and membership filtering
+func TestSQLiteStorage_Projects(t *testing.T) {
+	storage := setupSQLiteTest(t)
+	defer storage.Close()
+
+	ctx := context.Background()
+
+	first := newTestProject("project-a", "owner-1")
+	first.SetMember("editor-1", ProjectRoleEditor)
+	require.NoError(t, storage.CreateProject(ctx, first))
+
+	second := newTestProject("project-b", "owner-2")
+	require.NoError(t, storage.CreateProject(ctx, second))
+
+	loaded, err := storage.GetProject(ctx, "project-a")
+	require.NoError(t, err)
+	assert.Equal(t, "Publisher project-a", loaded.Name)
+	assert.Equal(t, "deepseek", loaded.ProviderSettings.Provider)
+	assert.Equal(t, "Use ekavica.", loaded.StyleGuide)
+	require.Len(t, loaded.Glossary, 1)
+	assert.Equal(t, "keep Cyrillic", loaded.Glossary[0].Note)
+	require.Len(t, loaded.Members, 1)
+	assert.True(t, loaded.CanEdit("editor-1"))
+
+	all, err := storage.ListProjects(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	mine, err := storage.ListProjects(ctx, "editor-1")
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, "project-a", mine[0].ID)
+
+	owned, err := storage.ListProjects(ctx, "owner-2")
+	require.NoError(t, err)
+	require.Len(t, owned, 1)
+	assert.Equal(t, "project-b", owned[0].ID)
+
+	loaded.RemoveMember("editor-1")
+	loaded.SetMember("viewer-1", ProjectRoleViewer)
+	loaded.StyleGuide = "Use ijekavica."
+	require.NoError(t, storage.UpdateProject(ctx, loaded))
+
+	updated, err := storage.GetProject(ctx, "project-a")
+	require.NoError(t, err)
+	assert.Equal(t, "Use ijekavica.", updated.StyleGuide)
+	assert.False(t, updated.IsMember("editor-1"))
+	assert.True(t, updated.IsMember("viewer-1"))
+
+	require.NoError(t, storage.DeleteProject(ctx, "project-a"))
+	_, err = storage.GetProject(ctx, "project-a")
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+
+	err = storage.UpdateProject(ctx, newTestProject("missing", "owner-1"))
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+// TestSQLiteStorage_ProjectScoping tests that sessions and cache entries are scoped to projects
+func TestSQLiteStorage_ProjectScoping(t *testing.T) {
+	storage := setupSQLiteTest(t)
+	defer storage.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, session := range []*TranslationSession{
+		{ID: "s1", ProjectID: "project-a", BookTitle: "A", InputFile: "a.epub", SourceLanguage: "ru", TargetLanguage: "sr", Provider: "openai", Model: "gpt-4", Status: "completed", StartTime: now, CreatedAt: now, UpdatedAt: now},
+		{ID: "s2", ProjectID: "project-b", BookTitle: "B", InputFile: "b.epub", SourceLanguage: "ru", TargetLanguage: "sr", Provider: "openai", Model: "gpt-4", Status: "completed", StartTime: now, CreatedAt: now, UpdatedAt: now},
+		{ID: "s3", BookTitle: "C", InputFile: "c.epub", SourceLanguage: "ru", TargetLanguage: "sr", Provider: "openai", Model: "gpt-4", Status: "completed", StartTime: now, CreatedAt: now, UpdatedAt: now},
+	} {
+		require.NoError(t, storage.CreateSession(ctx, session))
+	}
+
+	sessions, err := storage.ListProjectSessions(ctx, "project-a", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "s1", sessions[0].ID)
+	assert.Equal(t, "project-a", sessions[0].ProjectID)
+
+	session, err := storage.GetSession(ctx, "s2")
+	require.NoError(t, err)
+	assert.Equal(t, "project-b", session.ProjectID)
+
+	require.NoError(t, storage.CacheTranslation(ctx, &TranslationCache{
+		ID: "c1", ProjectID: "project-a", SourceText: "Привет", TargetText: "Здраво",
+		SourceLanguage: "ru", TargetLanguage: "sr", Provider: "openai", Model: "gpt-4",
+		CreatedAt: now, LastAccessedAt: now,
+	}))
+
+	cached, err := storage.GetProjectCachedTranslation(ctx, "project-a", "Привет", "ru", "sr", "openai", "gpt-4")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, "Здраво", cached.TargetText)
+
+	other, err := storage.GetProjectCachedTranslation(ctx, "project-b", "Привет", "ru", "sr", "openai", "gpt-4")
+	require.NoError(t, err)
+	assert.Nil(t, other, "cache entries must not leak across projects")
+
+	global, err := storage.GetCachedTranslation(ctx, "Привет", "ru", "sr", "openai", "gpt-4")
+	require.NoError(t, err)
+	assert.Nil(t, global, "project cache entries must not be returned for unscoped lookups")
+}
+
+// TestSQLiteStorage_PreparationResults tests storing preparation results
+func TestSQLiteStorage_PreparationResults(t *testing.T) {
+	storage := setupSQLiteTest(t)
+	defer storage.Close()
+
+	ctx := context.Background()
+
+	err := storage.SavePreparationResult(ctx, &PreparationResult{
+		SessionID: "prep-1",
+		ProjectID: "project-a",
+		Status:    "completed",
+		Analysis:  map[string]interface{}{"file_count": 3},
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	result, err := storage.GetPreparationResult(ctx, "prep-1")
+	require.NoError(t, err)
+	assert.Equal(t, "project-a", result.ProjectID)
+	assert.Equal(t, float64(3), result.Analysis["file_count"])
+
+	_, err = storage.GetPreparationResult(ctx, "missing")
+	assert.ErrorIs(t, err, ErrPreparationResultNotFound)
+}
+
+// TestSQLiteStorage_ProjectMigration tests that databases created before projects are upgraded
+func TestSQLiteStorage_ProjectMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		CREATE TABLE translation_sessions (
+			id TEXT PRIMARY KEY, book_title TEXT NOT NULL, input_file TEXT NOT NULL, output_file TEXT,
+			source_language TEXT NOT NULL, target_language TEXT NOT NULL, provider TEXT NOT NULL,
+			model TEXT NOT NULL, status TEXT NOT NULL, percent_complete REAL DEFAULT 0,
+			current_chapter INTEGER DEFAULT 0, total_chapters INTEGER DEFAULT 0,
+			items_completed INTEGER DEFAULT 0, items_failed INTEGER DEFAULT 0, items_total INTEGER DEFAULT 0,
+			start_time DATETIME NOT NULL, end_time DATETIME, error_message TEXT,
+			created_at DATETIME NOT NULL, updated_at DATETIME NOT NULL
+		);
+		CREATE TABLE translation_cache (
+			id TEXT PRIMARY KEY, source_text TEXT NOT NULL, target_text TEXT NOT NULL,
+			source_language TEXT NOT NULL, target_language TEXT NOT NULL, provider TEXT NOT NULL,
+			model TEXT NOT NULL, created_at DATETIME NOT NULL, access_count INTEGER DEFAULT 0,
+			last_accessed_at DATETIME NOT NULL
+		);
+	`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	storage, err := NewSQLiteStorage(&Config{Type: "sqlite", Database: dbPath})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, storage.CreateSession(ctx, &TranslationSession{
+		ID: "legacy", ProjectID: "project-a", BookTitle: "Legacy", InputFile: "l.epub",
+		SourceLanguage: "ru", TargetLanguage: "sr", Provider: "openai", Model: "gpt-4",
+		Status: "translating", StartTime: now, CreatedAt: now, UpdatedAt: now,
+	}))
+
+	session, err := storage.GetSession(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "project-a", session.ProjectID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -55,7 +56,7 @@ func (r *RedisStorage) GetSession(ctx context.Context, sessionID string) (*Trans
 	key := fmt.Sprintf("session:%s", sessionID)
 	data, err := r.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
-		return nil, fmt.Errorf("session not found: %s", sessionID)
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
 	}
 	if err != nil {
 		return nil, err
@@ -120,15 +121,116 @@ func (r *RedisStorage) ListSessions(ctx context.Context, limit, offset int) ([]*
 	return sessions, nil
 }
 
-// DeleteSession deletes a session from Redis
+// ListProjectSessions lists the translation sessions of a project from Redis with pagination
+func (r *RedisStorage) ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error) {
+	pattern := "session:*"
+	var cursor uint64
+	var sessions []*TranslationSession
+	count := 0
+
+	for {
+		keys, nextCursor, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			data, err := r.client.Get(ctx, key).Bytes()
+			if err != nil {
+				continue
+			}
+
+			session := &TranslationSession{}
+			if err := json.Unmarshal(data, session); err != nil || session.ProjectID != projectID {
+				continue
+			}
+
+			if count < offset {
+				count++
+				continue
+			}
+			if len(sessions) >= limit {
+				return sessions, nil
+			}
+
+			sessions = append(sessions, session)
+			count++
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession deletes a session and its event log from Redis
 func (r *RedisStorage) DeleteSession(ctx context.Context, sessionID string) error {
-	key := fmt.Sprintf("session:%s", sessionID)
-	return r.client.Del(ctx, key).Err()
+	return r.client.Del(ctx,
+		fmt.Sprintf("session:%s", sessionID),
+		fmt.Sprintf("events:%s", sessionID),
+		fmt.Sprintf("events:%s:seq", sessionID),
+	).Err()
+}
+
+// AppendSessionEvent appends an event to a session's log in Redis and assigns its sequence number
+func (r *RedisStorage) AppendSessionEvent(ctx context.Context, event *SessionEvent) error {
+	seqKey := fmt.Sprintf("events:%s:seq", event.SessionID)
+	sequence, err := r.client.Incr(ctx, seqKey).Result()
+	if err != nil {
+		return err
+	}
+	event.Sequence = sequence
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("events:%s", event.SessionID)
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.Expire(ctx, key, r.ttl)
+	pipe.Expire(ctx, seqKey, r.ttl)
+	_, err = pipe.Exec(ctx)
+
+	return err
 }
 
-// GetCachedTranslation retrieves a cached translation from Redis
+// ListSessionEvents returns the events of a session with a sequence greater than afterSequence from Redis
+func (r *RedisStorage) ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error) {
+	values, err := r.client.LRange(ctx, fmt.Sprintf("events:%s", sessionID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*SessionEvent
+	for _, value := range values {
+		event := &SessionEvent{}
+		if err := json.Unmarshal([]byte(value), event); err != nil {
+			continue
+		}
+		if event.Sequence > afterSequence {
+			events = append(events, event)
+		}
+	}
+
+	// Concurrent appends may push out of order
+	sort.Slice(events, func(i, j int) bool { return events[i].Sequence < events[j].Sequence })
+
+	return events, nil
+}
+
+// GetCachedTranslation retrieves a cached translation that is not scoped to a project from Redis
 func (r *RedisStorage) GetCachedTranslation(ctx context.Context, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
-	key := r.makeCacheKey(sourceText, sourceLanguage, targetLanguage, provider, model)
+	return r.GetProjectCachedTranslation(ctx, "", sourceText, sourceLanguage, targetLanguage, provider, model)
+}
+
+// GetProjectCachedTranslation retrieves a cached translation belonging to a project from Redis
+func (r *RedisStorage) GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
+	key := r.makeProjectCacheKey(projectID, sourceText, sourceLanguage, targetLanguage, provider, model)
 	data, err := r.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil
@@ -152,7 +254,7 @@ func (r *RedisStorage) GetCachedTranslation(ctx context.Context, sourceText, sou
 
 // CacheTranslation caches a translation in Redis
 func (r *RedisStorage) CacheTranslation(ctx context.Context, cache *TranslationCache) error {
-	key := r.makeCacheKey(cache.SourceText, cache.SourceLanguage, cache.TargetLanguage, cache.Provider, cache.Model)
+	key := r.makeProjectCacheKey(cache.ProjectID, cache.SourceText, cache.SourceLanguage, cache.TargetLanguage, cache.Provider, cache.Model)
 	data, err := json.Marshal(cache)
 	if err != nil {
 		return err
@@ -256,6 +358,125 @@ func (r *RedisStorage) GetStatistics(ctx context.Context) (*Statistics, error) {
 	return stats, nil
 }
 
+// CreateProject creates a new project in Redis
+func (r *RedisStorage) CreateProject(ctx context.Context, project *Project) error {
+	data, err := json.Marshal(project)
+	if err != nil {
+		return err
+	}
+
+	// Projects are long-lived, so they are stored without the cache TTL
+	key := fmt.Sprintf("project:%s", project.ID)
+	return r.client.Set(ctx, key, data, 0).Err()
+}
+
+// GetProject retrieves a project by ID from Redis
+func (r *RedisStorage) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	key := fmt.Sprintf("project:%s", projectID)
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	project := &Project{}
+	if err := json.Unmarshal(data, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// UpdateProject updates an existing project in Redis
+func (r *RedisStorage) UpdateProject(ctx context.Context, project *Project) error {
+	key := fmt.Sprintf("project:%s", project.ID)
+	exists, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, project.ID)
+	}
+
+	project.UpdatedAt = time.Now()
+	return r.CreateProject(ctx, project) // Redis SET overwrites
+}
+
+// ListProjects lists the projects a user owns or is a member of from Redis; an empty userID lists all projects
+func (r *RedisStorage) ListProjects(ctx context.Context, userID string) ([]*Project, error) {
+	pattern := "project:*"
+	var cursor uint64
+	var projects []*Project
+
+	for {
+		keys, nextCursor, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			data, err := r.client.Get(ctx, key).Bytes()
+			if err != nil {
+				continue
+			}
+
+			project := &Project{}
+			if err := json.Unmarshal(data, project); err != nil {
+				continue
+			}
+
+			if userID == "" || project.IsMember(userID) {
+				projects = append(projects, project)
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return projects, nil
+}
+
+// DeleteProject deletes a project from Redis
+func (r *RedisStorage) DeleteProject(ctx context.Context, projectID string) error {
+	key := fmt.Sprintf("project:%s", projectID)
+	return r.client.Del(ctx, key).Err()
+}
+
+// SavePreparationResult stores a preparation result in Redis
+func (r *RedisStorage) SavePreparationResult(ctx context.Context, result *PreparationResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("preparation:%s", result.SessionID)
+	return r.client.Set(ctx, key, data, r.ttl).Err()
+}
+
+// GetPreparationResult retrieves a preparation result by session ID from Redis
+func (r *RedisStorage) GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error) {
+	key := fmt.Sprintf("preparation:%s", sessionID)
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%w: %s", ErrPreparationResultNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PreparationResult{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // Ping checks the Redis connection
 func (r *RedisStorage) Ping(ctx context.Context) error {
 	return r.client.Ping(ctx).Err()
@@ -271,6 +492,14 @@ func (r *RedisStorage) makeCacheKey(sourceText, sourceLanguage, targetLanguage,
 	return fmt.Sprintf("cache:%s:%s:%s:%s:%s", sourceLanguage, targetLanguage, provider, model, hashString(sourceText))
 }
 
+// makeProjectCacheKey creates a cache key scoped to a project; unscoped entries keep the plain key
+func (r *RedisStorage) makeProjectCacheKey(projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) string {
+	if projectID == "" {
+		return r.makeCacheKey(sourceText, sourceLanguage, targetLanguage, provider, model)
+	}
+	return fmt.Sprintf("cache:project:%s:%s:%s:%s:%s:%s", projectID, sourceLanguage, targetLanguage, provider, model, hashString(sourceText))
+}
+
 // hashString creates a simple hash of a string (for cache keys)
 func hashString(s string) string {
 	h := uint32(0)
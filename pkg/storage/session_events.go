@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSessionNotFound is returned when a translation session does not exist
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionEvent is an entry in the append-only event log of a translation session.
+// Sequence numbers start at 1 and increase by one per session, so a client that
+// remembers the last sequence it saw can resume without gaps or duplicates.
+type SessionEvent struct {
+	SessionID string            `json:"session_id"`
+	Sequence  int64             `json:"sequence"`
+	EventType string            `json:"event_type"`
+	StepName  string            `json:"step_name,omitempty"`
+	Progress  float64           `json:"progress"`
+	Message   string            `json:"message,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// marshalEventMetadata encodes event metadata for the SQL backends
+func marshalEventMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(metadata)
+	return string(data), err
+}
+
+// unmarshalEventMetadata decodes event metadata stored by the SQL backends
+func unmarshalEventMetadata(data string) (map[string]string, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var metadata map[string]string
+	err := json.Unmarshal([]byte(data), &metadata)
+	return metadata, err
+}
+
+// scanSessionEventRows reads session events from a result set
+func scanSessionEventRows(rows *sql.Rows) ([]*SessionEvent, error) {
+	var events []*SessionEvent
+	for rows.Next() {
+		event := &SessionEvent{}
+		var stepName, message, metadata sql.NullString
+
+		if err := rows.Scan(
+			&event.SessionID, &event.Sequence, &event.EventType, &stepName,
+			&event.Progress, &message, &metadata, &event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		event.StepName = stepName.String
+		event.Message = message.String
+
+		var err error
+		if event.Metadata, err = unmarshalEventMetadata(metadata.String); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event metadata: %w", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
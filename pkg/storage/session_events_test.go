@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSQLiteStorage_SessionEvents tests the per-session event log
+func TestSQLiteStorage_SessionEvents(t *testing.T) {
+	storage := setupSQLiteTest(t)
+	defer storage.Close()
+
+	ctx := context.Background()
+
+	for i, eventType := range []string{"started", "progress_update", "completed"} {
+		event := &SessionEvent{
+			SessionID: "s1",
+			EventType: eventType,
+			Progress:  float64(i * 50),
+			Message:   eventType,
+			Metadata:  map[string]string{"step": eventType},
+			CreatedAt: time.Now(),
+		}
+		require.NoError(t, storage.AppendSessionEvent(ctx, event))
+		assert.Equal(t, int64(i+1), event.Sequence)
+	}
+
+	// Other sessions have their own sequence
+	other := &SessionEvent{SessionID: "s2", EventType: "started", CreatedAt: time.Now()}
+	require.NoError(t, storage.AppendSessionEvent(ctx, other))
+	assert.Equal(t, int64(1), other.Sequence)
+
+	events, err := storage.ListSessionEvents(ctx, "s1", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "started", events[0].EventType)
+	assert.Equal(t, "started", events[0].Metadata["step"])
+
+	events, err = storage.ListSessionEvents(ctx, "s1", 2)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), events[0].Sequence)
+	assert.Equal(t, "completed", events[0].EventType)
+	assert.Equal(t, float64(100), events[0].Progress)
+
+	require.NoError(t, storage.DeleteSession(ctx, "s1"))
+	events, err = storage.ListSessionEvents(ctx, "s1", 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = storage.GetSession(ctx, "missing")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -55,6 +56,7 @@ func (s *SQLiteStorage) initSchema() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS translation_sessions (
 		id TEXT PRIMARY KEY,
+		project_id TEXT NOT NULL DEFAULT '',
 		book_title TEXT NOT NULL,
 		input_file TEXT NOT NULL,
 		output_file TEXT,
@@ -81,6 +83,7 @@ func (s *SQLiteStorage) initSchema() error {
 
 	CREATE TABLE IF NOT EXISTS translation_cache (
 		id TEXT PRIMARY KEY,
+		project_id TEXT NOT NULL DEFAULT '',
 		source_text TEXT NOT NULL,
 		target_text TEXT NOT NULL,
 		source_language TEXT NOT NULL,
@@ -94,9 +97,99 @@ func (s *SQLiteStorage) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_cache_lookup ON translation_cache(source_text, source_language, target_language, provider, model);
 	CREATE INDEX IF NOT EXISTS idx_cache_last_accessed ON translation_cache(last_accessed_at);
+
+	CREATE TABLE IF NOT EXISTS projects (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT,
+		owner_id TEXT NOT NULL,
+		default_source_language TEXT,
+		default_target_language TEXT,
+		provider_settings TEXT,
+		glossary TEXT,
+		style_guide TEXT,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS project_members (
+		project_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		role TEXT NOT NULL,
+		added_at DATETIME NOT NULL,
+		PRIMARY KEY (project_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_project_members_user ON project_members(user_id);
+
+	CREATE TABLE IF NOT EXISTS preparation_results (
+		session_id TEXT PRIMARY KEY,
+		project_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		analysis TEXT,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS session_events (
+		session_id TEXT NOT NULL,
+		sequence INTEGER NOT NULL,
+		event_type TEXT NOT NULL,
+		step_name TEXT,
+		progress REAL DEFAULT 0,
+		message TEXT,
+		metadata TEXT,
+		created_at DATETIME NOT NULL,
+		PRIMARY KEY (session_id, sequence)
+	);
 	`
 
-	_, err := s.db.Exec(schema)
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// Databases created before projects existed lack the project_id columns
+	for _, table := range []string{"translation_sessions", "translation_cache"} {
+		if err := s.addColumnIfMissing(table, "project_id", "TEXT NOT NULL DEFAULT ''"); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.db.Exec(`
+	CREATE INDEX IF NOT EXISTS idx_sessions_project ON translation_sessions(project_id);
+	CREATE INDEX IF NOT EXISTS idx_cache_project_lookup ON translation_cache(project_id, source_language, target_language, provider, model);
+	`)
+	return err
+}
+
+// addColumnIfMissing adds a column to an existing table unless it is already present
+func (s *SQLiteStorage) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
@@ -104,14 +197,14 @@ func (s *SQLiteStorage) initSchema() error {
 func (s *SQLiteStorage) CreateSession(ctx context.Context, session *TranslationSession) error {
 	query := `
 		INSERT INTO translation_sessions (
-			id, book_title, input_file, output_file, source_language, target_language,
+			id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
-		session.ID, session.BookTitle, session.InputFile, session.OutputFile,
+		session.ID, session.ProjectID, session.BookTitle, session.InputFile, session.OutputFile,
 		session.SourceLanguage, session.TargetLanguage, session.Provider, session.Model,
 		session.Status, session.PercentComplete, session.CurrentChapter, session.TotalChapters,
 		session.ItemsCompleted, session.ItemsFailed, session.ItemsTotal,
@@ -124,7 +217,7 @@ func (s *SQLiteStorage) CreateSession(ctx context.Context, session *TranslationS
 // GetSession retrieves a session by ID
 func (s *SQLiteStorage) GetSession(ctx context.Context, sessionID string) (*TranslationSession, error) {
 	query := `
-		SELECT id, book_title, input_file, output_file, source_language, target_language,
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, end_time, error_message,
 			created_at, updated_at
@@ -137,7 +230,7 @@ func (s *SQLiteStorage) GetSession(ctx context.Context, sessionID string) (*Tran
 	var errorMessage sql.NullString
 
 	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
-		&session.ID, &session.BookTitle, &session.InputFile, &session.OutputFile,
+		&session.ID, &session.ProjectID, &session.BookTitle, &session.InputFile, &session.OutputFile,
 		&session.SourceLanguage, &session.TargetLanguage, &session.Provider, &session.Model,
 		&session.Status, &session.PercentComplete, &session.CurrentChapter, &session.TotalChapters,
 		&session.ItemsCompleted, &session.ItemsFailed, &session.ItemsTotal,
@@ -145,7 +238,7 @@ func (s *SQLiteStorage) GetSession(ctx context.Context, sessionID string) (*Tran
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("session not found: %s", sessionID)
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
 	}
 	if err != nil {
 		return nil, err
@@ -183,7 +276,7 @@ func (s *SQLiteStorage) UpdateSession(ctx context.Context, session *TranslationS
 // ListSessions lists translation sessions with pagination
 func (s *SQLiteStorage) ListSessions(ctx context.Context, limit, offset int) ([]*TranslationSession, error) {
 	query := `
-		SELECT id, book_title, input_file, output_file, source_language, target_language,
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
 			provider, model, status, percent_complete, current_chapter, total_chapters,
 			items_completed, items_failed, items_total, start_time, end_time, error_message,
 			created_at, updated_at
@@ -198,54 +291,109 @@ func (s *SQLiteStorage) ListSessions(ctx context.Context, limit, offset int) ([]
 	}
 	defer rows.Close()
 
-	var sessions []*TranslationSession
-	for rows.Next() {
-		session := &TranslationSession{}
-		var endTime sql.NullTime
-		var errorMessage sql.NullString
-
-		err := rows.Scan(
-			&session.ID, &session.BookTitle, &session.InputFile, &session.OutputFile,
-			&session.SourceLanguage, &session.TargetLanguage, &session.Provider, &session.Model,
-			&session.Status, &session.PercentComplete, &session.CurrentChapter, &session.TotalChapters,
-			&session.ItemsCompleted, &session.ItemsFailed, &session.ItemsTotal,
-			&session.StartTime, &endTime, &errorMessage, &session.CreatedAt, &session.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return scanSessionRows(rows)
+}
 
-		if endTime.Valid {
-			session.EndTime = &endTime.Time
-		}
-		if errorMessage.Valid {
-			session.ErrorMessage = errorMessage.String
-		}
+// ListProjectSessions lists the translation sessions of a project with pagination
+func (s *SQLiteStorage) ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error) {
+	query := `
+		SELECT id, project_id, book_title, input_file, output_file, source_language, target_language,
+			provider, model, status, percent_complete, current_chapter, total_chapters,
+			items_completed, items_failed, items_total, start_time, end_time, error_message,
+			created_at, updated_at
+		FROM translation_sessions
+		WHERE project_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
 
-		sessions = append(sessions, session)
+	rows, err := s.db.QueryContext(ctx, query, projectID, limit, offset)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return sessions, rows.Err()
+	return scanSessionRows(rows)
 }
 
-// DeleteSession deletes a session
+// DeleteSession deletes a session and its event log
 func (s *SQLiteStorage) DeleteSession(ctx context.Context, sessionID string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM session_events WHERE session_id = ?", sessionID); err != nil {
+		return err
+	}
 	_, err := s.db.ExecContext(ctx, "DELETE FROM translation_sessions WHERE id = ?", sessionID)
 	return err
 }
 
-// GetCachedTranslation retrieves a cached translation
+// AppendSessionEvent appends an event to a session's log and assigns its sequence number
+func (s *SQLiteStorage) AppendSessionEvent(ctx context.Context, event *SessionEvent) error {
+	metadata, err := marshalEventMetadata(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sequence int64
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(sequence), 0) + 1 FROM session_events WHERE session_id = ?",
+		event.SessionID,
+	).Scan(&sequence); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO session_events (session_id, sequence, event_type, step_name, progress, message, metadata, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, event.SessionID, sequence, event.EventType, event.StepName, event.Progress, event.Message, metadata, event.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	event.Sequence = sequence
+	return nil
+}
+
+// ListSessionEvents returns the events of a session with a sequence greater than afterSequence
+func (s *SQLiteStorage) ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT session_id, sequence, event_type, step_name, progress, message, metadata, created_at
+		FROM session_events
+		WHERE session_id = ? AND sequence > ?
+		ORDER BY sequence
+	`, sessionID, afterSequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSessionEventRows(rows)
+}
+
+// GetCachedTranslation retrieves a cached translation that is not scoped to a project
 func (s *SQLiteStorage) GetCachedTranslation(ctx context.Context, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
+	return s.GetProjectCachedTranslation(ctx, "", sourceText, sourceLanguage, targetLanguage, provider, model)
+}
+
+// GetProjectCachedTranslation retrieves a cached translation belonging to a project
+func (s *SQLiteStorage) GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
 	query := `
-		SELECT id, source_text, target_text, source_language, target_language, provider, model,
+		SELECT id, project_id, source_text, target_text, source_language, target_language, provider, model,
 			created_at, access_count, last_accessed_at
 		FROM translation_cache
-		WHERE source_text = ? AND source_language = ? AND target_language = ? AND provider = ? AND model = ?
+		WHERE project_id = ? AND source_text = ? AND source_language = ? AND target_language = ? AND provider = ? AND model = ?
 	`
 
 	cache := &TranslationCache{}
-	err := s.db.QueryRowContext(ctx, query, sourceText, sourceLanguage, targetLanguage, provider, model).Scan(
-		&cache.ID, &cache.SourceText, &cache.TargetText, &cache.SourceLanguage, &cache.TargetLanguage,
+	err := s.db.QueryRowContext(ctx, query, projectID, sourceText, sourceLanguage, targetLanguage, provider, model).Scan(
+		&cache.ID, &cache.ProjectID, &cache.SourceText, &cache.TargetText, &cache.SourceLanguage, &cache.TargetLanguage,
 		&cache.Provider, &cache.Model, &cache.CreatedAt, &cache.AccessCount, &cache.LastAccessedAt,
 	)
 
@@ -269,13 +417,13 @@ func (s *SQLiteStorage) GetCachedTranslation(ctx context.Context, sourceText, so
 func (s *SQLiteStorage) CacheTranslation(ctx context.Context, cache *TranslationCache) error {
 	query := `
 		INSERT OR REPLACE INTO translation_cache (
-			id, source_text, target_text, source_language, target_language, provider, model,
+			id, project_id, source_text, target_text, source_language, target_language, provider, model,
 			created_at, access_count, last_accessed_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := s.db.ExecContext(ctx, query,
-		cache.ID, cache.SourceText, cache.TargetText, cache.SourceLanguage, cache.TargetLanguage,
+		cache.ID, cache.ProjectID, cache.SourceText, cache.TargetText, cache.SourceLanguage, cache.TargetLanguage,
 		cache.Provider, cache.Model, cache.CreatedAt, cache.AccessCount, cache.LastAccessedAt,
 	)
 
@@ -347,6 +495,247 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*Statistics, error)
 	return stats, nil
 }
 
+// CreateProject creates a new project together with its members
+func (s *SQLiteStorage) CreateProject(ctx context.Context, project *Project) error {
+	providerSettings, glossary, err := marshalProjectData(project)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO projects (
+			id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		project.ID, project.Name, project.Description, project.OwnerID,
+		project.DefaultSourceLanguage, project.DefaultTargetLanguage,
+		providerSettings, glossary, project.StyleGuide, project.CreatedAt, project.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := s.replaceProjectMembers(ctx, tx, project); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetProject retrieves a project by ID
+func (s *SQLiteStorage) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	query := `
+		SELECT id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		FROM projects
+		WHERE id = ?
+	`
+
+	project, err := scanProject(s.db.QueryRowContext(ctx, query, projectID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadProjectMembers(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// UpdateProject updates an existing project and replaces its members
+func (s *SQLiteStorage) UpdateProject(ctx context.Context, project *Project) error {
+	providerSettings, glossary, err := marshalProjectData(project)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	project.UpdatedAt = time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE projects
+		SET name = ?, description = ?, owner_id = ?, default_source_language = ?,
+			default_target_language = ?, provider_settings = ?, glossary = ?, style_guide = ?,
+			updated_at = ?
+		WHERE id = ?
+	`,
+		project.Name, project.Description, project.OwnerID, project.DefaultSourceLanguage,
+		project.DefaultTargetLanguage, providerSettings, glossary, project.StyleGuide,
+		project.UpdatedAt, project.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, project.ID)
+	}
+
+	if err := s.replaceProjectMembers(ctx, tx, project); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListProjects lists the projects a user owns or is a member of; an empty userID lists all projects
+func (s *SQLiteStorage) ListProjects(ctx context.Context, userID string) ([]*Project, error) {
+	query := `
+		SELECT id, name, description, owner_id, default_source_language, default_target_language,
+			provider_settings, glossary, style_guide, created_at, updated_at
+		FROM projects
+	`
+	var args []interface{}
+	if userID != "" {
+		query += ` WHERE owner_id = ? OR id IN (SELECT project_id FROM project_members WHERE user_id = ?)`
+		args = append(args, userID, userID)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if err := s.loadProjectMembers(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	return projects, nil
+}
+
+// DeleteProject deletes a project and its memberships
+func (s *SQLiteStorage) DeleteProject(ctx context.Context, projectID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM project_members WHERE project_id = ?", projectID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM projects WHERE id = ?", projectID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replaceProjectMembers rewrites the member list of a project inside a transaction
+func (s *SQLiteStorage) replaceProjectMembers(ctx context.Context, tx *sql.Tx, project *Project) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM project_members WHERE project_id = ?", project.ID); err != nil {
+		return err
+	}
+
+	for _, member := range project.Members {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO project_members (project_id, user_id, role, added_at) VALUES (?, ?, ?, ?)",
+			project.ID, member.UserID, string(member.Role), member.AddedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadProjectMembers reads the members of a project
+func (s *SQLiteStorage) loadProjectMembers(ctx context.Context, project *Project) error {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT user_id, role, added_at FROM project_members WHERE project_id = ? ORDER BY added_at",
+		project.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	project.Members = []ProjectMember{}
+	for rows.Next() {
+		var member ProjectMember
+		var role string
+		if err := rows.Scan(&member.UserID, &role, &member.AddedAt); err != nil {
+			return err
+		}
+		member.Role = ProjectRole(role)
+		project.Members = append(project.Members, member)
+	}
+
+	return rows.Err()
+}
+
+// SavePreparationResult stores or replaces a preparation result
+func (s *SQLiteStorage) SavePreparationResult(ctx context.Context, result *PreparationResult) error {
+	analysis, err := json.Marshal(result.Analysis)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO preparation_results (session_id, project_id, status, analysis, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, result.SessionID, result.ProjectID, result.Status, string(analysis), result.CreatedAt)
+
+	return err
+}
+
+// GetPreparationResult retrieves a preparation result by session ID
+func (s *SQLiteStorage) GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error) {
+	result := &PreparationResult{}
+	var analysis sql.NullString
+
+	err := s.db.QueryRowContext(ctx,
+		"SELECT session_id, project_id, status, analysis, created_at FROM preparation_results WHERE session_id = ?",
+		sessionID,
+	).Scan(&result.SessionID, &result.ProjectID, &result.Status, &analysis, &result.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrPreparationResultNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if analysis.Valid && analysis.String != "" {
+		if err := json.Unmarshal([]byte(analysis.String), &result.Analysis); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
 // Ping checks the database connection
 func (s *SQLiteStorage) Ping(ctx context.Context) error {
 	return s.db.PingContext(ctx)
@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"time"
 )
 
 // TranslationSession represents a translation session in storage
 type TranslationSession struct {
 	ID              string    `json:"id"`
+	ProjectID       string    `json:"project_id,omitempty"`
 	BookTitle       string    `json:"book_title"`
 	InputFile       string    `json:"input_file"`
 	OutputFile      string    `json:"output_file"`
@@ -32,6 +35,7 @@ type TranslationSession struct {
 // TranslationCache represents a cached translation
 type TranslationCache struct {
 	ID              string    `json:"id"`
+	ProjectID       string    `json:"project_id,omitempty"`
 	SourceText      string    `json:"source_text"`
 	TargetText      string    `json:"target_text"`
 	SourceLanguage  string    `json:"source_language"`
@@ -52,11 +56,29 @@ type Storage interface {
 	ListSessions(ctx context.Context, limit, offset int) ([]*TranslationSession, error)
 	DeleteSession(ctx context.Context, sessionID string) error
 
+	ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error)
+
+	// Session event log
+	AppendSessionEvent(ctx context.Context, event *SessionEvent) error
+	ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error)
+
 	// Translation cache
 	GetCachedTranslation(ctx context.Context, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error)
+	GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error)
 	CacheTranslation(ctx context.Context, cache *TranslationCache) error
 	CleanupOldCache(ctx context.Context, olderThan time.Duration) error
 
+	// Project management
+	CreateProject(ctx context.Context, project *Project) error
+	GetProject(ctx context.Context, projectID string) (*Project, error)
+	UpdateProject(ctx context.Context, project *Project) error
+	ListProjects(ctx context.Context, userID string) ([]*Project, error)
+	DeleteProject(ctx context.Context, projectID string) error
+
+	// Preparation results
+	SavePreparationResult(ctx context.Context, result *PreparationResult) error
+	GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error)
+
 	// Statistics
 	GetStatistics(ctx context.Context) (*Statistics, error)
 
@@ -96,3 +118,49 @@ type Config struct {
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
 }
+
+// NewStorage creates the storage backend selected by config.Type
+func NewStorage(config *Config) (Storage, error) {
+	switch config.Type {
+	case "", "sqlite":
+		return NewSQLiteStorage(config)
+	case "postgres":
+		return NewPostgreSQLStorage(config)
+	case "redis":
+		return NewRedisStorage(config, 30*24*time.Hour)
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", config.Type)
+	}
+}
+
+// scanSessionRows reads translation sessions from a result set
+func scanSessionRows(rows *sql.Rows) ([]*TranslationSession, error) {
+	var sessions []*TranslationSession
+	for rows.Next() {
+		session := &TranslationSession{}
+		var endTime sql.NullTime
+		var errorMessage sql.NullString
+
+		err := rows.Scan(
+			&session.ID, &session.ProjectID, &session.BookTitle, &session.InputFile, &session.OutputFile,
+			&session.SourceLanguage, &session.TargetLanguage, &session.Provider, &session.Model,
+			&session.Status, &session.PercentComplete, &session.CurrentChapter, &session.TotalChapters,
+			&session.ItemsCompleted, &session.ItemsFailed, &session.ItemsTotal,
+			&session.StartTime, &endTime, &errorMessage, &session.CreatedAt, &session.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if endTime.Valid {
+			session.EndTime = &endTime.Time
+		}
+		if errorMessage.Valid {
+			session.ErrorMessage = errorMessage.String
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
@@ -359,6 +359,50 @@ func (m *mockStorage) GetStatistics(ctx context.Context) (*Statistics, error) {
 	return nil, nil
 }
 
+func (m *mockStorage) ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) AppendSessionEvent(ctx context.Context, event *SessionEvent) error {
+	return nil
+}
+
+func (m *mockStorage) ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) CreateProject(ctx context.Context, project *Project) error {
+	return nil
+}
+
+func (m *mockStorage) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) UpdateProject(ctx context.Context, project *Project) error {
+	return nil
+}
+
+func (m *mockStorage) ListProjects(ctx context.Context, userID string) ([]*Project, error) {
+	return nil, nil
+}
+
+func (m *mockStorage) DeleteProject(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (m *mockStorage) SavePreparationResult(ctx context.Context, result *PreparationResult) error {
+	return nil
+}
+
+func (m *mockStorage) GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error) {
+	return nil, nil
+}
+
 func (m *mockStorage) Ping(ctx context.Context) error {
 	return nil
 }
@@ -308,6 +308,84 @@ func (m *MockStorageImplementation) GetStatistics(ctx context.Context) (*Statist
 	return &Statistics{}, nil
 }
 
+func (m *MockStorageImplementation) ListProjectSessions(ctx context.Context, projectID string, limit, offset int) ([]*TranslationSession, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return []*TranslationSession{}, nil
+}
+
+func (m *MockStorageImplementation) AppendSessionEvent(ctx context.Context, event *SessionEvent) error {
+	return ctx.Err()
+}
+
+func (m *MockStorageImplementation) ListSessionEvents(ctx context.Context, sessionID string, afterSequence int64) ([]*SessionEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return []*SessionEvent{}, nil
+}
+
+func (m *MockStorageImplementation) GetProjectCachedTranslation(ctx context.Context, projectID, sourceText, sourceLanguage, targetLanguage, provider, model string) (*TranslationCache, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return &TranslationCache{
+		ProjectID:      projectID,
+		SourceText:     sourceText,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		Provider:       provider,
+		Model:          model,
+	}, nil
+}
+
+func (m *MockStorageImplementation) CreateProject(ctx context.Context, project *Project) error {
+	if project == nil {
+		return assert.AnError
+	}
+	return ctx.Err()
+}
+
+func (m *MockStorageImplementation) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return &Project{ID: projectID}, nil
+}
+
+func (m *MockStorageImplementation) UpdateProject(ctx context.Context, project *Project) error {
+	if project == nil {
+		return assert.AnError
+	}
+	return ctx.Err()
+}
+
+func (m *MockStorageImplementation) ListProjects(ctx context.Context, userID string) ([]*Project, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return []*Project{}, nil
+}
+
+func (m *MockStorageImplementation) DeleteProject(ctx context.Context, projectID string) error {
+	return ctx.Err()
+}
+
+func (m *MockStorageImplementation) SavePreparationResult(ctx context.Context, result *PreparationResult) error {
+	if result == nil {
+		return assert.AnError
+	}
+	return ctx.Err()
+}
+
+func (m *MockStorageImplementation) GetPreparationResult(ctx context.Context, sessionID string) (*PreparationResult, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return &PreparationResult{SessionID: sessionID}, nil
+}
+
 func (m *MockStorageImplementation) Ping(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
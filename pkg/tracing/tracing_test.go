@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupRecorder installs a tracer provider recording spans in memory
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return recorder
+}
+
+func TestStartAndEnd(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("provider unavailable"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "provider unavailable", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestInjectExtract(t *testing.T) {
+	setupRecorder(t)
+
+	ctx, span := Start(context.Background(), "coordinator")
+	defer span.End()
+
+	header := http.Header{}
+	Inject(ctx, header)
+	assert.NotEmpty(t, header.Get("traceparent"))
+
+	remote := trace.SpanContextFromContext(Extract(context.Background(), header))
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := setupRecorder(t)
+	gin.SetMode(gin.TestMode)
+
+	var handlerSpan trace.SpanContext
+	router := gin.New()
+	router.Use(Middleware())
+	router.POST("/api/v1/distributed/jobs", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusBadGateway)
+	})
+
+	// The caller's trace continues on the server
+	ctx, caller := Start(context.Background(), "distributed.job")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/distributed/jobs", nil)
+	Inject(ctx, req.Header)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	caller.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	server := spans[0]
+	assert.Equal(t, "POST /api/v1/distributed/jobs", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, caller.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, caller.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, server.SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, codes.Error, server.Status().Code)
+}
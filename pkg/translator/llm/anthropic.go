@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"digital.vasic.translator/pkg/translator"
 )
 
 // AnthropicClient implements Anthropic Claude API client
@@ -165,6 +167,8 @@ func (c *AnthropicClient) Translate(ctx context.Context, text string, prompt str
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	translator.RecordUsage(ctx, response.Usage.InputTokens, response.Usage.OutputTokens)
+
 	if len(response.Content) == 0 {
 		return "", fmt.Errorf("no content in response")
 	}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"digital.vasic.translator/pkg/translator"
 )
 
 // GeminiClient implements the LLMClient interface for Google Gemini
@@ -150,6 +151,10 @@ func (g *GeminiClient) Translate(ctx context.Context, text string, prompt string
 		return "", fmt.Errorf("failed to make Gemini request: %w", err)
 	}
 
+	if resp.UsageMetadata != nil {
+		translator.RecordUsage(ctx, resp.UsageMetadata.PromptTokenCount, resp.UsageMetadata.CandidatesTokenCount)
+	}
+
 	// Parse the response
 	translatedText, err := g.parseResponse(resp)
 	if err != nil {
@@ -225,7 +225,7 @@ func NewLLMTranslatorWithConfig(config TranslationConfig) (*LLMTranslator, error
 	return &LLMTranslator{
 		BaseTranslator: NewBaseTranslator(config),
 		provider:       provider,
-		client:         client,
+		client:         &tracedClient{LLMClient: client, model: config.Model},
 	}, nil
 }
 
@@ -243,6 +243,7 @@ func (lt *LLMTranslator) Translate(ctx context.Context, text string, contextStr
 	// Check cache
 	cacheKey := fmt.Sprintf("%s:%s", text, contextStr)
 	if cached, found := lt.CheckCache(cacheKey); found {
+		translator.RecordCacheHit(ctx)
 		return cached, nil
 	}
 
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"digital.vasic.translator/pkg/translator"
 )
 
 // OpenAIClient implements OpenAI API client
@@ -180,6 +182,8 @@ func (c *OpenAIClient) Translate(ctx context.Context, text string, prompt string
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	translator.RecordUsage(ctx, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response")
 	}
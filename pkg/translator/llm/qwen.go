@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"digital.vasic.translator/pkg/translator"
 )
 
 // QwenClient implements Qwen (Alibaba Cloud) LLM API client with OAuth support
@@ -350,6 +352,8 @@ func (c *QwenClient) Translate(ctx context.Context, text string, prompt string)
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	translator.RecordUsage(ctx, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response")
 	}
@@ -0,0 +1,29 @@
+package llm
+
+import (
+	"context"
+
+	"digital.vasic.translator/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedClient records a span for every request sent to a provider
+type tracedClient struct {
+	LLMClient
+	model string
+}
+
+// Translate sends a request to the provider within a span
+func (c *tracedClient) Translate(ctx context.Context, text string, prompt string) (string, error) {
+	ctx, span := tracing.Start(ctx, "llm.Translate",
+		attribute.String("llm.provider", c.GetProviderName()),
+		attribute.String("llm.model", c.model),
+		attribute.Int("llm.text_length", len(text)),
+		attribute.Int("llm.prompt_length", len(prompt)),
+	)
+	result, err := c.LLMClient.Translate(ctx, text, prompt)
+	span.SetAttributes(attribute.Int("llm.result_length", len(result)))
+	tracing.End(span, err)
+	return result, err
+}
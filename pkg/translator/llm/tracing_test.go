@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracedClient tests that every provider request records a span
+func TestTracedClient(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	client := &tracedClient{
+		LLMClient: &MockLLMClient{shouldFail: true, maxCallsToFail: 1},
+		model:     "mock-model",
+	}
+
+	if _, err := client.Translate(context.Background(), "hello", "prompt"); err == nil {
+		t.Fatal("expected first request to fail")
+	}
+	result, err := client.Translate(context.Background(), "hello", "prompt")
+	if err != nil || result != "HELLO" {
+		t.Fatalf("unexpected result %q, %v", result, err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error || spans[1].Status().Code == codes.Error {
+		t.Errorf("unexpected span statuses %v, %v", spans[0].Status(), spans[1].Status())
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[1].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if spans[1].Name() != "llm.Translate" ||
+		attrs["llm.provider"].AsString() != "mock" ||
+		attrs["llm.model"].AsString() != "mock-model" ||
+		attrs["llm.result_length"].AsInt64() != 5 {
+		t.Errorf("unexpected span %s with attributes %v", spans[1].Name(), attrs)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"digital.vasic.translator/pkg/translator"
 )
 
 // ZhipuClient implements Zhipu AI (GLM) API client
@@ -142,6 +144,8 @@ func (c *ZhipuClient) Translate(ctx context.Context, text string, prompt string)
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	translator.RecordUsage(ctx, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response")
 	}
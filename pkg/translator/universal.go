@@ -5,6 +5,7 @@ import (
 	"digital.vasic.translator/pkg/ebook"
 	"digital.vasic.translator/pkg/events"
 	"digital.vasic.translator/pkg/language"
+	"digital.vasic.translator/pkg/localization"
 	"fmt"
 )
 
@@ -14,6 +15,7 @@ type UniversalTranslator struct {
 	langDetector   *language.Detector
 	sourceLanguage language.Language
 	targetLanguage language.Language
+	recognizer     ebook.ImageTextRecognizer
 }
 
 // NewUniversalTranslator creates a new universal translator
@@ -30,6 +32,12 @@ func NewUniversalTranslator(
 	}
 }
 
+// SetImageTextRecognizer sets a recognizer for the text shown in images;
+// the recognized text is translated along with the book
+func (ut *UniversalTranslator) SetImageTextRecognizer(recognizer ebook.ImageTextRecognizer) {
+	ut.recognizer = recognizer
+}
+
 // TranslateBook translates an entire ebook
 func (ut *UniversalTranslator) TranslateBook(
 	ctx context.Context,
@@ -42,25 +50,7 @@ func (ut *UniversalTranslator) TranslateBook(
 	}
 	
 	// Detect source language if not specified
-	if ut.sourceLanguage.Code == "" && ut.langDetector != nil {
-		EmitProgress(eventBus, sessionID, "Detecting source language", nil)
-
-		sample := book.ExtractText()
-		if len(sample) > 2000 {
-			sample = sample[:2000]
-		}
-
-		detectedLang, err := ut.langDetector.Detect(ctx, sample)
-		if err == nil {
-			ut.sourceLanguage = detectedLang
-			EmitProgress(eventBus, sessionID,
-				fmt.Sprintf("Detected language: %s", detectedLang.Name),
-				map[string]interface{}{
-					"language_code": detectedLang.Code,
-					"language_name": detectedLang.Name,
-				})
-		}
-	}
+	ut.detectSourceLanguage(ctx, book, eventBus, sessionID)
 
 	// Update metadata language
 	if book.Metadata.Language == "" {
@@ -95,6 +85,180 @@ func (ut *UniversalTranslator) TranslateBook(
 	return nil
 }
 
+// detectSourceLanguage detects the source language from a sample of the book
+// when it was not specified
+func (ut *UniversalTranslator) detectSourceLanguage(
+	ctx context.Context,
+	book *ebook.Book,
+	eventBus *events.EventBus,
+	sessionID string,
+) {
+	if ut.sourceLanguage.Code != "" || ut.langDetector == nil {
+		return
+	}
+
+	EmitProgress(eventBus, sessionID, "Detecting source language", nil)
+
+	sample := book.ExtractText()
+	if len(sample) > 2000 {
+		sample = sample[:2000]
+	}
+
+	detection, err := ut.langDetector.DetectWithConfidence(ctx, sample)
+	if err == nil {
+		detectedLang := detection.Language
+		ut.sourceLanguage = detectedLang
+		EmitProgress(eventBus, sessionID,
+			fmt.Sprintf("Detected language: %s", detectedLang.Name),
+			map[string]interface{}{
+				"language_code": detectedLang.Code,
+				"language_name": detectedLang.Name,
+				"confidence":    detection.Confidence,
+				"source":        detection.Source,
+			})
+	}
+}
+
+// TranslateEPUBInPlace translates the EPUB at inputPath into a new EPUB at
+// outputPath, keeping its markup, styles and resources as they are
+func (ut *UniversalTranslator) TranslateEPUBInPlace(
+	ctx context.Context,
+	inputPath, outputPath string,
+	eventBus *events.EventBus,
+	sessionID string,
+) (*ebook.EPUBInPlaceStats, error) {
+	if ut.sourceLanguage.Code == "" && ut.langDetector != nil {
+		book, err := ebook.NewEPUBParser().Parse(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EPUB: %w", err)
+		}
+		ut.detectSourceLanguage(ctx, book, eventBus, sessionID)
+	}
+
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return ut.translator.TranslateWithProgress(ctx, text, context, eventBus, sessionID)
+	}
+	inPlace := ebook.NewEPUBInPlaceTranslator(translate, ut.targetLanguage.Code)
+	inPlace.Recognizer = ut.recognizer
+	inPlace.OnProgress = func(done, total int) {
+		EmitProgress(eventBus, sessionID,
+			fmt.Sprintf("Translated block %d/%d", done, total),
+			map[string]interface{}{
+				"block":        done,
+				"total_blocks": total,
+				"progress":     float64(done) / float64(total) * 100,
+			})
+	}
+
+	stats, err := inPlace.Translate(ctx, inputPath, outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("in-place translation failed: %w", err)
+	}
+	return stats, nil
+}
+
+// TranslateDOCXInPlace translates the Word document at inputPath into a new
+// document at outputPath, keeping its styles, run formatting, tables,
+// headers, footers, footnotes and comments
+func (ut *UniversalTranslator) TranslateDOCXInPlace(
+	ctx context.Context,
+	inputPath, outputPath string,
+	eventBus *events.EventBus,
+	sessionID string,
+) (*ebook.DOCXInPlaceStats, error) {
+	// The source language is detected when the document can be parsed;
+	// the translation itself does not depend on the parser
+	if ut.sourceLanguage.Code == "" && ut.langDetector != nil {
+		if book, err := ebook.NewDOCXParser(nil).Parse(inputPath); err == nil {
+			ut.detectSourceLanguage(ctx, book, eventBus, sessionID)
+		}
+	}
+
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return ut.translator.TranslateWithProgress(ctx, text, context, eventBus, sessionID)
+	}
+	inPlace := ebook.NewDOCXInPlaceTranslator(translate, ut.targetLanguage.Code)
+	inPlace.OnProgress = func(done, total int) {
+		EmitProgress(eventBus, sessionID,
+			fmt.Sprintf("Translated paragraph %d/%d", done, total),
+			map[string]interface{}{
+				"paragraph":        done,
+				"total_paragraphs": total,
+				"progress":         float64(done) / float64(total) * 100,
+			})
+	}
+
+	stats, err := inPlace.Translate(ctx, inputPath, outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("in-place translation failed: %w", err)
+	}
+	return stats, nil
+}
+
+// TranslateSubtitles translates the subtitle file at inputPath into a new
+// file at outputPath, keeping its timing, numbering and styling tags
+func (ut *UniversalTranslator) TranslateSubtitles(
+	ctx context.Context,
+	inputPath, outputPath string,
+	eventBus *events.EventBus,
+	sessionID string,
+) (*ebook.SubtitleStats, error) {
+	if ut.sourceLanguage.Code == "" && ut.langDetector != nil {
+		if book, err := ebook.NewUniversalParser().Parse(inputPath); err == nil {
+			ut.detectSourceLanguage(ctx, book, eventBus, sessionID)
+		}
+	}
+
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return ut.translator.TranslateWithProgress(ctx, text, context, eventBus, sessionID)
+	}
+	subtitles := ebook.NewSubtitleTranslator(translate)
+	subtitles.OnProgress = func(done, total int) {
+		EmitProgress(eventBus, sessionID,
+			fmt.Sprintf("Translated cue %d/%d", done, total),
+			map[string]interface{}{
+				"cue":        done,
+				"total_cues": total,
+				"progress":   float64(done) / float64(total) * 100,
+			})
+	}
+
+	stats, err := subtitles.Translate(ctx, inputPath, outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("subtitle translation failed: %w", err)
+	}
+	return stats, nil
+}
+
+// TranslateLocalization translates the localization file at inputPath, such
+// as a PO or XLIFF file, into a file of the same format at outputPath
+func (ut *UniversalTranslator) TranslateLocalization(
+	ctx context.Context,
+	inputPath, outputPath string,
+	eventBus *events.EventBus,
+	sessionID string,
+) (*localization.Stats, error) {
+	translate := func(ctx context.Context, text, context string) (string, error) {
+		return ut.translator.TranslateWithProgress(ctx, text, context, eventBus, sessionID)
+	}
+	localizer := localization.NewTranslator(translate, ut.targetLanguage.Code)
+	localizer.OnProgress = func(done, total int) {
+		EmitProgress(eventBus, sessionID,
+			fmt.Sprintf("Translated string %d/%d", done, total),
+			map[string]interface{}{
+				"string":        done,
+				"total_strings": total,
+				"progress":      float64(done) / float64(total) * 100,
+			})
+	}
+
+	stats, err := localizer.Translate(ctx, inputPath, outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("localization translation failed: %w", err)
+	}
+	return stats, nil
+}
+
 // translateMetadata translates book metadata
 func (ut *UniversalTranslator) translateMetadata(
 	ctx context.Context,
@@ -165,6 +329,53 @@ func (ut *UniversalTranslator) translateChapter(
 		}
 	}
 
+	// Translate image texts
+	for i := range chapter.Images {
+		if err := ut.translateImage(ctx, &chapter.Images[i], eventBus, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// translateImage translates the alternative text, title and caption of an
+// image, and the text recognized in it when a recognizer is set
+func (ut *UniversalTranslator) translateImage(
+	ctx context.Context,
+	image *ebook.Image,
+	eventBus *events.EventBus,
+	sessionID string,
+) error {
+	if ut.recognizer != nil && image.Text == "" && len(image.Data) > 0 {
+		text, err := ut.recognizer.RecognizeText(ctx, image.Data, image.MediaType)
+		if err != nil {
+			EmitProgress(eventBus, sessionID, "Warning: Failed to recognize image text",
+				map[string]interface{}{"image": image.ID, "error": err.Error()})
+		}
+		image.Text = text
+	}
+
+	texts := []struct {
+		text    *string
+		context string
+	}{
+		{&image.Alt, ebook.ImageContext},
+		{&image.Title, ebook.ImageContext},
+		{&image.Caption, ebook.ImageContext},
+		{&image.Text, ebook.ImageTextContext},
+	}
+	for _, t := range texts {
+		if *t.text == "" {
+			continue
+		}
+		translated, err := ut.translator.TranslateWithProgress(ctx, *t.text, t.context, eventBus, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to translate image text: %w", err)
+		}
+		*t.text = translated
+	}
+
 	return nil
 }
 
@@ -1,8 +1,15 @@
 package translator
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -213,4 +220,232 @@ func BenchmarkUniversalTranslator_TranslateBook(b *testing.B) {
 		
 		ut.TranslateBook(ctx, freshBook, eventBus, sessionID)
 	}
-}
\ No newline at end of file
+}
+
+// progressTest is an English to Serbian translator with an event bus that
+// collects its translation progress events
+type progressTest struct {
+	ut       *UniversalTranslator
+	eventBus *events.EventBus
+	progress chan events.Event
+}
+
+func newProgressTest(trans Translator) *progressTest {
+	pt := &progressTest{
+		eventBus: events.NewEventBus(),
+		progress: make(chan events.Event, 100),
+	}
+	pt.eventBus.Subscribe(events.EventTranslationProgress, func(event events.Event) {
+		pt.progress <- event
+	})
+	pt.ut = NewUniversalTranslator(trans, nil,
+		language.Language{Code: "en", Name: "English"},
+		language.Language{Code: "sr", Name: "Serbian"})
+	return pt
+}
+
+// assertProgress checks that translation progress was published
+func (pt *progressTest) assertProgress(t *testing.T) {
+	assert.Eventually(t, func() bool { return len(pt.progress) > 0 }, time.Second, 10*time.Millisecond)
+}
+
+// TestUniversalTranslator_TranslateEPUBInPlace tests in-place EPUB translation
+func TestUniversalTranslator_TranslateEPUBInPlace(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "book.epub")
+	output := filepath.Join(dir, "book_sr.epub")
+
+	book := &ebook.Book{
+		Metadata: ebook.Metadata{Title: "Book", Language: "en"},
+		Chapters: []ebook.Chapter{{
+			Title:    "Chapter",
+			Sections: []ebook.Section{{Content: "First paragraph.\n\nSecond paragraph."}},
+		}},
+	}
+	assert.NoError(t, ebook.NewEPUBWriter().Write(book, input))
+
+	mockTranslator := &MockTranslator{}
+	mockTranslator.On("TranslateWithProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("Prevedeno", nil)
+
+	pt := newProgressTest(mockTranslator)
+
+	stats, err := pt.ut.TranslateEPUBInPlace(context.Background(), input, output, pt.eventBus, "test-session")
+	assert.NoError(t, err)
+	assert.Greater(t, stats.Blocks, 0)
+	pt.assertProgress(t)
+
+	translated, err := ebook.NewEPUBParser().Parse(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "sr", translated.Metadata.Language)
+	assert.Contains(t, translated.ExtractText(), "Prevedeno")
+	assert.NotContains(t, translated.ExtractText(), "First paragraph")
+
+	t.Run("translation error", func(t *testing.T) {
+		failing := &MockTranslator{}
+		failing.On("TranslateWithProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", assert.AnError)
+
+		ut := NewUniversalTranslator(failing, nil, language.Language{Code: "en"}, language.Language{Code: "sr"})
+		_, err := ut.TranslateEPUBInPlace(context.Background(), input, filepath.Join(dir, "failed.epub"), nil, "test-session")
+		assert.Error(t, err)
+	})
+}
+
+// TestUniversalTranslator_TranslateDOCXInPlace tests in-place DOCX translation
+func TestUniversalTranslator_TranslateDOCXInPlace(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "manuscript.docx")
+	output := filepath.Join(dir, "manuscript_sr.docx")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range map[string]string{
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`,
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>First paragraph.</w:t></w:r></w:p><w:p><w:r><w:t>Second paragraph.</w:t></w:r></w:p></w:body></w:document>`,
+	} {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(data))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, os.WriteFile(input, buf.Bytes(), 0644))
+
+	mockTranslator := &MockTranslator{}
+	mockTranslator.On("TranslateWithProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("Prevedeno", nil)
+
+	pt := newProgressTest(mockTranslator)
+
+	stats, err := pt.ut.TranslateDOCXInPlace(context.Background(), input, output, pt.eventBus, "test-session")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Paragraphs)
+	pt.assertProgress(t)
+
+	r, err := zip.OpenReader(output)
+	assert.NoError(t, err)
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(data), "<w:t>Prevedeno</w:t>"))
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := pt.ut.TranslateDOCXInPlace(context.Background(), filepath.Join(dir, "missing.docx"), filepath.Join(dir, "failed.docx"), nil, "test-session")
+		assert.Error(t, err)
+	})
+}
+
+// TestUniversalTranslator_TranslateSubtitles tests cue by cue translation of subtitles
+func TestUniversalTranslator_TranslateSubtitles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "lesson.srt")
+	output := filepath.Join(dir, "lesson_sr.vtt")
+	srt := "1\n00:00:01,000 --> 00:00:04,000\n<i>Hello</i> there.\n\n2\n00:00:05,000 --> 00:00:08,000\nGoodbye.\n"
+	assert.NoError(t, os.WriteFile(input, []byte(srt), 0644))
+
+	mockTranslator := &MockTranslator{}
+	mockTranslator.On("TranslateWithProgress", mock.Anything, "<x1/>Hello<x2/> there.", mock.Anything, mock.Anything, mock.Anything).Return("<x1/>Zdravo<x2/> tamo.", nil)
+	mockTranslator.On("TranslateWithProgress", mock.Anything, "Goodbye.", mock.Anything, mock.Anything, mock.Anything).Return("Zbogom.", nil)
+
+	pt := newProgressTest(mockTranslator)
+
+	stats, err := pt.ut.TranslateSubtitles(context.Background(), input, output, pt.eventBus, "test-session")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Cues)
+	pt.assertProgress(t)
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:04.000\n<i>Zdravo</i> tamo.\n\n00:00:05.000 --> 00:00:08.000\nZbogom.\n\n", string(data))
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := pt.ut.TranslateSubtitles(context.Background(), filepath.Join(dir, "missing.srt"), output, nil, "test-session")
+		assert.Error(t, err)
+	})
+}
+
+// TestUniversalTranslator_TranslateLocalization tests string by string translation of a PO file
+func TestUniversalTranslator_TranslateLocalization(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "app.pot")
+	output := filepath.Join(dir, "app_sr.po")
+	pot := "msgid \"Hello, %s!\"\nmsgstr \"\"\n\nmsgid \"Save\"\nmsgstr \"\"\n"
+	assert.NoError(t, os.WriteFile(input, []byte(pot), 0644))
+
+	mockTranslator := &MockTranslator{}
+	mockTranslator.On("TranslateWithProgress", mock.Anything, "Hello, <x1/>!", mock.Anything, mock.Anything, mock.Anything).Return("Zdravo, <x1/>!", nil)
+	mockTranslator.On("TranslateWithProgress", mock.Anything, "Save", mock.Anything, mock.Anything, mock.Anything).Return("Sačuvaj", nil)
+
+	pt := newProgressTest(mockTranslator)
+
+	stats, err := pt.ut.TranslateLocalization(context.Background(), input, output, pt.eventBus, "test-session")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Units)
+	assert.Equal(t, 0, stats.NeedsReview)
+	pt.assertProgress(t)
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "msgid \"Hello, %s!\"\nmsgstr \"Zdravo, %s!\"\n\nmsgid \"Save\"\nmsgstr \"Sačuvaj\"\n", string(data))
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := pt.ut.TranslateLocalization(context.Background(), filepath.Join(dir, "missing.po"), output, nil, "test-session")
+		assert.Error(t, err)
+	})
+}
+
+// stubRecognizer recognizes the same text in every image
+type stubRecognizer struct {
+	text string
+	err  error
+}
+
+func (r stubRecognizer) RecognizeText(ctx context.Context, data []byte, mediaType string) (string, error) {
+	return r.text, r.err
+}
+
+// TestUniversalTranslator_TranslateImages tests translation of image texts
+func TestUniversalTranslator_TranslateImages(t *testing.T) {
+	ctx := context.Background()
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	mockTranslator := &MockTranslator{}
+	mockTranslator.On("TranslateWithProgress", ctx, "A map", ebook.ImageContext, mock.Anything, mock.Anything).Return("Mapa", nil)
+	mockTranslator.On("TranslateWithProgress", ctx, "The map", ebook.ImageContext, mock.Anything, mock.Anything).Return("Karta", nil)
+	mockTranslator.On("TranslateWithProgress", ctx, "North", ebook.ImageTextContext, mock.Anything, mock.Anything).Return("Sever", nil)
+	mockTranslator.On("TranslateWithProgress", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("Prevedeno", nil)
+
+	book := &ebook.Book{
+		Chapters: []ebook.Chapter{{
+			Title: "Chapter",
+			Images: []ebook.Image{
+				{ID: "map.png", Data: png, MediaType: "image/png", Alt: "A map", Caption: "The map"},
+				{ID: "missing.png"},
+			},
+		}},
+	}
+
+	ut := NewUniversalTranslator(mockTranslator, nil, language.Language{Code: "en"}, language.Language{Code: "sr"})
+	ut.SetImageTextRecognizer(stubRecognizer{text: "North"})
+	assert.NoError(t, ut.TranslateBook(ctx, book, nil, "test-session"))
+
+	images := book.Chapters[0].Images
+	assert.Equal(t, ebook.Image{ID: "map.png", Data: png, MediaType: "image/png", Alt: "Mapa", Caption: "Karta", Text: "Sever"}, images[0])
+	assert.Equal(t, ebook.Image{ID: "missing.png"}, images[1])
+
+	t.Run("recognition error", func(t *testing.T) {
+		book := &ebook.Book{Chapters: []ebook.Chapter{{Images: []ebook.Image{{Data: png, Alt: "A map"}}}}}
+		ut.SetImageTextRecognizer(stubRecognizer{err: assert.AnError})
+		assert.NoError(t, ut.TranslateBook(ctx, book, nil, "test-session"))
+		assert.Equal(t, "Mapa", book.Chapters[0].Images[0].Alt)
+		assert.Empty(t, book.Chapters[0].Images[0].Text)
+	})
+}
@@ -0,0 +1,41 @@
+package translator
+
+import "context"
+
+// UsageRecorder receives the tokens an LLM request consumed
+type UsageRecorder func(promptTokens, completionTokens int)
+
+type usageRecorderKey struct{}
+
+// WithUsageRecorder returns a context whose LLM requests report their token
+// usage to recorder
+func WithUsageRecorder(ctx context.Context, recorder UsageRecorder) context.Context {
+	return context.WithValue(ctx, usageRecorderKey{}, recorder)
+}
+
+// CacheHitRecorder is told when a translator answers from its memory
+type CacheHitRecorder func()
+
+type cacheHitRecorderKey struct{}
+
+// WithCacheHitRecorder returns a context whose translations report answers
+// from the translator's memory to recorder
+func WithCacheHitRecorder(ctx context.Context, recorder CacheHitRecorder) context.Context {
+	return context.WithValue(ctx, cacheHitRecorderKey{}, recorder)
+}
+
+// RecordCacheHit reports an answer from memory to the recorder of ctx, if any.
+// Translators call it for every translation they do not send to a provider.
+func RecordCacheHit(ctx context.Context) {
+	if recorder, ok := ctx.Value(cacheHitRecorderKey{}).(CacheHitRecorder); ok && recorder != nil {
+		recorder()
+	}
+}
+
+// RecordUsage reports token usage to the recorder of ctx, if any. Providers
+// call it for every response that states its usage.
+func RecordUsage(ctx context.Context, promptTokens, completionTokens int) {
+	if recorder, ok := ctx.Value(usageRecorderKey{}).(UsageRecorder); ok && recorder != nil {
+		recorder(promptTokens, completionTokens)
+	}
+}
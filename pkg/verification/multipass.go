@@ -4,12 +4,15 @@ import (
 	"context"
 	"digital.vasic.translator/pkg/ebook"
 	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/tracing"
 	"digital.vasic.translator/pkg/translator"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // MultiPassConfig configures multi-pass polishing
@@ -107,9 +110,15 @@ func (mpp *MultiPassPolisher) PolishBook(
 	ctx context.Context,
 	originalBook *ebook.Book,
 	translatedBook *ebook.Book,
-) (*MultiPassResult, error) {
+) (_ *MultiPassResult, err error) {
 	startTime := time.Now()
 
+	ctx, span := tracing.Start(ctx, "verification.PolishBook",
+		attribute.String("verification.session_id", mpp.sessionID),
+		attribute.Int("verification.pass_count", mpp.config.PassCount),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	result := &MultiPassResult{
 		SessionID:   mpp.sessionID,
 		BookID:      fmt.Sprintf("%s_%d", originalBook.Metadata.Title, time.Now().Unix()),
@@ -236,9 +245,15 @@ func (mpp *MultiPassPolisher) performPass(
 	originalBook *ebook.Book,
 	currentBook *ebook.Book,
 	previousNotes []*LiteraryNote,
-) (*PassResult, *ebook.Book, error) {
+) (_ *PassResult, _ *ebook.Book, err error) {
 	startTime := time.Now()
 
+	ctx, span := tracing.Start(ctx, "verification.pass",
+		attribute.Int("verification.pass", passNumber),
+		attribute.StringSlice("verification.providers", providers),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	passID := fmt.Sprintf("%s_pass_%d", mpp.sessionID, passNumber)
 
 	// Create pass record in database
@@ -113,7 +113,7 @@ func (v *Verifier) VerifyBook(ctx context.Context, book *ebook.Book) (*Verificat
 
 	// Emit verification start event
 	v.emitEvent(events.Event{
-		Type:      "verification_started",
+		Type:      events.EventVerificationStarted,
 		SessionID: v.sessionID,
 		Message:   "Starting translation verification",
 	})
@@ -129,7 +129,7 @@ func (v *Verifier) VerifyBook(ctx context.Context, book *ebook.Book) (*Verificat
 		location := fmt.Sprintf("Chapter %d/%d", i+1, totalChapters)
 
 		v.emitEvent(events.Event{
-			Type:      "verification_progress",
+			Type:      events.EventVerificationProgress,
 			SessionID: v.sessionID,
 			Message:   fmt.Sprintf("Verifying %s", location),
 			Data: map[string]interface{}{
@@ -152,7 +152,7 @@ func (v *Verifier) VerifyBook(ctx context.Context, book *ebook.Book) (*Verificat
 
 	// Emit completion event
 	completionEvent := events.NewEvent(
-		"verification_completed",
+		events.EventVerificationComplete,
 		fmt.Sprintf("Verification completed - Score: %.2f%%", result.QualityScore*100),
 		map[string]interface{}{
 			"quality_score":       result.QualityScore,
@@ -526,7 +526,7 @@ func (v *Verifier) emitEvent(event events.Event) {
 // emitWarning emits a warning event
 func (v *Verifier) emitWarning(message string) {
 	if v.eventBus != nil {
-		warningEvent := events.NewEvent("verification_warning", message, nil)
+		warningEvent := events.NewEvent(events.EventVerificationWarning, message, nil)
 		warningEvent.SessionID = v.sessionID
 		v.eventBus.Publish(warningEvent)
 	}
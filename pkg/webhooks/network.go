@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// net.IP.IsPrivate does not cover
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// forbiddenAddress reports whether webhooks must not be sent to an address:
+// loopback, private, link-local (including cloud metadata endpoints such as
+// 169.254.169.254), multicast and unspecified addresses
+func forbiddenAddress(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// lookupFunc resolves a host name to its addresses
+type lookupFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// checkHost resolves the host of a subscription URL and rejects it if any of
+// its addresses is forbidden
+func checkHost(ctx context.Context, lookup lookupFunc, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if forbiddenAddress(ip) {
+			return &InvalidSubscriptionError{Message: fmt.Sprintf("url must not point to a private or local address: %s", host)}
+		}
+		return nil
+	}
+
+	addrs, err := lookup(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return &InvalidSubscriptionError{Message: fmt.Sprintf("url host cannot be resolved: %s", host)}
+	}
+	for _, addr := range addrs {
+		if forbiddenAddress(addr.IP) {
+			return &InvalidSubscriptionError{Message: fmt.Sprintf("url must not point to a private or local address: %s resolves to %s", host, addr.IP)}
+		}
+	}
+	return nil
+}
+
+// guardConnection refuses connections to forbidden addresses. It runs after
+// name resolution, so a host that re-resolves to an internal address after
+// its subscription was created is still refused.
+func guardConnection(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || forbiddenAddress(ip) {
+		return fmt.Errorf("refusing to connect to private or local address %s", host)
+	}
+	return nil
+}
+
+// guardTransport returns a copy of transport whose connections are checked
+// with guardConnection. Proxies are not used, because a proxy would connect to
+// the endpoint on the dispatcher's behalf without the check. Transports other
+// than *http.Transport are returned unchanged.
+func guardTransport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	base, ok := transport.(*http.Transport)
+	if !ok {
+		return transport
+	}
+
+	guarded := base.Clone()
+	guarded.Proxy = nil
+	guarded.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   guardConnection,
+	}).DialContext
+	return guarded
+}
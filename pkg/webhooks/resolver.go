@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+)
+
+// Scope tells which project an event belongs to and which users may see it
+type Scope struct {
+	ProjectID string
+	UserIDs   []string
+}
+
+// includes reports whether a user may see events of the scope
+func (s Scope) includes(userID string) bool {
+	for _, id := range s.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// empty reports whether nobody may see events of the scope
+func (s Scope) empty() bool {
+	return s.ProjectID == "" && len(s.UserIDs) == 0
+}
+
+// ScopeResolver finds the scope of an event
+type ScopeResolver interface {
+	EventScope(ctx context.Context, event events.Event) (Scope, error)
+}
+
+// StorageResolver scopes events by the "project_id" and "user_id" fields of
+// their data, falling back to the project of the event's stored session.
+// Members of a project see all of its events. Translation events do not name
+// their user, so events of sessions without a project resolve to an empty
+// scope and are not delivered.
+type StorageResolver struct {
+	store storage.Storage
+}
+
+// NewStorageResolver creates a resolver; store may be nil to rely on event data alone
+func NewStorageResolver(store storage.Storage) *StorageResolver {
+	return &StorageResolver{store: store}
+}
+
+// EventScope resolves the scope of an event
+func (r *StorageResolver) EventScope(ctx context.Context, event events.Event) (Scope, error) {
+	var scope Scope
+	scope.ProjectID, _ = event.Data["project_id"].(string)
+	if userID, _ := event.Data["user_id"].(string); userID != "" {
+		scope.UserIDs = append(scope.UserIDs, userID)
+	}
+
+	if r.store == nil {
+		return scope, nil
+	}
+
+	if scope.ProjectID == "" && event.SessionID != "" {
+		session, err := r.store.GetSession(ctx, event.SessionID)
+		if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+			return scope, err
+		}
+		if session != nil {
+			scope.ProjectID = session.ProjectID
+		}
+	}
+
+	if scope.ProjectID != "" {
+		project, err := r.store.GetProject(ctx, scope.ProjectID)
+		if errors.Is(err, storage.ErrProjectNotFound) {
+			return scope, nil
+		}
+		if err != nil {
+			return scope, err
+		}
+
+		scope.UserIDs = append(scope.UserIDs, project.OwnerID)
+		for _, member := range project.Members {
+			scope.UserIDs = append(scope.UserIDs, member.UserID)
+		}
+	}
+
+	return scope, nil
+}
@@ -0,0 +1,693 @@
+// Package webhooks notifies users' HTTP endpoints of translation and
+// verification events. Requests are signed with HMAC-SHA256, failed requests
+// are retried with exponential backoff and give up into a dead-letter list.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSubscriptionNotFound is returned for unknown or foreign subscriptions
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+	// ErrDeadLetterNotFound is returned when a dead letter does not exist
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+	// ErrDispatcherClosed is returned after Close
+	ErrDispatcherClosed = errors.New("webhook dispatcher closed")
+)
+
+// InvalidSubscriptionError reports a subscription that cannot be created as given
+type InvalidSubscriptionError struct {
+	Message string
+}
+
+func (e *InvalidSubscriptionError) Error() string {
+	return e.Message
+}
+
+// DefaultEventTypes are the events subscriptions receive when they name none
+var DefaultEventTypes = []events.EventType{
+	events.EventTranslationStarted,
+	events.EventTranslationProgress,
+	events.EventTranslationCompleted,
+	events.EventTranslationError,
+	events.EventVerificationComplete,
+	events.EventVerificationWarning,
+}
+
+// Subscription sends matching events to a URL. Without a project it covers
+// the events of every project its owner belongs to and events whose data
+// names the owner as "user_id". Events of sessions outside projects carry no
+// user, so only project sessions are delivered in practice.
+type Subscription struct {
+	ID         string             `json:"id"`
+	OwnerID    string             `json:"owner_id"`
+	ProjectID  string             `json:"project_id,omitempty"`
+	URL        string             `json:"url"`
+	Secret     string             `json:"secret,omitempty"`
+	EventTypes []events.EventType `json:"event_types"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// wants reports whether the subscription covers an event type
+func (s *Subscription) wants(eventType events.EventType) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether an event of the scope goes to the subscription
+func (s *Subscription) matches(event events.Event, scope Scope) bool {
+	if !s.wants(event.Type) || !scope.includes(s.OwnerID) {
+		return false
+	}
+	return s.ProjectID == "" || s.ProjectID == scope.ProjectID
+}
+
+// redacted returns a copy without the secret
+func (s *Subscription) redacted() *Subscription {
+	copied := *s
+	copied.Secret = ""
+	copied.EventTypes = append([]events.EventType(nil), s.EventTypes...)
+	return &copied
+}
+
+// Payload is the JSON body of a webhook request
+type Payload struct {
+	DeliveryID     string       `json:"delivery_id"`
+	SubscriptionID string       `json:"subscription_id"`
+	ProjectID      string       `json:"project_id,omitempty"`
+	Event          events.Event `json:"event"`
+}
+
+// DeliveryAttempt is an entry of the delivery log
+type DeliveryAttempt struct {
+	DeliveryID     string           `json:"delivery_id"`
+	SubscriptionID string           `json:"subscription_id"`
+	EventID        string           `json:"event_id"`
+	EventType      events.EventType `json:"event_type"`
+	Attempt        int              `json:"attempt"`
+	StatusCode     int              `json:"status_code,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	Success        bool             `json:"success"`
+	DurationMs     int64            `json:"duration_ms"`
+	Timestamp      time.Time        `json:"timestamp"`
+}
+
+// DeadLetter is a delivery that failed every attempt
+type DeadLetter struct {
+	DeliveryID     string       `json:"delivery_id"`
+	SubscriptionID string       `json:"subscription_id"`
+	ProjectID      string       `json:"project_id,omitempty"`
+	Event          events.Event `json:"event"`
+	Attempts       int          `json:"attempts"`
+	LastError      string       `json:"last_error"`
+	FailedAt       time.Time    `json:"failed_at"`
+}
+
+// Options configures a dispatcher; zero values select the defaults
+type Options struct {
+	// MaxAttempts is the number of requests before a delivery is dead-lettered, 6 by default
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, doubling with every
+	// further retry up to MaxBackoff; 1s and 5m by default
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Timeout bounds each request, 10s by default
+	Timeout time.Duration
+
+	// Concurrency bounds the requests in flight, 8 by default
+	Concurrency int
+
+	// LogSize and DeadLetterSize bound the delivery log and the dead-letter
+	// list; the oldest entries are discarded first. 1000 by default.
+	LogSize        int
+	DeadLetterSize int
+
+	// StorePath keeps subscriptions in a JSON file across restarts when set
+	StorePath string
+
+	// HTTPClient sends the requests; its timeout is replaced by Timeout
+	HTTPClient *http.Client
+
+	// AllowPrivateNetworks permits endpoints on loopback, private and
+	// link-local addresses. Otherwise such URLs are rejected when a
+	// subscription is created and connections to them are refused.
+	AllowPrivateNetworks bool
+}
+
+// delivery is an event on its way to one subscription
+type delivery struct {
+	id           string
+	subscription *Subscription
+	projectID    string
+	event        events.Event
+}
+
+// Dispatcher delivers events from an event bus to webhook subscriptions
+type Dispatcher struct {
+	options  Options
+	resolver ScopeResolver
+	client   *http.Client
+	lookup   lookupFunc
+
+	mu            sync.RWMutex
+	subscriptions map[string]*Subscription
+	log           []DeliveryAttempt
+	deadLetters   []DeadLetter
+
+	subscription *events.Subscription
+	sem          chan struct{}
+	done         chan struct{}
+	closeOnce    sync.Once
+	wg           sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher that scopes events with resolver and
+// loads subscriptions from options.StorePath if it exists. Start subscribes
+// it to an event bus.
+func NewDispatcher(resolver ScopeResolver, options Options) (*Dispatcher, error) {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = 6
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = time.Second
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = 5 * time.Minute
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 10 * time.Second
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+	if options.LogSize <= 0 {
+		options.LogSize = 1000
+	}
+	if options.DeadLetterSize <= 0 {
+		options.DeadLetterSize = 1000
+	}
+
+	client := &http.Client{Timeout: options.Timeout}
+	if options.HTTPClient != nil {
+		copied := *options.HTTPClient
+		copied.Timeout = options.Timeout
+		client = &copied
+	}
+	if !options.AllowPrivateNetworks {
+		client.Transport = guardTransport(client.Transport)
+	}
+
+	d := &Dispatcher{
+		options:       options,
+		resolver:      resolver,
+		client:        client,
+		lookup:        net.DefaultResolver.LookupIPAddr,
+		subscriptions: make(map[string]*Subscription),
+		sem:           make(chan struct{}, options.Concurrency),
+		done:          make(chan struct{}),
+	}
+
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Start subscribes the dispatcher to the events of bus
+func (d *Dispatcher) Start(bus *events.EventBus) {
+	d.subscription = bus.SubscribeWithOptions(d.handle, events.SubscriberOptions{
+		Types:  DefaultEventTypes,
+		Policy: events.DropOldest,
+	})
+}
+
+// Close stops receiving events and waits for requests in flight. Deliveries
+// waiting for a retry are abandoned.
+func (d *Dispatcher) Close() {
+	d.closeOnce.Do(func() {
+		if d.subscription != nil {
+			d.subscription.Unsubscribe()
+		}
+		close(d.done)
+	})
+	d.wg.Wait()
+}
+
+// CreateSubscription validates and stores a subscription, generating its ID
+// and, if empty, its secret. The returned copy is the only one that carries
+// the secret.
+func (d *Dispatcher) CreateSubscription(sub *Subscription) (*Subscription, error) {
+	if sub.OwnerID == "" {
+		return nil, &InvalidSubscriptionError{Message: "owner is required"}
+	}
+
+	target, err := url.Parse(sub.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, &InvalidSubscriptionError{Message: "url must be an absolute http or https URL"}
+	}
+	if !d.options.AllowPrivateNetworks {
+		ctx, cancel := context.WithTimeout(context.Background(), d.options.Timeout)
+		err := checkHost(ctx, d.lookup, target.Hostname())
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sub.EventTypes) == 0 {
+		sub.EventTypes = append([]events.EventType(nil), DefaultEventTypes...)
+	}
+	for _, eventType := range sub.EventTypes {
+		if !supportedEventType(eventType) {
+			return nil, &InvalidSubscriptionError{Message: fmt.Sprintf("unsupported event type: %s", eventType)}
+		}
+	}
+
+	if sub.Secret == "" {
+		if sub.Secret, err = generateSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+	}
+	sub.ID = uuid.New().String()
+	sub.CreatedAt = time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subscriptions[sub.ID] = sub
+	if err := d.save(); err != nil {
+		delete(d.subscriptions, sub.ID)
+		return nil, err
+	}
+
+	copied := *sub
+	return &copied, nil
+}
+
+// ListSubscriptions returns the subscriptions of an owner without their secrets
+func (d *Dispatcher) ListSubscriptions(ownerID string) []*Subscription {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]*Subscription, 0)
+	for _, sub := range d.subscriptions {
+		if sub.OwnerID == ownerID {
+			result = append(result, sub.redacted())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+// GetSubscription returns a subscription of an owner without its secret
+func (d *Dispatcher) GetSubscription(ownerID, id string) (*Subscription, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	sub, ok := d.subscriptions[id]
+	if !ok || sub.OwnerID != ownerID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub.redacted(), nil
+}
+
+// DeleteSubscription removes a subscription of an owner along with its dead letters
+func (d *Dispatcher) DeleteSubscription(ownerID, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sub, ok := d.subscriptions[id]
+	if !ok || sub.OwnerID != ownerID {
+		return ErrSubscriptionNotFound
+	}
+
+	delete(d.subscriptions, id)
+	if err := d.save(); err != nil {
+		d.subscriptions[id] = sub
+		return err
+	}
+
+	kept := d.deadLetters[:0]
+	for _, letter := range d.deadLetters {
+		if letter.SubscriptionID != id {
+			kept = append(kept, letter)
+		}
+	}
+	d.deadLetters = kept
+
+	return nil
+}
+
+// Deliveries returns up to limit delivery log entries of a subscription of
+// an owner, newest first; limit <= 0 returns all of them
+func (d *Dispatcher) Deliveries(ownerID, id string, limit int) ([]DeliveryAttempt, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if sub, ok := d.subscriptions[id]; !ok || sub.OwnerID != ownerID {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	result := make([]DeliveryAttempt, 0)
+	for i := len(d.log) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		if d.log[i].SubscriptionID == id {
+			result = append(result, d.log[i])
+		}
+	}
+	return result, nil
+}
+
+// DeadLetters returns the dead letters of a subscription of an owner, oldest first
+func (d *Dispatcher) DeadLetters(ownerID, id string) ([]DeadLetter, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if sub, ok := d.subscriptions[id]; !ok || sub.OwnerID != ownerID {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	result := make([]DeadLetter, 0)
+	for _, letter := range d.deadLetters {
+		if letter.SubscriptionID == id {
+			result = append(result, letter)
+		}
+	}
+	return result, nil
+}
+
+// Redeliver removes a dead letter and delivers its event again with a fresh
+// set of attempts
+func (d *Dispatcher) Redeliver(ownerID, id, deliveryID string) error {
+	select {
+	case <-d.done:
+		return ErrDispatcherClosed
+	default:
+	}
+
+	d.mu.Lock()
+	sub, ok := d.subscriptions[id]
+	if !ok || sub.OwnerID != ownerID {
+		d.mu.Unlock()
+		return ErrSubscriptionNotFound
+	}
+
+	index := -1
+	for i, letter := range d.deadLetters {
+		if letter.SubscriptionID == id && letter.DeliveryID == deliveryID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		d.mu.Unlock()
+		return ErrDeadLetterNotFound
+	}
+	letter := d.deadLetters[index]
+	d.deadLetters = append(d.deadLetters[:index], d.deadLetters[index+1:]...)
+	d.mu.Unlock()
+
+	d.deliver(&delivery{id: letter.DeliveryID, subscription: sub, projectID: letter.ProjectID, event: letter.Event})
+	return nil
+}
+
+// handle fans an event out to the matching subscriptions
+func (d *Dispatcher) handle(event events.Event) {
+	d.mu.RLock()
+	candidates := make([]*Subscription, 0)
+	for _, sub := range d.subscriptions {
+		if sub.wants(event.Type) {
+			candidates = append(candidates, sub)
+		}
+	}
+	d.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.options.Timeout)
+	scope, err := d.resolver.EventScope(ctx, event)
+	cancel()
+	if err != nil {
+		log.Printf("Webhooks: failed to resolve scope of event %s: %v", event.ID, err)
+		return
+	}
+	if scope.empty() {
+		return
+	}
+
+	for _, sub := range candidates {
+		if sub.matches(event, scope) {
+			d.deliver(&delivery{id: uuid.New().String(), subscription: sub, projectID: scope.ProjectID, event: event})
+		}
+	}
+}
+
+// deliver sends a delivery in the background, retrying with backoff until it
+// succeeds, runs out of attempts or the dispatcher closes
+func (d *Dispatcher) deliver(del *delivery) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		backoff := d.options.InitialBackoff
+		var lastErr error
+		for attempt := 1; attempt <= d.options.MaxAttempts; attempt++ {
+			if attempt > 1 {
+				if !d.wait(backoff) {
+					return
+				}
+				backoff *= 2
+				if backoff > d.options.MaxBackoff {
+					backoff = d.options.MaxBackoff
+				}
+			}
+
+			select {
+			case d.sem <- struct{}{}:
+			case <-d.done:
+				return
+			}
+			lastErr = d.send(del, attempt)
+			<-d.sem
+
+			if lastErr == nil {
+				return
+			}
+		}
+
+		d.deadLetter(del, lastErr)
+	}()
+}
+
+// wait sleeps for delay and reports whether the dispatcher is still open
+func (d *Dispatcher) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-d.done:
+		return false
+	}
+}
+
+// send makes one signed request and records it in the delivery log
+func (d *Dispatcher) send(del *delivery, attempt int) error {
+	body, err := json.Marshal(Payload{
+		DeliveryID:     del.id,
+		SubscriptionID: del.subscription.ID,
+		ProjectID:      del.projectID,
+		Event:          del.event,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	entry := DeliveryAttempt{
+		DeliveryID:     del.id,
+		SubscriptionID: del.subscription.ID,
+		EventID:        del.event.ID,
+		EventType:      del.event.Type,
+		Attempt:        attempt,
+		Timestamp:      time.Now(),
+	}
+
+	err = d.post(del, body, &entry)
+	entry.DurationMs = time.Since(entry.Timestamp).Milliseconds()
+	entry.Success = err == nil
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	d.record(entry)
+
+	return err
+}
+
+// post sends the request; any status other than 2xx is a failure
+func (d *Dispatcher) post(del *delivery, body []byte, entry *DeliveryAttempt) error {
+	req, err := http.NewRequest("POST", del.subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "translator-webhooks/1.0")
+	req.Header.Set(SignatureHeader, Sign(del.subscription.Secret, timestamp, body))
+	req.Header.Set(TimestampHeader, fmt.Sprintf("%d", timestamp))
+	req.Header.Set(EventHeader, string(del.event.Type))
+	req.Header.Set(DeliveryHeader, del.id)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	entry.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// record appends to the delivery log
+func (d *Dispatcher) record(entry DeliveryAttempt) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.log = append(d.log, entry)
+	if len(d.log) > d.options.LogSize {
+		d.log = d.log[len(d.log)-d.options.LogSize:]
+	}
+}
+
+// deadLetter keeps a delivery that failed every attempt, unless its
+// subscription has been deleted meanwhile
+func (d *Dispatcher) deadLetter(del *delivery, lastErr error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.subscriptions[del.subscription.ID]; !ok {
+		return
+	}
+
+	d.deadLetters = append(d.deadLetters, DeadLetter{
+		DeliveryID:     del.id,
+		SubscriptionID: del.subscription.ID,
+		ProjectID:      del.projectID,
+		Event:          del.event,
+		Attempts:       d.options.MaxAttempts,
+		LastError:      lastErr.Error(),
+		FailedAt:       time.Now(),
+	})
+	if len(d.deadLetters) > d.options.DeadLetterSize {
+		d.deadLetters = d.deadLetters[len(d.deadLetters)-d.options.DeadLetterSize:]
+	}
+}
+
+// load reads the subscriptions stored at StorePath
+func (d *Dispatcher) load() error {
+	if d.options.StorePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(d.options.StorePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read webhook subscriptions: %w", err)
+	}
+
+	var subscriptions []*Subscription
+	if err := json.Unmarshal(data, &subscriptions); err != nil {
+		return fmt.Errorf("failed to parse webhook subscriptions: %w", err)
+	}
+	for _, sub := range subscriptions {
+		d.subscriptions[sub.ID] = sub
+	}
+	return nil
+}
+
+// save writes the subscriptions to StorePath; the caller holds mu
+func (d *Dispatcher) save() error {
+	if d.options.StorePath == "" {
+		return nil
+	}
+
+	subscriptions := make([]*Subscription, 0, len(d.subscriptions))
+	for _, sub := range d.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt) })
+
+	data, err := json.MarshalIndent(subscriptions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook subscriptions: %w", err)
+	}
+
+	// Write a temporary file first so a crash cannot leave a truncated store
+	tmp, err := os.CreateTemp(filepath.Dir(d.options.StorePath), ".webhooks-*")
+	if err != nil {
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), d.options.StorePath); err != nil {
+		return fmt.Errorf("failed to save webhook subscriptions: %w", err)
+	}
+	return nil
+}
+
+// supportedEventType reports whether subscriptions may ask for an event type
+func supportedEventType(eventType events.EventType) bool {
+	for _, t := range DefaultEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"digital.vasic.translator/pkg/events"
+	"digital.vasic.translator/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticResolver assigns every event to the same scope
+type staticResolver struct {
+	scope Scope
+}
+
+func (r staticResolver) EventScope(ctx context.Context, event events.Event) (Scope, error) {
+	return r.scope, nil
+}
+
+// receiver records the webhook requests it accepts
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	failures atomic.Int32
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.failures.Load() > 0 {
+		r.failures.Add(-1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func newTestDispatcher(t *testing.T, resolver ScopeResolver, options Options) (*Dispatcher, *events.EventBus) {
+	if options.InitialBackoff == 0 {
+		options.InitialBackoff = time.Millisecond
+	}
+	// Test endpoints listen on loopback
+	options.AllowPrivateNetworks = true
+	d, err := NewDispatcher(resolver, options)
+	require.NoError(t, err)
+
+	bus := events.NewEventBus()
+	d.Start(bus)
+	t.Cleanup(d.Close)
+	return d, bus
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"event":{}}`)
+	now := time.Now().Unix()
+	signature := Sign("secret", now, body)
+
+	assert.True(t, VerifySignature("secret", signature, strconv.FormatInt(now, 10), body, time.Minute))
+	assert.False(t, VerifySignature("other", signature, strconv.FormatInt(now, 10), body, time.Minute))
+	assert.False(t, VerifySignature("secret", signature, strconv.FormatInt(now, 10), []byte(`{}`), time.Minute))
+
+	old := now - 3600
+	assert.False(t, VerifySignature("secret", Sign("secret", old, body), strconv.FormatInt(old, 10), body, time.Minute))
+	assert.True(t, VerifySignature("secret", Sign("secret", old, body), strconv.FormatInt(old, 10), body, 0))
+}
+
+func TestDispatcherDeliversSignedEvents(t *testing.T) {
+	recv := &receiver{}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d, bus := newTestDispatcher(t, staticResolver{Scope{ProjectID: "p1", UserIDs: []string{"u1"}}}, Options{})
+
+	sub, err := d.CreateSubscription(&Subscription{
+		OwnerID:    "u1",
+		URL:        server.URL,
+		EventTypes: []events.EventType{events.EventTranslationCompleted},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.Secret)
+
+	bus.Publish(events.Event{Type: events.EventTranslationStarted, SessionID: "s1"})
+	bus.Publish(events.Event{Type: events.EventTranslationCompleted, SessionID: "s1", Message: "done"})
+
+	require.Eventually(t, func() bool { return recv.count() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	recv.mu.Lock()
+	req, body := recv.requests[0], recv.bodies[0]
+	recv.mu.Unlock()
+
+	assert.Equal(t, string(events.EventTranslationCompleted), req.Header.Get(EventHeader))
+	assert.True(t, VerifySignature(sub.Secret, req.Header.Get(SignatureHeader), req.Header.Get(TimestampHeader), body, time.Minute))
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, sub.ID, payload.SubscriptionID)
+	assert.Equal(t, "p1", payload.ProjectID)
+	assert.Equal(t, "done", payload.Event.Message)
+	assert.Equal(t, req.Header.Get(DeliveryHeader), payload.DeliveryID)
+
+	require.Eventually(t, func() bool {
+		log, _ := d.Deliveries("u1", sub.ID, 0)
+		return len(log) == 1 && log[0].Success && log[0].StatusCode == http.StatusNoContent
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcherScopesSubscriptions(t *testing.T) {
+	recv := &receiver{}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d, bus := newTestDispatcher(t, staticResolver{Scope{ProjectID: "p1", UserIDs: []string{"u1", "u2"}}}, Options{})
+
+	for _, sub := range []*Subscription{
+		{OwnerID: "u1", URL: server.URL},                  // member, any project
+		{OwnerID: "u2", ProjectID: "p1", URL: server.URL}, // member, this project
+		{OwnerID: "u2", ProjectID: "p2", URL: server.URL}, // other project
+		{OwnerID: "u3", URL: server.URL},                  // not a member
+		{OwnerID: "u3", ProjectID: "p1", URL: server.URL}, // not a member
+	} {
+		_, err := d.CreateSubscription(sub)
+		require.NoError(t, err)
+	}
+
+	bus.Publish(events.Event{Type: events.EventVerificationComplete, SessionID: "s1"})
+
+	require.Eventually(t, func() bool { return recv.count() == 2 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 2, recv.count())
+}
+
+func TestDispatcherRetriesAndDeadLetters(t *testing.T) {
+	recv := &receiver{}
+	recv.failures.Store(2)
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d, bus := newTestDispatcher(t, staticResolver{Scope{UserIDs: []string{"u1"}}}, Options{MaxAttempts: 3})
+
+	sub, err := d.CreateSubscription(&Subscription{OwnerID: "u1", URL: server.URL})
+	require.NoError(t, err)
+
+	// Succeeds on the third attempt
+	bus.Publish(events.Event{Type: events.EventTranslationError, SessionID: "s1"})
+	require.Eventually(t, func() bool { return recv.count() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	log, err := d.Deliveries("u1", sub.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, log, 3)
+	assert.True(t, log[0].Success)
+	assert.Equal(t, 3, log[0].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, log[2].StatusCode)
+	assert.Equal(t, log[0].DeliveryID, log[2].DeliveryID)
+
+	// Fails every attempt
+	recv.failures.Store(3)
+	bus.Publish(events.Event{Type: events.EventTranslationError, SessionID: "s2"})
+
+	var letters []DeadLetter
+	require.Eventually(t, func() bool {
+		letters, _ = d.DeadLetters("u1", sub.ID)
+		return len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "s2", letters[0].Event.SessionID)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Contains(t, letters[0].LastError, "503")
+
+	// Redelivery succeeds now that the endpoint is back
+	require.NoError(t, d.Redeliver("u1", sub.ID, letters[0].DeliveryID))
+	require.Eventually(t, func() bool { return recv.count() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	letters, _ = d.DeadLetters("u1", sub.ID)
+	assert.Empty(t, letters)
+	assert.ErrorIs(t, d.Redeliver("u1", sub.ID, "missing"), ErrDeadLetterNotFound)
+}
+
+func TestSubscriptionManagement(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "webhooks.json")
+	d, err := NewDispatcher(staticResolver{}, Options{StorePath: storePath})
+	require.NoError(t, err)
+	defer d.Close()
+	d.lookup = fakeLookup("93.184.215.14")
+
+	_, err = d.CreateSubscription(&Subscription{OwnerID: "u1", URL: "ftp://example.com"})
+	assert.IsType(t, &InvalidSubscriptionError{}, err)
+	_, err = d.CreateSubscription(&Subscription{OwnerID: "u1", URL: "https://example.com", EventTypes: []events.EventType{"unknown"}})
+	assert.IsType(t, &InvalidSubscriptionError{}, err)
+
+	sub, err := d.CreateSubscription(&Subscription{OwnerID: "u1", URL: "https://example.com/hook", Secret: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", sub.Secret)
+	assert.Equal(t, DefaultEventTypes, sub.EventTypes)
+
+	listed := d.ListSubscriptions("u1")
+	require.Len(t, listed, 1)
+	assert.Empty(t, listed[0].Secret)
+	assert.Empty(t, d.ListSubscriptions("u2"))
+
+	_, err = d.GetSubscription("u2", sub.ID)
+	assert.ErrorIs(t, err, ErrSubscriptionNotFound)
+
+	// Subscriptions survive a restart with their secrets
+	reloaded, err := NewDispatcher(staticResolver{}, Options{StorePath: storePath})
+	require.NoError(t, err)
+	defer reloaded.Close()
+	reloaded.mu.RLock()
+	assert.Equal(t, "s3cret", reloaded.subscriptions[sub.ID].Secret)
+	reloaded.mu.RUnlock()
+
+	assert.ErrorIs(t, d.DeleteSubscription("u2", sub.ID), ErrSubscriptionNotFound)
+	require.NoError(t, d.DeleteSubscription("u1", sub.ID))
+	assert.Empty(t, d.ListSubscriptions("u1"))
+}
+
+func fakeLookup(addresses ...string) lookupFunc {
+	return func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		result := make([]net.IPAddr, 0, len(addresses))
+		for _, address := range addresses {
+			result = append(result, net.IPAddr{IP: net.ParseIP(address)})
+		}
+		return result, nil
+	}
+}
+
+func TestPrivateNetworkTargets(t *testing.T) {
+	d, err := NewDispatcher(staticResolver{Scope{UserIDs: []string{"u1"}}}, Options{MaxAttempts: 1})
+	require.NoError(t, err)
+	defer d.Close()
+	d.lookup = fakeLookup("93.184.215.14", "192.168.1.10")
+
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.1/hook",
+		"http://100.64.0.1/hook",
+		"https://internal.example.com/hook",
+	} {
+		_, err := d.CreateSubscription(&Subscription{OwnerID: "u1", URL: target})
+		assert.IsType(t, &InvalidSubscriptionError{}, err, target)
+	}
+
+	// A host that resolves to a public address when the subscription is
+	// created may point elsewhere later; the connection itself is refused
+	recv := &receiver{}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	bus := events.NewEventBus()
+	d.Start(bus)
+	d.mu.Lock()
+	d.subscriptions["s1"] = &Subscription{ID: "s1", OwnerID: "u1", URL: server.URL, Secret: "s", EventTypes: DefaultEventTypes}
+	d.mu.Unlock()
+
+	bus.Publish(events.NewEvent(events.EventTranslationCompleted, "done", nil))
+	require.Eventually(t, func() bool {
+		letters, _ := d.DeadLetters("u1", "s1")
+		return len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	letters, err := d.DeadLetters("u1", "s1")
+	require.NoError(t, err)
+	assert.Contains(t, letters[0].LastError, "private or local address")
+	assert.Zero(t, recv.count())
+}
+
+func TestStorageResolver(t *testing.T) {
+	store, err := storage.NewSQLiteStorage(&storage.Config{
+		Type:     "sqlite",
+		Database: filepath.Join(t.TempDir(), "webhooks.db"),
+	})
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	project := &storage.Project{ID: "p1", Name: "Novel", OwnerID: "owner"}
+	project.SetMember("editor", storage.ProjectRoleEditor)
+	require.NoError(t, store.CreateProject(ctx, project))
+	require.NoError(t, store.CreateSession(ctx, &storage.TranslationSession{
+		ID:        "s1",
+		ProjectID: "p1",
+		StartTime: time.Now(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}))
+
+	resolver := NewStorageResolver(store)
+
+	scope, err := resolver.EventScope(ctx, events.Event{SessionID: "s1"})
+	require.NoError(t, err)
+	assert.Equal(t, "p1", scope.ProjectID)
+	assert.ElementsMatch(t, []string{"owner", "editor"}, scope.UserIDs)
+
+	scope, err = resolver.EventScope(ctx, events.Event{SessionID: "unknown", Data: map[string]interface{}{"user_id": "u9"}})
+	require.NoError(t, err)
+	assert.Equal(t, Scope{UserIDs: []string{"u9"}}, scope)
+}